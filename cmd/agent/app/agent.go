@@ -32,6 +32,8 @@ type Agent struct {
 	httpAddr   atomic.Value // string, set once agent starts listening
 	logger     *zap.Logger
 	closer     io.Closer
+	// cleanups release the reporters' connections to the collectors when the agent stops
+	cleanups []func()
 }
 
 // NewAgent creates the new Agent.
@@ -82,4 +84,7 @@ func (a *Agent) Stop() {
 		go processor.Stop()
 	}
 	a.closer.Close()
+	for _, cleanup := range a.cleanups {
+		cleanup()
+	}
 }
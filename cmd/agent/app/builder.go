@@ -16,6 +16,7 @@ package app
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/jaegertracing/jaeger/cmd/agent/app/httpserver"
 	"github.com/jaegertracing/jaeger/cmd/agent/app/processors"
 	"github.com/jaegertracing/jaeger/cmd/agent/app/reporter"
+	grpcreporter "github.com/jaegertracing/jaeger/cmd/agent/app/reporter/grpc"
 	tchreporter "github.com/jaegertracing/jaeger/cmd/agent/app/reporter/tchannel"
 	"github.com/jaegertracing/jaeger/cmd/agent/app/servers"
 	"github.com/jaegertracing/jaeger/cmd/agent/app/servers/thriftudp"
@@ -50,6 +52,9 @@ const (
 
 	compactProtocol Protocol = "compact"
 	binaryProtocol           = "binary"
+
+	tchannelReporter ReporterType = "tchannel"
+	grpcReporter     ReporterType = "grpc"
 )
 
 // Model used to distinguish the data transfer model
@@ -58,9 +63,14 @@ type Model string
 // Protocol used to distinguish the data transfer protocol
 type Protocol string
 
+// ReporterType used to select the transport spans are forwarded to collectors with
+type ReporterType string
+
 var (
 	errNoReporters = errors.New("agent requires at least one Reporter")
 
+	errNoSamplingCollectors = errors.New("grpc reporter requires collector host:ports to proxy sampling strategies and baggage restrictions over tchannel")
+
 	protocolFactoryMap = map[Protocol]thrift.TProtocolFactory{
 		compactProtocol: thrift.NewTCompactProtocolFactory(),
 		binaryProtocol:  thrift.NewTBinaryProtocolFactoryDefault(),
@@ -73,8 +83,17 @@ type Builder struct {
 	HTTPServer HTTPServerConfiguration  `yaml:"httpServer"`
 	Metrics    jmetrics.Builder         `yaml:"metrics"`
 
+	// ZipkinHTTPServer configures the server receiving Zipkin spans over HTTP, disabled if its HostPort is empty.
+	ZipkinHTTPServer ZipkinHTTPServerConfiguration `yaml:"zipkinHttpServer"`
+
+	// ReporterType selects the main reporter, either "tchannel" (default) or "grpc".
+	ReporterType ReporterType `yaml:"reporterType"`
+
 	tchreporter.Builder `yaml:",inline"`
 
+	// GRPC configures the gRPC reporter, used when ReporterType is "grpc".
+	GRPC grpcreporter.Builder `yaml:"grpc"`
+
 	otherReporters []reporter.Reporter
 	metricsFactory metrics.Factory
 }
@@ -99,6 +118,11 @@ type HTTPServerConfiguration struct {
 	HostPort string `yaml:"hostPort" validate:"nonzero"`
 }
 
+// ZipkinHTTPServerConfiguration holds config for a server receiving Zipkin v1/v2 JSON and Thrift spans over HTTP
+type ZipkinHTTPServerConfiguration struct {
+	HostPort string `yaml:"hostPort"`
+}
+
 // WithReporter adds auxiliary reporters.
 func (b *Builder) WithReporter(r reporter.Reporter) *Builder {
 	b.otherReporters = append(b.otherReporters, r)
@@ -111,8 +135,15 @@ func (b *Builder) WithMetricsFactory(mf metrics.Factory) *Builder {
 	return b
 }
 
-func (b *Builder) createMainReporter(mFactory metrics.Factory, logger *zap.Logger) (*tchreporter.Reporter, error) {
-	return b.CreateReporter(mFactory, logger)
+func (b *Builder) createMainReporter(mFactory metrics.Factory, logger *zap.Logger) (reporter.Reporter, error) {
+	switch b.ReporterType {
+	case "", tchannelReporter:
+		return b.CreateReporter(mFactory, logger)
+	case grpcReporter:
+		return b.GRPC.CreateReporter(mFactory, logger)
+	default:
+		return nil, fmt.Errorf("unknown reporter type %v", b.ReporterType)
+	}
 }
 
 func (b *Builder) getMetricsFactory() (metrics.Factory, error) {
@@ -138,20 +169,70 @@ func (b *Builder) CreateAgent(logger *zap.Logger) (*Agent, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot create main Reporter")
 	}
-	var rep reporter.Reporter = mainReporter
+	rep := mainReporter
 	if len(b.otherReporters) > 0 {
 		reps := append([]reporter.Reporter{mainReporter}, b.otherReporters...)
 		rep = reporter.NewMultiReporter(reps...)
 	}
+	var cleanups []func()
+	cleanup := func() {
+		for _, fn := range cleanups {
+			fn()
+		}
+	}
+	if closer, ok := mainReporter.(io.Closer); ok {
+		cleanups = append(cleanups, func() {
+			if err := closer.Close(); err != nil {
+				logger.Error("failed to close main reporter", zap.Error(err))
+			}
+		})
+	}
 	processors, err := b.GetProcessors(rep, mFactory, logger)
 	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	channel, closeChannel, err := b.samplingChannel(mainReporter, mFactory, logger)
+	if err != nil {
+		cleanup()
 		return nil, err
 	}
-	httpServer := b.HTTPServer.GetHTTPServer(b.CollectorServiceName, mainReporter.Channel(), mFactory)
+	if closeChannel != nil {
+		cleanups = append(cleanups, closeChannel)
+	}
+	if b.ZipkinHTTPServer.HostPort != "" {
+		zipkinProcessor, err := b.ZipkinHTTPServer.GetZipkinHTTPProcessor(rep, logger)
+		if err != nil {
+			cleanup()
+			return nil, errors.Wrap(err, "cannot create Zipkin HTTP server")
+		}
+		processors = append(processors, zipkinProcessor)
+	}
+	httpServer := b.HTTPServer.GetHTTPServer(b.CollectorServiceName, channel, mFactory)
 	if h := b.Metrics.Handler(); mFactory != nil && h != nil {
 		httpServer.Handler.(*http.ServeMux).Handle(b.Metrics.HTTPRoute, h)
 	}
-	return NewAgent(processors, httpServer, logger), nil
+	agent := NewAgent(processors, httpServer, logger)
+	agent.cleanups = cleanups
+	return agent, nil
+}
+
+// samplingChannel returns the TChannel used to proxy sampling strategies and baggage restrictions
+// to the collectors, which serve them only over TChannel. The TChannel reporter shares its channel,
+// with the gRPC reporter a separate channel is created to the collectors in CollectorHostPorts
+// and returned together with the function closing it.
+func (b *Builder) samplingChannel(mainReporter reporter.Reporter, mFactory metrics.Factory, logger *zap.Logger) (*tchannel.Channel, func(), error) {
+	if tchRep, ok := mainReporter.(*tchreporter.Reporter); ok {
+		return tchRep.Channel(), nil, nil
+	}
+	if len(b.CollectorHostPorts) == 0 {
+		return nil, nil, errNoSamplingCollectors
+	}
+	tchRep, err := b.CreateReporter(mFactory, logger)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot create sampling proxy")
+	}
+	return tchRep.Channel(), tchRep.Channel().Close, nil
 }
 
 // GetProcessors creates Processors with attached Reporter
@@ -193,6 +274,11 @@ func (c HTTPServerConfiguration) GetHTTPServer(svc string, channel *tchannel.Cha
 	return httpserver.NewHTTPServer(c.HostPort, mgr, mFactory)
 }
 
+// GetZipkinHTTPProcessor creates a processor that forwards the Zipkin spans received over HTTP with the reporter.
+func (c ZipkinHTTPServerConfiguration) GetZipkinHTTPProcessor(rep reporter.Reporter, logger *zap.Logger) (processors.Processor, error) {
+	return processors.NewZipkinHTTPProcessor(c.HostPort, rep, logger)
+}
+
 // GetThriftProcessor gets a TBufferedServer backed Processor using the collector configuration
 func (c *ProcessorConfiguration) GetThriftProcessor(
 	mFactory metrics.Factory,
@@ -52,6 +52,9 @@ processors:
 httpServer:
     hostPort: 4.4.4.4:5778
 
+zipkinHttpServer:
+    hostPort: 5.5.5.5:9411
+
 collectorHostPorts:
     - 127.0.0.1:14267
     - 127.0.0.1:14268
@@ -101,6 +104,7 @@ func TestBuilderFromConfig(t *testing.T) {
 		},
 	}, cfg.Processors[2])
 	assert.Equal(t, "4.4.4.4:5778", cfg.HTTPServer.HostPort)
+	assert.Equal(t, "5.5.5.5:9411", cfg.ZipkinHTTPServer.HostPort)
 
 	assert.Equal(t, 4, cfg.DiscoveryMinPeers)
 	assert.Equal(t, "some-collector-service", cfg.CollectorServiceName)
@@ -118,6 +122,51 @@ func TestBuilderWithExtraReporter(t *testing.T) {
 	assert.NotNil(t, agent)
 }
 
+func TestBuilderWithGRPCReporter(t *testing.T) {
+	cfg := &Builder{ReporterType: grpcReporter}
+	cfg.CollectorHostPorts = []string{"127.0.0.1:14267"}
+	cfg.GRPC.CollectorHostPorts = []string{"127.0.0.1:14250"}
+	agent, err := cfg.CreateAgent(zap.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, agent)
+
+	mainReporter, err := cfg.createMainReporter(metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	channel, closeChannel, err := cfg.samplingChannel(mainReporter, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	assert.NotNil(t, channel, "sampling strategies must be proxied over TChannel")
+	require.NotNil(t, closeChannel)
+	closeChannel()
+	assert.True(t, channel.Closed())
+	assert.Len(t, agent.cleanups, 2, "the gRPC reporter and the sampling channel must be closed with the agent")
+
+	cfg = &Builder{ReporterType: grpcReporter}
+	cfg.GRPC.CollectorHostPorts = []string{"127.0.0.1:14250"}
+	_, err = cfg.CreateAgent(zap.NewNop())
+	assert.Equal(t, errNoSamplingCollectors, err)
+
+	cfg = &Builder{ReporterType: grpcReporter}
+	_, err = cfg.CreateAgent(zap.NewNop())
+	assert.EqualError(t, err, "cannot create main Reporter: gRPC reporter requires either collector host:ports or a discovery.Discoverer")
+
+	cfg = &Builder{ReporterType: "bad"}
+	_, err = cfg.CreateAgent(zap.NewNop())
+	assert.EqualError(t, err, "cannot create main Reporter: unknown reporter type bad")
+}
+
+func TestBuilderWithZipkinHTTPServer(t *testing.T) {
+	cfg := &Builder{ZipkinHTTPServer: ZipkinHTTPServerConfiguration{HostPort: "127.0.0.1:0"}}
+	agent, err := cfg.CreateAgent(zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, agent.processors, 1)
+	agent.processors[0].Stop()
+
+	cfg = &Builder{ZipkinHTTPServer: ZipkinHTTPServerConfiguration{HostPort: "bad-host-port"}}
+	_, err = cfg.CreateAgent(zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot create Zipkin HTTP server")
+}
+
 func TestBuilderMetrics(t *testing.T) {
 	mf := metrics.NullFactory
 	b := new(Builder).WithMetricsFactory(mf)
@@ -20,6 +20,8 @@ import (
 	"strings"
 
 	"github.com/spf13/viper"
+
+	grpcreporter "github.com/jaegertracing/jaeger/cmd/agent/app/reporter/grpc"
 )
 
 const (
@@ -29,8 +31,13 @@ const (
 	suffixServerHostPort      = "server-host-port"
 	collectorHostPort         = "collector.host-port"
 	httpServerHostPort        = "http-server.host-port"
+	zipkinHTTPServerHostPort  = "zipkin-http-server.host-port"
 	discoveryMinPeers         = "discovery.min-peers"
 	discoveryConnCheckTimeout = "discovery.conn-check-timeout"
+	reporterType              = "reporter.type"
+	grpcHostPort              = "reporter.grpc.host-port"
+	grpcTimeout               = "reporter.grpc.timeout"
+	grpcMaxRetries            = "reporter.grpc.retry.max"
 )
 
 var defaultProcessors = []struct {
@@ -60,6 +67,10 @@ func AddFlags(flags *flag.FlagSet) {
 		httpServerHostPort,
 		defaultHTTPServerHostPort,
 		"host:port of the http server (e.g. for /sampling point and /baggage endpoint)")
+	flags.String(
+		zipkinHTTPServerHostPort,
+		"",
+		"host:port of the http server receiving Zipkin v1/v2 JSON and Thrift spans on /api/v1/spans and /api/v2/spans, e.g. :9411 (disabled if empty)")
 	flags.Int(
 		discoveryMinPeers,
 		defaultMinPeers,
@@ -68,6 +79,22 @@ func AddFlags(flags *flag.FlagSet) {
 		discoveryConnCheckTimeout,
 		defaultConnCheckTimeout,
 		"sets the timeout used when establishing new connections")
+	flags.String(
+		reporterType,
+		string(tchannelReporter),
+		"reporter type used to forward spans to collectors, tchannel or grpc (sampling strategies and baggage restrictions are always fetched over tchannel from --collector.host-port)")
+	flags.String(
+		grpcHostPort,
+		"",
+		"comma-separated string representing host:ports of a static list of collectors to connect to with the grpc reporter")
+	flags.Duration(
+		grpcTimeout,
+		grpcreporter.DefaultTimeout,
+		"timeout of a single attempt of the grpc reporter to submit a batch")
+	flags.Uint(
+		grpcMaxRetries,
+		grpcreporter.DefaultMaxRetries,
+		"how many times the grpc reporter retries a failed batch submission")
 }
 
 // InitFromViper initializes Builder with properties retrieved from Viper.
@@ -88,7 +115,15 @@ func (b *Builder) InitFromViper(v *viper.Viper) *Builder {
 		b.CollectorHostPorts = strings.Split(v.GetString(collectorHostPort), ",")
 	}
 	b.HTTPServer.HostPort = v.GetString(httpServerHostPort)
+	b.ZipkinHTTPServer.HostPort = v.GetString(zipkinHTTPServerHostPort)
 	b.DiscoveryMinPeers = v.GetInt(discoveryMinPeers)
 	b.ConnCheckTimeout = v.GetDuration(discoveryConnCheckTimeout)
+	b.ReporterType = ReporterType(v.GetString(reporterType))
+	if len(v.GetString(grpcHostPort)) > 0 {
+		b.GRPC.CollectorHostPorts = strings.Split(v.GetString(grpcHostPort), ",")
+	}
+	b.GRPC.Timeout = v.GetDuration(grpcTimeout)
+	maxRetries := uint(v.GetInt(grpcMaxRetries))
+	b.GRPC.MaxRetries = &maxRetries
 	return b
 }
@@ -17,6 +17,7 @@ package app
 import (
 	"flag"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -37,10 +38,15 @@ func TestBingFlags(t *testing.T) {
 		"--collector.host-port=1.2.3.4:555,1.2.3.4:666",
 		"--discovery.min-peers=42",
 		"--http-server.host-port=:8080",
+		"--zipkin-http-server.host-port=:9411",
 		"--processor.jaeger-binary.server-host-port=:1111",
 		"--processor.jaeger-binary.server-max-packet-size=4242",
 		"--processor.jaeger-binary.server-queue-size=42",
 		"--processor.jaeger-binary.workers=42",
+		"--reporter.type=grpc",
+		"--reporter.grpc.host-port=1.2.3.4:777,1.2.3.4:888",
+		"--reporter.grpc.timeout=3s",
+		"--reporter.grpc.retry.max=5",
 	})
 	require.NoError(t, err)
 
@@ -49,8 +55,14 @@ func TestBingFlags(t *testing.T) {
 	assert.Equal(t, []string{"1.2.3.4:555", "1.2.3.4:666"}, b.CollectorHostPorts)
 	assert.Equal(t, 42, b.DiscoveryMinPeers)
 	assert.Equal(t, ":8080", b.HTTPServer.HostPort)
+	assert.Equal(t, ":9411", b.ZipkinHTTPServer.HostPort)
 	assert.Equal(t, ":1111", b.Processors[2].Server.HostPort)
 	assert.Equal(t, 4242, b.Processors[2].Server.MaxPacketSize)
 	assert.Equal(t, 42, b.Processors[2].Server.QueueSize)
 	assert.Equal(t, 42, b.Processors[2].Workers)
+	assert.Equal(t, grpcReporter, b.ReporterType)
+	assert.Equal(t, []string{"1.2.3.4:777", "1.2.3.4:888"}, b.GRPC.CollectorHostPorts)
+	assert.Equal(t, 3*time.Second, b.GRPC.Timeout)
+	require.NotNil(t, b.GRPC.MaxRetries)
+	assert.Equal(t, uint(5), *b.GRPC.MaxRetries)
 }
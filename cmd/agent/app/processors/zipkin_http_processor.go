@@ -0,0 +1,149 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processors
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/agent/app/reporter"
+	"github.com/jaegertracing/jaeger/pkg/zipkin"
+	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
+)
+
+const (
+	unableToReadBodyErrFormat = "Unable to process request body: %v"
+
+	// zipkinHTTPReadTimeout bounds the time to read a request, so that slow clients cannot hold connections open
+	zipkinHTTPReadTimeout = 30 * time.Second
+	// zipkinHTTPMaxBodySize bounds the size of a request body, before and after it is decompressed
+	zipkinHTTPMaxBodySize = 5 * 1024 * 1024
+)
+
+// ZipkinHTTPProcessor is a server that receives Zipkin spans over HTTP, in the same formats as the
+// Zipkin endpoints of the collector, and forwards them with the reporter
+type ZipkinHTTPProcessor struct {
+	server      *http.Server
+	listener    net.Listener
+	reporter    reporter.Reporter
+	maxBodySize int64
+	logger      *zap.Logger
+}
+
+// NewZipkinHTTPProcessor creates a ZipkinHTTPProcessor listening on hostPort
+func NewZipkinHTTPProcessor(hostPort string, reporter reporter.Reporter, logger *zap.Logger) (*ZipkinHTTPProcessor, error) {
+	listener, err := net.Listen("tcp", hostPort)
+	if err != nil {
+		return nil, err
+	}
+	s := &ZipkinHTTPProcessor{
+		listener:    listener,
+		reporter:    reporter,
+		maxBodySize: zipkinHTTPMaxBodySize,
+		logger:      logger,
+	}
+	r := mux.NewRouter()
+	r.HandleFunc("/api/v1/spans", s.saveSpans(zipkin.DeserializeV1)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/spans", s.saveSpans(zipkin.DeserializeV2)).Methods(http.MethodPost)
+	s.server = &http.Server{Handler: r, ReadTimeout: zipkinHTTPReadTimeout}
+	return s, nil
+}
+
+// Addr returns the address the processor listens on
+func (s *ZipkinHTTPProcessor) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Serve serves the HTTP requests until the processor is stopped
+func (s *ZipkinHTTPProcessor) Serve() {
+	s.logger.Info("Listening for Zipkin HTTP traffic", zap.Stringer("address", s.listener.Addr()))
+	if err := s.server.Serve(s.listener); err != nil && err != http.ErrServerClosed {
+		s.logger.Error("Zipkin http server failure", zap.Error(err))
+	}
+}
+
+// Stop stops serving
+func (s *ZipkinHTTPProcessor) Stop() {
+	s.server.Close()
+	// the listener is only closed by the server once it serves
+	s.listener.Close()
+}
+
+// saveSpans returns a handler that decodes the request body with the deserializer of the API version
+// and emits the spans with the reporter
+func (s *ZipkinHTTPProcessor) saveSpans(
+	deserialize func(contentType string, body []byte) ([]*zipkincore.Span, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		var body io.ReadCloser = http.MaxBytesReader(w, r.Body, s.maxBodySize)
+		if strings.Contains(r.Header.Get("Content-Encoding"), "gzip") {
+			gz, err := gzip.NewReader(body)
+			if err != nil {
+				s.handleBodyError(w, err, http.StatusBadRequest)
+				return
+			}
+			defer gz.Close()
+			// a small compressed body can inflate to a much larger one
+			body = http.MaxBytesReader(w, gz, s.maxBodySize)
+		}
+		bodyBytes, err := ioutil.ReadAll(body)
+		if err != nil {
+			s.handleBodyError(w, err, http.StatusInternalServerError)
+			return
+		}
+		contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Cannot parse Content-Type: %v", err), http.StatusBadRequest)
+			return
+		}
+		spans, err := deserialize(contentType, bodyBytes)
+		if err == zipkin.ErrUnsupportedContentType {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf(unableToReadBodyErrFormat, err), http.StatusBadRequest)
+			return
+		}
+		if len(spans) > 0 {
+			if err := s.reporter.EmitZipkinBatch(spans); err != nil {
+				http.Error(w, fmt.Sprintf("Cannot submit Zipkin batch: %v", err), http.StatusInternalServerError)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// handleBodyError responds to a request whose body could not be read with statusCode,
+// or with 413 if the body exceeds the maximum size
+func (s *ZipkinHTTPProcessor) handleBodyError(w http.ResponseWriter, err error, statusCode int) {
+	// http.MaxBytesReader does not export its error
+	if err.Error() == "http: request body too large" {
+		statusCode = http.StatusRequestEntityTooLarge
+	}
+	http.Error(w, fmt.Sprintf(unableToReadBodyErrFormat, err), statusCode)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processors
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/agent/app/testutils"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
+)
+
+var zipkinHTTPClient = &http.Client{Timeout: 2 * time.Second}
+
+type failingReporter struct{}
+
+func (failingReporter) EmitZipkinBatch(spans []*zipkincore.Span) error {
+	return errors.New("collector down")
+}
+
+func (failingReporter) EmitBatch(batch *jaeger.Batch) error {
+	return errors.New("collector down")
+}
+
+func postZipkin(t *testing.T, url string, contentType string, body string) int {
+	res, err := zipkinHTTPClient.Post(url, contentType, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	res.Body.Close()
+	return res.StatusCode
+}
+
+func TestZipkinHTTPProcessor(t *testing.T) {
+	reporter := testutils.NewInMemoryReporter()
+	processor, err := NewZipkinHTTPProcessor("127.0.0.1:0", reporter, zap.NewNop())
+	require.NoError(t, err)
+	go processor.Serve()
+	defer processor.Stop()
+	url := "http://" + processor.Addr().String()
+
+	code := postZipkin(t, url+"/api/v1/spans", "application/json",
+		`[{"traceId":"1111111111111111","id":"1111111111111111","name":"v1"}]`)
+	assert.Equal(t, http.StatusAccepted, code)
+
+	code = postZipkin(t, url+"/api/v2/spans", "application/json",
+		`[{"traceId":"1111111111111111","id":"2222222222222222","name":"v2"}]`)
+	assert.Equal(t, http.StatusAccepted, code)
+
+	spans := reporter.ZipkinSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "v1", spans[0].Name)
+	assert.Equal(t, "v2", spans[1].Name)
+
+	code = postZipkin(t, url+"/api/v2/spans", "text/plain", "[]")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func postGzippedZipkin(t *testing.T, url string, body string) int {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	req, err := http.NewRequest(http.MethodPost, url, &buf)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	res, err := zipkinHTTPClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	return res.StatusCode
+}
+
+func TestZipkinHTTPProcessorBodySize(t *testing.T) {
+	reporter := testutils.NewInMemoryReporter()
+	processor, err := NewZipkinHTTPProcessor("127.0.0.1:0", reporter, zap.NewNop())
+	require.NoError(t, err)
+	processor.maxBodySize = 100
+	go processor.Serve()
+	defer processor.Stop()
+	url := "http://" + processor.Addr().String() + "/api/v2/spans"
+	spans := `[{"traceId":"1111111111111111","id":"2222222222222222","name":"v2"}]`
+	tooManySpans := "[" + strings.Repeat(`{"traceId":"1111111111111111","id":"2222222222222222"},`, 10) + "{}]"
+
+	assert.Equal(t, http.StatusAccepted, postGzippedZipkin(t, url, spans))
+	assert.Len(t, reporter.ZipkinSpans(), 1)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, postZipkin(t, url, "application/json", tooManySpans))
+	// the compressed body is smaller than the maximum size, but not once decompressed
+	assert.Equal(t, http.StatusRequestEntityTooLarge, postGzippedZipkin(t, url, tooManySpans))
+	assert.Len(t, reporter.ZipkinSpans(), 1)
+
+	assert.Equal(t, zipkinHTTPReadTimeout, processor.server.ReadTimeout)
+}
+
+func TestZipkinHTTPProcessorReporterError(t *testing.T) {
+	processor, err := NewZipkinHTTPProcessor("127.0.0.1:0", failingReporter{}, zap.NewNop())
+	require.NoError(t, err)
+	go processor.Serve()
+	defer processor.Stop()
+
+	code := postZipkin(t, "http://"+processor.Addr().String()+"/api/v2/spans", "application/json",
+		`[{"traceId":"1111111111111111","id":"2222222222222222"}]`)
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestZipkinHTTPProcessorListenError(t *testing.T) {
+	_, err := NewZipkinHTTPProcessor("bad-host-port", testutils.NewInMemoryReporter(), zap.NewNop())
+	assert.Error(t, err)
+}
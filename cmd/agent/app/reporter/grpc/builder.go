@@ -0,0 +1,151 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+
+	"github.com/jaegertracing/jaeger/pkg/discovery"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a single PostSpans call.
+	DefaultTimeout = time.Second
+	// DefaultMaxRetries is the default number of times a failed PostSpans call is retried.
+	DefaultMaxRetries = 3
+)
+
+var errNoCollectors = errors.New("gRPC reporter requires either collector host:ports or a discovery.Discoverer")
+
+// Builder Struct to hold configurations
+type Builder struct {
+	// CollectorHostPorts are host:ports of a static list of Jaeger Collectors.
+	CollectorHostPorts []string `yaml:"collectorHostPorts"`
+
+	// Timeout is the timeout of a single attempt to submit a batch.
+	// If zero, defaults to DefaultTimeout.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// MaxRetries is how many times a failed submission is retried, each time
+	// on the next collector selected by the round-robin balancer.
+	// If not set, defaults to DefaultMaxRetries; zero disables retries.
+	MaxRetries *uint `yaml:"maxRetries"`
+
+	discoverer discovery.Discoverer
+	notifier   discovery.Notifier
+}
+
+// NewBuilder creates a new reporter builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// WithDiscoverer sets service discovery
+func (b *Builder) WithDiscoverer(d discovery.Discoverer) *Builder {
+	b.discoverer = d
+	return b
+}
+
+// WithDiscoveryNotifier sets service discovery notifier, used to update
+// the set of collectors the reporter balances across.
+func (b *Builder) WithDiscoveryNotifier(n discovery.Notifier) *Builder {
+	b.notifier = n
+	return b
+}
+
+// CreateReporter creates the gRPC-based Reporter
+func (b *Builder) CreateReporter(mFactory metrics.Factory, logger *zap.Logger) (*Reporter, error) {
+	// Use static collectors if specified.
+	if len(b.CollectorHostPorts) != 0 {
+		d := discovery.FixedDiscoverer(b.CollectorHostPorts)
+		b = b.WithDiscoverer(d).WithDiscoveryNotifier(&discovery.Dispatcher{})
+	}
+	if b.discoverer == nil {
+		return nil, errNoCollectors
+	}
+	instances, err := b.discoverer.Instances()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot discover collectors")
+	}
+	logger.Info("Creating gRPC reporter", zap.Strings("collectors", instances))
+
+	r, cleanupResolver := manual.GenerateAndRegisterManualResolver()
+	r.InitialAddrs(toAddresses(instances))
+	conn, err := grpc.Dial(
+		r.Scheme()+":///round_robin",
+		grpc.WithInsecure(),
+		grpc.WithBalancerName(roundrobin.Name),
+	)
+	if err != nil {
+		cleanupResolver()
+		return nil, errors.Wrap(err, "cannot dial collectors")
+	}
+
+	reporter := New(conn, defaultDuration(b.Timeout, DefaultTimeout), b.maxRetries(), mFactory, logger)
+	reporter.closeFn = cleanupResolver
+	if b.notifier != nil {
+		ch := make(chan []string)
+		stop := make(chan struct{})
+		b.notifier.Register(ch)
+		go func() {
+			for {
+				select {
+				case instances := <-ch:
+					logger.Info("Updating collectors of gRPC reporter", zap.Strings("collectors", instances))
+					r.NewAddress(toAddresses(instances))
+				case <-stop:
+					return
+				}
+			}
+		}()
+		reporter.closeFn = func() {
+			// unregister while the goroutine is still draining ch, so that a concurrent Notify cannot block
+			b.notifier.Unregister(ch)
+			close(stop)
+			cleanupResolver()
+		}
+	}
+	return reporter, nil
+}
+
+func (b *Builder) maxRetries() uint {
+	if b.MaxRetries == nil {
+		return DefaultMaxRetries
+	}
+	return *b.MaxRetries
+}
+
+func toAddresses(instances []string) []resolver.Address {
+	addresses := make([]resolver.Address, len(instances))
+	for i, instance := range instances {
+		addresses[i] = resolver.Address{Addr: instance}
+	}
+	return addresses
+}
+
+func defaultDuration(value, defaultVal time.Duration) time.Duration {
+	if value == 0 {
+		return defaultVal
+	}
+	return value
+}
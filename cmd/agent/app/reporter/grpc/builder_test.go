@@ -0,0 +1,94 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	"github.com/jaegertracing/jaeger/pkg/discovery"
+)
+
+var yamlConfig = `
+collectorHostPorts:
+    - 127.0.0.1:14250
+    - 127.0.0.1:14251
+timeout: 2s
+maxRetries: 5
+`
+
+func TestBuilderFromConfig(t *testing.T) {
+	cfg := Builder{}
+	err := yaml.Unmarshal([]byte(yamlConfig), &cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1:14250", "127.0.0.1:14251"}, cfg.CollectorHostPorts)
+	assert.Equal(t, 2*time.Second, cfg.Timeout)
+	require.NotNil(t, cfg.MaxRetries)
+	assert.Equal(t, uint(5), *cfg.MaxRetries)
+}
+
+func TestBuilderDefaults(t *testing.T) {
+	cfg := NewBuilder()
+	cfg.CollectorHostPorts = []string{"127.0.0.1:14250"}
+	reporter, err := cfg.CreateReporter(metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	defer reporter.Close()
+	assert.Equal(t, DefaultTimeout, reporter.timeout)
+	assert.Equal(t, uint(DefaultMaxRetries), reporter.maxRetries)
+	assert.NotNil(t, cfg.discoverer)
+	assert.NotNil(t, cfg.notifier)
+}
+
+func TestBuilderNoRetries(t *testing.T) {
+	cfg := Builder{}
+	err := yaml.Unmarshal([]byte("collectorHostPorts: [\"127.0.0.1:14250\"]\nmaxRetries: 0"), &cfg)
+	require.NoError(t, err)
+	reporter, err := cfg.CreateReporter(metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	defer reporter.Close()
+	assert.Equal(t, uint(0), reporter.maxRetries)
+}
+
+func TestBuilderWithDiscovery(t *testing.T) {
+	_, err := NewBuilder().CreateReporter(metrics.NullFactory, zap.NewNop())
+	assert.Equal(t, errNoCollectors, err)
+
+	_, err = NewBuilder().WithDiscoverer(fakeDiscoverer{}).CreateReporter(metrics.NullFactory, zap.NewNop())
+	assert.EqualError(t, err, "cannot discover collectors: discoverer error")
+
+	notifier := &discovery.Dispatcher{}
+	reporter, err := NewBuilder().
+		WithDiscoverer(discovery.FixedDiscoverer([]string{"127.0.0.1:14250"})).
+		WithDiscoveryNotifier(notifier).
+		CreateReporter(metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	notifier.Notify([]string{"127.0.0.1:14251"})
+	require.NoError(t, reporter.Close())
+	// the reporter must have unregistered from the notifier
+	notifier.Notify([]string{"127.0.0.1:14252"})
+}
+
+type fakeDiscoverer struct{}
+
+func (fakeDiscoverer) Instances() ([]string, error) {
+	return nil, errors.New("discoverer error")
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	zipkinS "github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer/zipkin"
+	"github.com/jaegertracing/jaeger/model"
+	jConverter "github.com/jaegertracing/jaeger/model/converter/thrift/jaeger"
+	zConverter "github.com/jaegertracing/jaeger/model/converter/thrift/zipkin"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
+)
+
+const (
+	jaegerBatches = "jaeger"
+	zipkinBatches = "zipkin"
+)
+
+type batchMetrics struct {
+	// Number of successful batch submissions to collector
+	BatchesSubmitted metrics.Counter `metric:"batches.submitted"`
+
+	// Number of failed batch submissions to collector
+	BatchesFailures metrics.Counter `metric:"batches.failures"`
+
+	// Number of spans in a batch submitted to collector
+	BatchSize metrics.Gauge `metric:"batch_size"`
+
+	// Number of successful span submissions to collector
+	SpansSubmitted metrics.Counter `metric:"spans.submitted"`
+
+	// Number of failed span submissions to collector
+	SpansFailures metrics.Counter `metric:"spans.failures"`
+
+	// Number of retried submissions to collector
+	Retries metrics.Counter `metric:"retries"`
+}
+
+// Reporter forwards received spans to central collector tier over gRPC.
+type Reporter struct {
+	conn           *grpc.ClientConn
+	client         model.CollectorServiceV2Client
+	timeout        time.Duration
+	maxRetries     uint
+	sanitizer      zipkinS.Sanitizer
+	batchesMetrics map[string]batchMetrics
+	logger         *zap.Logger
+	closeFn        func()
+}
+
+// New creates a gRPC-based Reporter that submits spans over the given connection.
+func New(conn *grpc.ClientConn, timeout time.Duration, maxRetries uint, mFactory metrics.Factory, zlogger *zap.Logger) *Reporter {
+	batchesMetrics := map[string]batchMetrics{}
+	for _, s := range []string{zipkinBatches, jaegerBatches} {
+		bm := batchMetrics{}
+		metrics.Init(&bm, mFactory.Namespace("grpc-reporter", map[string]string{"format": s}), nil)
+		batchesMetrics[s] = bm
+	}
+	return &Reporter{
+		conn:           conn,
+		client:         model.NewCollectorServiceV2Client(conn),
+		timeout:        timeout,
+		maxRetries:     maxRetries,
+		sanitizer:      zipkinS.NewChainedSanitizer(zipkinS.NewStandardSanitizers()...),
+		batchesMetrics: batchesMetrics,
+		logger:         zlogger,
+	}
+}
+
+// EmitBatch implements EmitBatch() of Reporter
+func (r *Reporter) EmitBatch(batch *jaeger.Batch) error {
+	spans := jConverter.ToDomain(batch.Spans, batch.Process)
+	mBatch := &model.Batch{Spans: spans}
+	if len(spans) > 0 {
+		// all spans share the same process, send it only once
+		mBatch.Process = *spans[0].Process
+		for _, span := range spans {
+			span.Process = nil
+		}
+	}
+	return r.send(mBatch, "Could not submit jaeger batch", r.batchesMetrics[jaegerBatches])
+}
+
+// EmitZipkinBatch implements EmitZipkinBatch() of Reporter
+func (r *Reporter) EmitZipkinBatch(zSpans []*zipkincore.Span) error {
+	sanitized := make([]*zipkincore.Span, len(zSpans))
+	for i, span := range zSpans {
+		sanitized[i] = r.sanitizer.Sanitize(span)
+	}
+	trace, err := zConverter.ToDomain(sanitized)
+	if err != nil {
+		// the conversion always returns usable spans, errors are only informational
+		r.logger.Warn("Warning while converting zipkin to domain spans", zap.Error(err))
+	}
+	return r.send(&model.Batch{Spans: trace.Spans}, "Could not submit zipkin batch", r.batchesMetrics[zipkinBatches])
+}
+
+func (r *Reporter) send(batch *model.Batch, errMsg string, batchMetrics batchMetrics) error {
+	size := int64(len(batch.Spans))
+	req := &model.PostSpansRequest{Batch: batch}
+	var err error
+	for attempt := uint(0); attempt <= r.maxRetries; attempt++ {
+		if attempt > 0 {
+			batchMetrics.Retries.Inc(1)
+		}
+		if err = r.post(req); err == nil || !isRetryable(err) {
+			break
+		}
+	}
+	if err != nil {
+		batchMetrics.BatchesFailures.Inc(1)
+		batchMetrics.SpansFailures.Inc(size)
+		r.logger.Error(errMsg, zap.Error(err))
+		return err
+	}
+
+	r.logger.Debug("Span batch submitted by the agent", zap.Int64("span-count", size))
+	batchMetrics.BatchSize.Update(size)
+	batchMetrics.BatchesSubmitted.Inc(1)
+	batchMetrics.SpansSubmitted.Inc(size)
+	return nil
+}
+
+func (r *Reporter) post(req *model.PostSpansRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	_, err := r.client.PostSpans(ctx, req, grpc.FailFast(false))
+	return err
+}
+
+// isRetryable returns false for errors that would fail the same way on any collector.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.Unimplemented, codes.PermissionDenied, codes.Unauthenticated:
+		return false
+	default:
+		return true
+	}
+}
+
+// Close stops watching for collector changes and closes the underlying gRPC connection.
+func (r *Reporter) Close() error {
+	if r.closeFn != nil {
+		r.closeFn()
+	}
+	return r.conn.Close()
+}
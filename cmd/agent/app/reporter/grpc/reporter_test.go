@@ -0,0 +1,164 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	mTestutils "github.com/uber/jaeger-lib/metrics/testutils"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
+)
+
+type mockCollector struct {
+	sync.Mutex
+	requests []*model.PostSpansRequest
+	errors   []error // returned by subsequent calls, nil once exhausted
+}
+
+func (c *mockCollector) PostSpans(ctx context.Context, r *model.PostSpansRequest) (*model.PostSpansResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+	c.requests = append(c.requests, r)
+	if len(c.errors) > 0 {
+		err := c.errors[0]
+		c.errors = c.errors[1:]
+		return nil, err
+	}
+	return &model.PostSpansResponse{Ok: true}, nil
+}
+
+func (c *mockCollector) getRequests() []*model.PostSpansRequest {
+	c.Lock()
+	defer c.Unlock()
+	return c.requests
+}
+
+func initRequirements(t *testing.T, errs ...error) (*metrics.LocalFactory, *mockCollector, *Reporter, func()) {
+	collector := &mockCollector{errors: errs}
+	server := grpc.NewServer()
+	model.RegisterCollectorServiceV2Server(server, collector)
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+
+	metricsFactory := metrics.NewLocalFactory(0)
+	maxRetries := uint(2)
+	b := &Builder{CollectorHostPorts: []string{lis.Addr().String()}, Timeout: time.Second, MaxRetries: &maxRetries}
+	reporter, err := b.CreateReporter(metricsFactory, zap.NewNop())
+	require.NoError(t, err)
+	return metricsFactory, collector, reporter, func() {
+		reporter.Close()
+		server.Stop()
+	}
+}
+
+func TestJaegerGRPCReporterSuccess(t *testing.T) {
+	metricsFactory, collector, reporter, closer := initRequirements(t)
+	defer closer()
+
+	batch := &jaeger.Batch{
+		Process: &jaeger.Process{ServiceName: "svc"},
+		Spans:   []*jaeger.Span{{OperationName: "span1", TraceIdLow: 1, SpanId: 2}},
+	}
+	require.NoError(t, reporter.EmitBatch(batch))
+
+	requests := collector.getRequests()
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].Batch.Spans, 1)
+	assert.Equal(t, "span1", requests[0].Batch.Spans[0].OperationName)
+	assert.Equal(t, model.NewTraceID(0, 1), requests[0].Batch.Spans[0].TraceID)
+	assert.Equal(t, "svc", requests[0].Batch.Process.ServiceName)
+	checkCounters(t, metricsFactory, 1, 1, 0, 0, 0, "jaeger")
+}
+
+func TestZipkinGRPCReporterSuccess(t *testing.T) {
+	metricsFactory, collector, reporter, closer := initRequirements(t)
+	defer closer()
+
+	span := zipkincore.NewSpan()
+	span.Name = "span1"
+	span.Annotations = []*zipkincore.Annotation{
+		{Value: zipkincore.SERVER_RECV, Host: &zipkincore.Endpoint{ServiceName: "svc"}},
+	}
+	require.NoError(t, reporter.EmitZipkinBatch([]*zipkincore.Span{span}))
+
+	requests := collector.getRequests()
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].Batch.Spans, 1)
+	assert.Equal(t, "span1", requests[0].Batch.Spans[0].OperationName)
+	assert.Equal(t, "svc", requests[0].Batch.Spans[0].Process.ServiceName)
+	checkCounters(t, metricsFactory, 1, 1, 0, 0, 0, "zipkin")
+}
+
+func TestGRPCReporterRetries(t *testing.T) {
+	metricsFactory, collector, reporter, closer := initRequirements(t, status.Error(codes.Unavailable, "try again"))
+	defer closer()
+
+	require.NoError(t, reporter.EmitBatch(&jaeger.Batch{
+		Process: &jaeger.Process{ServiceName: "svc"},
+		Spans:   []*jaeger.Span{{OperationName: "span1"}},
+	}))
+	assert.Len(t, collector.getRequests(), 2)
+	checkCounters(t, metricsFactory, 1, 1, 0, 0, 1, "jaeger")
+}
+
+func TestGRPCReporterFailure(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "try again")
+	metricsFactory, collector, reporter, closer := initRequirements(t, unavailable, unavailable, unavailable)
+	defer closer()
+
+	require.Error(t, reporter.EmitBatch(&jaeger.Batch{
+		Process: &jaeger.Process{ServiceName: "svc"},
+		Spans:   []*jaeger.Span{{OperationName: "span1"}},
+	}))
+	assert.Len(t, collector.getRequests(), 3)
+	checkCounters(t, metricsFactory, 0, 0, 1, 1, 2, "jaeger")
+}
+
+func TestGRPCReporterDoesNotRetryInvalidArgument(t *testing.T) {
+	metricsFactory, collector, reporter, closer := initRequirements(t, status.Error(codes.InvalidArgument, "bad batch"))
+	defer closer()
+
+	require.Error(t, reporter.EmitBatch(&jaeger.Batch{
+		Process: &jaeger.Process{ServiceName: "svc"},
+		Spans:   []*jaeger.Span{{OperationName: "span1"}},
+	}))
+	assert.Len(t, collector.getRequests(), 1)
+	checkCounters(t, metricsFactory, 0, 0, 1, 1, 0, "jaeger")
+}
+
+func checkCounters(t *testing.T, mf *metrics.LocalFactory, batchesSubmitted, spansSubmitted, batchesFailures, spansFailures, retries int, format string) {
+	mTestutils.AssertCounterMetrics(t, mf, []mTestutils.ExpectedMetric{
+		{Name: "grpc-reporter.batches.submitted", Tags: map[string]string{"format": format}, Value: batchesSubmitted},
+		{Name: "grpc-reporter.spans.submitted", Tags: map[string]string{"format": format}, Value: spansSubmitted},
+		{Name: "grpc-reporter.batches.failures", Tags: map[string]string{"format": format}, Value: batchesFailures},
+		{Name: "grpc-reporter.spans.failures", Tags: map[string]string{"format": format}, Value: spansFailures},
+		{Name: "grpc-reporter.retries", Tags: map[string]string{"format": format}, Value: retries},
+	}...)
+}
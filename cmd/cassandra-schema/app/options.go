@@ -0,0 +1,122 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/jaegertracing/jaeger/plugin/storage/cassandra/schema"
+)
+
+const (
+	schemaDir         = "schema.dir"
+	mode              = "schema.mode"
+	datacenter        = "schema.datacenter"
+	replicationFactor = "schema.replication-factor"
+	traceTTL          = "schema.trace-ttl"
+	dependenciesTTL   = "schema.dependencies-ttl"
+
+	modeProd = "prod"
+	modeTest = "test"
+)
+
+// Options holds the parameters used to create or upgrade the schema, mirroring the ones of create.sh.
+type Options struct {
+	SchemaDir         string
+	Mode              string
+	Datacenter        string
+	ReplicationFactor int
+	TraceTTL          time.Duration
+	DependenciesTTL   time.Duration
+}
+
+// AddFlags adds flags for Options
+func AddFlags(flagSet *flag.FlagSet) {
+	flagSet.String(
+		schemaDir,
+		"./plugin/storage/cassandra/schema",
+		"The directory containing the v###.cql.tmpl schema templates")
+	flagSet.String(
+		mode,
+		modeTest,
+		"prod or test. Test keyspace is usable on a single node cluster (no replication)")
+	flagSet.String(
+		datacenter,
+		"",
+		"The datacenter name for the network topology used in prod mode")
+	flagSet.Int(
+		replicationFactor,
+		0,
+		"The replication factor of the keyspace (default 2 for prod, 1 for test)")
+	flagSet.Duration(
+		traceTTL,
+		48*time.Hour,
+		"The time to live for trace data")
+	flagSet.Duration(
+		dependenciesTTL,
+		0,
+		"The time to live for dependencies data, 0 means no TTL")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (o *Options) InitFromViper(v *viper.Viper) *Options {
+	o.SchemaDir = v.GetString(schemaDir)
+	o.Mode = v.GetString(mode)
+	o.Datacenter = v.GetString(datacenter)
+	o.ReplicationFactor = v.GetInt(replicationFactor)
+	o.TraceTTL = v.GetDuration(traceTTL)
+	o.DependenciesTTL = v.GetDuration(dependenciesTTL)
+	return o
+}
+
+// Params returns the template parameters for the given keyspace.
+func (o *Options) Params(keyspace string) (schema.Params, error) {
+	replication, err := o.replication()
+	if err != nil {
+		return schema.Params{}, err
+	}
+	return schema.Params{
+		Keyspace:        keyspace,
+		Replication:     replication,
+		TraceTTL:        o.TraceTTL,
+		DependenciesTTL: o.DependenciesTTL,
+	}, nil
+}
+
+func (o *Options) replication() (string, error) {
+	switch o.Mode {
+	case modeProd:
+		if o.Datacenter == "" {
+			return "", fmt.Errorf("missing --%s parameter for %s mode", datacenter, modeProd)
+		}
+		factor := o.ReplicationFactor
+		if factor == 0 {
+			factor = 2
+		}
+		return fmt.Sprintf("{'class': 'NetworkTopologyStrategy', '%s': '%d' }", o.Datacenter, factor), nil
+	case modeTest:
+		factor := o.ReplicationFactor
+		if factor == 0 {
+			factor = 1
+		}
+		return fmt.Sprintf("{'class': 'SimpleStrategy', 'replication_factor': '%d'}", factor), nil
+	default:
+		return "", fmt.Errorf("invalid --%s=%s, expecting %q or %q", mode, o.Mode, modeProd, modeTest)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/plugin/storage/cassandra/schema"
+)
+
+func TestOptionsWithFlags(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--schema.dir=/cassandra-schema",
+		"--schema.mode=prod",
+		"--schema.datacenter=dc1",
+		"--schema.replication-factor=3",
+		"--schema.trace-ttl=24h",
+		"--schema.dependencies-ttl=720h",
+	})
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, Options{
+		SchemaDir:         "/cassandra-schema",
+		Mode:              "prod",
+		Datacenter:        "dc1",
+		ReplicationFactor: 3,
+		TraceTTL:          24 * time.Hour,
+		DependenciesTTL:   720 * time.Hour,
+	}, *opts)
+}
+
+func TestOptionsDefaults(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{})
+	opts := new(Options).InitFromViper(v)
+	params, err := opts.Params("jaeger_v1_test")
+	require.NoError(t, err)
+	assert.Equal(t, schema.Params{
+		Keyspace:        "jaeger_v1_test",
+		Replication:     "{'class': 'SimpleStrategy', 'replication_factor': '1'}",
+		TraceTTL:        48 * time.Hour,
+		DependenciesTTL: 0,
+	}, params)
+}
+
+func TestOptionsReplication(t *testing.T) {
+	testCases := []struct {
+		options     Options
+		replication string
+		err         string
+	}{
+		{
+			options:     Options{Mode: "test", ReplicationFactor: 2},
+			replication: "{'class': 'SimpleStrategy', 'replication_factor': '2'}",
+		},
+		{
+			options:     Options{Mode: "prod", Datacenter: "dc1"},
+			replication: "{'class': 'NetworkTopologyStrategy', 'dc1': '2' }",
+		},
+		{
+			options: Options{Mode: "prod"},
+			err:     "missing --schema.datacenter parameter for prod mode",
+		},
+		{
+			options: Options{Mode: "staging"},
+			err:     `invalid --schema.mode=staging, expecting "prod" or "test"`,
+		},
+	}
+	for _, testCase := range testCases {
+		params, err := testCase.options.Params("keyspace")
+		if testCase.err != "" {
+			assert.EqualError(t, err, testCase.err)
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, testCase.replication, params.Replication)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/cassandra-schema/app"
+	"github.com/jaegertracing/jaeger/cmd/flags"
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/pkg/version"
+	"github.com/jaegertracing/jaeger/plugin/storage/cassandra"
+	"github.com/jaegertracing/jaeger/plugin/storage/cassandra/schema"
+)
+
+func main() {
+	v := viper.New()
+	cOptions := cassandra.NewOptions("cassandra")
+	command := &cobra.Command{
+		Use:   "jaeger-cassandra-schema",
+		Short: "Jaeger cassandra-schema creates and upgrades the Cassandra keyspace used by Jaeger",
+		Long: `Jaeger cassandra-schema creates the Cassandra keyspace given by --cassandra.keyspace if it does not exist
+and applies all schema versions that have not been applied yet. The applied versions are recorded
+in the schema_migrations table of the keyspace, so running the command again is a no-op.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.TryLoadConfigFile(v); err != nil {
+				return err
+			}
+			logger, err := zap.NewProduction()
+			if err != nil {
+				return err
+			}
+			cOptions.InitFromViper(v)
+			schemaOptions := new(app.Options).InitFromViper(v)
+
+			// the keyspace may not exist yet, so the session must not be bound to it
+			cfg := *cOptions.GetPrimary()
+			params, err := schemaOptions.Params(cfg.Keyspace)
+			if err != nil {
+				return err
+			}
+			templates, err := schema.LoadTemplates(schemaOptions.SchemaDir)
+			if err != nil {
+				return err
+			}
+			cfg.Keyspace = ""
+			session, err := cfg.NewSession()
+			if err != nil {
+				return err
+			}
+			defer session.Close()
+
+			migrator, err := schema.NewMigrator(session, templates, params, logger)
+			if err != nil {
+				return err
+			}
+			schemaVersion, err := migrator.Migrate()
+			if err != nil {
+				return err
+			}
+			logger.Info("Cassandra schema is up to date", zap.String("keyspace", params.Keyspace), zap.Int("version", schemaVersion))
+			return nil
+		},
+	}
+
+	command.AddCommand(version.Command())
+
+	config.AddFlags(
+		v,
+		command,
+		flags.AddConfigFileFlag,
+		cOptions.AddFlags,
+		app.AddFlags,
+	)
+
+	if err := command.Execute(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
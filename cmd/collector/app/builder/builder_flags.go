@@ -16,10 +16,14 @@ package builder
 
 import (
 	"flag"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 
 	"github.com/jaegertracing/jaeger/cmd/collector/app"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/tailsampling"
 )
 
 const (
@@ -28,7 +32,20 @@ const (
 	collectorWriteCacheTTL = "collector.write-cache-ttl"
 	collectorPort          = "collector.port"
 	collectorHTTPPort      = "collector.http-port"
+	collectorGRPCPort      = "collector.grpc-port"
 	collectorZipkinHTTPort = "collector.zipkin.http-port"
+	downsamplingRatio      = "collector.downsampling.ratio"
+	downsamplingHashSalt   = "collector.downsampling.hashsalt"
+
+	tailSamplingDecisionWait     = "collector.tail-sampling.decision-wait"
+	tailSamplingMaxTraces        = "collector.tail-sampling.max-traces"
+	tailSamplingMaxSpans         = "collector.tail-sampling.max-spans-per-trace"
+	tailSamplingErrors           = "collector.tail-sampling.errors"
+	tailSamplingLatencyThreshold = "collector.tail-sampling.latency-threshold"
+	tailSamplingServices         = "collector.tail-sampling.services"
+	tailSamplingTags             = "collector.tail-sampling.tags"
+	tailSamplingProbability      = "collector.tail-sampling.probability"
+
 	// CollectorDefaultHealthCheckHTTPPort is the default HTTP Port for health check
 	CollectorDefaultHealthCheckHTTPPort = 14269
 )
@@ -43,8 +60,31 @@ type CollectorOptions struct {
 	CollectorPort int
 	// CollectorHTTPPort is the port that the collector service listens in on for http requests
 	CollectorHTTPPort int
+	// CollectorGRPCPort is the port that the collector service listens in on for gRPC requests
+	CollectorGRPCPort int
 	// CollectorZipkinHTTPPort is the port that the Zipkin collector service listens in on for http requests
 	CollectorZipkinHTTPPort int
+	// DownsamplingRatio is the fraction of traces written to the span storage
+	DownsamplingRatio float64
+	// DownsamplingHashSalt is the salt used when hashing trace IDs for downsampling
+	DownsamplingHashSalt string
+	// TailSamplingDecisionWait is how long spans are buffered per trace before deciding whether to keep the trace.
+	// Zero disables tail sampling
+	TailSamplingDecisionWait time.Duration
+	// TailSamplingMaxTraces is the maximum number of traces buffered for tail sampling
+	TailSamplingMaxTraces int
+	// TailSamplingMaxSpansPerTrace is the maximum number of spans buffered for a single trace
+	TailSamplingMaxSpansPerTrace int
+	// TailSamplingErrors keeps traces that contain spans with the error tag
+	TailSamplingErrors bool
+	// TailSamplingLatencyThreshold keeps traces that last at least this long. Zero disables the policy
+	TailSamplingLatencyThreshold time.Duration
+	// TailSamplingServices keeps traces that contain spans from any of these services
+	TailSamplingServices []string
+	// TailSamplingTags keeps traces that contain spans with any of these tag values
+	TailSamplingTags map[string]string
+	// TailSamplingProbability is the fraction of the remaining traces that are kept
+	TailSamplingProbability float64
 }
 
 // AddFlags adds flags for CollectorOptions
@@ -53,7 +93,27 @@ func AddFlags(flags *flag.FlagSet) {
 	flags.Int(collectorNumWorkers, app.DefaultNumWorkers, "The number of workers pulling items from the queue")
 	flags.Int(collectorPort, 14267, "The tchannel port for the collector service")
 	flags.Int(collectorHTTPPort, 14268, "The http port for the collector service")
+	flags.Int(collectorGRPCPort, 14250, "The gRPC port for the collector service, or 0 to disable it")
 	flags.Int(collectorZipkinHTTPort, 0, "The http port for the Zipkin collector service e.g. 9411")
+	flags.Float64(downsamplingRatio, 1.0, "Ratio of traces written to the span storage, e.g. 0.1 keeps 10% of the traces")
+	flags.String(downsamplingHashSalt, "", "Salt used when hashing trace IDs for downsampling")
+	flags.Duration(
+		tailSamplingDecisionWait,
+		0,
+		"How long to buffer the spans of a trace before deciding whether to keep it. Zero value disables tail sampling")
+	flags.Int(tailSamplingMaxTraces, tailsampling.DefaultMaxTraces, "The maximum number of traces buffered for tail sampling")
+	flags.Int(
+		tailSamplingMaxSpans,
+		tailsampling.DefaultMaxSpansPerTrace,
+		"The maximum number of spans buffered for a single trace. The decision for the trace is made when it is reached")
+	flags.Bool(tailSamplingErrors, true, "Keep traces that contain spans tagged with error=true")
+	flags.Duration(
+		tailSamplingLatencyThreshold,
+		0,
+		"Keep traces that last at least this long. Zero value disables the latency policy")
+	flags.String(tailSamplingServices, "", "Comma-separated list of services whose traces are kept")
+	flags.String(tailSamplingTags, "", "Comma-separated list of key=value span tags whose traces are kept, e.g. sampling.priority=1")
+	flags.Float64(tailSamplingProbability, 0, "Probability of keeping a trace that is not kept by any other tail sampling policy")
 }
 
 // InitFromViper initializes CollectorOptions with properties from viper
@@ -62,6 +122,66 @@ func (cOpts *CollectorOptions) InitFromViper(v *viper.Viper) *CollectorOptions {
 	cOpts.NumWorkers = v.GetInt(collectorNumWorkers)
 	cOpts.CollectorPort = v.GetInt(collectorPort)
 	cOpts.CollectorHTTPPort = v.GetInt(collectorHTTPPort)
+	cOpts.CollectorGRPCPort = v.GetInt(collectorGRPCPort)
 	cOpts.CollectorZipkinHTTPPort = v.GetInt(collectorZipkinHTTPort)
+	cOpts.DownsamplingRatio = v.GetFloat64(downsamplingRatio)
+	cOpts.DownsamplingHashSalt = v.GetString(downsamplingHashSalt)
+	cOpts.TailSamplingDecisionWait = v.GetDuration(tailSamplingDecisionWait)
+	cOpts.TailSamplingMaxTraces = v.GetInt(tailSamplingMaxTraces)
+	cOpts.TailSamplingMaxSpansPerTrace = v.GetInt(tailSamplingMaxSpans)
+	cOpts.TailSamplingErrors = v.GetBool(tailSamplingErrors)
+	cOpts.TailSamplingLatencyThreshold = v.GetDuration(tailSamplingLatencyThreshold)
+	cOpts.TailSamplingServices = splitList(v.GetString(tailSamplingServices))
+	cOpts.TailSamplingTags = make(map[string]string)
+	for _, tag := range splitList(v.GetString(tailSamplingTags)) {
+		if kv := strings.SplitN(tag, "=", 2); len(kv) == 2 {
+			cOpts.TailSamplingTags[kv[0]] = kv[1]
+		}
+	}
+	cOpts.TailSamplingProbability = v.GetFloat64(tailSamplingProbability)
 	return cOpts
 }
+
+// Validate returns an error if the options cannot be used to start the collector
+func (cOpts *CollectorOptions) Validate() error {
+	if cOpts.DownsamplingRatio < 0 || cOpts.DownsamplingRatio > 1 {
+		return fmt.Errorf("%s must be between 0 and 1, got %v", downsamplingRatio, cOpts.DownsamplingRatio)
+	}
+	return nil
+}
+
+// TailSamplingOptions returns the options for the tail sampling buffer, with the policies enabled by the flags
+func (cOpts *CollectorOptions) TailSamplingOptions() tailsampling.Options {
+	var policies []tailsampling.Policy
+	if cOpts.TailSamplingErrors {
+		policies = append(policies, tailsampling.ErrorPolicy())
+	}
+	if cOpts.TailSamplingLatencyThreshold > 0 {
+		policies = append(policies, tailsampling.LatencyPolicy(cOpts.TailSamplingLatencyThreshold))
+	}
+	if len(cOpts.TailSamplingServices) > 0 {
+		policies = append(policies, tailsampling.ServicePolicy(cOpts.TailSamplingServices...))
+	}
+	for key, value := range cOpts.TailSamplingTags {
+		policies = append(policies, tailsampling.TagPolicy(key, value))
+	}
+	if cOpts.TailSamplingProbability > 0 {
+		policies = append(policies, tailsampling.ProbabilisticPolicy(cOpts.TailSamplingProbability))
+	}
+	return tailsampling.Options{
+		DecisionWait:     cOpts.TailSamplingDecisionWait,
+		MaxTraces:        cOpts.TailSamplingMaxTraces,
+		MaxSpansPerTrace: cOpts.TailSamplingMaxSpansPerTrace,
+		Policies:         policies,
+	}
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/tailsampling"
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func TestTailSamplingFlags(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{})
+	cOpts := new(CollectorOptions).InitFromViper(v)
+	assert.Equal(t, time.Duration(0), cOpts.TailSamplingDecisionWait)
+	assert.Equal(t, tailsampling.DefaultMaxTraces, cOpts.TailSamplingMaxTraces)
+	assert.Equal(t, tailsampling.DefaultMaxSpansPerTrace, cOpts.TailSamplingMaxSpansPerTrace)
+	opts := cOpts.TailSamplingOptions()
+	assert.Len(t, opts.Policies, 1) // errors policy only
+
+	command.ParseFlags([]string{
+		"--collector.tail-sampling.decision-wait=10s",
+		"--collector.tail-sampling.max-traces=100",
+		"--collector.tail-sampling.max-spans-per-trace=10",
+		"--collector.tail-sampling.errors=false",
+		"--collector.tail-sampling.latency-threshold=1s",
+		"--collector.tail-sampling.services=foo, bar",
+		"--collector.tail-sampling.tags=sampling.priority=1,invalid,http.status_code=500",
+		"--collector.tail-sampling.probability=0.1",
+	})
+	cOpts = new(CollectorOptions).InitFromViper(v)
+	assert.Equal(t, 10*time.Second, cOpts.TailSamplingDecisionWait)
+	assert.Equal(t, 100, cOpts.TailSamplingMaxTraces)
+	assert.Equal(t, 10, cOpts.TailSamplingMaxSpansPerTrace)
+	assert.False(t, cOpts.TailSamplingErrors)
+	assert.Equal(t, time.Second, cOpts.TailSamplingLatencyThreshold)
+	assert.Equal(t, []string{"foo", "bar"}, cOpts.TailSamplingServices)
+	assert.Equal(t, map[string]string{"sampling.priority": "1", "http.status_code": "500"}, cOpts.TailSamplingTags)
+	assert.Equal(t, 0.1, cOpts.TailSamplingProbability)
+
+	opts = cOpts.TailSamplingOptions()
+	assert.Equal(t, 10*time.Second, opts.DecisionWait)
+	assert.Equal(t, 100, opts.MaxTraces)
+	assert.Equal(t, 10, opts.MaxSpansPerTrace)
+	assert.Len(t, opts.Policies, 5) // latency, services, 2 tags, probabilistic
+}
+
+func TestValidateDownsamplingRatio(t *testing.T) {
+	for _, ratio := range []string{"0", "0.5", "1"} {
+		v, command := config.Viperize(AddFlags)
+		command.ParseFlags([]string{"--collector.downsampling.ratio=" + ratio})
+		assert.NoError(t, new(CollectorOptions).InitFromViper(v).Validate())
+	}
+	for _, ratio := range []string{"-0.1", "1.5"} {
+		v, command := config.Viperize(AddFlags)
+		command.ParseFlags([]string{"--collector.downsampling.ratio=" + ratio})
+		assert.EqualError(t, new(CollectorOptions).InitFromViper(v).Validate(),
+			"collector.downsampling.ratio must be between 0 and 1, got "+ratio)
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	basicB "github.com/jaegertracing/jaeger/cmd/builder"
 	"github.com/jaegertracing/jaeger/cmd/collector/app"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	zs "github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer/zipkin"
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
@@ -40,12 +41,21 @@ type SpanHandlerBuilder struct {
 	metricsFactory metrics.Factory
 	collectorOpts  *CollectorOptions
 	spanWriter     spanstore.Writer
+	aggregator     strategystore.Aggregator
 }
 
 // NewSpanHandlerBuilder returns new SpanHandlerBuilder with configured span storage.
 func NewSpanHandlerBuilder(cOpts *CollectorOptions, spanWriter spanstore.Writer, opts ...basicB.Option) (*SpanHandlerBuilder, error) {
 	options := basicB.ApplyOptions(opts...)
 
+	if cOpts.DownsamplingRatio < 1.0 {
+		spanWriter = spanstore.NewDownsamplingWriter(spanWriter, spanstore.DownsamplingOptions{
+			Ratio:          cOpts.DownsamplingRatio,
+			HashSalt:       cOpts.DownsamplingHashSalt,
+			MetricsFactory: options.MetricsFactory,
+		})
+	}
+
 	spanHb := &SpanHandlerBuilder{
 		collectorOpts:  cOpts,
 		logger:         options.Logger,
@@ -56,30 +66,36 @@ func NewSpanHandlerBuilder(cOpts *CollectorOptions, spanWriter spanstore.Writer,
 	return spanHb, nil
 }
 
-// BuildHandlers builds span handlers (Zipkin, Jaeger)
-func (spanHb *SpanHandlerBuilder) BuildHandlers() (app.ZipkinSpansHandler, app.JaegerBatchesHandler) {
+// WithAggregator sets the Aggregator that records the throughput of root spans for adaptive sampling
+func (spanHb *SpanHandlerBuilder) WithAggregator(aggregator strategystore.Aggregator) *SpanHandlerBuilder {
+	spanHb.aggregator = aggregator
+	return spanHb
+}
+
+// BuildHandlers builds span handlers (Zipkin, Jaeger, gRPC)
+func (spanHb *SpanHandlerBuilder) BuildHandlers() (app.ZipkinSpansHandler, app.JaegerBatchesHandler, *app.GRPCHandler) {
 	hostname, _ := os.Hostname()
 	hostMetrics := spanHb.metricsFactory.Namespace("", map[string]string{"host": hostname})
 
-	zSanitizer := zs.NewChainedSanitizer(
-		zs.NewSpanDurationSanitizer(),
-		zs.NewSpanStartTimeSanitizer(),
-		zs.NewParentIDSanitizer(),
-		zs.NewErrorTagSanitizer(),
-	)
+	zSanitizer := zs.NewChainedSanitizer(zs.NewStandardSanitizers()...)
 
-	spanProcessor := app.NewSpanProcessor(
-		spanHb.spanWriter,
+	options := []app.Option{
 		app.Options.ServiceMetrics(spanHb.metricsFactory),
 		app.Options.HostMetrics(hostMetrics),
 		app.Options.Logger(spanHb.logger),
 		app.Options.SpanFilter(defaultSpanFilter),
 		app.Options.NumWorkers(spanHb.collectorOpts.NumWorkers),
 		app.Options.QueueSize(spanHb.collectorOpts.QueueSize),
-	)
+		app.Options.TailSampling(spanHb.collectorOpts.TailSamplingOptions()),
+	}
+	if spanHb.aggregator != nil {
+		options = append(options, app.Options.PreSave(app.HandleRootSpan(spanHb.aggregator, spanHb.logger)))
+	}
+	spanProcessor := app.NewSpanProcessor(spanHb.spanWriter, options...)
 
 	return app.NewZipkinSpanHandler(spanHb.logger, spanProcessor, zSanitizer),
-		app.NewJaegerSpanHandler(spanHb.logger, spanProcessor)
+		app.NewJaegerSpanHandler(spanHb.logger, spanProcessor),
+		app.NewGRPCHandler(spanHb.logger, spanProcessor)
 }
 
 func defaultSpanFilter(*model.Span) bool {
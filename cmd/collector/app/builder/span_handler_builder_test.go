@@ -26,6 +26,7 @@ import (
 	"github.com/jaegertracing/jaeger/cmd/flags"
 	"github.com/jaegertracing/jaeger/pkg/config"
 	"github.com/jaegertracing/jaeger/plugin/storage/memory"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
 func TestNewSpanHandlerBuilder(t *testing.T) {
@@ -44,11 +45,55 @@ func TestNewSpanHandlerBuilder(t *testing.T) {
 	)
 	require.NoError(t, err)
 	assert.NotNil(t, handler)
-	zipkin, jaeger := handler.BuildHandlers()
+	zipkin, jaeger, grpc := handler.BuildHandlers()
 	assert.NotNil(t, zipkin)
 	assert.NotNil(t, jaeger)
+	assert.NotNil(t, grpc)
 }
 
+func TestSpanHandlerBuilderWithDownsampling(t *testing.T) {
+	v, command := config.Viperize(flags.AddFlags, AddFlags)
+	command.ParseFlags([]string{"--collector.downsampling.ratio=0.5", "--collector.downsampling.hashsalt=salt"})
+	cOpts := new(CollectorOptions).InitFromViper(v)
+	assert.Equal(t, 0.5, cOpts.DownsamplingRatio)
+	assert.Equal(t, "salt", cOpts.DownsamplingHashSalt)
+
+	spanWriter := memory.NewStore()
+	handler, err := NewSpanHandlerBuilder(cOpts, spanWriter)
+	require.NoError(t, err)
+	assert.IsType(t, &spanstore.DownsamplingWriter{}, handler.spanWriter)
+
+	command.ParseFlags([]string{"--collector.downsampling.ratio=1"})
+	cOpts = new(CollectorOptions).InitFromViper(v)
+	handler, err = NewSpanHandlerBuilder(cOpts, spanWriter)
+	require.NoError(t, err)
+	assert.Equal(t, spanWriter, handler.spanWriter)
+}
+
+func TestSpanHandlerBuilderWithAggregator(t *testing.T) {
+	v, command := config.Viperize(flags.AddFlags, AddFlags)
+	command.ParseFlags([]string{})
+	cOpts := new(CollectorOptions).InitFromViper(v)
+
+	handler, err := NewSpanHandlerBuilder(cOpts, memory.NewStore())
+	require.NoError(t, err)
+	agg := &fakeAggregator{}
+	assert.Equal(t, handler, handler.WithAggregator(agg))
+	assert.Equal(t, agg, handler.aggregator)
+	zipkin, jaeger, grpc := handler.BuildHandlers()
+	assert.NotNil(t, zipkin)
+	assert.NotNil(t, jaeger)
+	assert.NotNil(t, grpc)
+}
+
+type fakeAggregator struct{}
+
+func (*fakeAggregator) RecordThroughput(string, string, string, float64) {}
+
+func (*fakeAggregator) Start() {}
+
+func (*fakeAggregator) Stop() {}
+
 func TestDefaultSpanFilter(t *testing.T) {
 	assert.True(t, defaultSpanFilter(nil))
 }
@@ -0,0 +1,92 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/gogo/gateway"
+	"github.com/gorilla/mux"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+// GRPCHandler implements model.CollectorServiceV2Server
+type GRPCHandler struct {
+	logger        *zap.Logger
+	spanProcessor SpanProcessor
+}
+
+// NewGRPCHandler returns a GRPCHandler that passes incoming spans to the given SpanProcessor
+func NewGRPCHandler(logger *zap.Logger, spanProcessor SpanProcessor) *GRPCHandler {
+	return &GRPCHandler{
+		logger:        logger,
+		spanProcessor: spanProcessor,
+	}
+}
+
+// PostSpans implements model.CollectorServiceV2Server.PostSpans
+func (g *GRPCHandler) PostSpans(ctx context.Context, r *model.PostSpansRequest) (*model.PostSpansResponse, error) {
+	batch := r.GetBatch()
+	if batch == nil {
+		return nil, status.Error(codes.InvalidArgument, "batch is required")
+	}
+	for _, span := range batch.Spans {
+		if span.GetProcess() == nil {
+			span.Process = &batch.Process
+		}
+	}
+	oks, err := g.spanProcessor.ProcessSpans(batch.Spans, JaegerFormatType)
+	if err != nil {
+		g.logger.Error("cannot process spans", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	batchOk := true
+	for _, ok := range oks {
+		if !ok {
+			batchOk = false
+			break
+		}
+	}
+	g.logger.Debug("Span batch processed by the collector.", zap.Bool("ok", batchOk))
+	return &model.PostSpansResponse{Ok: batchOk}, nil
+}
+
+// RegisterGatewayRoutes exposes PostSpans as POST /api/v2/spans (the grpc-gateway mapping declared
+// in model.proto) on the given router. Requests are dispatched to this handler in-process,
+// so the route works even when the gRPC port is disabled.
+func (g *GRPCHandler) RegisterGatewayRoutes(router *mux.Router) error {
+	gwMux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &gateway.JSONPb{OrigName: true}))
+	if err := model.RegisterCollectorServiceV2HandlerClient(context.Background(), gwMux, inProcessClient{g}); err != nil {
+		return err
+	}
+	router.Handle("/api/v2/spans", gwMux).Methods(http.MethodPost)
+	return nil
+}
+
+// inProcessClient adapts GRPCHandler to model.CollectorServiceV2Client for the grpc-gateway.
+type inProcessClient struct {
+	handler *GRPCHandler
+}
+
+func (c inProcessClient) PostSpans(ctx context.Context, in *model.PostSpansRequest, opts ...grpc.CallOption) (*model.PostSpansResponse, error) {
+	return c.handler.PostSpans(ctx, in)
+}
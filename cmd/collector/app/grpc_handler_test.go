@@ -0,0 +1,182 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+type mockSpanProcessor struct {
+	expectedError error
+	accepted      bool
+	mux           sync.Mutex
+	spans         []*model.Span
+}
+
+func (p *mockSpanProcessor) ProcessSpans(spans []*model.Span, spanFormat string) ([]bool, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.spans = append(p.spans, spans...)
+	oks := make([]bool, len(spans))
+	for i := range oks {
+		oks[i] = p.accepted
+	}
+	return oks, p.expectedError
+}
+
+func (p *mockSpanProcessor) getSpans() []*model.Span {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.spans
+}
+
+// initializeGRPCTestServer starts a gRPC server and returns its address together with a function
+// that stops the server and checks that it was serving without errors.
+func initializeGRPCTestServer(t *testing.T, beforeServe func(s *grpc.Server)) (func(), net.Addr) {
+	server := grpc.NewServer()
+	beforeServe(server)
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(lis)
+	}()
+	stop := func() {
+		server.Stop()
+		assert.NoError(t, <-serveErr)
+	}
+	return stop, lis.Addr()
+}
+
+func newClient(t *testing.T, addr net.Addr) (model.CollectorServiceV2Client, *grpc.ClientConn) {
+	conn, err := grpc.Dial(addr.String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	return model.NewCollectorServiceV2Client(conn), conn
+}
+
+func TestPostSpans(t *testing.T) {
+	testCases := []struct {
+		caption  string
+		accepted bool
+	}{
+		{caption: "spans rejected", accepted: false},
+		{caption: "spans accepted", accepted: true},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.caption, func(t *testing.T) {
+			processor := &mockSpanProcessor{accepted: testCase.accepted}
+			stop, addr := initializeGRPCTestServer(t, func(s *grpc.Server) {
+				handler := NewGRPCHandler(zap.NewNop(), processor)
+				model.RegisterCollectorServiceV2Server(s, handler)
+			})
+			defer stop()
+			client, conn := newClient(t, addr)
+			defer conn.Close()
+			r, err := client.PostSpans(context.Background(), &model.PostSpansRequest{
+				Batch: &model.Batch{
+					Spans: []*model.Span{
+						{
+							OperationName: "test-operation",
+						},
+						{
+							OperationName: "test-operation-2",
+							Process:       &model.Process{ServiceName: "bar"},
+						},
+					},
+					Process: model.Process{ServiceName: "foo"},
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, testCase.accepted, r.GetOk())
+			spans := processor.getSpans()
+			require.Len(t, spans, 2)
+			assert.Equal(t, "foo", spans[0].Process.ServiceName)
+			assert.Equal(t, "bar", spans[1].Process.ServiceName)
+		})
+	}
+}
+
+func TestPostSpansWithError(t *testing.T) {
+	processor := &mockSpanProcessor{expectedError: errTestError}
+	stop, addr := initializeGRPCTestServer(t, func(s *grpc.Server) {
+		handler := NewGRPCHandler(zap.NewNop(), processor)
+		model.RegisterCollectorServiceV2Server(s, handler)
+	})
+	defer stop()
+	client, conn := newClient(t, addr)
+	defer conn.Close()
+	r, err := client.PostSpans(context.Background(), &model.PostSpansRequest{
+		Batch: &model.Batch{
+			Spans: []*model.Span{{OperationName: "fake-operation"}},
+		},
+	})
+	require.Error(t, err)
+	require.Nil(t, r)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = client.PostSpans(context.Background(), &model.PostSpansRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPostSpansViaGateway(t *testing.T) {
+	processor := &mockSpanProcessor{}
+	handler := NewGRPCHandler(zap.NewNop(), processor)
+	r := mux.NewRouter()
+	require.NoError(t, handler.RegisterGatewayRoutes(r))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	var body bytes.Buffer
+	m := &jsonpb.Marshaler{}
+	require.NoError(t, m.Marshal(&body, &model.PostSpansRequest{
+		Batch: &model.Batch{
+			Spans: []*model.Span{
+				{
+					TraceID:       model.NewTraceID(1, 2),
+					SpanID:        model.NewSpanID(3),
+					OperationName: "gateway-operation",
+				},
+			},
+			Process: model.Process{ServiceName: "foo"},
+		},
+	}))
+	res, err := httpClient.Post(server.URL+"/api/v2/spans", "application/json", &body)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	spans := processor.getSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "gateway-operation", spans[0].OperationName)
+	assert.Equal(t, model.NewTraceID(1, 2), spans[0].TraceID)
+	assert.Equal(t, "foo", spans[0].Process.ServiceName)
+}
@@ -19,6 +19,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/tailsampling"
 	"github.com/jaegertracing/jaeger/model"
 )
 
@@ -42,6 +43,7 @@ type options struct {
 	queueSize        int
 	reportBusy       bool
 	extraFormatTypes []string
+	tailSampling     tailsampling.Options
 }
 
 // Option is a function that sets some option on StorageBuilder.
@@ -134,6 +136,14 @@ func (options) ExtraFormatTypes(extraFormatTypes []string) Option {
 	}
 }
 
+// TailSampling creates an Option that buffers spans per trace and saves only the traces accepted
+// by the tail sampling policies. Tail sampling is disabled if tailSampling.DecisionWait is zero.
+func (options) TailSampling(tailSampling tailsampling.Options) Option {
+	return func(b *options) {
+		b.tailSampling = tailSampling
+	}
+}
+
 func (o options) apply(opts ...Option) options {
 	ret := options{}
 	for _, opt := range opts {
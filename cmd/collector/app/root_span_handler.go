@@ -0,0 +1,76 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/model"
+)
+
+const (
+	samplerTypeKey  = "sampler.type"
+	samplerParamKey = "sampler.param"
+)
+
+// HandleRootSpan returns a function that records throughput for root spans
+func HandleRootSpan(aggregator strategystore.Aggregator, logger *zap.Logger) ProcessSpan {
+	return func(span *model.Span) {
+		// Only root spans carry the sampler tags describing the sampling decision of the trace
+		if span.ParentSpanID() != 0 || span.Process == nil {
+			return
+		}
+		service := span.Process.ServiceName
+		if service == "" || span.OperationName == "" {
+			return
+		}
+		samplerType, samplerParam := getSamplerParams(span, logger)
+		if samplerType == "" {
+			return
+		}
+		aggregator.RecordThroughput(service, span.OperationName, samplerType, samplerParam)
+	}
+}
+
+// getSamplerParams returns the sampler type and param tags of a span, or an empty type if they are missing.
+func getSamplerParams(span *model.Span, logger *zap.Logger) (string, float64) {
+	tags := model.KeyValues(span.Tags)
+	samplerType, ok := tags.FindByKey(samplerTypeKey)
+	if !ok {
+		return "", 0
+	}
+	samplerParam, ok := tags.FindByKey(samplerParamKey)
+	if !ok {
+		return "", 0
+	}
+	switch samplerParam.VType {
+	case model.Float64Type:
+		return samplerType.AsString(), samplerParam.Float64()
+	case model.StringType:
+		// zipkin spans carry all tags as strings
+		param, err := strconv.ParseFloat(samplerParam.VStr, 64)
+		if err != nil {
+			logger.Debug("Sampler param is not a number", zap.String("param", samplerParam.VStr))
+			return "", 0
+		}
+		return samplerType.AsString(), param
+	default:
+		logger.Debug("Sampler param has an unexpected type", zap.Stringer("type", samplerParam.VType))
+		return "", 0
+	}
+}
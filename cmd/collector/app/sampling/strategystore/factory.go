@@ -17,6 +17,8 @@ package strategystore
 import (
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/storage"
 )
 
 // Factory defines an interface for a factory that can create implementations of different strategy storage components.
@@ -26,9 +28,17 @@ import (
 //
 // plugin.Configurable
 type Factory interface {
-	// Initialize performs internal initialization of the factory.
-	Initialize(metricsFactory metrics.Factory, logger *zap.Logger) error
+	// Initialize performs internal initialization of the factory. Strategy stores that need to share
+	// data between collectors obtain their storage components from ssFactory.
+	Initialize(metricsFactory metrics.Factory, ssFactory storage.SamplingStoreFactory, logger *zap.Logger) error
 
 	// CreateStrategyStore initializes the StrategyStore and returns it.
 	CreateStrategyStore() (StrategyStore, error)
 }
+
+// AggregatorFactory is an additional interface that can be implemented by a factory whose
+// strategy store adapts to the throughput of the spans received by collectors.
+type AggregatorFactory interface {
+	// CreateAggregator creates the Aggregator that the collector feeds with root spans.
+	CreateAggregator() (Aggregator, error)
+}
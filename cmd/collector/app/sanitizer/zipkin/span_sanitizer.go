@@ -45,6 +45,17 @@ func NewChainedSanitizer(sanitizers ...Sanitizer) ChainedSanitizer {
 	return sanitizers
 }
 
+// NewStandardSanitizers returns the list of sanitizers applied to every Zipkin span
+// accepted by the collector.
+func NewStandardSanitizers() []Sanitizer {
+	return []Sanitizer{
+		NewSpanDurationSanitizer(),
+		NewSpanStartTimeSanitizer(),
+		NewParentIDSanitizer(),
+		NewErrorTagSanitizer(),
+	}
+}
+
 // Sanitize calls each Sanitize, returning the first error
 func (cs ChainedSanitizer) Sanitize(span *zc.Span) *zc.Span {
 	for _, s := range cs {
@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/tailsampling"
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/pkg/queue"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
@@ -37,6 +38,7 @@ type spanProcessor struct {
 	spanWriter      spanstore.Writer
 	reportBusy      bool
 	numWorkers      int
+	tailSampler     *tailsampling.Buffer // if set, spans are saved only when their trace is sampled
 }
 
 type queueItem struct {
@@ -83,9 +85,18 @@ func newSpanProcessor(spanWriter spanstore.Writer, opts ...Option) *spanProcesso
 		numWorkers:      options.numWorkers,
 		spanWriter:      spanWriter,
 	}
+	saveSpan := sp.saveSpan
+	if options.tailSampling.DecisionWait > 0 {
+		sp.tailSampler = tailsampling.NewBuffer(
+			options.tailSampling,
+			sp.saveSpan,
+			options.hostMetrics.Namespace("tail-sampling", nil),
+			options.logger)
+		saveSpan = sp.tailSampler.Add
+	}
 	sp.processSpan = ChainedProcessSpan(
 		options.preSave,
-		sp.saveSpan,
+		saveSpan,
 	)
 
 	return &sp
@@ -94,6 +105,9 @@ func newSpanProcessor(spanWriter spanstore.Writer, opts ...Option) *spanProcesso
 // Stop halts the span processor and all its go-routines.
 func (sp *spanProcessor) Stop() {
 	sp.queue.Stop()
+	if sp.tailSampler != nil {
+		sp.tailSampler.Close()
+	}
 }
 
 func (sp *spanProcessor) saveSpan(span *model.Span) {
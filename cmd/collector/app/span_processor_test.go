@@ -28,6 +28,7 @@ import (
 	"golang.org/x/net/context"
 
 	zipkinSanitizer "github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer/zipkin"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/tailsampling"
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/pkg/testutils"
 	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
@@ -301,3 +302,49 @@ func TestSpanProcessorBusy(t *testing.T) {
 	assert.Error(t, err, "expcting busy error")
 	assert.Nil(t, res)
 }
+
+type recordingWriter struct {
+	sync.Mutex
+	services []string
+}
+
+func (w *recordingWriter) WriteSpan(span *model.Span) error {
+	w.Lock()
+	defer w.Unlock()
+	w.services = append(w.services, span.Process.ServiceName)
+	return nil
+}
+
+func TestSpanProcessorTailSampling(t *testing.T) {
+	w := &recordingWriter{}
+	mb := metrics.NewLocalFactory(time.Hour)
+	p := newSpanProcessor(w,
+		Options.HostMetrics(mb),
+		Options.TailSampling(tailsampling.Options{
+			DecisionWait: time.Hour,
+			Policies:     []tailsampling.Policy{tailsampling.ServicePolicy("keep")},
+		}),
+	)
+
+	for _, span := range []*model.Span{
+		{
+			TraceID: model.TraceID{Low: 1},
+			Process: &model.Process{ServiceName: "keep"},
+		},
+		{
+			TraceID: model.TraceID{Low: 2},
+			Process: &model.Process{ServiceName: "drop"},
+		},
+	} {
+		p.processItemFromQueue(&queueItem{queuedTime: time.Now(), span: span})
+	}
+	assert.Empty(t, w.services)
+
+	// Stop makes the sampling decision for all buffered traces
+	p.Stop()
+	assert.Equal(t, []string{"keep"}, w.services)
+	metricsTest.AssertCounterMetrics(t, mb,
+		metricsTest.ExpectedMetric{Name: "tail-sampling.traces|result=kept", Value: 1},
+		metricsTest.ExpectedMetric{Name: "tail-sampling.traces|result=dropped", Value: 1},
+	)
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/cache"
+)
+
+const (
+	// DefaultMaxTraces is the default number of traces held in the buffer
+	DefaultMaxTraces = 50000
+	// DefaultMaxSpansPerTrace is the default number of spans buffered for a single trace
+	DefaultMaxSpansPerTrace = 1000
+	// DefaultMaxDecidedTraces is the default number of trace IDs whose decision is remembered for late spans
+	DefaultMaxDecidedTraces = 100000
+
+	minCheckInterval = 10 * time.Millisecond
+)
+
+// Options configures the tail sampling Buffer
+type Options struct {
+	// DecisionWait is how long the spans of a trace are buffered, counting from the arrival of its first span,
+	// before the policies decide whether to keep the trace
+	DecisionWait time.Duration
+	// MaxTraces is the maximum number of traces held in the buffer. When it is exceeded, the decision
+	// for the oldest trace is made early
+	MaxTraces int
+	// MaxSpansPerTrace is the maximum number of spans buffered for a single trace. When it is reached,
+	// the decision for the trace is made early
+	MaxSpansPerTrace int
+	// MaxDecidedTraces is the number of most recently decided traces whose decision is applied
+	// to the spans that arrive after it was made
+	MaxDecidedTraces int
+	// Policies decide which traces are kept. A trace is kept if any of the policies accepts it
+	Policies []Policy
+}
+
+type bufferMetrics struct {
+	// TracesKept is the number of traces accepted by at least one policy
+	TracesKept metrics.Counter `metric:"traces" tags:"result=kept"`
+	// TracesDropped is the number of traces rejected by all policies
+	TracesDropped metrics.Counter `metric:"traces" tags:"result=dropped"`
+	// TracesEvicted is the number of traces decided before DecisionWait because the buffer was full
+	TracesEvicted metrics.Counter `metric:"traces.evicted"`
+	// TracesSpanLimit is the number of traces decided before DecisionWait because they reached MaxSpansPerTrace
+	TracesSpanLimit metrics.Counter `metric:"traces.span-limit"`
+	// LateSpans is the number of spans that arrived after the decision for their trace was made
+	LateSpans metrics.Counter `metric:"spans.late"`
+	// SpansDropped is the number of spans belonging to dropped traces
+	SpansDropped metrics.Counter `metric:"spans.dropped"`
+	// BufferedTraces is the number of traces currently waiting for a decision
+	BufferedTraces metrics.Gauge `metric:"traces.buffered"`
+}
+
+type pendingTrace struct {
+	arrival time.Time
+	element *list.Element
+	spans   []*model.Span
+}
+
+// Buffer holds spans grouped by trace ID for Options.DecisionWait and then passes all spans
+// of the trace to the save function, or drops them, depending on the policies. The decisions
+// for the last Options.MaxDecidedTraces traces are remembered, and spans that arrive after
+// the decision for their trace was made are saved or dropped right away. Spans of traces
+// that are no longer remembered are buffered and decided as a new trace.
+type Buffer struct {
+	options Options
+	save    func(span *model.Span)
+	logger  *zap.Logger
+	metrics bufferMetrics
+	timeNow func() time.Time
+
+	decisions cache.Cache // of trace ID to whether the trace was kept
+
+	lock   sync.Mutex
+	traces map[model.TraceID]*pendingTrace
+	order  *list.List // of model.TraceID, in the order of arrival
+
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// NewBuffer creates a Buffer and starts the goroutine that makes the sampling decisions.
+func NewBuffer(options Options, save func(span *model.Span), metricsFactory metrics.Factory, logger *zap.Logger) *Buffer {
+	if options.MaxTraces <= 0 {
+		options.MaxTraces = DefaultMaxTraces
+	}
+	if options.MaxSpansPerTrace <= 0 {
+		options.MaxSpansPerTrace = DefaultMaxSpansPerTrace
+	}
+	if options.MaxDecidedTraces <= 0 {
+		options.MaxDecidedTraces = DefaultMaxDecidedTraces
+	}
+	b := &Buffer{
+		options:   options,
+		save:      save,
+		logger:    logger,
+		timeNow:   time.Now,
+		decisions: cache.NewLRU(options.MaxDecidedTraces),
+		traces:    make(map[model.TraceID]*pendingTrace),
+		order:     list.New(),
+		stop:      make(chan struct{}),
+	}
+	metrics.Init(&b.metrics, metricsFactory, nil)
+
+	checkInterval := options.DecisionWait / 10
+	if checkInterval < minCheckInterval {
+		checkInterval = minCheckInterval
+	}
+	b.done.Add(1)
+	go b.run(checkInterval)
+	return b
+}
+
+// Add buffers the span until the sampling decision for its trace is made. If the decision
+// was already made, the span is saved or dropped according to it.
+func (b *Buffer) Add(span *model.Span) {
+	var evicted, full *pendingTrace
+	b.lock.Lock()
+	trace, ok := b.traces[span.TraceID]
+	if !ok {
+		if kept, decided := b.decisions.Get(span.TraceID.String()).(bool); decided {
+			b.lock.Unlock()
+			b.addLate(span, kept)
+			return
+		}
+		if len(b.traces) >= b.options.MaxTraces {
+			evicted = b.removeLocked(b.order.Front())
+		}
+		trace = &pendingTrace{arrival: b.timeNow()}
+		trace.element = b.order.PushBack(span.TraceID)
+		b.traces[span.TraceID] = trace
+	}
+	trace.spans = append(trace.spans, span)
+	if len(trace.spans) >= b.options.MaxSpansPerTrace {
+		full = b.removeLocked(trace.element)
+	}
+	b.lock.Unlock()
+
+	if evicted != nil {
+		b.metrics.TracesEvicted.Inc(1)
+		b.decide(evicted)
+	}
+	if full != nil {
+		b.metrics.TracesSpanLimit.Inc(1)
+		b.decide(full)
+	}
+}
+
+func (b *Buffer) addLate(span *model.Span, kept bool) {
+	b.metrics.LateSpans.Inc(1)
+	if kept {
+		b.save(span)
+	} else {
+		b.metrics.SpansDropped.Inc(1)
+	}
+}
+
+// Close stops the background goroutine and makes the decision for all buffered traces.
+func (b *Buffer) Close() error {
+	close(b.stop)
+	b.done.Wait()
+	for _, trace := range b.expired(func(*pendingTrace) bool { return true }) {
+		b.decide(trace)
+	}
+	return nil
+}
+
+func (b *Buffer) run(checkInterval time.Duration) {
+	defer b.done.Done()
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			deadline := b.timeNow().Add(-b.options.DecisionWait)
+			expired := b.expired(func(trace *pendingTrace) bool {
+				return !trace.arrival.After(deadline)
+			})
+			for _, trace := range expired {
+				b.decide(trace)
+			}
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// expired removes and returns the traces from the front of the buffer for which isExpired returns true.
+func (b *Buffer) expired(isExpired func(trace *pendingTrace) bool) []*pendingTrace {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	var expired []*pendingTrace
+	for e := b.order.Front(); e != nil; e = b.order.Front() {
+		if !isExpired(b.traces[e.Value.(model.TraceID)]) {
+			break
+		}
+		expired = append(expired, b.removeLocked(e))
+	}
+	b.metrics.BufferedTraces.Update(int64(len(b.traces)))
+	return expired
+}
+
+func (b *Buffer) removeLocked(e *list.Element) *pendingTrace {
+	traceID := b.order.Remove(e).(model.TraceID)
+	trace := b.traces[traceID]
+	delete(b.traces, traceID)
+	return trace
+}
+
+func (b *Buffer) decide(pending *pendingTrace) {
+	trace := &model.Trace{Spans: pending.spans}
+	for _, policy := range b.options.Policies {
+		if policy(trace) {
+			b.decisions.Put(pending.spans[0].TraceID.String(), true)
+			b.metrics.TracesKept.Inc(1)
+			for _, span := range pending.spans {
+				b.save(span)
+			}
+			return
+		}
+	}
+	b.decisions.Put(pending.spans[0].TraceID.String(), false)
+	b.metrics.TracesDropped.Inc(1)
+	b.metrics.SpansDropped.Inc(int64(len(pending.spans)))
+	b.logger.Debug("Trace dropped by tail sampling",
+		zap.Stringer("trace-id", pending.spans[0].TraceID), zap.Int("spans", len(pending.spans)))
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/jaeger-lib/metrics"
+	mTestutils "github.com/uber/jaeger-lib/metrics/testutils"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+type spanRecorder struct {
+	sync.Mutex
+	spans []*model.Span
+}
+
+func (r *spanRecorder) save(span *model.Span) {
+	r.Lock()
+	defer r.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+func (r *spanRecorder) count() int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.spans)
+}
+
+func spanForTrace(traceID uint64, service string) *model.Span {
+	return &model.Span{
+		TraceID: model.TraceID{Low: traceID},
+		Process: &model.Process{ServiceName: service},
+	}
+}
+
+func TestBufferDecidesOnClose(t *testing.T) {
+	recorder := &spanRecorder{}
+	metricsFactory := metrics.NewLocalFactory(0)
+	buffer := NewBuffer(Options{
+		DecisionWait: time.Hour,
+		Policies:     []Policy{ServicePolicy("keep")},
+	}, recorder.save, metricsFactory, zap.NewNop())
+
+	buffer.Add(spanForTrace(1, "keep"))
+	buffer.Add(spanForTrace(2, "drop"))
+	buffer.Add(spanForTrace(1, "other"))
+	buffer.Add(spanForTrace(2, "drop"))
+	assert.Equal(t, 0, recorder.count())
+
+	assert.NoError(t, buffer.Close())
+	assert.Len(t, recorder.spans, 2)
+	for _, span := range recorder.spans {
+		assert.Equal(t, uint64(1), span.TraceID.Low)
+	}
+	mTestutils.AssertCounterMetrics(t, metricsFactory,
+		mTestutils.ExpectedMetric{Name: "traces", Tags: map[string]string{"result": "kept"}, Value: 1},
+		mTestutils.ExpectedMetric{Name: "traces", Tags: map[string]string{"result": "dropped"}, Value: 1},
+		mTestutils.ExpectedMetric{Name: "spans.dropped", Value: 2},
+	)
+}
+
+func TestBufferDecidesAfterWait(t *testing.T) {
+	recorder := &spanRecorder{}
+	metricsFactory := metrics.NewLocalFactory(0)
+	buffer := NewBuffer(Options{
+		DecisionWait: time.Millisecond,
+		Policies:     []Policy{ServicePolicy("keep")},
+	}, recorder.save, metricsFactory, zap.NewNop())
+	defer buffer.Close()
+
+	buffer.Add(spanForTrace(1, "keep"))
+	buffer.Add(spanForTrace(1, "keep"))
+	for i := 0; i < 100 && recorder.count() < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, 2, recorder.count())
+	mTestutils.AssertGaugeMetrics(t, metricsFactory, mTestutils.ExpectedMetric{Name: "traces.buffered", Value: 0})
+}
+
+func TestBufferEvictsOldestTrace(t *testing.T) {
+	recorder := &spanRecorder{}
+	metricsFactory := metrics.NewLocalFactory(0)
+	buffer := NewBuffer(Options{
+		DecisionWait: time.Hour,
+		MaxTraces:    2,
+		Policies:     []Policy{ServicePolicy("keep")},
+	}, recorder.save, metricsFactory, zap.NewNop())
+
+	buffer.Add(spanForTrace(1, "keep"))
+	buffer.Add(spanForTrace(2, "keep"))
+	buffer.Add(spanForTrace(2, "keep"))
+	assert.Equal(t, 0, recorder.count())
+
+	buffer.Add(spanForTrace(3, "keep"))
+	assert.Equal(t, 1, recorder.count())
+	assert.Equal(t, uint64(1), recorder.spans[0].TraceID.Low)
+
+	assert.NoError(t, buffer.Close())
+	assert.Equal(t, 4, recorder.count())
+	mTestutils.AssertCounterMetrics(t, metricsFactory,
+		mTestutils.ExpectedMetric{Name: "traces.evicted", Value: 1},
+		mTestutils.ExpectedMetric{Name: "traces", Tags: map[string]string{"result": "kept"}, Value: 3},
+	)
+}
+
+func TestBufferAppliesDecisionToLateSpans(t *testing.T) {
+	recorder := &spanRecorder{}
+	metricsFactory := metrics.NewLocalFactory(0)
+	buffer := NewBuffer(Options{
+		DecisionWait: time.Hour,
+		MaxTraces:    1,
+		Policies:     []Policy{ServicePolicy("keep")},
+	}, recorder.save, metricsFactory, zap.NewNop())
+
+	buffer.Add(spanForTrace(1, "keep"))
+	buffer.Add(spanForTrace(2, "drop"))
+	buffer.Add(spanForTrace(3, "drop"))
+	assert.Equal(t, 1, recorder.count())
+
+	buffer.Add(spanForTrace(1, "other"))
+	assert.Equal(t, 2, recorder.count())
+	buffer.Add(spanForTrace(2, "keep"))
+	assert.Equal(t, 2, recorder.count())
+
+	assert.NoError(t, buffer.Close())
+	assert.Equal(t, 2, recorder.count())
+	mTestutils.AssertCounterMetrics(t, metricsFactory,
+		mTestutils.ExpectedMetric{Name: "spans.late", Value: 2},
+		mTestutils.ExpectedMetric{Name: "traces", Tags: map[string]string{"result": "kept"}, Value: 1},
+		mTestutils.ExpectedMetric{Name: "traces", Tags: map[string]string{"result": "dropped"}, Value: 2},
+		mTestutils.ExpectedMetric{Name: "spans.dropped", Value: 3},
+	)
+}
+
+func TestBufferDecidesAtSpanLimit(t *testing.T) {
+	recorder := &spanRecorder{}
+	metricsFactory := metrics.NewLocalFactory(0)
+	buffer := NewBuffer(Options{
+		DecisionWait:     time.Hour,
+		MaxSpansPerTrace: 2,
+		Policies:         []Policy{ServicePolicy("keep")},
+	}, recorder.save, metricsFactory, zap.NewNop())
+
+	buffer.Add(spanForTrace(1, "keep"))
+	assert.Equal(t, 0, recorder.count())
+	buffer.Add(spanForTrace(1, "keep"))
+	assert.Equal(t, 2, recorder.count())
+	buffer.Add(spanForTrace(1, "keep"))
+	assert.Equal(t, 3, recorder.count())
+
+	assert.NoError(t, buffer.Close())
+	mTestutils.AssertCounterMetrics(t, metricsFactory,
+		mTestutils.ExpectedMetric{Name: "traces.span-limit", Value: 1},
+		mTestutils.ExpectedMetric{Name: "spans.late", Value: 1},
+		mTestutils.ExpectedMetric{Name: "traces", Tags: map[string]string{"result": "kept"}, Value: 1},
+	)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+const errorTag = "error"
+
+// Policy decides whether a buffered trace should be kept.
+type Policy func(trace *model.Trace) bool
+
+// ErrorPolicy keeps traces that contain at least one span tagged with error=true.
+func ErrorPolicy() Policy {
+	return func(trace *model.Trace) bool {
+		for _, span := range trace.Spans {
+			for i := range span.Tags {
+				tag := &span.Tags[i]
+				if tag.Key != errorTag {
+					continue
+				}
+				if tag.VType == model.BoolType && tag.Bool() || tag.VType == model.StringType && tag.AsString() == "true" {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// LatencyPolicy keeps traces that last at least threshold, measured from the earliest
+// span start time to the latest span end time.
+func LatencyPolicy(threshold time.Duration) Policy {
+	return func(trace *model.Trace) bool {
+		var start, end time.Time
+		for i, span := range trace.Spans {
+			spanEnd := span.StartTime.Add(span.Duration)
+			if i == 0 || span.StartTime.Before(start) {
+				start = span.StartTime
+			}
+			if i == 0 || spanEnd.After(end) {
+				end = spanEnd
+			}
+		}
+		return end.Sub(start) >= threshold
+	}
+}
+
+// ServicePolicy keeps traces that contain at least one span from any of the given services.
+func ServicePolicy(services ...string) Policy {
+	serviceSet := make(map[string]struct{}, len(services))
+	for _, service := range services {
+		serviceSet[service] = struct{}{}
+	}
+	return func(trace *model.Trace) bool {
+		for _, span := range trace.Spans {
+			if span.Process == nil {
+				continue
+			}
+			if _, ok := serviceSet[span.Process.ServiceName]; ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// TagPolicy keeps traces that contain at least one span with the given tag, whose value
+// in string form is equal to value.
+func TagPolicy(key, value string) Policy {
+	return func(trace *model.Trace) bool {
+		for _, span := range trace.Spans {
+			for i := range span.Tags {
+				if span.Tags[i].Key == key && span.Tags[i].AsString() == value {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// ProbabilisticPolicy keeps the given fraction of traces. The decision is derived from the hash
+// of the trace ID, so that all collectors make the same decision for the same trace, whatever
+// the distribution of the trace IDs generated by the clients.
+func ProbabilisticPolicy(probability float64) Policy {
+	if probability >= 1 {
+		return func(trace *model.Trace) bool { return true }
+	}
+	boundary := uint64(probability * math.MaxUint64)
+	return func(trace *model.Trace) bool {
+		if len(trace.Spans) == 0 {
+			return false
+		}
+		return hashTraceID(trace.Spans[0].TraceID) < boundary
+	}
+}
+
+// hashTraceID returns the FNV-1a hash of the trace ID, spread by the finalizer of MurmurHash3
+// because the high bits of FNV-1a vary little when only the last bytes of the input do.
+func hashTraceID(traceID model.TraceID) uint64 {
+	var idBytes [16]byte
+	binary.BigEndian.PutUint64(idBytes[:8], traceID.High)
+	binary.BigEndian.PutUint64(idBytes[8:], traceID.Low)
+	hasher := fnv.New64a()
+	hasher.Write(idBytes[:])
+	hash := hasher.Sum64()
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return hash
+}
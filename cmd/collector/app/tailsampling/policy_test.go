@@ -0,0 +1,101 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+func makeTrace(spans ...*model.Span) *model.Trace {
+	return &model.Trace{Spans: spans}
+}
+
+func makeSpan(service string, start time.Time, duration time.Duration, tags ...model.KeyValue) *model.Span {
+	return &model.Span{
+		TraceID:   model.TraceID{Low: 42},
+		StartTime: start,
+		Duration:  duration,
+		Tags:      tags,
+		Process:   &model.Process{ServiceName: service},
+	}
+}
+
+func TestErrorPolicy(t *testing.T) {
+	now := time.Now()
+	policy := ErrorPolicy()
+	assert.False(t, policy(makeTrace(makeSpan("foo", now, time.Second))))
+	assert.False(t, policy(makeTrace(makeSpan("foo", now, time.Second, model.Bool("error", false)))))
+	assert.False(t, policy(makeTrace(makeSpan("foo", now, time.Second, model.String("other", "true")))))
+	assert.True(t, policy(makeTrace(
+		makeSpan("foo", now, time.Second),
+		makeSpan("foo", now, time.Second, model.Bool("error", true)))))
+	assert.True(t, policy(makeTrace(makeSpan("foo", now, time.Second, model.String("error", "true")))))
+}
+
+func TestLatencyPolicy(t *testing.T) {
+	now := time.Now()
+	policy := LatencyPolicy(time.Second)
+	assert.False(t, policy(makeTrace(makeSpan("foo", now, 500*time.Millisecond))))
+	assert.True(t, policy(makeTrace(makeSpan("foo", now, time.Second))))
+	// the trace spans from the earliest start to the latest end of its spans
+	assert.True(t, policy(makeTrace(
+		makeSpan("foo", now.Add(600*time.Millisecond), 500*time.Millisecond),
+		makeSpan("foo", now, 500*time.Millisecond))))
+}
+
+func TestServicePolicy(t *testing.T) {
+	now := time.Now()
+	policy := ServicePolicy("foo", "bar")
+	assert.False(t, policy(makeTrace(makeSpan("baz", now, time.Second), &model.Span{})))
+	assert.True(t, policy(makeTrace(makeSpan("baz", now, time.Second), makeSpan("bar", now, time.Second))))
+}
+
+func TestTagPolicy(t *testing.T) {
+	now := time.Now()
+	policy := TagPolicy("http.status_code", "500")
+	assert.False(t, policy(makeTrace(makeSpan("foo", now, time.Second, model.Int64("http.status_code", 200)))))
+	assert.True(t, policy(makeTrace(makeSpan("foo", now, time.Second, model.Int64("http.status_code", 500)))))
+	assert.True(t, policy(makeTrace(makeSpan("foo", now, time.Second, model.String("http.status_code", "500")))))
+}
+
+func TestProbabilisticPolicy(t *testing.T) {
+	traceWithID := func(low uint64) *model.Trace {
+		return makeTrace(&model.Span{TraceID: model.TraceID{Low: low}})
+	}
+	assert.False(t, ProbabilisticPolicy(0)(traceWithID(0)))
+	assert.False(t, ProbabilisticPolicy(0.5)(makeTrace()))
+	assert.True(t, ProbabilisticPolicy(1)(traceWithID(math.MaxUint64)))
+
+	// sequential and small trace IDs are kept in proportion too
+	policy := ProbabilisticPolicy(0.3)
+	kept := 0
+	for i := uint64(0); i < 10000; i++ {
+		if policy(traceWithID(i)) {
+			kept++
+		}
+	}
+	assert.InDelta(t, 3000, kept, 300)
+
+	// the decision is the same for all spans and collectors
+	for i := uint64(0); i < 100; i++ {
+		assert.Equal(t, policy(traceWithID(i)), ProbabilisticPolicy(0.3)(traceWithID(i)))
+	}
+}
@@ -24,16 +24,11 @@ import (
 	"strings"
 	"time"
 
-	"github.com/apache/thrift/lib/go/thrift"
-	"github.com/go-openapi/loads"
-	"github.com/go-openapi/strfmt"
-	"github.com/go-openapi/swag"
 	"github.com/gorilla/mux"
 	tchanThrift "github.com/uber/tchannel-go/thrift"
 
 	"github.com/jaegertracing/jaeger/cmd/collector/app"
-	"github.com/jaegertracing/jaeger/swagger-gen/models"
-	"github.com/jaegertracing/jaeger/swagger-gen/restapi"
+	pkgzipkin "github.com/jaegertracing/jaeger/pkg/zipkin"
 	"github.com/jaegertracing/jaeger/swagger-gen/restapi/operations"
 	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
 )
@@ -41,17 +36,14 @@ import (
 // APIHandler handles all HTTP calls to the collector
 type APIHandler struct {
 	zipkinSpansHandler app.ZipkinSpansHandler
-	zipkinV2Formats    strfmt.Registry
 }
 
 // NewAPIHandler returns a new APIHandler
 func NewAPIHandler(
 	zipkinSpansHandler app.ZipkinSpansHandler,
 ) *APIHandler {
-	swaggerSpec, _ := loads.Analyzed(restapi.SwaggerJSON, "")
 	return &APIHandler{
 		zipkinSpansHandler: zipkinSpansHandler,
-		zipkinV2Formats:    operations.NewZipkinAPI(swaggerSpec).Formats(),
 	}
 }
 
@@ -62,61 +54,31 @@ func (aH *APIHandler) RegisterRoutes(router *mux.Router) {
 }
 
 func (aH *APIHandler) saveSpans(w http.ResponseWriter, r *http.Request) {
-	bRead := r.Body
-	defer r.Body.Close()
-	if strings.Contains(r.Header.Get("Content-Encoding"), "gzip") {
-		gz, err := gunzip(bRead)
-		if err != nil {
-			http.Error(w, fmt.Sprintf(app.UnableToReadBodyErrFormat, err), http.StatusBadRequest)
-			return
-		}
-		defer gz.Close()
-		bRead = gz
-	}
-
-	bodyBytes, err := ioutil.ReadAll(bRead)
-	if err != nil {
-		http.Error(w, fmt.Sprintf(app.UnableToReadBodyErrFormat, err), http.StatusInternalServerError)
-		return
-	}
-
-	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Cannot parse Content-Type: %v", err), http.StatusBadRequest)
-		return
-	}
-
-	var tSpans []*zipkincore.Span
-	if contentType == "application/x-thrift" {
-		tSpans, err = deserializeThrift(bodyBytes)
-	} else if contentType == "application/json" {
-		tSpans, err = DeserializeJSON(bodyBytes)
-	} else {
-		http.Error(w, "Unsupported Content-Type", http.StatusBadRequest)
-		return
-	}
-	if err != nil {
-		http.Error(w, fmt.Sprintf(app.UnableToReadBodyErrFormat, err), http.StatusBadRequest)
-		return
+	if aH.saveSpansWith(w, r, pkgzipkin.DeserializeV1) {
+		w.WriteHeader(http.StatusAccepted)
 	}
+}
 
-	if err := aH.saveThriftSpans(tSpans); err != nil {
-		http.Error(w, fmt.Sprintf("Cannot submit Zipkin batch: %v", err), http.StatusInternalServerError)
-		return
+func (aH *APIHandler) saveSpansV2(w http.ResponseWriter, r *http.Request) {
+	if aH.saveSpansWith(w, r, pkgzipkin.DeserializeV2) {
+		w.WriteHeader(operations.PostSpansAcceptedCode)
 	}
-
-	w.WriteHeader(http.StatusAccepted)
 }
 
-func (aH *APIHandler) saveSpansV2(w http.ResponseWriter, r *http.Request) {
+// saveSpansWith decodes the request body with the deserializer of the API version and submits the spans,
+// it returns false if it responded with an error
+func (aH *APIHandler) saveSpansWith(
+	w http.ResponseWriter,
+	r *http.Request,
+	deserialize func(contentType string, body []byte) ([]*zipkincore.Span, error),
+) bool {
 	bRead := r.Body
 	defer r.Body.Close()
 	if strings.Contains(r.Header.Get("Content-Encoding"), "gzip") {
 		gz, err := gunzip(bRead)
 		if err != nil {
 			http.Error(w, fmt.Sprintf(app.UnableToReadBodyErrFormat, err), http.StatusBadRequest)
-			return
+			return false
 		}
 		defer gz.Close()
 		bRead = gz
@@ -125,43 +87,31 @@ func (aH *APIHandler) saveSpansV2(w http.ResponseWriter, r *http.Request) {
 	bodyBytes, err := ioutil.ReadAll(bRead)
 	if err != nil {
 		http.Error(w, fmt.Sprintf(app.UnableToReadBodyErrFormat, err), http.StatusInternalServerError)
-		return
+		return false
 	}
 
 	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
 
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Cannot parse Content-Type: %v", err), http.StatusBadRequest)
-		return
-	}
-
-	if contentType != "application/json" {
-		http.Error(w, "Unsupported Content-Type", http.StatusBadRequest)
-		return
+		return false
 	}
 
-	var spans models.ListOfSpans
-	if err = swag.ReadJSON(bodyBytes, &spans); err != nil {
-		http.Error(w, fmt.Sprintf(app.UnableToReadBodyErrFormat, err), http.StatusBadRequest)
-		return
-	}
-	if err = spans.Validate(aH.zipkinV2Formats); err != nil {
-		http.Error(w, fmt.Sprintf(app.UnableToReadBodyErrFormat, err), http.StatusBadRequest)
-		return
+	tSpans, err := deserialize(contentType, bodyBytes)
+	if err == pkgzipkin.ErrUnsupportedContentType {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
 	}
-
-	tSpans, err := spansV2ToThrift(spans)
 	if err != nil {
 		http.Error(w, fmt.Sprintf(app.UnableToReadBodyErrFormat, err), http.StatusBadRequest)
-		return
+		return false
 	}
 
 	if err := aH.saveThriftSpans(tSpans); err != nil {
 		http.Error(w, fmt.Sprintf("Cannot submit Zipkin batch: %v", err), http.StatusInternalServerError)
-		return
+		return false
 	}
-
-	w.WriteHeader(operations.PostSpansAcceptedCode)
+	return true
 }
 
 func gunzip(r io.ReadCloser) (*gzip.Reader, error) {
@@ -181,27 +131,3 @@ func (aH *APIHandler) saveThriftSpans(tSpans []*zipkincore.Span) error {
 	}
 	return nil
 }
-
-func deserializeThrift(b []byte) ([]*zipkincore.Span, error) {
-	buffer := thrift.NewTMemoryBuffer()
-	buffer.Write(b)
-
-	transport := thrift.NewTBinaryProtocolTransport(buffer)
-	_, size, err := transport.ReadListBegin() // Ignore the returned element type
-	if err != nil {
-		return nil, err
-	}
-
-	// We don't depend on the size returned by ReadListBegin to preallocate the array because it
-	// sometimes returns a nil error on bad input and provides an unreasonably large int for size
-	var spans []*zipkincore.Span
-	for i := 0; i < size; i++ {
-		zs := &zipkincore.Span{}
-		if err = zs.Read(transport); err != nil {
-			return nil, err
-		}
-		spans = append(spans, zs)
-	}
-
-	return spans, nil
-}
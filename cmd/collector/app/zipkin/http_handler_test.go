@@ -26,7 +26,9 @@ import (
 	"time"
 
 	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/golang/protobuf/proto"
 	"github.com/gorilla/mux"
+	zipkinProto "github.com/openzipkin/zipkin-go/proto/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	jaegerClient "github.com/uber/jaeger-client-go"
@@ -38,6 +40,28 @@ import (
 
 var httpClient = &http.Client{Timeout: 2 * time.Second}
 
+var endpointFmt = `{"serviceName": "%s", "ipv4": "%s", "ipv6": "%s", "port": %d}`
+var annoFmt = `{"value": "%s", "timestamp": %d, "endpoint": %s}`
+var binaAnnoFmt = `{"key": "%s", "value": "%s", "endpoint": %s}`
+var spanFmt = `[{"name": "%s", "id": "%s", "parentId": "%s", "traceId": "%s", "timestamp": %d, "duration": %d, "debug": %t, "annotations": [%s], "binaryAnnotations": [%s]}]`
+
+func createEndpoint(serviveName string, ipv4 string, ipv6 string, port int) string {
+	return fmt.Sprintf(endpointFmt, serviveName, ipv4, ipv6, port)
+}
+
+func createAnno(val string, ts int, endpoint string) string {
+	return fmt.Sprintf(annoFmt, val, ts, endpoint)
+}
+
+func createBinAnno(key string, val string, endpoint string) string {
+	return fmt.Sprintf(binaAnnoFmt, key, val, endpoint)
+}
+
+func createSpan(name string, id string, parentID string, traceID string, ts int64, duration int64, debug bool,
+	anno string, binAnno string) string {
+	return fmt.Sprintf(spanFmt, name, id, parentID, traceID, ts, duration, debug, anno, binAnno)
+}
+
 type mockZipkinHandler struct {
 	err   error
 	mux   sync.Mutex
@@ -224,12 +248,6 @@ func TestFormatBadBody(t *testing.T) {
 	assert.EqualValues(t, "Unable to process request body: *zipkincore.Span field 0 read error: EOF\n", resBodyStr)
 }
 
-func TestDeserializeWithBadListStart(t *testing.T) {
-	spanBytes := zipkinSerialize([]*zipkincore.Span{{}})
-	_, err := deserializeThrift(append([]byte{0, 255, 255}, spanBytes...))
-	assert.Error(t, err)
-}
-
 func TestCannotReadBodyFromRequest(t *testing.T) {
 	handler := NewAPIHandler(&mockZipkinHandler{})
 	req, err := http.NewRequest(http.MethodPost, "whatever", &errReader{})
@@ -285,6 +303,38 @@ func TestSaveSpansV2(t *testing.T) {
 	assert.EqualValues(t, "Cannot submit Zipkin batch: Bad times ahead\n", resBody)
 }
 
+func TestSaveSpansV2Proto(t *testing.T) {
+	server, handler := initializeTestServer(nil)
+	defer server.Close()
+	span := &zipkinProto.Span{
+		TraceId:       []byte{0, 0, 0, 0, 0, 0, 0, 1},
+		Id:            []byte{0, 0, 0, 0, 0, 0, 0, 2},
+		Name:          "foo",
+		LocalEndpoint: &zipkinProto.Endpoint{ServiceName: "foo"},
+	}
+	body, err := proto.Marshal(&zipkinProto.ListOfSpans{Spans: []*zipkinProto.Span{span}})
+	require.NoError(t, err)
+
+	statusCode, resBody, err := postBytes(server.URL+`/api/v2/spans`, body, createHeader("application/x-protobuf"))
+	require.NoError(t, err)
+	assert.EqualValues(t, http.StatusAccepted, statusCode)
+	assert.Empty(t, resBody)
+	waitForSpans(t, handler.zipkinSpansHandler.(*mockZipkinHandler), 1)
+	assert.Equal(t, "foo", handler.zipkinSpansHandler.(*mockZipkinHandler).getSpans()[0].Name)
+
+	statusCode, resBody, err = postBytes(server.URL+`/api/v2/spans`, []byte("not good"), createHeader("application/x-protobuf"))
+	require.NoError(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, statusCode)
+	assert.Contains(t, resBody, "Unable to process request body")
+
+	body, err = proto.Marshal(&zipkinProto.ListOfSpans{Spans: []*zipkinProto.Span{{TraceId: []byte{1}}}})
+	require.NoError(t, err)
+	statusCode, resBody, err = postBytes(server.URL+`/api/v2/spans`, body, createHeader("application/x-protobuf"))
+	require.NoError(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, statusCode)
+	assert.EqualValues(t, "Unable to process request body: invalid length for trace id: 1 bytes\n", resBody)
+}
+
 type errReader struct{}
 
 func (e *errReader) Read(p []byte) (int, error) {
@@ -32,14 +32,17 @@ import (
 	"github.com/uber/tchannel-go"
 	"github.com/uber/tchannel-go/thrift"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	basicB "github.com/jaegertracing/jaeger/cmd/builder"
 	"github.com/jaegertracing/jaeger/cmd/collector/app"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/builder"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/zipkin"
 	"github.com/jaegertracing/jaeger/cmd/env"
 	"github.com/jaegertracing/jaeger/cmd/flags"
+	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/pkg/config"
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	pMetrics "github.com/jaegertracing/jaeger/pkg/metrics"
@@ -88,6 +91,9 @@ func main() {
 			}
 
 			builderOpts := new(builder.CollectorOptions).InitFromViper(v)
+			if err := builderOpts.Validate(); err != nil {
+				logger.Fatal("Invalid collector options", zap.Error(err))
+			}
 
 			mBldr := new(pMetrics.Builder).InitFromViper(v)
 			baseFactory, err := mBldr.CreateMetricsFactory("jaeger")
@@ -105,6 +111,8 @@ func main() {
 			}
 
 			metricsFactory := baseFactory.Namespace("collector", nil)
+			strategyStore, aggregator := initializeSampling(strategyStoreFactory, storageFactory, v, metricsFactory, logger)
+
 			handlerBuilder, err := builder.NewSpanHandlerBuilder(
 				builderOpts,
 				spanWriter,
@@ -114,18 +122,18 @@ func main() {
 			if err != nil {
 				logger.Fatal("Unable to set up builder", zap.Error(err))
 			}
+			handlerBuilder.WithAggregator(aggregator)
 
 			ch, err := tchannel.NewChannel(serviceName, &tchannel.ChannelOptions{})
 			if err != nil {
 				logger.Fatal("Unable to create new TChannel", zap.Error(err))
 			}
 			server := thrift.NewServer(ch)
-			zipkinSpansHandler, jaegerBatchesHandler := handlerBuilder.BuildHandlers()
+			zipkinSpansHandler, jaegerBatchesHandler, grpcHandler := handlerBuilder.BuildHandlers()
 			server.Register(jc.NewTChanCollectorServer(jaegerBatchesHandler))
 			server.Register(zc.NewTChanZipkinCollectorServer(zipkinSpansHandler))
 
-			samplingHandler := initializeSamplingHandler(strategyStoreFactory, v, metricsFactory, logger)
-			server.Register(sc.NewTChanSamplingManagerServer(samplingHandler))
+			server.Register(sc.NewTChanSamplingManagerServer(sampling.NewHandler(strategyStore)))
 
 			portStr := ":" + strconv.Itoa(builderOpts.CollectorPort)
 			listener, err := net.Listen("tcp", portStr)
@@ -134,9 +142,14 @@ func main() {
 			}
 			ch.Serve(listener)
 
+			grpcServer := startGRPCServer(logger, builderOpts.CollectorGRPCPort, grpcHandler, hc)
+
 			r := mux.NewRouter()
 			apiHandler := app.NewAPIHandler(jaegerBatchesHandler)
 			apiHandler.RegisterRoutes(r)
+			if err := grpcHandler.RegisterGatewayRoutes(r); err != nil {
+				logger.Fatal("Unable to register gRPC gateway routes", zap.Error(err))
+			}
 			if h := mBldr.Handler(); h != nil {
 				logger.Info("Registering metrics handler with HTTP server", zap.String("route", mBldr.HTTPRoute))
 				r.Handle(mBldr.HTTPRoute, h)
@@ -158,12 +171,26 @@ func main() {
 			hc.Ready()
 			select {
 			case <-signalsChannel:
+				if grpcServer != nil {
+					grpcServer.GracefulStop()
+				}
+				if aggregator != nil {
+					aggregator.Stop()
+				}
+				if closer, ok := strategyStore.(io.Closer); ok {
+					if err := closer.Close(); err != nil {
+						logger.Error("Failed to close sampling strategy store", zap.Error(err))
+					}
+				}
 				if closer, ok := spanWriter.(io.Closer); ok {
 					err := closer.Close()
 					if err != nil {
 						logger.Error("Failed to close span writer", zap.Error(err))
 					}
 				}
+				if err := storageFactory.Close(); err != nil {
+					logger.Error("Failed to close storage factory", zap.Error(err))
+				}
 
 				logger.Info("Jaeger Collector is finishing")
 			}
@@ -193,6 +220,31 @@ func main() {
 	}
 }
 
+func startGRPCServer(
+	logger *zap.Logger,
+	grpcPort int,
+	grpcHandler *app.GRPCHandler,
+	hc *healthcheck.HealthCheck,
+) *grpc.Server {
+	if grpcPort == 0 {
+		return nil
+	}
+	server := grpc.NewServer()
+	model.RegisterCollectorServiceV2Server(server, grpcHandler)
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(grpcPort))
+	if err != nil {
+		logger.Fatal("Unable to start listening on gRPC port", zap.Error(err))
+	}
+	logger.Info("Starting Jaeger Collector gRPC server", zap.Int("grpc-port", grpcPort))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			logger.Fatal("Could not launch gRPC service", zap.Error(err))
+		}
+		hc.Set(healthcheck.Unavailable)
+	}()
+	return server
+}
+
 func startZipkinHTTPAPI(
 	logger *zap.Logger,
 	zipkinPort int,
@@ -213,19 +265,27 @@ func startZipkinHTTPAPI(
 	}
 }
 
-func initializeSamplingHandler(
+func initializeSampling(
 	samplingStrategyStoreFactory *ss.Factory,
+	storageFactory *storage.Factory,
 	v *viper.Viper,
 	metricsFactory metrics.Factory,
 	logger *zap.Logger,
-) sampling.Handler {
+) (strategystore.StrategyStore, strategystore.Aggregator) {
 	samplingStrategyStoreFactory.InitFromViper(v)
-	if err := samplingStrategyStoreFactory.Initialize(metricsFactory, logger); err != nil {
+	if err := samplingStrategyStoreFactory.Initialize(metricsFactory, storageFactory, logger); err != nil {
 		logger.Fatal("Failed to init sampling strategy store factory", zap.Error(err))
 	}
 	strategyStore, err := samplingStrategyStoreFactory.CreateStrategyStore()
 	if err != nil {
 		logger.Fatal("Failed to create sampling strategy store", zap.Error(err))
 	}
-	return sampling.NewHandler(strategyStore)
+	aggregator, err := samplingStrategyStoreFactory.CreateAggregator()
+	if err != nil {
+		logger.Fatal("Failed to create sampling aggregator", zap.Error(err))
+	}
+	if aggregator != nil {
+		aggregator.Start()
+	}
+	return strategyStore, aggregator
 }
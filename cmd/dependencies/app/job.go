@@ -0,0 +1,121 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/adjuster"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// Job computes the dependency links between services from the spans stored in a time window,
+// the same way the memory storage computes them on the fly, and writes them to the dependencies storage.
+type Job struct {
+	reader  spanstore.Reader
+	writer  dependencystore.Writer
+	options Options
+	deduper adjuster.Adjuster
+	logger  *zap.Logger
+}
+
+// NewJob creates a Job that reads traces with reader and writes the links with writer
+func NewJob(reader spanstore.Reader, writer dependencystore.Writer, options Options, logger *zap.Logger) *Job {
+	return &Job{
+		reader:  reader,
+		writer:  writer,
+		options: options,
+		deduper: adjuster.SpanIDDeduper(),
+		logger:  logger,
+	}
+}
+
+// minQueryWindow is the smallest time window the traces of a service are queried in. The job fails
+// rather than undercount the dependencies if such a window still holds too many traces.
+const minQueryWindow = time.Millisecond
+
+// Run computes the dependency links of the traces that started in the lookback window before endTs,
+// and writes them with the timestamp endTs.
+func (j *Job) Run(endTs time.Time) ([]model.DependencyLink, error) {
+	services, err := j.reader.GetServices()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get services")
+	}
+	aggregator := dependencystore.NewAggregator()
+	seen := map[model.TraceID]struct{}{}
+	addTrace := func(trace *model.Trace) {
+		if len(trace.Spans) == 0 {
+			return
+		}
+		// traces spanning several services or time windows are found once for each of them
+		traceID := trace.Spans[0].TraceID
+		if _, ok := seen[traceID]; ok {
+			return
+		}
+		seen[traceID] = struct{}{}
+		// SpanIDDeduper never returns an err
+		trace, _ = j.deduper.Adjust(trace)
+		aggregator.AddTrace(trace)
+	}
+	for _, service := range services {
+		if err := j.findTraces(service, endTs.Add(-j.options.Lookback), endTs, addTrace); err != nil {
+			return nil, err
+		}
+	}
+	links := aggregator.Links()
+	j.logger.Info("Computed dependencies",
+		zap.Time("end", endTs), zap.Duration("lookback", j.options.Lookback),
+		zap.Int("traces", len(seen)), zap.Int("links", len(links)))
+	if err := j.writer.WriteDependencies(endTs, links); err != nil {
+		return nil, errors.Wrap(err, "Failed to write dependencies")
+	}
+	return links, nil
+}
+
+// findTraces calls fn with every trace of the service that started between start and end. Since the
+// readers cannot page through the results of a query, a window holding more traces than a query
+// returns is split in two halves that are queried separately.
+func (j *Job) findTraces(service string, start, end time.Time, fn func(trace *model.Trace)) error {
+	traces, err := j.reader.FindTraces(&spanstore.TraceQueryParameters{
+		ServiceName:  service,
+		StartTimeMin: start,
+		StartTimeMax: end,
+		NumTraces:    j.options.TracesPerQuery,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Failed to find traces of service %s", service)
+	}
+	if len(traces) < j.options.TracesPerQuery {
+		for _, trace := range traces {
+			fn(trace)
+		}
+		return nil
+	}
+	window := end.Sub(start)
+	if window <= minQueryWindow {
+		return errors.Errorf("Found more than %d traces of service %s between %v and %v, increase %s",
+			j.options.TracesPerQuery, service, start, end, tracesPerQuery)
+	}
+	middle := start.Add(window / 2)
+	if err := j.findTraces(service, start, middle, fn); err != nil {
+		return err
+	}
+	return j.findTraces(service, middle, end, fn)
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	depStoreMocks "github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+	spanStoreMocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
+)
+
+var endTs = time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newSpan(traceID model.TraceID, spanID, parentID uint64, service string) *model.Span {
+	span := &model.Span{
+		TraceID: traceID,
+		SpanID:  model.SpanID(spanID),
+		Process: model.NewProcess(service, nil),
+	}
+	if parentID != 0 {
+		span.References = []model.SpanRef{model.NewChildOfRef(traceID, model.SpanID(parentID))}
+	}
+	return span
+}
+
+func matchQuery(service string, start, end time.Time) interface{} {
+	return mock.MatchedBy(func(query *spanstore.TraceQueryParameters) bool {
+		return query.ServiceName == service &&
+			query.StartTimeMin.Equal(start) &&
+			query.StartTimeMax.Equal(end) &&
+			query.NumTraces == 2
+	})
+}
+
+func TestJobRun(t *testing.T) {
+	traceID1 := model.NewTraceID(0, 1)
+	trace1 := &model.Trace{Spans: []*model.Span{
+		newSpan(traceID1, 1, 0, "frontend"),
+		newSpan(traceID1, 2, 1, "backend"),
+	}}
+	traceID2 := model.NewTraceID(0, 2)
+	trace2 := &model.Trace{Spans: []*model.Span{
+		newSpan(traceID2, 1, 0, "frontend"),
+		newSpan(traceID2, 2, 1, "backend"),
+		newSpan(traceID2, 3, 2, "db"),
+	}}
+
+	start, middle := endTs.Add(-time.Hour), endTs.Add(-30*time.Minute)
+	reader := new(spanStoreMocks.Reader)
+	reader.On("GetServices").Return([]string{"frontend", "backend", "db"}, nil)
+	// the window of frontend holds as many traces as a query returns, so it is queried in two halves
+	reader.On("FindTraces", matchQuery("frontend", start, endTs)).Return([]*model.Trace{trace1, trace2}, nil)
+	reader.On("FindTraces", matchQuery("frontend", start, middle)).Return([]*model.Trace{trace1}, nil)
+	reader.On("FindTraces", matchQuery("frontend", middle, endTs)).Return([]*model.Trace{trace1, trace2}, nil).Once()
+	reader.On("FindTraces", matchQuery("frontend", middle, endTs.Add(-15*time.Minute))).Return([]*model.Trace{}, nil)
+	reader.On("FindTraces", matchQuery("frontend", endTs.Add(-15*time.Minute), endTs)).Return([]*model.Trace{trace2}, nil)
+	reader.On("FindTraces", matchQuery("backend", start, endTs)).Return([]*model.Trace{trace1, {}}, nil).Once()
+	reader.On("FindTraces", matchQuery("backend", start, middle)).Return([]*model.Trace{trace1}, nil)
+	reader.On("FindTraces", matchQuery("backend", middle, endTs)).Return([]*model.Trace{{}}, nil)
+	reader.On("FindTraces", matchQuery("db", start, endTs)).Return([]*model.Trace{trace2}, nil)
+
+	expected := []model.DependencyLink{
+		{Parent: "backend", Child: "db", CallCount: 1},
+		{Parent: "frontend", Child: "backend", CallCount: 2},
+	}
+	writer := new(depStoreMocks.Writer)
+	writer.On("WriteDependencies", endTs, expected).Return(nil)
+
+	job := NewJob(reader, writer, Options{Lookback: time.Hour, TracesPerQuery: 2}, zap.NewNop())
+	links, err := job.Run(endTs)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, links)
+	reader.AssertExpectations(t)
+	writer.AssertExpectations(t)
+}
+
+func TestJobRunTooManyTraces(t *testing.T) {
+	traceID := model.NewTraceID(0, 1)
+	trace := &model.Trace{Spans: []*model.Span{newSpan(traceID, 1, 0, "frontend")}}
+	reader := new(spanStoreMocks.Reader)
+	reader.On("GetServices").Return([]string{"frontend"}, nil)
+	reader.On("FindTraces", mock.Anything).Return([]*model.Trace{trace, trace}, nil)
+	writer := new(depStoreMocks.Writer)
+
+	job := NewJob(reader, writer, Options{Lookback: time.Second, TracesPerQuery: 2}, zap.NewNop())
+	_, err := job.Run(endTs)
+	assert.Contains(t, err.Error(), "Found more than 2 traces of service frontend between")
+	assert.Contains(t, err.Error(), "increase dependencies.traces-per-query")
+	writer.AssertNotCalled(t, "WriteDependencies", mock.Anything, mock.Anything)
+}
+
+func TestJobRunErrors(t *testing.T) {
+	testCases := []struct {
+		caption       string
+		servicesErr   error
+		findErr       error
+		writeErr      error
+		expectedError string
+	}{
+		{
+			caption:       "services error",
+			servicesErr:   errors.New("services-error"),
+			expectedError: "Failed to get services: services-error",
+		},
+		{
+			caption:       "find error",
+			findErr:       errors.New("find-error"),
+			expectedError: "Failed to find traces of service frontend: find-error",
+		},
+		{
+			caption:       "write error",
+			writeErr:      errors.New("write-error"),
+			expectedError: "Failed to write dependencies: write-error",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.caption, func(t *testing.T) {
+			reader := new(spanStoreMocks.Reader)
+			reader.On("GetServices").Return([]string{"frontend"}, testCase.servicesErr)
+			reader.On("FindTraces", mock.Anything).Return([]*model.Trace{}, testCase.findErr)
+			writer := new(depStoreMocks.Writer)
+			writer.On("WriteDependencies", endTs, mock.Anything).Return(testCase.writeErr)
+
+			job := NewJob(reader, writer, Options{Lookback: time.Hour, TracesPerQuery: 2}, zap.NewNop())
+			_, err := job.Run(endTs)
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"flag"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	endTime        = "dependencies.end-time"
+	lookback       = "dependencies.lookback"
+	tracesPerQuery = "dependencies.traces-per-query"
+
+	defaultLookback       = 24 * time.Hour
+	defaultTracesPerQuery = 1000
+)
+
+// Options holds the configuration of the dependencies job
+type Options struct {
+	// EndTime is the RFC3339 end of the time window, or empty for the current time
+	EndTime string
+	// Lookback is the duration of the time window
+	Lookback time.Duration
+	// TracesPerQuery limits the number of traces loaded by each query. The time window of a service
+	// with more traces is split into smaller windows until each of them fits in a query.
+	TracesPerQuery int
+}
+
+// AddFlags adds flags for Options
+func AddFlags(flagSet *flag.FlagSet) {
+	flagSet.String(
+		endTime,
+		"",
+		"The end of the time window of the spans to process, in RFC3339 format (default now)")
+	flagSet.Duration(
+		lookback,
+		defaultLookback,
+		"The duration of the time window of the spans to process")
+	flagSet.Int(
+		tracesPerQuery,
+		defaultTracesPerQuery,
+		"The maximum number of traces loaded by each query, the time window of a service with more traces is queried in smaller parts")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (o *Options) InitFromViper(v *viper.Viper) *Options {
+	o.EndTime = v.GetString(endTime)
+	o.Lookback = v.GetDuration(lookback)
+	o.TracesPerQuery = v.GetInt(tracesPerQuery)
+	return o
+}
+
+// End returns the end of the time window, which defaults to now
+func (o *Options) End(now time.Time) (time.Time, error) {
+	if o.EndTime == "" {
+		return now, nil
+	}
+	end, err := time.Parse(time.RFC3339, o.EndTime)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "Invalid %s", endTime)
+	}
+	return end, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func TestOptionsWithFlags(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--dependencies.end-time=2018-06-01T00:00:00Z",
+		"--dependencies.lookback=1h",
+		"--dependencies.traces-per-query=100",
+	})
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, Options{EndTime: "2018-06-01T00:00:00Z", Lookback: time.Hour, TracesPerQuery: 100}, *opts)
+
+	end, err := opts.End(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC), end.UTC())
+}
+
+func TestOptionsDefaults(t *testing.T) {
+	v, _ := config.Viperize(AddFlags)
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, Options{Lookback: defaultLookback, TracesPerQuery: defaultTracesPerQuery}, *opts)
+
+	now := time.Now()
+	end, err := opts.End(now)
+	assert.NoError(t, err)
+	assert.Equal(t, now, end)
+}
+
+func TestOptionsInvalidEndTime(t *testing.T) {
+	opts := Options{EndTime: "yesterday"}
+	_, err := opts.End(time.Now())
+	assert.Contains(t, err.Error(), "Invalid dependencies.end-time")
+}
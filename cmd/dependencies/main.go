@@ -0,0 +1,104 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/dependencies/app"
+	"github.com/jaegertracing/jaeger/cmd/env"
+	"github.com/jaegertracing/jaeger/cmd/flags"
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/pkg/version"
+	"github.com/jaegertracing/jaeger/plugin/storage"
+)
+
+func main() {
+	storageFactory, err := storage.NewFactory(storage.FactoryConfigFromEnvAndCLI(os.Args, os.Stderr))
+	if err != nil {
+		fmt.Printf("Cannot initialize storage factory: %v\n", err)
+		os.Exit(1)
+	}
+	v := viper.New()
+	command := &cobra.Command{
+		Use:   "jaeger-dependencies",
+		Short: "Jaeger dependencies computes the links between services from the stored spans",
+		Long: `Jaeger dependencies reads the traces that started in a time window from the span storage,
+counts the calls between parent and child services, and writes the resulting links to the dependencies storage.
+It is meant to run periodically, e.g. daily as a Kubernetes CronJob, for backends that do not compute dependencies on the fly.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.TryLoadConfigFile(v); err != nil {
+				return err
+			}
+			logger, err := zap.NewProduction()
+			if err != nil {
+				return err
+			}
+			options := new(app.Options).InitFromViper(v)
+			endTs, err := options.End(time.Now())
+			if err != nil {
+				return err
+			}
+
+			storageFactory.InitFromViper(v)
+			if err := storageFactory.Initialize(metrics.NullFactory, logger); err != nil {
+				return err
+			}
+			spanReader, err := storageFactory.CreateSpanReader()
+			if err != nil {
+				return err
+			}
+			depWriter, err := storageFactory.CreateDependencyWriter()
+			if err != nil {
+				return err
+			}
+
+			_, err = app.NewJob(spanReader, depWriter, *options, logger).Run(endTs)
+			if closer, ok := depWriter.(io.Closer); ok {
+				if err := closer.Close(); err != nil {
+					logger.Error("Failed to close dependency writer", zap.Error(err))
+				}
+			}
+			if err := storageFactory.Close(); err != nil {
+				logger.Error("Failed to close storage factory", zap.Error(err))
+			}
+			return err
+		},
+	}
+
+	command.AddCommand(version.Command())
+	command.AddCommand(env.Command())
+
+	config.AddFlags(
+		v,
+		command,
+		flags.AddConfigFileFlag,
+		storageFactory.AddFlags,
+		app.AddFlags,
+	)
+
+	if err := command.Execute(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
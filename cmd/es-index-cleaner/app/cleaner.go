@@ -0,0 +1,178 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gopkg.in/olivere/elastic.v5"
+
+	"github.com/jaegertracing/jaeger/pkg/es"
+	"github.com/jaegertracing/jaeger/plugin/storage/es/dependencystore"
+	"github.com/jaegertracing/jaeger/plugin/storage/es/spanstore"
+)
+
+// The index families written by plugin/storage/es. Daily indices are named <prefix><family><yyyy-mm-dd>.
+var indexFamilies = []string{spanstore.SpanIndexPrefix, spanstore.ServiceIndexPrefix, dependencystore.DependencyIndexPrefix}
+
+const (
+	indexDateLayout         = "2006-01-02"
+	archiveSpanIndex        = spanstore.SpanIndexPrefix + spanstore.ArchiveIndexSuffix
+	archiveServiceIndex     = spanstore.ServiceIndexPrefix + spanstore.ArchiveIndexSuffix
+	archiveSpanTimeField    = "startTimeMillis"
+	archiveServiceNameField = "serviceName"
+)
+
+// Cleaner deletes data older than a cutoff date from the ElasticSearch indices used by Jaeger.
+type Cleaner struct {
+	client      *elastic.Client
+	indexPrefix string
+	options     Options
+	logger      *zap.Logger
+}
+
+// NewCleaner creates a new Cleaner. The index prefix must match the es.index-prefix used by Jaeger.
+func NewCleaner(client *elastic.Client, indexPrefix string, options Options, logger *zap.Logger) *Cleaner {
+	if indexPrefix != "" {
+		indexPrefix += "-"
+	}
+	return &Cleaner{
+		client:      client,
+		indexPrefix: indexPrefix,
+		options:     options,
+		logger:      logger,
+	}
+}
+
+// Cutoff returns the start of the UTC day numOfDays days before now.
+// Data from before the cutoff is deleted, so the indices of today and of the last numOfDays days are kept.
+func Cutoff(now time.Time, numOfDays int) time.Time {
+	year, month, day := now.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -numOfDays)
+}
+
+// Clean deletes the data older than the cutoff, according to the options.
+func (c *Cleaner) Clean(ctx context.Context, cutoff time.Time) error {
+	if c.options.Archive {
+		return c.cleanArchive(ctx, cutoff)
+	}
+	return c.cleanIndices(ctx, cutoff)
+}
+
+// cleanIndices deletes the daily span, service and dependency indices older than the cutoff.
+// Every index is deleted separately, so that a single failure does not prevent deleting the others.
+func (c *Cleaner) cleanIndices(ctx context.Context, cutoff time.Time) error {
+	settings, err := c.client.IndexGetSettings("_all").Do(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list indices")
+	}
+	indices := make([]string, 0, len(settings))
+	for index := range settings {
+		indices = append(indices, index)
+	}
+	sort.Strings(indices)
+	toDelete := filterIndices(indices, c.indexPrefix, cutoff)
+	if len(toDelete) == 0 {
+		c.logger.Info("No indices to delete", zap.Time("cutoff", cutoff))
+		return nil
+	}
+
+	var failed int
+	for _, index := range toDelete {
+		if c.options.DryRun {
+			c.logger.Info("Would remove index (dry run)", zap.String("index", index))
+			continue
+		}
+		if _, err := c.client.DeleteIndex(index).Do(ctx); err != nil {
+			c.logger.Error("Failed to remove index", zap.String("index", index), zap.Error(err))
+			failed++
+			continue
+		}
+		c.logger.Info("Removed index", zap.String("index", index))
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to remove %d of %d indices", failed, len(toDelete))
+	}
+	return nil
+}
+
+// cleanArchive deletes the archived spans that started before the cutoff, and the archived services and
+// operations of the services that have no archived spans left. Archived traces are kept in single indices
+// rather than in daily ones, so they are removed by query instead of by deleting indices.
+func (c *Cleaner) cleanArchive(ctx context.Context, cutoff time.Time) error {
+	spanIndex := c.indexPrefix + archiveSpanIndex
+	cutoffMillis := cutoff.UnixNano() / int64(time.Millisecond)
+	if err := c.deleteByQuery(ctx, spanIndex, elastic.NewRangeQuery(archiveSpanTimeField).Lt(cutoffMillis), "archived spans"); err != nil {
+		return err
+	}
+	// services do not record when they were archived, so they are kept as long as they have recent spans
+	return spanstore.ForEachServiceWithoutSpans(
+		ctx,
+		es.WrapESClient(c.client, nil),
+		[]string{spanIndex},
+		[]string{c.indexPrefix + archiveServiceIndex},
+		elastic.NewRangeQuery(archiveSpanTimeField).Gte(cutoffMillis),
+		func(services []interface{}) error {
+			query := elastic.NewTermsQuery(archiveServiceNameField, services...)
+			return c.deleteByQuery(ctx, c.indexPrefix+archiveServiceIndex, query, "archived services")
+		})
+}
+
+// deleteByQuery deletes the documents matching the query from the index, or only counts them in a dry run.
+func (c *Cleaner) deleteByQuery(ctx context.Context, index string, query elastic.Query, documents string) error {
+	if c.options.DryRun {
+		count, err := c.client.Count(index).Query(query).Do(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to count %s in %s", documents, index)
+		}
+		c.logger.Info("Would remove "+documents+" (dry run)", zap.String("index", index), zap.Int64("count", count))
+		return nil
+	}
+	res, err := c.client.DeleteByQuery(index).Query(query).Do(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove %s from %s", documents, index)
+	}
+	if len(res.Failures) > 0 {
+		return errors.Errorf("failed to remove %d %s from %s", len(res.Failures), documents, index)
+	}
+	c.logger.Info("Removed "+documents, zap.String("index", index), zap.Int64("count", res.Deleted))
+	return nil
+}
+
+// filterIndices returns the daily Jaeger indices with the given prefix that are older than the cutoff.
+// Indices that do not follow the daily naming scheme, e.g. archive or rolled over indices, are never returned.
+func filterIndices(indices []string, prefix string, cutoff time.Time) []string {
+	var result []string
+	for _, index := range indices {
+		for _, family := range indexFamilies {
+			if !strings.HasPrefix(index, prefix+family) {
+				continue
+			}
+			date, err := time.Parse(indexDateLayout, strings.TrimPrefix(index, prefix+family))
+			if err != nil {
+				continue
+			}
+			if date.Before(cutoff) {
+				result = append(result, index)
+			}
+		}
+	}
+	return result
+}
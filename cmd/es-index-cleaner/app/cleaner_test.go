@@ -0,0 +1,203 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gopkg.in/olivere/elastic.v5"
+)
+
+var testCutoff = time.Date(2018, time.August, 10, 0, 0, 0, 0, time.UTC)
+
+// fakeES lists the given indices and records the deletions and queries it receives.
+type fakeES struct {
+	sync.Mutex
+	indices     []string
+	failDelete  map[string]bool
+	deleted     []string
+	queryBodies map[string]map[string]interface{}
+	refreshed   []string
+}
+
+func (f *fakeES) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	switch {
+	case path == "_all/_settings":
+		settings := make(map[string]interface{})
+		for _, index := range f.indices {
+			settings[index] = map[string]interface{}{"settings": map[string]interface{}{}}
+		}
+		json.NewEncoder(w).Encode(settings)
+	case r.Method == http.MethodDelete:
+		if f.failDelete[path] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.deleted = append(f.deleted, path)
+		w.Write([]byte(`{"acknowledged": true}`))
+	case strings.HasSuffix(path, "/_count"), strings.HasSuffix(path, "/_delete_by_query"):
+		var body map[string]interface{}
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		if f.queryBodies == nil {
+			f.queryBodies = make(map[string]map[string]interface{})
+		}
+		f.queryBodies[path] = body
+		w.Write([]byte(`{"count": 3, "deleted": 3}`))
+	case strings.HasSuffix(path, "/_search"):
+		var body map[string]interface{}
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		if f.queryBodies == nil {
+			f.queryBodies = make(map[string]map[string]interface{})
+		}
+		f.queryBodies[path] = body
+		if strings.Contains(path, "service") {
+			w.Write([]byte(`{"hits": {"total": 3, "hits": []}, "aggregations": {"distinct_services": {"buckets": [
+				{"key": "svc1", "doc_count": 2}, {"key": "svc2", "doc_count": 1}]}}}`))
+			return
+		}
+		w.Write([]byte(`{"hits": {"total": 2, "hits": []},
+			"aggregations": {"distinct_services": {"buckets": [{"key": "svc1", "doc_count": 2}]}}}`))
+	case strings.HasSuffix(path, "/_refresh"):
+		f.refreshed = append(f.refreshed, strings.TrimSuffix(path, "/_refresh"))
+		w.Write([]byte(`{"_shards": {"total": 1, "successful": 1, "failed": 0}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func withCleaner(t *testing.T, es *fakeES, indexPrefix string, options Options, fn func(c *Cleaner)) {
+	server := httptest.NewServer(es)
+	defer server.Close()
+	client, err := elastic.NewClient(
+		elastic.SetURL(server.URL),
+		elastic.SetSniff(false),
+		elastic.SetHealthcheck(false))
+	require.NoError(t, err)
+	fn(NewCleaner(client, indexPrefix, options, zap.NewNop()))
+}
+
+func TestCutoff(t *testing.T) {
+	now := time.Date(2018, time.August, 12, 17, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2018, time.August, 12, 0, 0, 0, 0, time.UTC), Cutoff(now, 0))
+	assert.Equal(t, testCutoff, Cutoff(now, 2))
+}
+
+func TestFilterIndices(t *testing.T) {
+	indices := []string{
+		"jaeger-span-2018-08-09",
+		"jaeger-span-2018-08-10",
+		"jaeger-service-2018-08-01",
+		"jaeger-dependencies-2018-08-09",
+		"jaeger-span-archive",
+		"jaeger-span-000001",
+		"staging-jaeger-span-2018-08-09",
+		"other-index-2018-08-01",
+	}
+	assert.Equal(t, []string{
+		"jaeger-span-2018-08-09",
+		"jaeger-service-2018-08-01",
+		"jaeger-dependencies-2018-08-09",
+	}, filterIndices(indices, "", testCutoff))
+	assert.Equal(t, []string{"staging-jaeger-span-2018-08-09"}, filterIndices(indices, "staging-", testCutoff))
+}
+
+func TestCleanIndices(t *testing.T) {
+	es := &fakeES{indices: []string{"jaeger-span-2018-08-09", "jaeger-service-2018-08-09", "jaeger-span-2018-08-10"}}
+	withCleaner(t, es, "", Options{}, func(c *Cleaner) {
+		require.NoError(t, c.Clean(context.Background(), testCutoff))
+	})
+	assert.Equal(t, []string{"jaeger-service-2018-08-09", "jaeger-span-2018-08-09"}, es.deleted)
+}
+
+func TestCleanIndicesWithPrefix(t *testing.T) {
+	es := &fakeES{indices: []string{"jaeger-span-2018-08-09", "staging-jaeger-span-2018-08-09"}}
+	withCleaner(t, es, "staging", Options{}, func(c *Cleaner) {
+		require.NoError(t, c.Clean(context.Background(), testCutoff))
+	})
+	assert.Equal(t, []string{"staging-jaeger-span-2018-08-09"}, es.deleted)
+}
+
+func TestCleanIndicesDryRun(t *testing.T) {
+	es := &fakeES{indices: []string{"jaeger-span-2018-08-09"}}
+	withCleaner(t, es, "", Options{DryRun: true}, func(c *Cleaner) {
+		require.NoError(t, c.Clean(context.Background(), testCutoff))
+	})
+	assert.Empty(t, es.deleted)
+}
+
+func TestCleanIndicesPartialFailure(t *testing.T) {
+	es := &fakeES{
+		indices:    []string{"jaeger-span-2018-08-08", "jaeger-span-2018-08-09"},
+		failDelete: map[string]bool{"jaeger-span-2018-08-08": true},
+	}
+	withCleaner(t, es, "", Options{}, func(c *Cleaner) {
+		err := c.Clean(context.Background(), testCutoff)
+		assert.EqualError(t, err, "failed to remove 1 of 2 indices")
+	})
+	assert.Equal(t, []string{"jaeger-span-2018-08-09"}, es.deleted)
+}
+
+func TestCleanArchive(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		es := &fakeES{}
+		withCleaner(t, es, "staging", Options{Archive: true, DryRun: dryRun}, func(c *Cleaner) {
+			require.NoError(t, c.Clean(context.Background(), testCutoff))
+		})
+		op := "_delete_by_query"
+		if dryRun {
+			op = "_count"
+		}
+		spanPath := "staging-jaeger-span-archive/" + op
+		require.Contains(t, es.queryBodies, spanPath)
+		query := es.queryBodies[spanPath]["query"].(map[string]interface{})
+		rangeQuery := query["range"].(map[string]interface{})[archiveSpanTimeField].(map[string]interface{})
+		assert.Equal(t, float64(testCutoff.Unix()*1000), rangeQuery["to"])
+		assert.Equal(t, false, rangeQuery["include_upper"])
+
+		assert.Equal(t, []string{"staging-jaeger-span-archive,staging-jaeger-service-archive"}, es.refreshed)
+		require.Contains(t, es.queryBodies, "staging-jaeger-service-archive/_search")
+
+		searchPath := "staging-jaeger-span-archive/_search"
+		require.Contains(t, es.queryBodies, searchPath)
+		query = es.queryBodies[searchPath]["query"].(map[string]interface{})
+		filter := query["bool"].(map[string]interface{})["filter"].([]interface{})
+		require.Len(t, filter, 2)
+		assert.Equal(t, []interface{}{"svc1", "svc2"}, filter[0].(map[string]interface{})["terms"].(map[string]interface{})["process.serviceName"])
+		rangeQuery = filter[1].(map[string]interface{})["range"].(map[string]interface{})[archiveSpanTimeField].(map[string]interface{})
+		assert.Equal(t, float64(testCutoff.Unix()*1000), rangeQuery["from"])
+		assert.Equal(t, true, rangeQuery["include_lower"])
+
+		servicePath := "staging-jaeger-service-archive/" + op
+		require.Contains(t, es.queryBodies, servicePath)
+		query = es.queryBodies[servicePath]["query"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"svc2"}, query["terms"].(map[string]interface{})[archiveServiceNameField])
+	}
+}
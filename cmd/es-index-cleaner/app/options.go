@@ -0,0 +1,58 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"flag"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	dryRun  = "dry-run"
+	archive = "archive"
+)
+
+// Options holds the configuration of the index cleaner
+type Options struct {
+	// DryRun only logs what would be deleted
+	DryRun bool
+	// Archive makes the cleaner remove old spans from the archive span index, and the services without spans
+	// left from the archive service index, instead of removing daily indices.
+	// Daily indices are left untouched in this mode, so the cleaner has to be run once with and once without
+	// Archive to clean both
+	Archive bool
+}
+
+// AddFlags adds flags for Options
+func AddFlags(flagSet *flag.FlagSet) {
+	flagSet.Bool(
+		dryRun,
+		false,
+		"Only log the indices (or archived spans) that would be deleted, without deleting them")
+	flagSet.Bool(
+		archive,
+		false,
+		"Delete spans older than the given number of days from the jaeger-span-archive index, and the services "+
+			"without spans left from the jaeger-service-archive index, instead of deleting daily indices. "+
+			"Daily indices are not deleted when this flag is set")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (o *Options) InitFromViper(v *viper.Viper) *Options {
+	o.DryRun = v.GetBool(dryRun)
+	o.Archive = v.GetBool(archive)
+	return o
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gopkg.in/olivere/elastic.v5"
+
+	"github.com/jaegertracing/jaeger/cmd/es-index-cleaner/app"
+	"github.com/jaegertracing/jaeger/cmd/flags"
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/pkg/version"
+	"github.com/jaegertracing/jaeger/plugin/storage/es"
+)
+
+func main() {
+	v := viper.New()
+	esOptions := es.NewOptions("es")
+	command := &cobra.Command{
+		Use:   "jaeger-es-index-cleaner NUM_OF_DAYS",
+		Short: "Jaeger es-index-cleaner removes old Jaeger indices from ElasticSearch",
+		Long: `Jaeger es-index-cleaner removes the daily span, service and dependency indices that are older than NUM_OF_DAYS days.
+Today's indices are never removed, e.g. NUM_OF_DAYS=1 keeps the indices of today and yesterday.
+With --archive, spans older than NUM_OF_DAYS days are removed from the archive index instead, and daily indices
+are not removed; run the command once with and once without --archive to clean both.
+The command exits with a non-zero code if any index could not be removed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			numOfDays, err := strconv.Atoi(args[0])
+			if err != nil || numOfDays < 0 {
+				return fmt.Errorf("NUM_OF_DAYS must be a non-negative integer, got %q", args[0])
+			}
+			if err := flags.TryLoadConfigFile(v); err != nil {
+				return err
+			}
+			logger, err := zap.NewProduction()
+			if err != nil {
+				return err
+			}
+			esOptions.InitFromViper(v)
+			cfg := esOptions.GetPrimary()
+			options, err := cfg.GetConfigs()
+			if err != nil {
+				return err
+			}
+			client, err := elastic.NewClient(options...)
+			if err != nil {
+				return err
+			}
+			defer client.Stop()
+
+			cleanerOptions := new(app.Options).InitFromViper(v)
+			cleaner := app.NewCleaner(client, cfg.GetIndexPrefix(), *cleanerOptions, logger)
+			return cleaner.Clean(context.Background(), app.Cutoff(time.Now(), numOfDays))
+		},
+	}
+
+	command.AddCommand(version.Command())
+
+	config.AddFlags(
+		v,
+		command,
+		flags.AddConfigFileFlag,
+		esOptions.AddFlags,
+		app.AddFlags,
+	)
+
+	if err := command.Execute(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
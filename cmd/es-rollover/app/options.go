@@ -0,0 +1,60 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"flag"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	maxAge  = "rollover.max-age"
+	maxDocs = "rollover.max-docs"
+	maxSize = "rollover.max-size"
+)
+
+// Options holds the conditions under which the write aliases are rolled over to new indices.
+// Empty (zero) conditions are not sent to ElasticSearch. If no condition is set at all,
+// ElasticSearch rolls the indices over unconditionally.
+type Options struct {
+	MaxAge  string
+	MaxDocs int64
+	MaxSize string
+}
+
+// AddFlags adds flags for Options
+func AddFlags(flagSet *flag.FlagSet) {
+	flagSet.String(
+		maxAge,
+		"",
+		"Roll over when the current index is older than this ElasticSearch time unit, e.g. 1d or 12h")
+	flagSet.Int64(
+		maxDocs,
+		0,
+		"Roll over when the current index holds at least this many documents")
+	flagSet.String(
+		maxSize,
+		"",
+		"Roll over when the current index is at least this ElasticSearch byte size, e.g. 50gb (requires ElasticSearch 6.1+)")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (o *Options) InitFromViper(v *viper.Viper) *Options {
+	o.MaxAge = v.GetString(maxAge)
+	o.MaxDocs = v.GetInt64(maxDocs)
+	o.MaxSize = v.GetString(maxSize)
+	return o
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func TestOptionsWithFlags(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--rollover.max-age=2d",
+		"--rollover.max-docs=1000",
+		"--rollover.max-size=5gb",
+	})
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, Options{MaxAge: "2d", MaxDocs: 1000, MaxSize: "5gb"}, *opts)
+}
+
+func TestOptionsDefaults(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{})
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, Options{}, *opts)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gopkg.in/olivere/elastic.v5"
+
+	"github.com/jaegertracing/jaeger/plugin/storage/es/spanstore"
+)
+
+// indexSet describes a family of rolled over indices together with the aliases pointing at them.
+type indexSet struct {
+	prefix     string
+	readAlias  string
+	writeAlias string
+	mapping    string
+}
+
+// initialIndex is the name of the first index of the set. ElasticSearch derives the names of
+// the following indices by incrementing the numeric suffix on every rollover.
+func (s indexSet) initialIndex() string {
+	return s.prefix + "000001"
+}
+
+// Rollover creates and rolls over the indices used by the ElasticSearch storage
+// when it is configured to read and write through aliases.
+type Rollover struct {
+	client   *elastic.Client
+	logger   *zap.Logger
+	indexSet []indexSet
+}
+
+// NewRollover creates a new Rollover. The index prefix must match the es.index-prefix used by Jaeger,
+// the number of shards and replicas is applied to every index it creates.
+func NewRollover(client *elastic.Client, indexPrefix string, numShards, numReplicas int64, logger *zap.Logger) *Rollover {
+	if indexPrefix != "" {
+		indexPrefix += "-"
+	}
+	return &Rollover{
+		client: client,
+		logger: logger,
+		indexSet: []indexSet{
+			newIndexSet(indexPrefix+spanstore.SpanIndexPrefix, spanstore.GetSpanMapping(numShards, numReplicas)),
+			newIndexSet(indexPrefix+spanstore.ServiceIndexPrefix, spanstore.GetServiceMapping(numShards, numReplicas)),
+		},
+	}
+}
+
+// newIndexSet uses the same alias names as the span reader and writer in plugin/storage/es/spanstore.
+func newIndexSet(prefix string, mapping string) indexSet {
+	return indexSet{
+		prefix:     prefix,
+		readAlias:  prefix + "read",
+		writeAlias: prefix + "write",
+		mapping:    mapping,
+	}
+}
+
+// Init installs the index templates, then creates the initial indices and points both the
+// read and the write alias at them. Index sets whose write alias already exists are left untouched,
+// so it is safe to run Init more than once.
+func (r *Rollover) Init(ctx context.Context) error {
+	for _, set := range r.indexSet {
+		if err := r.putTemplate(ctx, set); err != nil {
+			return err
+		}
+		exists, err := r.client.IndexExists(set.writeAlias).Do(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check if alias %s exists", set.writeAlias)
+		}
+		if exists {
+			r.logger.Info("Alias already exists, skipping", zap.String("alias", set.writeAlias))
+			continue
+		}
+		body, err := unmarshalMapping(set.mapping)
+		if err != nil {
+			return err
+		}
+		body["aliases"] = map[string]interface{}{
+			set.readAlias:  map[string]interface{}{},
+			set.writeAlias: map[string]interface{}{},
+		}
+		if _, err := r.client.CreateIndex(set.initialIndex()).BodyJson(body).Do(ctx); err != nil {
+			return errors.Wrapf(err, "failed to create index %s", set.initialIndex())
+		}
+		r.logger.Info("Created index",
+			zap.String("index", set.initialIndex()),
+			zap.Strings("aliases", []string{set.readAlias, set.writeAlias}))
+	}
+	return nil
+}
+
+// Rollover rolls the write aliases over to new indices if any of the conditions is met,
+// and adds the new indices to the read aliases. The old indices remain readable
+// until they are deleted.
+func (r *Rollover) Rollover(ctx context.Context, conditions Options) error {
+	for _, set := range r.indexSet {
+		service := r.client.RolloverIndex(set.writeAlias)
+		if conditions.MaxAge != "" {
+			service = service.AddMaxIndexAgeCondition(conditions.MaxAge)
+		}
+		if conditions.MaxDocs > 0 {
+			service = service.AddMaxIndexDocsCondition(conditions.MaxDocs)
+		}
+		if conditions.MaxSize != "" {
+			service = service.AddCondition("max_size", conditions.MaxSize)
+		}
+		res, err := service.Do(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to roll over alias %s", set.writeAlias)
+		}
+		if !res.RolledOver {
+			r.logger.Info("Conditions not met, index not rolled over",
+				zap.String("alias", set.writeAlias),
+				zap.String("index", res.OldIndex))
+			continue
+		}
+		if _, err := r.client.Alias().Add(res.NewIndex, set.readAlias).Do(ctx); err != nil {
+			return errors.Wrapf(err, "failed to add index %s to alias %s", res.NewIndex, set.readAlias)
+		}
+		r.logger.Info("Rolled over index",
+			zap.String("alias", set.writeAlias),
+			zap.String("old_index", res.OldIndex),
+			zap.String("new_index", res.NewIndex))
+	}
+	return nil
+}
+
+// putTemplate makes sure that the indices created by rollover get the same settings and mappings as the initial one.
+func (r *Rollover) putTemplate(ctx context.Context, set indexSet) error {
+	body, err := unmarshalMapping(set.mapping)
+	if err != nil {
+		return err
+	}
+	body["template"] = set.prefix + "*"
+	name := set.prefix + "template"
+	if _, err := r.client.IndexPutTemplate(name).BodyJson(body).Do(ctx); err != nil {
+		return errors.Wrapf(err, "failed to put index template %s", name)
+	}
+	return nil
+}
+
+func unmarshalMapping(mapping string) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(mapping), &body); err != nil {
+		return nil, errors.Wrap(err, "failed to parse index mapping")
+	}
+	return body, nil
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gopkg.in/olivere/elastic.v5"
+)
+
+type esRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+// fakeES records the requests it receives and answers them like ElasticSearch would.
+type fakeES struct {
+	sync.Mutex
+	existingAliases map[string]bool
+	rolledOver      bool
+	requests        []esRequest
+}
+
+func (f *fakeES) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	req := esRequest{method: r.Method, path: r.URL.Path}
+	if data, _ := ioutil.ReadAll(r.Body); len(data) > 0 {
+		json.Unmarshal(data, &req.body)
+	}
+	f.requests = append(f.requests, req)
+
+	switch {
+	case r.Method == http.MethodHead:
+		if f.existingAliases[strings.TrimPrefix(r.URL.Path, "/")] {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case strings.HasSuffix(r.URL.Path, "/_rollover"):
+		alias := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/_rollover")
+		prefix := strings.TrimSuffix(alias, "write")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"old_index":   prefix + "000001",
+			"new_index":   prefix + "000002",
+			"rolled_over": f.rolledOver,
+		})
+	default:
+		w.Write([]byte(`{"acknowledged": true}`))
+	}
+}
+
+func (f *fakeES) find(method, path string) *esRequest {
+	f.Lock()
+	defer f.Unlock()
+	for i := range f.requests {
+		if f.requests[i].method == method && f.requests[i].path == path {
+			return &f.requests[i]
+		}
+	}
+	return nil
+}
+
+func withRollover(t *testing.T, es *fakeES, fn func(r *Rollover)) {
+	server := httptest.NewServer(es)
+	defer server.Close()
+	client, err := elastic.NewClient(
+		elastic.SetURL(server.URL),
+		elastic.SetSniff(false),
+		elastic.SetHealthcheck(false))
+	require.NoError(t, err)
+	fn(NewRollover(client, "", 3, 2, zap.NewNop()))
+}
+
+func TestInit(t *testing.T) {
+	es := &fakeES{}
+	withRollover(t, es, func(r *Rollover) {
+		require.NoError(t, r.Init(context.Background()))
+	})
+
+	for _, prefix := range []string{"jaeger-span-", "jaeger-service-"} {
+		template := es.find(http.MethodPut, "/_template/"+prefix+"template")
+		require.NotNil(t, template, prefix)
+		assert.Equal(t, prefix+"*", template.body["template"])
+		assert.Contains(t, template.body, "mappings")
+
+		index := es.find(http.MethodPut, "/"+prefix+"000001")
+		require.NotNil(t, index, prefix)
+		assert.Equal(t, map[string]interface{}{
+			prefix + "read":  map[string]interface{}{},
+			prefix + "write": map[string]interface{}{},
+		}, index.body["aliases"])
+		settings := index.body["settings"].(map[string]interface{})
+		assert.EqualValues(t, 3, settings["index.number_of_shards"])
+		assert.EqualValues(t, 2, settings["index.number_of_replicas"])
+	}
+}
+
+func TestInitSkipsExistingAliases(t *testing.T) {
+	es := &fakeES{existingAliases: map[string]bool{"jaeger-span-write": true}}
+	withRollover(t, es, func(r *Rollover) {
+		require.NoError(t, r.Init(context.Background()))
+	})
+	assert.Nil(t, es.find(http.MethodPut, "/jaeger-span-000001"))
+	assert.NotNil(t, es.find(http.MethodPut, "/jaeger-service-000001"))
+}
+
+func TestRollover(t *testing.T) {
+	es := &fakeES{rolledOver: true}
+	withRollover(t, es, func(r *Rollover) {
+		require.NoError(t, r.Rollover(context.Background(), Options{MaxAge: "2d", MaxDocs: 100, MaxSize: "5gb"}))
+	})
+
+	rollover := es.find(http.MethodPost, "/jaeger-span-write/_rollover")
+	require.NotNil(t, rollover)
+	assert.Equal(t, map[string]interface{}{
+		"max_age":  "2d",
+		"max_docs": float64(100),
+		"max_size": "5gb",
+	}, rollover.body["conditions"])
+	require.NotNil(t, es.find(http.MethodPost, "/jaeger-service-write/_rollover"))
+
+	aliases := es.find(http.MethodPost, "/_aliases")
+	require.NotNil(t, aliases)
+	assert.Contains(t, aliases.body["actions"], map[string]interface{}{
+		"add": map[string]interface{}{"index": "jaeger-span-000002", "alias": "jaeger-span-read"},
+	})
+}
+
+func TestRolloverConditionsNotMet(t *testing.T) {
+	es := &fakeES{rolledOver: false}
+	withRollover(t, es, func(r *Rollover) {
+		require.NoError(t, r.Rollover(context.Background(), Options{MaxDocs: 100}))
+	})
+	assert.NotNil(t, es.find(http.MethodPost, "/jaeger-span-write/_rollover"))
+	assert.Nil(t, es.find(http.MethodPost, "/_aliases"))
+}
+
+func TestRolloverIndexPrefix(t *testing.T) {
+	r := NewRollover(nil, "staging", 1, 1, zap.NewNop())
+	require.Len(t, r.indexSet, 2)
+	assert.Equal(t, "staging-jaeger-span-000001", r.indexSet[0].initialIndex())
+	assert.Equal(t, "staging-jaeger-span-read", r.indexSet[0].readAlias)
+	assert.Equal(t, "staging-jaeger-service-write", r.indexSet[1].writeAlias)
+}
+
+func TestRolloverError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client, err := elastic.NewClient(elastic.SetURL(server.URL), elastic.SetSniff(false), elastic.SetHealthcheck(false))
+	require.NoError(t, err)
+	r := NewRollover(client, "", 1, 1, zap.NewNop())
+
+	err = r.Rollover(context.Background(), Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to roll over alias jaeger-span-write")
+
+	err = r.Init(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to put index template jaeger-span-template")
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gopkg.in/olivere/elastic.v5"
+
+	"github.com/jaegertracing/jaeger/cmd/es-rollover/app"
+	"github.com/jaegertracing/jaeger/cmd/flags"
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/pkg/version"
+	"github.com/jaegertracing/jaeger/plugin/storage/es"
+)
+
+const (
+	actionInit     = "init"
+	actionRollover = "rollover"
+)
+
+func main() {
+	v := viper.New()
+	esOptions := es.NewOptions("es")
+	command := &cobra.Command{
+		Use:   "jaeger-es-rollover (init|rollover)",
+		Short: "Jaeger es-rollover manages the ElasticSearch indices used with --es.use-aliases",
+		Long: `Jaeger es-rollover manages the ElasticSearch indices used when Jaeger reads and writes through aliases.
+"init" creates the initial span and service indices together with their read and write aliases.
+"rollover" creates new indices, switches the write aliases to them and adds them to the read aliases
+if any of the rollover conditions is met. It is meant to be run periodically, e.g. as a cron job.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{actionInit, actionRollover},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.TryLoadConfigFile(v); err != nil {
+				return err
+			}
+			logger, err := zap.NewProduction()
+			if err != nil {
+				return err
+			}
+			esOptions.InitFromViper(v)
+			cfg := esOptions.GetPrimary()
+			options, err := cfg.GetConfigs()
+			if err != nil {
+				return err
+			}
+			client, err := elastic.NewClient(options...)
+			if err != nil {
+				return err
+			}
+			defer client.Stop()
+
+			rollover := app.NewRollover(client, cfg.GetIndexPrefix(), cfg.GetNumShards(), cfg.GetNumReplicas(), logger)
+			switch args[0] {
+			case actionInit:
+				return rollover.Init(context.Background())
+			case actionRollover:
+				conditions := new(app.Options).InitFromViper(v)
+				return rollover.Rollover(context.Background(), *conditions)
+			default:
+				return fmt.Errorf("unknown action %q, must be one of %q or %q", args[0], actionInit, actionRollover)
+			}
+		},
+	}
+
+	command.AddCommand(version.Command())
+
+	config.AddFlags(
+		v,
+		command,
+		flags.AddConfigFileFlag,
+		esOptions.AddFlags,
+		app.AddFlags,
+	)
+
+	if err := command.Execute(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
@@ -15,29 +15,25 @@
 package builder
 
 import (
-	"fmt"
-
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/cmd/ingester/app"
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/consumer"
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/decorator"
 	kafkaConsumer "github.com/jaegertracing/jaeger/pkg/kafka/consumer"
+	kafkaProducer "github.com/jaegertracing/jaeger/pkg/kafka/producer"
 	"github.com/jaegertracing/jaeger/plugin/storage/kafka"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
 // CreateConsumer creates a new span consumer for the ingester
 func CreateConsumer(logger *zap.Logger, metricsFactory metrics.Factory, spanWriter spanstore.Writer, options app.Options) (*consumer.Consumer, error) {
-	var unmarshaller kafka.Unmarshaller
-	if options.Encoding == app.EncodingJSON {
-		unmarshaller = kafka.NewJSONUnmarshaller()
-	} else if options.Encoding == app.EncodingProto {
-		unmarshaller = kafka.NewProtobufUnmarshaller()
-	} else {
-		return nil, fmt.Errorf(`encoding '%s' not recognised, use one of ("%s" or "%s")`,
-			options.Encoding, app.EncodingProto, app.EncodingJSON)
+	// messages with an encoding header are decoded according to it
+	unmarshaller, err := kafka.NewUnmarshaller(options.Encoding)
+	if err != nil {
+		return nil, err
 	}
 
 	spParams := processor.SpanProcessorParams{
@@ -47,22 +43,45 @@ func CreateConsumer(logger *zap.Logger, metricsFactory metrics.Factory, spanWrit
 	spanProcessor := processor.NewSpanProcessor(spParams)
 
 	consumerConfig := kafkaConsumer.Configuration{
-		Brokers: options.Brokers,
-		Topic:   options.Topic,
-		GroupID: options.GroupID,
+		Brokers:        options.Brokers,
+		Topic:          options.Topic,
+		GroupID:        options.GroupID,
+		Authentication: options.Authentication,
+	}
+	if consumerConfig.ProtocolVersion, err = options.ProtocolVersion(); err != nil {
+		return nil, err
 	}
 	saramaConsumer, err := consumerConfig.NewConsumer()
 	if err != nil {
 		return nil, err
 	}
 
+	var deadLetterQueue *decorator.DeadLetterQueue
+	if options.DeadLetterTopic != "" {
+		version, err := options.DeadLetterProtocolVersion()
+		if err != nil {
+			return nil, err
+		}
+		producerConfig := kafkaProducer.Configuration{
+			Brokers:         options.Brokers,
+			ProtocolVersion: version,
+			Authentication:  options.Authentication,
+		}
+		producer, err := producerConfig.NewSyncProducer()
+		if err != nil {
+			return nil, err
+		}
+		deadLetterQueue = decorator.NewDeadLetterQueue(producer, options.DeadLetterTopic, metricsFactory)
+	}
+
 	factoryParams := consumer.ProcessorFactoryParams{
-		Topic:          options.Topic,
-		Parallelism:    options.Parallelism,
-		SaramaConsumer: saramaConsumer,
-		BaseProcessor:  spanProcessor,
-		Logger:         logger,
-		Factory:        metricsFactory,
+		Topic:           options.Topic,
+		Parallelism:     options.Parallelism,
+		SaramaConsumer:  saramaConsumer,
+		BaseProcessor:   spanProcessor,
+		Logger:          logger,
+		Factory:         metricsFactory,
+		DeadLetterQueue: deadLetterQueue,
 	}
 	processorFactory, err := consumer.NewProcessorFactory(factoryParams)
 	if err != nil {
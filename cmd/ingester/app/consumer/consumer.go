@@ -69,7 +69,11 @@ func (c *Consumer) Start() {
 func (c *Consumer) Close() error {
 	close(c.close)
 	c.isClosed.Wait()
-	return c.internalConsumer.Close()
+	err := c.internalConsumer.Close()
+	if dlqErr := c.processorFactory.close(); err == nil {
+		err = dlqErr
+	}
+	return err
 }
 
 func (c *Consumer) mainLoop() {
@@ -48,3 +48,7 @@ func (m *saramaMessageWrapper) Partition() int32 {
 func (m *saramaMessageWrapper) Offset() int64 {
 	return m.ConsumerMessage.Offset
 }
+
+func (m *saramaMessageWrapper) Headers() []*sarama.RecordHeader {
+	return m.ConsumerMessage.Headers
+}
@@ -34,6 +34,8 @@ type ProcessorFactoryParams struct {
 	SaramaConsumer consumer.Consumer
 	Factory        metrics.Factory
 	Logger         *zap.Logger
+	// DeadLetterQueue receives the messages that cannot be processed, if not nil
+	DeadLetterQueue *decorator.DeadLetterQueue
 }
 
 // ProcessorFactory is a factory for creating startedProcessors
@@ -44,6 +46,7 @@ type ProcessorFactory struct {
 	logger         *zap.Logger
 	baseProcessor  processor.SpanProcessor
 	parallelism    int
+	deadLetters    *decorator.DeadLetterQueue
 }
 
 // NewProcessorFactory constructs a new ProcessorFactory
@@ -55,6 +58,7 @@ func NewProcessorFactory(params ProcessorFactoryParams) (*ProcessorFactory, erro
 		logger:         params.Logger,
 		baseProcessor:  params.BaseProcessor,
 		parallelism:    params.Parallelism,
+		deadLetters:    params.DeadLetterQueue,
 	}, nil
 }
 
@@ -67,7 +71,13 @@ func (c *ProcessorFactory) new(partition int32, minOffset int64) processor.SpanP
 
 	om := offset.NewManager(minOffset, markOffset, partition, c.metricsFactory)
 
-	retryProcessor := decorator.NewRetryingProcessor(c.metricsFactory, c.baseProcessor)
+	var retryProcessor processor.SpanProcessor
+	if c.deadLetters != nil {
+		retryProcessor = decorator.NewDeadLetterProcessor(c.deadLetters,
+			decorator.NewRetryingProcessor(c.metricsFactory, c.baseProcessor, decorator.PropagateError(true)))
+	} else {
+		retryProcessor = decorator.NewRetryingProcessor(c.metricsFactory, c.baseProcessor)
+	}
 	cp := NewCommittingProcessor(retryProcessor, om)
 	spanProcessor := processor.NewDecoratedProcessor(c.metricsFactory, cp)
 	pp := processor.NewParallelProcessor(spanProcessor, c.parallelism, c.logger)
@@ -75,6 +85,14 @@ func (c *ProcessorFactory) new(partition int32, minOffset int64) processor.SpanP
 	return newStartedProcessor(pp, om)
 }
 
+// close closes the dead letter queue, if any, once no more messages are processed
+func (c *ProcessorFactory) close() error {
+	if c.deadLetters == nil {
+		return nil
+	}
+	return c.deadLetters.Close()
+}
+
 type service interface {
 	Start()
 	io.Closer
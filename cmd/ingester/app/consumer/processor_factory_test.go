@@ -18,12 +18,14 @@ import (
 	"testing"
 	"time"
 
+	saramaMocks "github.com/Shopify/sarama/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
 	kmocks "github.com/jaegertracing/jaeger/cmd/ingester/app/consumer/mocks"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/decorator"
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/mocks"
 )
 
@@ -59,6 +61,27 @@ func Test_new(t *testing.T) {
 	mockConsumer.AssertCalled(t, "MarkPartitionOffset", topic, partition, offset, "")
 }
 
+func Test_newWithDeadLetterQueue(t *testing.T) {
+	mockConsumer := &kmocks.Consumer{}
+	mockConsumer.On("MarkPartitionOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	producer := saramaMocks.NewSyncProducer(t, nil)
+
+	pf, err := NewProcessorFactory(ProcessorFactoryParams{
+		Topic:           "coelacanth",
+		SaramaConsumer:  mockConsumer,
+		Factory:         metrics.NullFactory,
+		Logger:          zap.NewNop(),
+		BaseProcessor:   &mocks.SpanProcessor{},
+		Parallelism:     1,
+		DeadLetterQueue: decorator.NewDeadLetterQueue(producer, "coelacanth-dead-letter", metrics.NullFactory),
+	})
+	assert.NoError(t, err)
+	p := pf.new(21, 555)
+	assert.NotNil(t, p)
+	assert.NoError(t, p.Close())
+	assert.NoError(t, pf.close())
+}
+
 type fakeService struct {
 	startCalled bool
 	closeCalled bool
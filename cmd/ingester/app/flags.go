@@ -19,9 +19,12 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/spf13/viper"
 
+	"github.com/jaegertracing/jaeger/pkg/kafka/auth"
 	kafkaConsumer "github.com/jaegertracing/jaeger/pkg/kafka/consumer"
 )
 
@@ -43,6 +46,14 @@ const (
 	SuffixParallelism = ".parallelism"
 	// SuffixEncoding is a suffix for the encoding flag
 	SuffixEncoding = ".encoding"
+	// SuffixProtocolVersion is a suffix for the kafka protocol version flag
+	SuffixProtocolVersion = ".protocol-version"
+	// SuffixDeadLetterTopic is a suffix for the dead letter topic flag
+	SuffixDeadLetterTopic = ".dead-letter-topic"
+	// SuffixDependenciesFlushInterval is a suffix for the dependencies flush interval flag
+	SuffixDependenciesFlushInterval = ".dependencies.flush-interval"
+	// SuffixDependenciesTraceTTL is a suffix for the dependencies trace TTL flag
+	SuffixDependenciesTraceTTL = ".dependencies.trace-ttl"
 
 	// DefaultBroker is the default kafka broker
 	DefaultBroker = "127.0.0.1:9092"
@@ -54,13 +65,26 @@ const (
 	DefaultParallelism = 1000
 	// DefaultEncoding is the default span encoding
 	DefaultEncoding = EncodingProto
+	// DefaultDependenciesTraceTTL is the default time the spans of a trace are remembered to count dependencies
+	DefaultDependenciesTraceTTL = 5 * time.Minute
 )
 
 // Options stores the configuration options for the Ingester
 type Options struct {
 	kafkaConsumer.Configuration
-	Parallelism int
-	Encoding    string
+	// KafkaVersion is the kafka version of the brokers, parsed into the ProtocolVersion of the consumer
+	KafkaVersion    string
+	Parallelism     int
+	Encoding        string
+	DeadLetterTopic string
+	Dependencies    DependenciesOptions
+}
+
+// DependenciesOptions stores the configuration of the streaming dependency aggregation,
+// which is disabled if FlushInterval is zero
+type DependenciesOptions struct {
+	FlushInterval time.Duration
+	TraceTTL      time.Duration
 }
 
 // AddFlags adds flags for Builder
@@ -85,6 +109,25 @@ func AddFlags(flagSet *flag.FlagSet) {
 		ConfigPrefix+SuffixEncoding,
 		DefaultEncoding,
 		fmt.Sprintf(`The encoding of spans ("%s" or "%s") consumed from kafka`, EncodingProto, EncodingJSON))
+	flagSet.String(
+		ConfigPrefix+SuffixProtocolVersion,
+		"",
+		"The kafka version of the brokers, e.g. '1.0.0' (the kafka client default is used if empty). "+
+			"The headers describing the encoding of the spans are only read if it is 0.11.0.0 or later")
+	flagSet.String(
+		ConfigPrefix+SuffixDeadLetterTopic,
+		"",
+		"The kafka topic where messages that cannot be decoded or written to storage are published (disabled if empty)")
+	flagSet.Duration(
+		ConfigPrefix+SuffixDependenciesFlushInterval,
+		0,
+		"How often the calls between services counted from the consumed spans are written to the dependencies storage "+
+			"as a new record (0 disables counting). The calls counted since the last flush are served on /dependencies of the health check port")
+	flagSet.Duration(
+		ConfigPrefix+SuffixDependenciesTraceTTL,
+		DefaultDependenciesTraceTTL,
+		"How long the spans of a trace are remembered after its latest span to count the calls between services")
+	auth.AddFlags(ConfigPrefix, flagSet)
 }
 
 // InitFromViper initializes Builder with properties from viper
@@ -94,4 +137,35 @@ func (o *Options) InitFromViper(v *viper.Viper) {
 	o.GroupID = v.GetString(ConfigPrefix + SuffixGroupID)
 	o.Parallelism = v.GetInt(ConfigPrefix + SuffixParallelism)
 	o.Encoding = v.GetString(ConfigPrefix + SuffixEncoding)
+	o.KafkaVersion = v.GetString(ConfigPrefix + SuffixProtocolVersion)
+	o.DeadLetterTopic = v.GetString(ConfigPrefix + SuffixDeadLetterTopic)
+	o.Dependencies.FlushInterval = v.GetDuration(ConfigPrefix + SuffixDependenciesFlushInterval)
+	o.Dependencies.TraceTTL = v.GetDuration(ConfigPrefix + SuffixDependenciesTraceTTL)
+	o.Authentication.InitFromViper(ConfigPrefix, v)
+}
+
+// ProtocolVersion returns the configured kafka version of the brokers, or the zero version,
+// which stands for the kafka client default, if none is configured
+func (o *Options) ProtocolVersion() (sarama.KafkaVersion, error) {
+	if o.KafkaVersion == "" {
+		return sarama.KafkaVersion{}, nil
+	}
+	return sarama.ParseKafkaVersion(o.KafkaVersion)
+}
+
+// DeadLetterProtocolVersion returns the kafka version used to publish and replay the dead letters, which defaults
+// to 0.11.0.0, and fails if the configured version does not support the message headers describing the failures
+func (o *Options) DeadLetterProtocolVersion() (sarama.KafkaVersion, error) {
+	if o.KafkaVersion == "" {
+		return sarama.V0_11_0_0, nil
+	}
+	version, err := o.ProtocolVersion()
+	if err != nil {
+		return version, err
+	}
+	if !version.IsAtLeast(sarama.V0_11_0_0) {
+		return version, fmt.Errorf("%s must be %s or later to use a dead letter topic, got %s",
+			ConfigPrefix+SuffixProtocolVersion, sarama.V0_11_0_0, version)
+	}
+	return version, nil
 }
@@ -16,10 +16,14 @@ package app
 
 import (
 	"testing"
+	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
+	"github.com/jaegertracing/jaeger/pkg/kafka/auth"
 )
 
 func TestOptionsWithFlags(t *testing.T) {
@@ -30,7 +34,16 @@ func TestOptionsWithFlags(t *testing.T) {
 		"--ingester.brokers=127.0.0.1:9092,0.0.0:1234",
 		"--ingester.group-id=group1",
 		"--ingester.parallelism=5",
-		"--ingester.encoding=json"})
+		"--ingester.encoding=json",
+		"--ingester.protocol-version=1.0.0",
+		"--ingester.dead-letter-topic=topic1-dead-letter",
+		"--ingester.dependencies.flush-interval=1m",
+		"--ingester.dependencies.trace-ttl=10m",
+		"--ingester.sasl.mechanism=SCRAM-SHA-256",
+		"--ingester.sasl.username=user",
+		"--ingester.sasl.password=secret",
+		"--ingester.tls=true",
+		"--ingester.tls.ca=/tmp/ca"})
 	o.InitFromViper(v)
 
 	assert.Equal(t, "topic1", o.Topic)
@@ -38,6 +51,15 @@ func TestOptionsWithFlags(t *testing.T) {
 	assert.Equal(t, "group1", o.GroupID)
 	assert.Equal(t, 5, o.Parallelism)
 	assert.Equal(t, EncodingJSON, o.Encoding)
+	assert.Equal(t, "1.0.0", o.KafkaVersion)
+	assert.Equal(t, "topic1-dead-letter", o.DeadLetterTopic)
+	assert.Equal(t, DependenciesOptions{FlushInterval: time.Minute, TraceTTL: 10 * time.Minute}, o.Dependencies)
+	assert.Equal(t, auth.AuthenticationConfig{
+		SASLMechanism: auth.MechanismSCRAMSHA256,
+		Username:      "user",
+		Password:      "secret",
+		TLS:           tlscfg.Options{Enabled: true, CaPath: "/tmp/ca"},
+	}, o.Authentication)
 }
 
 func TestFlagDefaults(t *testing.T) {
@@ -51,4 +73,52 @@ func TestFlagDefaults(t *testing.T) {
 	assert.Equal(t, DefaultGroupID, o.GroupID)
 	assert.Equal(t, DefaultParallelism, o.Parallelism)
 	assert.Equal(t, DefaultEncoding, o.Encoding)
+	assert.Empty(t, o.KafkaVersion)
+	assert.Empty(t, o.DeadLetterTopic)
+	assert.Equal(t, DependenciesOptions{TraceTTL: DefaultDependenciesTraceTTL}, o.Dependencies)
+	assert.Equal(t, auth.AuthenticationConfig{}, o.Authentication)
+}
+
+func TestProtocolVersion(t *testing.T) {
+	testCases := []struct {
+		kafkaVersion            string
+		expectedVersion         sarama.KafkaVersion
+		expectedError           string
+		expectedDeadLetterError string
+		// expectedDeadLetterVersion is expectedVersion if not set
+		expectedDeadLetterVersion sarama.KafkaVersion
+	}{
+		// the dead letters default to the first version with message headers
+		{kafkaVersion: "", expectedVersion: sarama.KafkaVersion{}, expectedDeadLetterVersion: sarama.V0_11_0_0},
+		{kafkaVersion: "1.0.0", expectedVersion: sarama.V1_0_0_0},
+		{
+			kafkaVersion:            "0.10.2.0",
+			expectedVersion:         sarama.V0_10_2_0,
+			expectedDeadLetterError: "ingester.protocol-version must be 0.11.0.0 or later to use a dead letter topic, got 0.10.2.0",
+		},
+		{kafkaVersion: "kafka", expectedError: "invalid version `kafka`"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.kafkaVersion, func(t *testing.T) {
+			o := &Options{KafkaVersion: testCase.kafkaVersion}
+			version, err := o.ProtocolVersion()
+			deadLetterVersion, deadLetterErr := o.DeadLetterProtocolVersion()
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.EqualError(t, deadLetterErr, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersion, version)
+			if testCase.expectedDeadLetterError != "" {
+				assert.EqualError(t, deadLetterErr, testCase.expectedDeadLetterError)
+				return
+			}
+			assert.NoError(t, deadLetterErr)
+			if testCase.expectedDeadLetterVersion == (sarama.KafkaVersion{}) {
+				testCase.expectedDeadLetterVersion = testCase.expectedVersion
+			}
+			assert.Equal(t, testCase.expectedDeadLetterVersion, deadLetterVersion)
+		})
+	}
 }
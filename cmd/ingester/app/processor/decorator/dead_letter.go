@@ -0,0 +1,139 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decorator
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
+	"github.com/uber/jaeger-lib/metrics"
+
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+)
+
+// Headers describing why and from where a message was published to the dead letter topic
+const (
+	HeaderPrefix          = "jaeger-dead-letter-"
+	HeaderError           = HeaderPrefix + "error"
+	HeaderErrorType       = HeaderPrefix + "error-type"
+	HeaderSourceTopic     = HeaderPrefix + "source-topic"
+	HeaderSourcePartition = HeaderPrefix + "source-partition"
+	HeaderSourceOffset    = HeaderPrefix + "source-offset"
+
+	// ErrorTypeUnmarshal is the error type of the messages that could not be decoded
+	ErrorTypeUnmarshal = "unmarshal"
+	// ErrorTypeWrite is the error type of the spans that could not be written to storage
+	ErrorTypeWrite = "write"
+)
+
+// sourceMessage is implemented by the kafka messages of the consumer
+type sourceMessage interface {
+	Key() []byte
+	Topic() string
+	Partition() int32
+	Offset() int64
+}
+
+// DeadLetterQueue publishes the messages that could not be processed to a kafka topic,
+// so that they are not lost and can be replayed once the cause of the failure is fixed
+type DeadLetterQueue struct {
+	producer  sarama.SyncProducer
+	topic     string
+	published metrics.Counter
+	failed    metrics.Counter
+}
+
+// NewDeadLetterQueue creates a DeadLetterQueue publishing to topic with producer
+func NewDeadLetterQueue(producer sarama.SyncProducer, topic string, f metrics.Factory) *DeadLetterQueue {
+	m := f.Namespace("dead-letter", nil)
+	return &DeadLetterQueue{
+		producer:  producer,
+		topic:     topic,
+		published: m.Counter("published", nil),
+		failed:    m.Counter("failed", nil),
+	}
+}
+
+// Publish sends the message that failed with err to the dead letter topic, and returns once it is acknowledged
+func (q *DeadLetterQueue) Publish(message processor.Message, err error) error {
+	errorType := ErrorTypeWrite
+	if processor.IsUnmarshalError(err) {
+		errorType = ErrorTypeUnmarshal
+	}
+	msg := &sarama.ProducerMessage{
+		Topic: q.topic,
+		Value: sarama.ByteEncoder(message.Value()),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(HeaderError), Value: []byte(err.Error())},
+			{Key: []byte(HeaderErrorType), Value: []byte(errorType)},
+		},
+	}
+	if m, ok := message.(processor.HeadersMessage); ok {
+		// keep the headers describing the span, e.g. its encoding, for the replay
+		for _, header := range m.Headers() {
+			if header != nil && !strings.HasPrefix(string(header.Key), HeaderPrefix) {
+				msg.Headers = append(msg.Headers, *header)
+			}
+		}
+	}
+	if source, ok := message.(sourceMessage); ok {
+		if key := source.Key(); key != nil {
+			msg.Key = sarama.ByteEncoder(key)
+		}
+		msg.Headers = append(msg.Headers,
+			sarama.RecordHeader{Key: []byte(HeaderSourceTopic), Value: []byte(source.Topic())},
+			sarama.RecordHeader{Key: []byte(HeaderSourcePartition), Value: []byte(strconv.Itoa(int(source.Partition())))},
+			sarama.RecordHeader{Key: []byte(HeaderSourceOffset), Value: []byte(strconv.FormatInt(source.Offset(), 10))},
+		)
+	}
+	if _, _, err := q.producer.SendMessage(msg); err != nil {
+		q.failed.Inc(1)
+		return errors.Wrap(err, "Failed to publish message to the dead letter topic")
+	}
+	q.published.Inc(1)
+	return nil
+}
+
+// Close closes the producer
+func (q *DeadLetterQueue) Close() error {
+	return q.producer.Close()
+}
+
+type deadLetterDecorator struct {
+	processor processor.SpanProcessor
+	queue     *DeadLetterQueue
+	io.Closer
+}
+
+// NewDeadLetterProcessor returns a processor that publishes the messages that processor fails to process
+// to the dead letter queue. The wrapped processor must propagate its errors, e.g. once retries are exhausted.
+// The error of the publication, if any, is returned so that the offset of the message is not committed.
+func NewDeadLetterProcessor(queue *DeadLetterQueue, processor processor.SpanProcessor) processor.SpanProcessor {
+	return &deadLetterDecorator{
+		processor: processor,
+		queue:     queue,
+	}
+}
+
+func (d *deadLetterDecorator) Process(message processor.Message) error {
+	if err := d.processor.Process(message); err != nil {
+		// the offset of the message is not committed if it could not be published
+		return d.queue.Publish(message, err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decorator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/mocks"
+	kmocks "github.com/jaegertracing/jaeger/pkg/kafka/mocks"
+)
+
+// fakeProducer keeps the published messages, or fails to publish them with err
+type fakeProducer struct {
+	sent   []*sarama.ProducerMessage
+	err    error
+	closed bool
+}
+
+func (p *fakeProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	if p.err != nil {
+		return 0, 0, p.err
+	}
+	p.sent = append(p.sent, msg)
+	return 0, int64(len(p.sent)), nil
+}
+
+func (p *fakeProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	for _, msg := range msgs {
+		if _, _, err := p.SendMessage(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *fakeProducer) Close() error {
+	p.closed = true
+	return nil
+}
+
+type fakeSourceMsg struct {
+	fakeMsg
+}
+
+func (fakeSourceMsg) Key() []byte      { return []byte("trace-id") }
+func (fakeSourceMsg) Topic() string    { return "jaeger-spans" }
+func (fakeSourceMsg) Partition() int32 { return 3 }
+func (fakeSourceMsg) Offset() int64    { return 42 }
+
+func headers(msg *sarama.ProducerMessage) map[string]string {
+	h := map[string]string{}
+	for _, header := range msg.Headers {
+		h[string(header.Key)] = string(header.Value)
+	}
+	return h
+}
+
+func TestDeadLetterProcessorWriteError(t *testing.T) {
+	producer := &fakeProducer{}
+	lf := metrics.NewLocalFactory(0)
+	queue := NewDeadLetterQueue(producer, "jaeger-spans-dead-letter", lf)
+	mockProcessor := &mocks.SpanProcessor{}
+	msg := fakeSourceMsg{}
+	mockProcessor.On("Process", msg).Return(errors.New("storage down")).Once()
+	mockProcessor.On("Process", msg).Return(nil)
+	dp := NewDeadLetterProcessor(queue, mockProcessor)
+
+	assert.NoError(t, dp.Process(msg))
+	assert.NoError(t, dp.Process(msg))
+	assert.NoError(t, queue.Close())
+	assert.True(t, producer.closed)
+
+	require.Len(t, producer.sent, 1)
+	published := producer.sent[0]
+	assert.Equal(t, "jaeger-spans-dead-letter", published.Topic)
+	assert.Equal(t, sarama.ByteEncoder("trace-id"), published.Key)
+	assert.Equal(t, map[string]string{
+		HeaderError:           "storage down",
+		HeaderErrorType:       ErrorTypeWrite,
+		HeaderSourceTopic:     "jaeger-spans",
+		HeaderSourcePartition: "3",
+		HeaderSourceOffset:    "42",
+	}, headers(published))
+	c, _ := lf.Snapshot()
+	assert.Equal(t, int64(1), c["dead-letter.published"])
+}
+
+func TestDeadLetterProcessorUnmarshalError(t *testing.T) {
+	producer := &fakeProducer{}
+	queue := NewDeadLetterQueue(producer, "jaeger-spans-dead-letter", metrics.NullFactory)
+	unmarshaller := &kmocks.Unmarshaller{}
+	unmarshaller.On("Unmarshal", []byte(nil)).Return(nil, errors.New("corrupted"))
+	spanProcessor := processor.NewSpanProcessor(processor.SpanProcessorParams{Unmarshaller: unmarshaller})
+	dp := NewDeadLetterProcessor(queue, spanProcessor)
+
+	assert.NoError(t, dp.Process(&fakeMsg{}))
+
+	require.Len(t, producer.sent, 1)
+	published := producer.sent[0]
+	assert.Nil(t, published.Key)
+	assert.Equal(t, map[string]string{
+		HeaderError:     "cannot unmarshall byte array into span: corrupted",
+		HeaderErrorType: ErrorTypeUnmarshal,
+	}, headers(published))
+}
+
+func TestDeadLetterProcessorPublishError(t *testing.T) {
+	producer := &fakeProducer{err: errors.New("kafka down")}
+	lf := metrics.NewLocalFactory(0)
+	queue := NewDeadLetterQueue(producer, "jaeger-spans-dead-letter", lf)
+	mockProcessor := &mocks.SpanProcessor{}
+	mockProcessor.On("Process", fakeMsg{}).Return(errors.New("storage down"))
+	dp := NewDeadLetterProcessor(queue, mockProcessor)
+
+	assert.EqualError(t, dp.Process(fakeMsg{}), "Failed to publish message to the dead letter topic: kafka down")
+	c, _ := lf.Snapshot()
+	assert.Equal(t, int64(1), c["dead-letter.failed"])
+}
+
+type fakeHeadersMsg struct {
+	fakeMsg
+}
+
+func (fakeHeadersMsg) Headers() []*sarama.RecordHeader {
+	return []*sarama.RecordHeader{
+		{Key: []byte("jaeger-encoding"), Value: []byte("json")},
+		{Key: []byte(HeaderError), Value: []byte("previous failure")},
+	}
+}
+
+func TestDeadLetterQueueKeepsHeaders(t *testing.T) {
+	producer := &fakeProducer{}
+	queue := NewDeadLetterQueue(producer, "jaeger-spans-dead-letter", metrics.NullFactory)
+
+	assert.NoError(t, queue.Publish(fakeHeadersMsg{}, errors.New("storage down")))
+
+	require.Len(t, producer.sent, 1)
+	assert.Equal(t, map[string]string{
+		HeaderError:       "storage down",
+		HeaderErrorType:   ErrorTypeWrite,
+		"jaeger-encoding": "json",
+	}, headers(producer.sent[0]))
+}
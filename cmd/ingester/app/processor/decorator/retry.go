@@ -112,6 +112,14 @@ func (d *retryDecorator) Process(message processor.Message) error {
 	if err == nil {
 		return nil
 	}
+	if processor.IsUnmarshalError(err) {
+		// retrying cannot fix a message that cannot be decoded
+		d.exhausted.Inc(1)
+		if d.options.propagateError {
+			return err
+		}
+		return nil
+	}
 
 	for attempts := uint(0); err != nil && d.options.maxAttempts > attempts; attempts++ {
 		time.Sleep(d.computeInterval(attempts))
@@ -22,7 +22,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/uber/jaeger-lib/metrics"
 
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/mocks"
+	kmocks "github.com/jaegertracing/jaeger/pkg/kafka/mocks"
 )
 
 type fakeMsg struct{}
@@ -87,6 +89,21 @@ func TestNewRetryingProcessorNoErrorPropagation(t *testing.T) {
 	assert.Equal(t, int64(1), c["span-processor.retry-attempts"])
 }
 
+func TestNewRetryingProcessorUnmarshalError(t *testing.T) {
+	unmarshaller := &kmocks.Unmarshaller{}
+	unmarshaller.On("Unmarshal", []byte(nil)).Return(nil, errors.New("corrupted"))
+	spanProcessor := processor.NewSpanProcessor(processor.SpanProcessorParams{Unmarshaller: unmarshaller})
+	lf := metrics.NewLocalFactory(0)
+	rp := NewRetryingProcessor(lf, spanProcessor, PropagateError(true))
+
+	assert.EqualError(t, rp.Process(&fakeMsg{}), "cannot unmarshall byte array into span: corrupted")
+
+	unmarshaller.AssertNumberOfCalls(t, "Unmarshal", 1)
+	c, _ := lf.Snapshot()
+	assert.Equal(t, int64(1), c["span-processor.retry-exhausted"])
+	assert.Equal(t, int64(0), c["span-processor.retry-attempts"])
+}
+
 type fakeRand struct{}
 
 func (f *fakeRand) Int63n(v int64) int64 {
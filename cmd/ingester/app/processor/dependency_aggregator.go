@@ -0,0 +1,212 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+)
+
+// DependencyAggregatorParams stores the necessary parameters for a DependencyAggregator
+type DependencyAggregatorParams struct {
+	Writer dependencystore.Writer
+	// FlushInterval is how often the counted calls are written and reset
+	FlushInterval time.Duration
+	// TraceTTL is how long the spans of a trace are remembered after its latest span was received
+	TraceTTL time.Duration
+	Factory  metrics.Factory
+	Logger   *zap.Logger
+}
+
+type aggregatorMetrics struct {
+	Flushes     metrics.Counter `metric:"flushes"`
+	FlushErrors metrics.Counter `metric:"flush-errors"`
+	Traces      metrics.Gauge   `metric:"traces"`
+}
+
+// aggregatedTrace remembers the service of each span of a trace received so far,
+// so that the calls can be counted whichever of the parent or child span arrives first
+type aggregatedTrace struct {
+	services map[model.SpanID]string
+	// orphans holds the services of the spans whose parent span has not been received yet
+	orphans    map[model.SpanID][]string
+	lastUpdate time.Time
+}
+
+// DependencyAggregator implements spanstore.Writer, counting the calls between parent and
+// child services as spans are consumed, and periodically writing them to the dependencies storage.
+// Spans with an ID already seen in their trace are ignored, so that retried messages are
+// not counted twice. As a consequence, spans sharing their ID with their parent, as emitted
+// by some Zipkin clients, are not counted.
+//
+// Every flush writes a separate dependencies record with the calls counted since the previous
+// flush, so a link between two services is returned once per flush by the dependency readers.
+// The query service sums the call counts of the links between the same services.
+type DependencyAggregator struct {
+	sync.Mutex
+	writer        dependencystore.Writer
+	flushInterval time.Duration
+	traceTTL      time.Duration
+	metrics       aggregatorMetrics
+	logger        *zap.Logger
+	timeNow       func() time.Time
+
+	traces     map[model.TraceID]*aggregatedTrace
+	aggregator *dependencystore.Aggregator
+
+	closed chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewDependencyAggregator creates a new DependencyAggregator
+func NewDependencyAggregator(params DependencyAggregatorParams) *DependencyAggregator {
+	a := &DependencyAggregator{
+		writer:        params.Writer,
+		flushInterval: params.FlushInterval,
+		traceTTL:      params.TraceTTL,
+		logger:        params.Logger,
+		timeNow:       time.Now,
+		traces:        map[model.TraceID]*aggregatedTrace{},
+		aggregator:    dependencystore.NewAggregator(),
+		closed:        make(chan struct{}),
+	}
+	metrics.Init(&a.metrics, params.Factory.Namespace("dependency-aggregator", nil), nil)
+	return a
+}
+
+// Start begins flushing the counted calls periodically
+func (a *DependencyAggregator) Start() {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		ticker := time.NewTicker(a.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.flush()
+				a.evictTraces()
+			case <-a.closed:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the periodic flush and writes the calls counted since the last flush
+func (a *DependencyAggregator) Close() error {
+	close(a.closed)
+	a.wg.Wait()
+	a.flush()
+	return nil
+}
+
+// WriteSpan implements spanstore.Writer
+func (a *DependencyAggregator) WriteSpan(span *model.Span) error {
+	a.Lock()
+	defer a.Unlock()
+	trace, ok := a.traces[span.TraceID]
+	if !ok {
+		trace = &aggregatedTrace{
+			services: map[model.SpanID]string{},
+			orphans:  map[model.SpanID][]string{},
+		}
+		a.traces[span.TraceID] = trace
+	}
+	trace.lastUpdate = a.timeNow()
+	if _, ok := trace.services[span.SpanID]; ok {
+		return nil
+	}
+	service := span.Process.ServiceName
+	trace.services[span.SpanID] = service
+	if parentID := span.ParentSpanID(); parentID != 0 {
+		if parentService, ok := trace.services[parentID]; ok {
+			a.addCall(parentService, service)
+		} else {
+			trace.orphans[parentID] = append(trace.orphans[parentID], service)
+		}
+	}
+	for _, childService := range trace.orphans[span.SpanID] {
+		a.addCall(service, childService)
+	}
+	delete(trace.orphans, span.SpanID)
+	return nil
+}
+
+func (a *DependencyAggregator) addCall(parent, child string) {
+	if parent != child {
+		a.aggregator.AddCalls(parent, child, 1)
+	}
+}
+
+// Links returns the calls counted since the last flush
+func (a *DependencyAggregator) Links() []model.DependencyLink {
+	a.Lock()
+	defer a.Unlock()
+	return a.aggregator.Links()
+}
+
+// ServeHTTP returns the calls counted since the last flush as JSON
+func (a *DependencyAggregator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Data []model.DependencyLink `json:"data"`
+	}{Data: a.Links()})
+}
+
+// flush writes the counted calls and resets them. If writing fails,
+// the calls are kept and written with the next flush.
+func (a *DependencyAggregator) flush() {
+	a.Lock()
+	aggregator := a.aggregator
+	a.aggregator = dependencystore.NewAggregator()
+	a.Unlock()
+
+	links := aggregator.Links()
+	if len(links) == 0 {
+		return
+	}
+	a.metrics.Flushes.Inc(1)
+	if err := a.writer.WriteDependencies(a.timeNow(), links); err != nil {
+		a.metrics.FlushErrors.Inc(1)
+		a.logger.Error("Failed to write dependencies", zap.Error(err))
+		a.Lock()
+		for _, link := range links {
+			a.aggregator.AddCalls(link.Parent, link.Child, link.CallCount)
+		}
+		a.Unlock()
+	}
+}
+
+// evictTraces forgets the traces that have not received any span for longer than the trace TTL
+func (a *DependencyAggregator) evictTraces() {
+	a.Lock()
+	defer a.Unlock()
+	cutoff := a.timeNow().Add(-a.traceTTL)
+	for traceID, trace := range a.traces {
+		if trace.lastUpdate.Before(cutoff) {
+			delete(a.traces, traceID)
+		}
+	}
+	a.metrics.Traces.Update(int64(len(a.traces)))
+}
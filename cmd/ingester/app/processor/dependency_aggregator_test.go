@@ -0,0 +1,152 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	depStoreMocks "github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+var _ spanstore.Writer = new(DependencyAggregator)
+var _ http.Handler = new(DependencyAggregator)
+
+func newDependencySpan(traceID model.TraceID, spanID, parentID uint64, service string) *model.Span {
+	span := &model.Span{
+		TraceID: traceID,
+		SpanID:  model.SpanID(spanID),
+		Process: model.NewProcess(service, nil),
+	}
+	if parentID != 0 {
+		span.References = []model.SpanRef{model.NewChildOfRef(traceID, model.SpanID(parentID))}
+	}
+	return span
+}
+
+func newTestAggregator(writer *depStoreMocks.Writer, factory metrics.Factory) *DependencyAggregator {
+	return NewDependencyAggregator(DependencyAggregatorParams{
+		Writer:        writer,
+		FlushInterval: time.Hour,
+		TraceTTL:      time.Minute,
+		Factory:       factory,
+		Logger:        zap.NewNop(),
+	})
+}
+
+func TestDependencyAggregatorCountsCalls(t *testing.T) {
+	a := newTestAggregator(new(depStoreMocks.Writer), metrics.NullFactory)
+	traceID := model.NewTraceID(0, 1)
+	// the child spans arrive before their parent, and one span is retried
+	for _, span := range []*model.Span{
+		newDependencySpan(traceID, 3, 2, "db"),
+		newDependencySpan(traceID, 2, 1, "backend"),
+		newDependencySpan(traceID, 4, 1, "backend"),
+		newDependencySpan(traceID, 1, 0, "frontend"),
+		newDependencySpan(traceID, 4, 1, "backend"),
+		newDependencySpan(traceID, 5, 4, "backend"),
+	} {
+		assert.NoError(t, a.WriteSpan(span))
+	}
+	assert.Equal(t, []model.DependencyLink{
+		{Parent: "backend", Child: "db", CallCount: 1},
+		{Parent: "frontend", Child: "backend", CallCount: 2},
+	}, a.Links())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	resp := httptest.NewRecorder()
+	a.ServeHTTP(resp, req)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"data":[
+		{"parent":"backend","child":"db","callCount":1},
+		{"parent":"frontend","child":"backend","callCount":2}
+	]}`, resp.Body.String())
+}
+
+func TestDependencyAggregatorFlush(t *testing.T) {
+	writer := new(depStoreMocks.Writer)
+	factory := metrics.NewLocalFactory(0)
+	a := newTestAggregator(writer, factory)
+	now := time.Unix(1000, 0)
+	a.timeNow = func() time.Time { return now }
+	traceID := model.NewTraceID(0, 1)
+	require.NoError(t, a.WriteSpan(newDependencySpan(traceID, 1, 0, "frontend")))
+	require.NoError(t, a.WriteSpan(newDependencySpan(traceID, 2, 1, "backend")))
+	links := []model.DependencyLink{{Parent: "frontend", Child: "backend", CallCount: 1}}
+
+	// failed writes are retried with the next flush
+	writer.On("WriteDependencies", now, links).Return(errors.New("write-error")).Once()
+	a.flush()
+	assert.Equal(t, links, a.Links())
+
+	writer.On("WriteDependencies", now, links).Return(nil).Once()
+	a.flush()
+	assert.Empty(t, a.Links())
+	a.flush()
+	writer.AssertExpectations(t)
+
+	// the trace is remembered until its TTL expires
+	a.evictTraces()
+	require.NoError(t, a.WriteSpan(newDependencySpan(traceID, 3, 1, "backend")))
+	assert.Len(t, a.Links(), 1)
+	now = now.Add(2 * time.Minute)
+	a.evictTraces()
+	assert.Empty(t, a.traces)
+
+	counters, gauges := factory.Snapshot()
+	assert.Equal(t, int64(2), counters["dependency-aggregator.flushes"])
+	assert.Equal(t, int64(1), counters["dependency-aggregator.flush-errors"])
+	assert.Equal(t, int64(0), gauges["dependency-aggregator.traces"])
+}
+
+func TestDependencyAggregatorStartClose(t *testing.T) {
+	writer := new(depStoreMocks.Writer)
+	flushed := make(chan struct{}, 10)
+	writer.On("WriteDependencies", mock.Anything, mock.Anything).Return(nil).Run(func(mock.Arguments) {
+		flushed <- struct{}{}
+	})
+	a := NewDependencyAggregator(DependencyAggregatorParams{
+		Writer:        writer,
+		FlushInterval: time.Millisecond,
+		TraceTTL:      time.Minute,
+		Factory:       metrics.NullFactory,
+		Logger:        zap.NewNop(),
+	})
+	a.Start()
+	traceID := model.NewTraceID(0, 1)
+	require.NoError(t, a.WriteSpan(newDependencySpan(traceID, 1, 0, "frontend")))
+	require.NoError(t, a.WriteSpan(newDependencySpan(traceID, 2, 1, "backend")))
+	select {
+	case <-flushed:
+	case <-time.After(time.Second):
+		t.Fatal("dependencies were not flushed")
+	}
+
+	// the calls counted after the last periodic flush are written when closing
+	require.NoError(t, a.WriteSpan(newDependencySpan(traceID, 3, 1, "backend")))
+	assert.NoError(t, a.Close())
+	assert.Empty(t, a.Links())
+}
@@ -17,6 +17,7 @@ package processor
 import (
 	"io"
 
+	"github.com/Shopify/sarama"
 	"github.com/pkg/errors"
 
 	"github.com/jaegertracing/jaeger/plugin/storage/kafka"
@@ -36,9 +37,15 @@ type Message interface {
 	Value() []byte
 }
 
+// HeadersMessage is implemented by the messages carrying kafka headers
+type HeadersMessage interface {
+	Headers() []*sarama.RecordHeader
+}
+
 // SpanProcessorParams stores the necessary parameters for a SpanProcessor
 type SpanProcessorParams struct {
-	Writer       spanstore.Writer
+	Writer spanstore.Writer
+	// Unmarshaller decodes the messages without an encoding header
 	Unmarshaller kafka.Unmarshaller
 }
 
@@ -59,9 +66,28 @@ func NewSpanProcessor(params SpanProcessorParams) *KafkaSpanProcessor {
 
 // Process unmarshals and writes a single kafka message
 func (s KafkaSpanProcessor) Process(message Message) error {
-	mSpan, err := s.unmarshaller.Unmarshal(message.Value())
+	unmarshaller := s.unmarshaller
+	if m, ok := message.(HeadersMessage); ok {
+		detected, err := kafka.DetectUnmarshaller(m.Headers(), s.unmarshaller)
+		if err != nil {
+			return unmarshalError{errors.Wrap(err, "cannot detect the encoding of the message")}
+		}
+		unmarshaller = detected
+	}
+	mSpan, err := unmarshaller.Unmarshal(message.Value())
 	if err != nil {
-		return errors.Wrap(err, "cannot unmarshall byte array into span")
+		return unmarshalError{errors.Wrap(err, "cannot unmarshall byte array into span")}
 	}
 	return s.writer.WriteSpan(mSpan)
 }
+
+// unmarshalError marks the errors of messages that cannot be decoded, which are pointless to retry
+type unmarshalError struct {
+	error
+}
+
+// IsUnmarshalError returns true if the error was caused by a message that cannot be decoded
+func IsUnmarshalError(err error) bool {
+	_, ok := errors.Cause(err).(unmarshalError)
+	return ok
+}
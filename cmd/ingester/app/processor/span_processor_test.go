@@ -17,12 +17,14 @@ package processor
 import (
 	"testing"
 
+	"github.com/Shopify/sarama"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
 	cmocks "github.com/jaegertracing/jaeger/cmd/ingester/app/consumer/mocks"
 	"github.com/jaegertracing/jaeger/model"
 	umocks "github.com/jaegertracing/jaeger/pkg/kafka/mocks"
+	"github.com/jaegertracing/jaeger/plugin/storage/kafka"
 	smocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
 )
 
@@ -67,8 +69,55 @@ func TestSpanProcessor_ProcessError(t *testing.T) {
 	message.On("Value").Return(data)
 	unmarshallerMock.On("Unmarshal", data).Return(nil, errors.New("moocow"))
 
-	assert.Error(t, processor.Process(message))
+	err := processor.Process(message)
+	assert.EqualError(t, err, "cannot unmarshall byte array into span: moocow")
+	assert.True(t, IsUnmarshalError(err))
+	assert.False(t, IsUnmarshalError(errors.New("moocow")))
 
 	message.AssertExpectations(t)
 	writer.AssertNotCalled(t, "WriteSpan")
 }
+
+type headersMessage struct {
+	value   []byte
+	headers []*sarama.RecordHeader
+}
+
+func (m headersMessage) Value() []byte                   { return m.value }
+func (m headersMessage) Headers() []*sarama.RecordHeader { return m.headers }
+
+func TestSpanProcessor_ProcessDetectsEncoding(t *testing.T) {
+	writer := &smocks.Writer{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller: kafka.NewProtobufUnmarshaller(),
+		Writer:       writer,
+	})
+	writer.On("WriteSpan", &model.Span{OperationName: "op"}).Return(nil)
+
+	message := headersMessage{
+		value: []byte(`{"operationName": "op"}`),
+		headers: []*sarama.RecordHeader{
+			{Key: []byte(kafka.HeaderEncoding), Value: []byte(kafka.EncodingJSON)},
+		},
+	}
+	assert.NoError(t, processor.Process(message))
+	writer.AssertExpectations(t)
+}
+
+func TestSpanProcessor_ProcessUnknownEncoding(t *testing.T) {
+	writer := &smocks.Writer{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller: kafka.NewProtobufUnmarshaller(),
+		Writer:       writer,
+	})
+
+	message := headersMessage{
+		headers: []*sarama.RecordHeader{
+			{Key: []byte(kafka.HeaderEncoding), Value: []byte("thrift")},
+		},
+	}
+	err := processor.Process(message)
+	assert.Error(t, err)
+	assert.True(t, IsUnmarshalError(err))
+	writer.AssertNotCalled(t, "WriteSpan")
+}
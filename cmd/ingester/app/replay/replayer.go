@@ -0,0 +1,165 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/decorator"
+)
+
+// OffsetGetter returns the oldest or newest offset of a partition, e.g. sarama.Client
+type OffsetGetter interface {
+	GetOffset(topic string, partitionID int32, time int64) (int64, error)
+}
+
+// defaultIdleTimeout is how long the replay of a partition waits for its next message by default
+const defaultIdleTimeout = 10 * time.Second
+
+// Params are the parameters of a Replayer
+type Params struct {
+	Consumer    sarama.Consumer
+	Offsets     OffsetGetter
+	Producer    sarama.SyncProducer
+	SourceTopic string
+	TargetTopic string
+	// IdleTimeout is how long the replay of a partition waits for its next message before it stops, in case the
+	// offsets before the high-water mark hold no message, e.g. transaction markers. defaultIdleTimeout is used if zero.
+	IdleTimeout time.Duration
+	Logger      *zap.Logger
+}
+
+// Replayer copies the messages of the dead letter topic back to the topic consumed by the ingester
+type Replayer struct {
+	consumer    sarama.Consumer
+	offsets     OffsetGetter
+	producer    sarama.SyncProducer
+	sourceTopic string
+	targetTopic string
+	idleTimeout time.Duration
+	logger      *zap.Logger
+}
+
+// New creates a Replayer
+func New(params Params) *Replayer {
+	idleTimeout := params.IdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = defaultIdleTimeout
+	}
+	return &Replayer{
+		consumer:    params.Consumer,
+		offsets:     params.Offsets,
+		producer:    params.Producer,
+		sourceTopic: params.SourceTopic,
+		targetTopic: params.TargetTopic,
+		idleTimeout: idleTimeout,
+		logger:      params.Logger,
+	}
+}
+
+// Replay copies all messages published to the source topic before it was called, and returns how many
+// were copied. Messages are not removed from the source topic, so they are copied again if Replay is
+// called again before they expire.
+func (r *Replayer) Replay() (int, error) {
+	partitions, err := r.consumer.Partitions(r.sourceTopic)
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to get partitions")
+	}
+	replayed := 0
+	for _, partition := range partitions {
+		count, err := r.replayPartition(partition)
+		replayed += count
+		if err != nil {
+			return replayed, err
+		}
+	}
+	return replayed, nil
+}
+
+func (r *Replayer) replayPartition(partition int32) (int, error) {
+	oldest, err := r.offsets.GetOffset(r.sourceTopic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Failed to get oldest offset of partition %d", partition)
+	}
+	// the high-water mark is the offset of the next message to be published
+	highWaterMark, err := r.offsets.GetOffset(r.sourceTopic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Failed to get newest offset of partition %d", partition)
+	}
+	if oldest >= highWaterMark {
+		return 0, nil
+	}
+	pc, err := r.consumer.ConsumePartition(r.sourceTopic, partition, oldest)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Failed to consume partition %d", partition)
+	}
+	defer pc.Close()
+
+	replayed := 0
+	idle := time.NewTimer(r.idleTimeout)
+	defer idle.Stop()
+	for {
+		select {
+		case msg, ok := <-pc.Messages():
+			// offsets may be skipped, so the messages published after the replay started are not replayed
+			// rather than waiting for the message at the offset before the high-water mark
+			if !ok || msg.Offset >= highWaterMark {
+				return r.partitionReplayed(partition, replayed), nil
+			}
+			if _, _, err := r.producer.SendMessage(r.replayedMessage(msg)); err != nil {
+				return replayed, errors.Wrapf(err, "Failed to replay message at offset %d of partition %d", msg.Offset, partition)
+			}
+			replayed++
+			if msg.Offset >= highWaterMark-1 {
+				return r.partitionReplayed(partition, replayed), nil
+			}
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(r.idleTimeout)
+		case <-idle.C:
+			r.logger.Warn("No message before the high-water mark of dead letter partition, stopping its replay",
+				zap.Int32("partition", partition), zap.Int64("high-water-mark", highWaterMark), zap.Duration("idle-timeout", r.idleTimeout))
+			return r.partitionReplayed(partition, replayed), nil
+		}
+	}
+}
+
+func (r *Replayer) partitionReplayed(partition int32, replayed int) int {
+	r.logger.Info("Replayed dead letter partition", zap.Int32("partition", partition), zap.Int("messages", replayed))
+	return replayed
+}
+
+// replayedMessage copies the message to the target topic, without the dead letter headers
+func (r *Replayer) replayedMessage(msg *sarama.ConsumerMessage) *sarama.ProducerMessage {
+	replayed := &sarama.ProducerMessage{
+		Topic: r.targetTopic,
+		Value: sarama.ByteEncoder(msg.Value),
+	}
+	if msg.Key != nil {
+		replayed.Key = sarama.ByteEncoder(msg.Key)
+	}
+	for _, header := range msg.Headers {
+		if !strings.HasPrefix(string(header.Key), decorator.HeaderPrefix) {
+			replayed.Headers = append(replayed.Headers, *header)
+		}
+	}
+	return replayed
+}
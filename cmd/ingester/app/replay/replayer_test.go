@@ -0,0 +1,207 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	saramaMocks "github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/decorator"
+)
+
+const (
+	sourceTopic = "jaeger-spans-dead-letter"
+	targetTopic = "jaeger-spans"
+)
+
+type fakeOffsets struct {
+	oldest, newest map[int32]int64
+	err            error
+}
+
+func (o *fakeOffsets) GetOffset(topic string, partition int32, time int64) (int64, error) {
+	if time == sarama.OffsetOldest {
+		return o.oldest[partition], o.err
+	}
+	return o.newest[partition], o.err
+}
+
+type fakeSyncProducer struct {
+	sent []*sarama.ProducerMessage
+	err  error
+}
+
+func (p *fakeSyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	if p.err != nil {
+		return 0, 0, p.err
+	}
+	p.sent = append(p.sent, msg)
+	return 0, int64(len(p.sent)), nil
+}
+
+func (p *fakeSyncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	for _, msg := range msgs {
+		if _, _, err := p.SendMessage(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *fakeSyncProducer) Close() error {
+	return nil
+}
+
+// deadLetter returns a message to yield from the consumer mock, which numbers the messages of a partition from 1
+func deadLetter(key string) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Topic: sourceTopic,
+		Key:   []byte(key),
+		Value: []byte("span"),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte(decorator.HeaderError), Value: []byte("storage down")},
+			{Key: []byte("encoding"), Value: []byte("protobuf")},
+		},
+	}
+}
+
+func TestReplay(t *testing.T) {
+	consumer := saramaMocks.NewConsumer(t, nil)
+	consumer.SetTopicMetadata(map[string][]int32{sourceTopic: {0, 1}})
+	pc := consumer.ExpectConsumePartition(sourceTopic, 0, 1)
+	pc.YieldMessage(deadLetter("trace-1"))
+	pc.YieldMessage(deadLetter("trace-2"))
+	// published after the replay started
+	pc.YieldMessage(deadLetter("trace-3"))
+
+	producer := &fakeSyncProducer{}
+	replayer := New(Params{
+		Consumer:    consumer,
+		Offsets:     &fakeOffsets{oldest: map[int32]int64{0: 1, 1: 3}, newest: map[int32]int64{0: 3, 1: 3}},
+		Producer:    producer,
+		SourceTopic: sourceTopic,
+		TargetTopic: targetTopic,
+		Logger:      zap.NewNop(),
+	})
+	replayed, err := replayer.Replay()
+	require.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+	require.Len(t, producer.sent, 2)
+	assert.Equal(t, &sarama.ProducerMessage{
+		Topic:   targetTopic,
+		Key:     sarama.ByteEncoder("trace-1"),
+		Value:   sarama.ByteEncoder("span"),
+		Headers: []sarama.RecordHeader{{Key: []byte("encoding"), Value: []byte("protobuf")}},
+	}, producer.sent[0])
+	assert.Equal(t, sarama.ByteEncoder("trace-2"), producer.sent[1].Key)
+}
+
+func TestReplaySkippedOffsets(t *testing.T) {
+	testCases := []struct {
+		caption  string
+		oldest   int64
+		newest   int64
+		messages int
+		replayed int
+	}{
+		{
+			// offset 0 holds no message, and the message at offset 1 was published after the replay started
+			caption:  "skipped offset before the high-water mark",
+			oldest:   0,
+			newest:   1,
+			messages: 1,
+			replayed: 0,
+		},
+		{
+			// offsets 3 and 4 hold no message, e.g. transaction markers
+			caption:  "skipped offsets at the end of the partition",
+			oldest:   1,
+			newest:   5,
+			messages: 2,
+			replayed: 2,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.caption, func(t *testing.T) {
+			consumer := saramaMocks.NewConsumer(t, nil)
+			consumer.SetTopicMetadata(map[string][]int32{sourceTopic: {0}})
+			pc := consumer.ExpectConsumePartition(sourceTopic, 0, testCase.oldest)
+			for i := 0; i < testCase.messages; i++ {
+				pc.YieldMessage(deadLetter("trace"))
+			}
+			producer := &fakeSyncProducer{}
+			replayer := New(Params{
+				Consumer:    consumer,
+				Offsets:     &fakeOffsets{oldest: map[int32]int64{0: testCase.oldest}, newest: map[int32]int64{0: testCase.newest}},
+				Producer:    producer,
+				SourceTopic: sourceTopic,
+				TargetTopic: targetTopic,
+				IdleTimeout: 10 * time.Millisecond,
+				Logger:      zap.NewNop(),
+			})
+			replayed, err := replayer.Replay()
+			require.NoError(t, err)
+			assert.Equal(t, testCase.replayed, replayed)
+			assert.Len(t, producer.sent, testCase.replayed)
+		})
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	testCases := []struct {
+		caption       string
+		offsetsErr    error
+		producerErr   error
+		expectedError string
+	}{
+		{
+			caption:       "offsets error",
+			offsetsErr:    errors.New("offsets-error"),
+			expectedError: "Failed to get oldest offset of partition 0: offsets-error",
+		},
+		{
+			caption:       "producer error",
+			producerErr:   errors.New("producer-error"),
+			expectedError: "Failed to replay message at offset 1 of partition 0: producer-error",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.caption, func(t *testing.T) {
+			consumer := saramaMocks.NewConsumer(t, nil)
+			consumer.SetTopicMetadata(map[string][]int32{sourceTopic: {0}})
+			if testCase.offsetsErr == nil {
+				pc := consumer.ExpectConsumePartition(sourceTopic, 0, 1)
+				pc.YieldMessage(deadLetter("trace-1"))
+			}
+			replayer := New(Params{
+				Consumer:    consumer,
+				Offsets:     &fakeOffsets{oldest: map[int32]int64{0: 1}, newest: map[int32]int64{0: 2}, err: testCase.offsetsErr},
+				Producer:    &fakeSyncProducer{err: testCase.producerErr},
+				SourceTopic: sourceTopic,
+				TargetTopic: targetTopic,
+				Logger:      zap.NewNop(),
+			})
+			_, err := replayer.Replay()
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
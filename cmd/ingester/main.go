@@ -22,20 +22,28 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/Shopify/sarama"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/cmd/env"
 	"github.com/jaegertracing/jaeger/cmd/flags"
 	"github.com/jaegertracing/jaeger/cmd/ingester/app"
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/builder"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/replay"
 	"github.com/jaegertracing/jaeger/pkg/config"
 	pMetrics "github.com/jaegertracing/jaeger/pkg/metrics"
 	"github.com/jaegertracing/jaeger/pkg/version"
 	"github.com/jaegertracing/jaeger/plugin/storage"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
+// dependenciesRoute serves the calls between services counted since the last flush on the health check port
+const dependenciesRoute = "/dependencies"
+
 func main() {
 	var signalsChannel = make(chan os.Signal, 0)
 	signal.Notify(signalsChannel, os.Interrupt, syscall.SIGTERM)
@@ -84,7 +92,15 @@ func main() {
 
 			options := app.Options{}
 			options.InitFromViper(v)
-			consumer, err := builder.CreateConsumer(logger, metricsFactory, spanWriter, options)
+			consumerWriter := spanWriter
+			var dependencyAggregator *processor.DependencyAggregator
+			if options.Dependencies.FlushInterval > 0 {
+				dependencyAggregator = startDependencyAggregator(storageFactory, metricsFactory, logger, options.Dependencies)
+				hc.Handle(dependenciesRoute, dependencyAggregator)
+				logger.Info("Serving in-progress dependencies on the health check port", zap.String("route", dependenciesRoute))
+				consumerWriter = spanstore.NewCompositeWriter(spanWriter, dependencyAggregator)
+			}
+			consumer, err := builder.CreateConsumer(logger, metricsFactory, consumerWriter, options)
 			if err != nil {
 				logger.Fatal("Unable to create consumer", zap.Error(err))
 			}
@@ -98,12 +114,18 @@ func main() {
 				if err != nil {
 					logger.Error("Failed to close consumer", zap.Error(err))
 				}
+				if dependencyAggregator != nil {
+					dependencyAggregator.Close()
+				}
 				if closer, ok := spanWriter.(io.Closer); ok {
 					err := closer.Close()
 					if err != nil {
 						logger.Error("Failed to close span writer", zap.Error(err))
 					}
 				}
+				if err := storageFactory.Close(); err != nil {
+					logger.Error("Failed to close storage factory", zap.Error(err))
+				}
 				logger.Info("Jaeger Ingester has finished closing")
 			}
 			return nil
@@ -112,6 +134,7 @@ func main() {
 
 	command.AddCommand(version.Command())
 	command.AddCommand(env.Command())
+	command.AddCommand(replayCommand())
 
 	config.AddFlags(
 		v,
@@ -128,3 +151,91 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// replayCommand creates the command copying the messages of the dead letter topic back to the ingester topic
+func replayCommand() *cobra.Command {
+	v := viper.New()
+	command := &cobra.Command{
+		Use:   "replay-dead-letters",
+		Short: "Copies the messages of the dead letter topic back to the topic consumed by the ingester",
+		Long: `Copies all messages currently in the dead letter topic back to the topic consumed by the ingester,
+without the headers describing the failure. Messages are not removed from the dead letter topic.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.TryLoadConfigFile(v); err != nil {
+				return err
+			}
+			logger, err := zap.NewProduction()
+			if err != nil {
+				return err
+			}
+			options := app.Options{}
+			options.InitFromViper(v)
+			if options.DeadLetterTopic == "" {
+				return fmt.Errorf("%s must be set", app.ConfigPrefix+app.SuffixDeadLetterTopic)
+			}
+
+			saramaConfig := sarama.NewConfig()
+			if saramaConfig.Version, err = options.DeadLetterProtocolVersion(); err != nil {
+				return err
+			}
+			saramaConfig.Producer.Return.Successes = true
+			if err := options.Authentication.SetConfiguration(saramaConfig); err != nil {
+				return err
+			}
+			client, err := sarama.NewClient(options.Brokers, saramaConfig)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			consumer, err := sarama.NewConsumerFromClient(client)
+			if err != nil {
+				return err
+			}
+			defer consumer.Close()
+			producer, err := sarama.NewSyncProducerFromClient(client)
+			if err != nil {
+				return err
+			}
+			defer producer.Close()
+
+			replayed, err := replay.New(replay.Params{
+				Consumer:    consumer,
+				Offsets:     client,
+				Producer:    producer,
+				SourceTopic: options.DeadLetterTopic,
+				TargetTopic: options.Topic,
+				Logger:      logger,
+			}).Replay()
+			logger.Info("Replayed dead letters", zap.Int("messages", replayed))
+			return err
+		},
+	}
+	config.AddFlags(
+		v,
+		command,
+		flags.AddConfigFileFlag,
+		app.AddFlags,
+	)
+	return command
+}
+
+func startDependencyAggregator(
+	storageFactory *storage.Factory,
+	metricsFactory metrics.Factory,
+	logger *zap.Logger,
+	options app.DependenciesOptions,
+) *processor.DependencyAggregator {
+	depWriter, err := storageFactory.CreateDependencyWriter()
+	if err != nil {
+		logger.Fatal("Failed to create dependency writer", zap.Error(err))
+	}
+	aggregator := processor.NewDependencyAggregator(processor.DependencyAggregatorParams{
+		Writer:        depWriter,
+		FlushInterval: options.FlushInterval,
+		TraceTTL:      options.TraceTTL,
+		Factory:       metricsFactory,
+		Logger:        logger,
+	})
+	aggregator.Start()
+	return aggregator
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/storage"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+const (
+	// AdminRoutePrefix is the path prefix of the admin API, which is served on the health check port
+	AdminRoutePrefix = "/api/admin/"
+
+	cutoffParam = "cutoff"
+
+	purgeRunning = "running"
+	purgeDone    = "done"
+	purgeFailed  = "failed"
+)
+
+var (
+	errCutoffParameterRequired = fmt.Errorf("Parameter '%s' is required", cutoffParam)
+	errCutoffNotPositive       = fmt.Errorf("Parameter '%s' must be a positive number of microseconds", cutoffParam)
+	errPurgeRunning            = errors.New("A purge is already running")
+)
+
+// PurgeStatus describes the last purge started through the admin API
+type PurgeStatus struct {
+	State    string     `json:"state"`
+	Cutoff   time.Time  `json:"cutoff"`
+	Started  time.Time  `json:"started"`
+	Finished *time.Time `json:"finished,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// AdminHandler serves the admin API that deletes traces from the span storage. It is not authenticated,
+// so it is meant to be served on the health check port rather than next to the UI.
+type AdminHandler struct {
+	purger spanstore.Purger
+	logger *zap.Logger
+	router *mux.Router
+	// api provides the error and JSON response helpers shared with the query API
+	api *APIHandler
+
+	sync.Mutex
+	purge *PurgeStatus
+}
+
+// NewAdminHandler creates an AdminHandler deleting the traces with the given purger
+func NewAdminHandler(purger spanstore.Purger, logger *zap.Logger) *AdminHandler {
+	aH := &AdminHandler{
+		purger: purger,
+		logger: logger,
+		router: mux.NewRouter(),
+		api:    &APIHandler{logger: logger},
+	}
+	aH.router.HandleFunc(AdminRoutePrefix+"traces/{"+traceIDParam+"}", aH.deleteTrace).Methods(http.MethodDelete)
+	aH.router.HandleFunc(AdminRoutePrefix+"purge", aH.startPurge).Methods(http.MethodPost)
+	aH.router.HandleFunc(AdminRoutePrefix+"purge", aH.getPurge).Methods(http.MethodGet)
+	return aH
+}
+
+// ServeHTTP implements http.Handler
+func (aH *AdminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	aH.router.ServeHTTP(w, r)
+}
+
+// deleteTrace implements the REST API DELETE:/api/admin/traces/{trace-id}.
+// It deletes the trace from the span storage, e.g. to honor a request to remove personal data.
+func (aH *AdminHandler) deleteTrace(w http.ResponseWriter, r *http.Request) {
+	traceID, ok := aH.api.parseTraceID(w, r)
+	if !ok {
+		return
+	}
+	err := aH.purger.DeleteTrace(traceID)
+	if err == storage.ErrPurgerNotSupported {
+		aH.api.handleError(w, err, http.StatusNotImplemented)
+		return
+	}
+	if aH.api.handleError(w, err, http.StatusInternalServerError) {
+		return
+	}
+	aH.logger.Info("Deleted trace", zap.String("trace_id", traceID.String()))
+	structuredRes := structuredResponse{
+		Data:   []string{},
+		Errors: []structuredError{},
+	}
+	aH.api.writeJSON(w, r, &structuredRes)
+}
+
+// startPurge implements the REST API POST:/api/admin/purge?cutoff={micros}.
+// It starts deleting all spans that started before the cutoff, given in microseconds since epoch,
+// and responds with 202 Accepted and the status of the purge, which can be polled with getPurge.
+func (aH *AdminHandler) startPurge(w http.ResponseWriter, r *http.Request) {
+	value := r.FormValue(cutoffParam)
+	if value == "" {
+		aH.api.handleError(w, errCutoffParameterRequired, http.StatusBadRequest)
+		return
+	}
+	micros, err := strconv.ParseInt(value, 10, 64)
+	if aH.api.handleError(w, errors.Wrapf(err, "Unable to parse %s", cutoffParam), http.StatusBadRequest) {
+		return
+	}
+	if micros <= 0 {
+		aH.api.handleError(w, errCutoffNotPositive, http.StatusBadRequest)
+		return
+	}
+	cutoff := model.EpochMicrosecondsAsTime(uint64(micros))
+
+	aH.Lock()
+	if aH.purge != nil && aH.purge.State == purgeRunning {
+		aH.Unlock()
+		aH.api.handleError(w, errPurgeRunning, http.StatusConflict)
+		return
+	}
+	aH.purge = &PurgeStatus{State: purgeRunning, Cutoff: cutoff, Started: time.Now()}
+	status := *aH.purge
+	aH.Unlock()
+
+	aH.logger.Info("Purging spans", zap.Time("cutoff", cutoff))
+	go aH.runPurge(cutoff)
+
+	// writeJSON cannot set the content type once the status is written
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", AdminRoutePrefix+"purge")
+	w.WriteHeader(http.StatusAccepted)
+	aH.api.writeJSON(w, r, &structuredResponse{
+		Data:   status,
+		Errors: []structuredError{},
+	})
+}
+
+func (aH *AdminHandler) runPurge(cutoff time.Time) {
+	err := aH.purger.Purge(cutoff)
+	finished := time.Now()
+
+	aH.Lock()
+	defer aH.Unlock()
+	aH.purge.Finished = &finished
+	if err != nil {
+		aH.logger.Error("Failed to purge spans", zap.Time("cutoff", cutoff), zap.Error(err))
+		aH.purge.State = purgeFailed
+		aH.purge.Error = err.Error()
+		return
+	}
+	aH.logger.Info("Purged spans", zap.Time("cutoff", cutoff))
+	aH.purge.State = purgeDone
+}
+
+// getPurge implements the REST API GET:/api/admin/purge.
+// It responds with the status of the last purge, or with no data if no purge was started.
+func (aH *AdminHandler) getPurge(w http.ResponseWriter, r *http.Request) {
+	aH.Lock()
+	var status *PurgeStatus
+	if aH.purge != nil {
+		copied := *aH.purge
+		status = &copied
+	}
+	aH.Unlock()
+	aH.api.writeJSON(w, r, &structuredResponse{
+		Data:   status,
+		Errors: []structuredError{},
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/storage"
+	spanstoremocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
+)
+
+func deleteJSON(url string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	return execJSON(req, out)
+}
+
+func withAdminServer(purger *spanstoremocks.Purger, test func(url string)) {
+	server := httptest.NewServer(NewAdminHandler(purger, zap.NewNop()))
+	defer server.Close()
+	test(server.URL + AdminRoutePrefix)
+}
+
+// purgeStatus polls the purge status until the purge is not running anymore
+func purgeStatus(t *testing.T, url string) PurgeStatus {
+	for i := 0; i < 100; i++ {
+		var status PurgeStatus
+		require.NoError(t, getJSON(url+"purge", &structuredResponse{Data: &status}))
+		if status.State != purgeRunning {
+			return status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.FailNow(t, "the purge did not finish")
+	return PurgeStatus{}
+}
+
+func TestAdminAPI_NotOnQueryPort(t *testing.T) {
+	withTestServer(t, func(ts *testServer) {
+		err := deleteJSON(ts.server.URL+"/api/admin/traces/"+mockTraceID.String(), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "404 error from server")
+		err = postJSON(ts.server.URL+"/api/admin/purge?cutoff=1000", []string{}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "404 error from server")
+	})
+}
+
+func TestDeleteTrace(t *testing.T) {
+	testCases := []struct {
+		caption     string
+		traceID     string
+		err         error
+		expectedErr string
+	}{
+		{caption: "success", traceID: mockTraceID.String()},
+		{caption: "bad trace ID", traceID: "xyz", expectedErr: "400 error from server"},
+		{caption: "storage error", traceID: mockTraceID.String(), err: errors.New("cannot delete"), expectedErr: parsedError(500, "cannot delete")},
+		{caption: "not supported", traceID: mockTraceID.String(), err: storage.ErrPurgerNotSupported, expectedErr: parsedError(501, storage.ErrPurgerNotSupported.Error())},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.caption, func(t *testing.T) {
+			purger := &spanstoremocks.Purger{}
+			purger.On("DeleteTrace", mockTraceID).Return(testCase.err)
+			withAdminServer(purger, func(url string) {
+				var response structuredResponse
+				err := deleteJSON(url+"traces/"+testCase.traceID, &response)
+				if testCase.expectedErr == "" {
+					assert.NoError(t, err)
+					purger.AssertExpectations(t)
+				} else {
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), testCase.expectedErr)
+				}
+			})
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+	cutoff := time.Unix(1000, 0).UTC()
+	testCases := []struct {
+		caption       string
+		query         string
+		err           error
+		expectedErr   string
+		expectedState string
+	}{
+		{caption: "success", query: "?cutoff=1000000000", expectedState: purgeDone},
+		{caption: "missing cutoff", expectedErr: parsedError(400, "Parameter 'cutoff' is required")},
+		{caption: "bad cutoff", query: "?cutoff=yesterday", expectedErr: "400 error from server"},
+		{caption: "non-positive cutoff", query: "?cutoff=0", expectedErr: parsedError(400, "Parameter 'cutoff' must be a positive number of microseconds")},
+		{caption: "storage error", query: "?cutoff=1000000000", err: errors.New("cannot purge"), expectedState: purgeFailed},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.caption, func(t *testing.T) {
+			purger := &spanstoremocks.Purger{}
+			purger.On("Purge", cutoff).Return(testCase.err)
+			withAdminServer(purger, func(url string) {
+				var started PurgeStatus
+				err := postJSON(url+"purge"+testCase.query, []string{}, &structuredResponse{Data: &started})
+				if testCase.expectedErr != "" {
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), testCase.expectedErr)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, cutoff, started.Cutoff.UTC())
+
+				status := purgeStatus(t, url)
+				assert.Equal(t, testCase.expectedState, status.State)
+				assert.Equal(t, cutoff, status.Cutoff.UTC())
+				require.NotNil(t, status.Finished)
+				if testCase.err != nil {
+					assert.Equal(t, testCase.err.Error(), status.Error)
+				}
+				purger.AssertExpectations(t)
+			})
+		})
+	}
+}
+
+func TestPurgeAccepted(t *testing.T) {
+	cutoff := time.Unix(1000, 0).UTC()
+	release := make(chan struct{})
+	purger := &spanstoremocks.Purger{}
+	purger.On("Purge", cutoff).Run(func(mock.Arguments) { <-release }).Return(nil)
+	withAdminServer(purger, func(url string) {
+		var status PurgeStatus
+		require.NoError(t, getJSON(url+"purge", &structuredResponse{Data: &status}))
+		assert.Empty(t, status.State, "no purge was started yet")
+
+		resp, err := http.Post(url+"purge?cutoff=1000000000", "", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.Equal(t, AdminRoutePrefix+"purge", resp.Header.Get("Location"))
+
+		require.NoError(t, getJSON(url+"purge", &structuredResponse{Data: &status}))
+		assert.Equal(t, purgeRunning, status.State)
+		assert.Nil(t, status.Finished)
+
+		err = postJSON(url+"purge?cutoff=1000000000", []string{}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), parsedError(409, errPurgeRunning.Error()))
+
+		close(release)
+		assert.Equal(t, purgeDone, purgeStatus(t, url).State)
+	})
+}
@@ -22,9 +22,12 @@ import (
 
 const (
 	queryPort        = "query.port"
+	queryGRPCPort    = "query.grpc-port"
 	queryBasePath    = "query.base-path"
 	queryStaticFiles = "query.static-files"
 	queryUIConfig    = "query.ui-config"
+	queryAdminAPI    = "query.admin-api"
+	queryZipkinAPI   = "query.zipkin-api"
 	// QueryDefaultHealthCheckHTTPPort is the default HTTP Port for health check
 	QueryDefaultHealthCheckHTTPPort = 16687
 )
@@ -33,27 +36,39 @@ const (
 type QueryOptions struct {
 	// Port is the port that the query service listens in on
 	Port int
+	// GRPCPort is the port that the query service serves the QueryServiceV2 gRPC API on; 0 disables it
+	GRPCPort int
 	// BasePath is the prefix for all UI and API HTTP routes
 	BasePath string
 	// StaticAssets is the path for the static assets for the UI (https://github.com/uber/jaeger-ui)
 	StaticAssets string
 	// UIConfig is the path to a configuration file for the UI
 	UIConfig string
+	// AdminAPI enables the HTTP routes that delete traces from the span storage
+	AdminAPI bool
+	// ZipkinAPI enables the read-only Zipkin v2 HTTP routes under /api/v2
+	ZipkinAPI bool
 }
 
 // AddFlags adds flags for QueryOptions
 func AddFlags(flagSet *flag.FlagSet) {
 	flagSet.Int(queryPort, 16686, "The port for the query service")
+	flagSet.Int(queryGRPCPort, 16685, "The port for the query service gRPC API, or 0 to disable it")
 	flagSet.String(queryBasePath, "/", "The base path for all HTTP routes, e.g. /jaeger; useful when running behind a reverse proxy")
 	flagSet.String(queryStaticFiles, "", "The directory path override for the static assets for the UI")
 	flagSet.String(queryUIConfig, "", "The path to the UI configuration file in JSON format")
+	flagSet.Bool(queryAdminAPI, false, "Enable the admin API for deleting traces from the span storage under /api/admin on the health check port; it is not authenticated")
+	flagSet.Bool(queryZipkinAPI, false, "Enable the read-only Zipkin v2 API under /api/v2 for Zipkin compatible tools")
 }
 
 // InitFromViper initializes QueryOptions with properties from viper
 func (qOpts *QueryOptions) InitFromViper(v *viper.Viper) *QueryOptions {
 	qOpts.Port = v.GetInt(queryPort)
+	qOpts.GRPCPort = v.GetInt(queryGRPCPort)
 	qOpts.BasePath = v.GetString(queryBasePath)
 	qOpts.StaticAssets = v.GetString(queryStaticFiles)
 	qOpts.UIConfig = v.GetString(queryUIConfig)
+	qOpts.AdminAPI = v.GetBool(queryAdminAPI)
+	qOpts.ZipkinAPI = v.GetBool(queryZipkinAPI)
 	return qOpts
 }
@@ -29,10 +29,16 @@ func TestQueryBuilderFlags(t *testing.T) {
 		"--query.ui-config=some.json",
 		"--query.base-path=/jaeger",
 		"--query.port=80",
+		"--query.grpc-port=81",
+		"--query.admin-api=true",
+		"--query.zipkin-api=true",
 	})
 	qOpts := new(QueryOptions).InitFromViper(v)
 	assert.Equal(t, "/dev/null", qOpts.StaticAssets)
 	assert.Equal(t, "some.json", qOpts.UIConfig)
 	assert.Equal(t, "/jaeger", qOpts.BasePath)
 	assert.Equal(t, 80, qOpts.Port)
+	assert.Equal(t, 81, qOpts.GRPCPort)
+	assert.True(t, qOpts.AdminAPI)
+	assert.True(t, qOpts.ZipkinAPI)
 }
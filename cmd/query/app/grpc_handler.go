@@ -0,0 +1,268 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/adjuster"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+var (
+	errMissingQuery         = errors.New("query parameters are required")
+	errNegativeNumTraces    = errors.New("num_traces cannot be negative")
+	errStartTimeMinAfterMax = errors.New("start_time_min cannot be after start_time_max")
+)
+
+// GRPCHandler implements model.QueryServiceV2Server on top of the same storage
+// readers and adjusters used by the JSON APIHandler.
+type GRPCHandler struct {
+	spanReader        spanstore.Reader
+	archiveSpanReader spanstore.Reader
+	dependencyReader  dependencystore.Reader
+	adjuster          adjuster.Adjuster
+	logger            *zap.Logger
+	queryLookback     time.Duration
+	timeNow           func() time.Time
+}
+
+// GRPCHandlerOptions contains the optional dependencies of the GRPCHandler
+type GRPCHandlerOptions struct {
+	ArchiveSpanReader spanstore.Reader
+	Adjuster          adjuster.Adjuster
+	Logger            *zap.Logger
+	// QueryLookbackDuration is how far back FindTraces searches when the query has no start_time_min,
+	// like HandlerOptions.QueryLookbackDuration of the APIHandler
+	QueryLookbackDuration time.Duration
+}
+
+// NewGRPCHandler returns a GRPCHandler
+func NewGRPCHandler(spanReader spanstore.Reader, dependencyReader dependencystore.Reader, options GRPCHandlerOptions) *GRPCHandler {
+	if options.Adjuster == nil {
+		options.Adjuster = adjuster.Sequence(StandardAdjusters...)
+	}
+	if options.Logger == nil {
+		options.Logger = zap.NewNop()
+	}
+	if options.QueryLookbackDuration == 0 {
+		options.QueryLookbackDuration = defaultTraceQueryLookbackDuration
+	}
+	return &GRPCHandler{
+		spanReader:        spanReader,
+		archiveSpanReader: options.ArchiveSpanReader,
+		dependencyReader:  dependencyReader,
+		adjuster:          options.Adjuster,
+		logger:            options.Logger,
+		queryLookback:     options.QueryLookbackDuration,
+		timeNow:           time.Now,
+	}
+}
+
+// GetTrace implements model.QueryServiceV2Server.GetTrace
+func (g *GRPCHandler) GetTrace(ctx context.Context, r *model.GetTraceRequest) (*model.GetTraceResponse, error) {
+	traceID, err := model.TraceIDFromString(r.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot parse trace ID: %v", err)
+	}
+	trace, err := trace(traceID, g.spanReader, g.archiveSpanReader)
+	if err == spanstore.ErrTraceNotFound {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, g.internalError("GetTrace", err)
+	}
+	return &model.GetTraceResponse{Trace: g.adjust(trace)}, nil
+}
+
+// FindTraces implements model.QueryServiceV2Server.FindTraces.
+// Each trace found is sent to the client as a separate SpansResponseChunk.
+func (g *GRPCHandler) FindTraces(r *model.FindTracesRequest, stream model.QueryServiceV2_FindTracesServer) error {
+	query, err := g.toTraceQueryParameters(r.GetQuery())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+	traces, err := g.spanReader.FindTraces(query)
+	if err != nil {
+		return g.internalError("FindTraces", err)
+	}
+	for _, trace := range traces {
+		trace = g.adjust(trace)
+		if err := stream.Send(&model.SpansResponseChunk{Spans: trace.Spans}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetServices implements model.QueryServiceV2Server.GetServices
+func (g *GRPCHandler) GetServices(ctx context.Context, r *model.GetServicesRequest) (*model.GetServicesResponse, error) {
+	services, err := g.spanReader.GetServices()
+	if err != nil {
+		return nil, g.internalError("GetServices", err)
+	}
+	return &model.GetServicesResponse{Services: services}, nil
+}
+
+// GetOperations implements model.QueryServiceV2Server.GetOperations
+func (g *GRPCHandler) GetOperations(ctx context.Context, r *model.GetOperationsRequest) (*model.GetOperationsResponse, error) {
+	if r.Service == "" {
+		return nil, status.Error(codes.InvalidArgument, ErrServiceParameterRequired.Error())
+	}
+	operations, err := g.spanReader.GetOperations(r.Service)
+	if err != nil {
+		return nil, g.internalError("GetOperations", err)
+	}
+	return &model.GetOperationsResponse{Operations: operations}, nil
+}
+
+// GetDependencies implements model.QueryServiceV2Server.GetDependencies
+func (g *GRPCHandler) GetDependencies(ctx context.Context, r *model.GetDependenciesRequest) (*model.GetDependenciesResponse, error) {
+	endTs := g.timeNow()
+	if r.EndTime != nil {
+		ts, err := types.TimestampFromProto(r.EndTime)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid end_time: %v", err)
+		}
+		endTs = ts
+	}
+	lookback := defaultDependencyLookbackDuration
+	if r.Lookback != nil {
+		d, err := types.DurationFromProto(r.Lookback)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid lookback: %v", err)
+		}
+		lookback = d
+	}
+	dependencies, err := g.dependencyReader.GetDependencies(endTs, lookback)
+	if err != nil {
+		return nil, g.internalError("GetDependencies", err)
+	}
+	return &model.GetDependenciesResponse{Dependencies: mergeDependencies(dependencies)}, nil
+}
+
+// mergeDependencies sums the call counts of links between the same parent and child,
+// like APIHandler.deduplicateDependencies does for the HTTP API
+func mergeDependencies(dependencies []model.DependencyLink) []*model.DependencyLink {
+	type key struct {
+		parent string
+		child  string
+	}
+	merged := make(map[key]*model.DependencyLink)
+	links := make([]*model.DependencyLink, 0, len(dependencies))
+	for _, d := range dependencies {
+		k := key{parent: d.Parent, child: d.Child}
+		if link, ok := merged[k]; ok {
+			link.CallCount += d.CallCount
+			continue
+		}
+		link := &model.DependencyLink{Parent: d.Parent, Child: d.Child, CallCount: d.CallCount}
+		merged[k] = link
+		links = append(links, link)
+	}
+	return links
+}
+
+func (g *GRPCHandler) adjust(trace *model.Trace) *model.Trace {
+	adjusted, err := g.adjuster.Adjust(trace)
+	if err != nil {
+		g.logger.Warn("Failed to adjust trace", zap.Error(err))
+	}
+	if adjusted == nil {
+		return trace
+	}
+	return adjusted
+}
+
+func (g *GRPCHandler) internalError(method string, err error) error {
+	g.logger.Error("gRPC handler, Internal Server Error", zap.String("method", method), zap.Error(err))
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (g *GRPCHandler) toTraceQueryParameters(q *model.TraceQueryParameters) (*spanstore.TraceQueryParameters, error) {
+	if q == nil {
+		return nil, errMissingQuery
+	}
+	query := &spanstore.TraceQueryParameters{
+		ServiceName:   q.ServiceName,
+		OperationName: q.OperationName,
+		Tags:          q.Tags,
+		NumTraces:     int(q.NumTraces),
+	}
+	var err error
+	if q.StartTimeMin != nil {
+		if query.StartTimeMin, err = types.TimestampFromProto(q.StartTimeMin); err != nil {
+			return nil, err
+		}
+	}
+	if q.StartTimeMax != nil {
+		if query.StartTimeMax, err = types.TimestampFromProto(q.StartTimeMax); err != nil {
+			return nil, err
+		}
+	}
+	if q.DurationMin != nil {
+		if query.DurationMin, err = types.DurationFromProto(q.DurationMin); err != nil {
+			return nil, err
+		}
+	}
+	if q.DurationMax != nil {
+		if query.DurationMax, err = types.DurationFromProto(q.DurationMax); err != nil {
+			return nil, err
+		}
+	}
+	if query.StartTimeMax.IsZero() {
+		query.StartTimeMax = g.timeNow()
+	}
+	if query.StartTimeMin.IsZero() {
+		query.StartTimeMin = query.StartTimeMax.Add(-g.queryLookback)
+	}
+	if query.NumTraces == 0 {
+		query.NumTraces = defaultQueryLimit
+	}
+	if err := validateTraceQuery(query); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+// validateTraceQuery applies the checks of the HTTP query parser, so that invalid queries
+// are rejected with InvalidArgument instead of failing in the storage
+func validateTraceQuery(query *spanstore.TraceQueryParameters) error {
+	if query.ServiceName == "" {
+		return ErrServiceParameterRequired
+	}
+	if query.NumTraces < 0 {
+		return errNegativeNumTraces
+	}
+	if query.StartTimeMin.After(query.StartTimeMax) {
+		return errStartTimeMinAfterMax
+	}
+	if query.DurationMin != 0 && len(query.Tags) > 0 {
+		return errCannotQueryTagAndDuration
+	}
+	if query.DurationMin != 0 && query.DurationMax != 0 && query.DurationMax < query.DurationMin {
+		return errMaxDurationGreaterThanMin
+	}
+	return nil
+}
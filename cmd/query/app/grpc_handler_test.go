@@ -0,0 +1,250 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/model"
+	depsmocks "github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+	spanstoremocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
+)
+
+type grpcTestServer struct {
+	spanReader       *spanstoremocks.Reader
+	dependencyReader *depsmocks.Reader
+	client           model.QueryServiceV2Client
+}
+
+func withGRPCServer(t *testing.T, doTest func(s *grpcTestServer)) {
+	withGRPCServerOptions(t, GRPCHandlerOptions{}, doTest)
+}
+
+func withGRPCServerOptions(t *testing.T, options GRPCHandlerOptions, doTest func(s *grpcTestServer)) {
+	spanReader := &spanstoremocks.Reader{}
+	dependencyReader := &depsmocks.Reader{}
+	handler := NewGRPCHandler(spanReader, dependencyReader, options)
+	server := grpc.NewServer()
+	model.RegisterQueryServiceV2Server(server, handler)
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	doTest(&grpcTestServer{
+		spanReader:       spanReader,
+		dependencyReader: dependencyReader,
+		client:           model.NewQueryServiceV2Client(conn),
+	})
+}
+
+func TestGRPCGetTrace(t *testing.T) {
+	withGRPCServer(t, func(s *grpcTestServer) {
+		s.spanReader.On("GetTrace", mockTraceID).Return(mockTrace, nil).Once()
+		res, err := s.client.GetTrace(context.Background(), &model.GetTraceRequest{Id: mockTraceID.String()})
+		require.NoError(t, err)
+		require.NotNil(t, res.Trace)
+		assert.Len(t, res.Trace.Spans, len(mockTrace.Spans))
+	})
+}
+
+func TestGRPCGetTraceErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		traceID    string
+		storageErr error
+		code       codes.Code
+	}{
+		{name: "bad id", traceID: "not-a-trace-id", code: codes.InvalidArgument},
+		{name: "not found", traceID: mockTraceID.String(), storageErr: spanstore.ErrTraceNotFound, code: codes.NotFound},
+		{name: "storage error", traceID: mockTraceID.String(), storageErr: errStorage, code: codes.Internal},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.name, func(t *testing.T) {
+			withGRPCServer(t, func(s *grpcTestServer) {
+				s.spanReader.On("GetTrace", mockTraceID).Return(nil, testCase.storageErr).Once()
+				_, err := s.client.GetTrace(context.Background(), &model.GetTraceRequest{Id: testCase.traceID})
+				require.Error(t, err)
+				assert.Equal(t, testCase.code, status.Code(err))
+			})
+		})
+	}
+}
+
+func TestGRPCGetTraceFromArchive(t *testing.T) {
+	archiveReader := &spanstoremocks.Reader{}
+	archiveReader.On("GetTrace", mockTraceID).Return(mockTrace, nil).Once()
+	withGRPCServerOptions(t, GRPCHandlerOptions{ArchiveSpanReader: archiveReader}, func(s *grpcTestServer) {
+		s.spanReader.On("GetTrace", mockTraceID).Return(nil, spanstore.ErrTraceNotFound).Once()
+		res, err := s.client.GetTrace(context.Background(), &model.GetTraceRequest{Id: mockTraceID.String()})
+		require.NoError(t, err)
+		assert.Len(t, res.Trace.Spans, len(mockTrace.Spans))
+	})
+}
+
+func TestGRPCFindTraces(t *testing.T) {
+	withGRPCServer(t, func(s *grpcTestServer) {
+		startMin := time.Unix(100, 0)
+		s.spanReader.On("FindTraces", mock.MatchedBy(func(q *spanstore.TraceQueryParameters) bool {
+			return q.ServiceName == "svc" &&
+				q.StartTimeMin.Equal(startMin) &&
+				q.DurationMin == time.Second &&
+				q.NumTraces == defaultQueryLimit
+		})).Return([]*model.Trace{mockTrace, mockTrace}, nil).Once()
+
+		tsMin, err := types.TimestampProto(startMin)
+		require.NoError(t, err)
+		stream, err := s.client.FindTraces(context.Background(), &model.FindTracesRequest{
+			Query: &model.TraceQueryParameters{
+				ServiceName:  "svc",
+				StartTimeMin: tsMin,
+				DurationMin:  types.DurationProto(time.Second),
+			},
+		})
+		require.NoError(t, err)
+		var chunks int
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			assert.Len(t, chunk.Spans, len(mockTrace.Spans))
+			chunks++
+		}
+		assert.Equal(t, 2, chunks)
+	})
+}
+
+func TestGRPCFindTracesLookback(t *testing.T) {
+	withGRPCServerOptions(t, GRPCHandlerOptions{QueryLookbackDuration: time.Minute}, func(s *grpcTestServer) {
+		s.spanReader.On("FindTraces", mock.MatchedBy(func(q *spanstore.TraceQueryParameters) bool {
+			return q.StartTimeMax.Sub(q.StartTimeMin) == time.Minute
+		})).Return([]*model.Trace{}, nil).Once()
+
+		stream, err := s.client.FindTraces(context.Background(), &model.FindTracesRequest{
+			Query: &model.TraceQueryParameters{ServiceName: "svc"},
+		})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+		s.spanReader.AssertExpectations(t)
+	})
+}
+
+func TestGRPCFindTracesErrors(t *testing.T) {
+	tsMin, err := types.TimestampProto(time.Unix(200, 0))
+	require.NoError(t, err)
+	tsMax, err := types.TimestampProto(time.Unix(100, 0))
+	require.NoError(t, err)
+	invalidQueries := []*model.TraceQueryParameters{
+		nil,
+		{},
+		{ServiceName: "svc", NumTraces: -1},
+		{ServiceName: "svc", StartTimeMin: tsMin, StartTimeMax: tsMax},
+		{ServiceName: "svc", Tags: map[string]string{"k": "v"}, DurationMin: types.DurationProto(time.Second)},
+		{ServiceName: "svc", DurationMin: types.DurationProto(time.Second), DurationMax: types.DurationProto(time.Millisecond)},
+	}
+	withGRPCServer(t, func(s *grpcTestServer) {
+		for _, query := range invalidQueries {
+			stream, err := s.client.FindTraces(context.Background(), &model.FindTracesRequest{Query: query})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "%v", query)
+		}
+
+		s.spanReader.On("FindTraces", mock.AnythingOfType("*spanstore.TraceQueryParameters")).
+			Return(nil, errStorage).Once()
+		stream, err := s.client.FindTraces(context.Background(), &model.FindTracesRequest{
+			Query: &model.TraceQueryParameters{ServiceName: "svc"},
+		})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGRPCGetServices(t *testing.T) {
+	withGRPCServer(t, func(s *grpcTestServer) {
+		expected := []string{"trifle", "bling"}
+		s.spanReader.On("GetServices").Return(expected, nil).Once()
+		res, err := s.client.GetServices(context.Background(), &model.GetServicesRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, res.Services)
+
+		s.spanReader.On("GetServices").Return(nil, errStorage).Once()
+		_, err = s.client.GetServices(context.Background(), &model.GetServicesRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGRPCGetOperations(t *testing.T) {
+	withGRPCServer(t, func(s *grpcTestServer) {
+		expected := []string{"", "get"}
+		s.spanReader.On("GetOperations", "abc/trifle").Return(expected, nil).Once()
+		res, err := s.client.GetOperations(context.Background(), &model.GetOperationsRequest{Service: "abc/trifle"})
+		require.NoError(t, err)
+		assert.Equal(t, expected, res.Operations)
+
+		_, err = s.client.GetOperations(context.Background(), &model.GetOperationsRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGRPCGetDependencies(t *testing.T) {
+	withGRPCServer(t, func(s *grpcTestServer) {
+		endTs := time.Unix(0, 1476374248550*millisToNanosMultiplier).UTC()
+		expected := []model.DependencyLink{
+			{Parent: "killer", Child: "queen", CallCount: 12},
+		}
+		s.dependencyReader.On("GetDependencies", endTs, time.Hour).Return([]model.DependencyLink{
+			{Parent: "killer", Child: "queen", CallCount: 10},
+			{Parent: "killer", Child: "queen", CallCount: 2},
+		}, nil).Once()
+
+		ts, err := types.TimestampProto(endTs)
+		require.NoError(t, err)
+		res, err := s.client.GetDependencies(context.Background(), &model.GetDependenciesRequest{
+			EndTime:  ts,
+			Lookback: types.DurationProto(time.Hour),
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Dependencies, 1)
+		assert.Equal(t, expected[0], *res.Dependencies[0])
+
+		s.dependencyReader.On("GetDependencies", mock.Anything, defaultDependencyLookbackDuration).
+			Return(nil, errStorage).Once()
+		_, err = s.client.GetDependencies(context.Background(), &model.GetDependenciesRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
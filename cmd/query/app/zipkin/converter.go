@@ -0,0 +1,173 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/opentracing/opentracing-go/ext"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/converter/thrift/zipkin"
+)
+
+// Span is a span in the Zipkin v2 JSON format
+type Span struct {
+	TraceID        string            `json:"traceId"`
+	ID             string            `json:"id"`
+	ParentID       string            `json:"parentId,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Kind           string            `json:"kind,omitempty"`
+	Timestamp      uint64            `json:"timestamp,omitempty"`
+	Duration       uint64            `json:"duration,omitempty"`
+	Debug          bool              `json:"debug,omitempty"`
+	LocalEndpoint  *Endpoint         `json:"localEndpoint,omitempty"`
+	RemoteEndpoint *Endpoint         `json:"remoteEndpoint,omitempty"`
+	Annotations    []Annotation      `json:"annotations,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+// Endpoint is the network context of a node in the Zipkin v2 JSON format
+type Endpoint struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	IPV4        string `json:"ipv4,omitempty"`
+	IPV6        string `json:"ipv6,omitempty"`
+	Port        int64  `json:"port,omitempty"`
+}
+
+// Annotation is a timestamped event in the Zipkin v2 JSON format
+type Annotation struct {
+	Timestamp uint64 `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+var spanKinds = map[string]string{
+	string(ext.SpanKindRPCClientEnum): "CLIENT",
+	string(ext.SpanKindRPCServerEnum): "SERVER",
+	string(ext.SpanKindProducerEnum):  "PRODUCER",
+	string(ext.SpanKindConsumerEnum):  "CONSUMER",
+}
+
+// FromDomain converts the spans of a trace to the Zipkin v2 format
+func FromDomain(spans []*model.Span) []Span {
+	zSpans := make([]Span, 0, len(spans))
+	for _, span := range spans {
+		zSpans = append(zSpans, FromDomainSpan(span))
+	}
+	return zSpans
+}
+
+// FromDomainSpan converts a span to the Zipkin v2 format. The span.kind and peer.* tags
+// are converted back to the span kind and the remote endpoint.
+func FromDomainSpan(span *model.Span) Span {
+	zSpan := Span{
+		TraceID:   traceIDToString(span.TraceID),
+		ID:        fmt.Sprintf("%016x", uint64(span.SpanID)),
+		Name:      span.OperationName,
+		Timestamp: model.TimeAsEpochMicroseconds(span.StartTime),
+		Duration:  model.DurationAsMicroseconds(span.Duration),
+		Debug:     span.Flags.IsDebug(),
+	}
+	if parentID := span.ParentSpanID(); parentID != 0 {
+		zSpan.ParentID = fmt.Sprintf("%016x", uint64(parentID))
+	}
+	if span.Process != nil {
+		zSpan.LocalEndpoint = &Endpoint{ServiceName: span.Process.ServiceName}
+		if ip, ok := model.KeyValues(span.Process.Tags).FindByKey(zipkin.IPTagName); ok {
+			setIP(zSpan.LocalEndpoint, ip)
+		}
+	}
+	remote := &Endpoint{}
+	for i := range span.Tags {
+		tag := &span.Tags[i]
+		switch tag.Key {
+		case string(ext.SpanKind):
+			zSpan.Kind = spanKinds[tag.AsString()]
+		case string(ext.PeerService):
+			remote.ServiceName = tag.AsString()
+		case string(ext.PeerHostIPv4), string(ext.PeerHostIPv6):
+			setIP(remote, *tag)
+		case string(ext.PeerPort):
+			if tag.VType == model.Int64Type {
+				remote.Port = tag.Int64()
+			} else if port, err := strconv.ParseInt(tag.AsString(), 10, 64); err == nil {
+				remote.Port = port
+			}
+		default:
+			if zSpan.Tags == nil {
+				zSpan.Tags = make(map[string]string)
+			}
+			zSpan.Tags[tag.Key] = tag.AsString()
+		}
+	}
+	if *remote != (Endpoint{}) {
+		zSpan.RemoteEndpoint = remote
+	}
+	for _, log := range span.Logs {
+		zSpan.Annotations = append(zSpan.Annotations, Annotation{
+			Timestamp: model.TimeAsEpochMicroseconds(log.Timestamp),
+			Value:     annotationValue(log.Fields),
+		})
+	}
+	return zSpan
+}
+
+func traceIDToString(traceID model.TraceID) string {
+	if traceID.High == 0 {
+		return fmt.Sprintf("%016x", traceID.Low)
+	}
+	return fmt.Sprintf("%016x%016x", traceID.High, traceID.Low)
+}
+
+// setIP sets the address of the endpoint from a tag holding either a packed IPv4 number,
+// the bytes of an IPv6 address or a textual address
+func setIP(endpoint *Endpoint, tag model.KeyValue) {
+	var ip net.IP
+	switch tag.VType {
+	case model.Int64Type:
+		v := uint32(tag.Int64())
+		ip = net.IPv4(byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	case model.BinaryType:
+		ip = net.IP(tag.Binary())
+	default:
+		ip = net.ParseIP(tag.AsString())
+	}
+	if ip == nil || len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+		return
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		endpoint.IPV4 = ipv4.String()
+	} else {
+		endpoint.IPV6 = ip.String()
+	}
+}
+
+// annotationValue is the value of the only "event" field of a log,
+// all other logs are encoded as a JSON object of their fields
+func annotationValue(fields []model.KeyValue) string {
+	if len(fields) == 1 && fields[0].Key == zipkin.DefaultLogFieldKey {
+		return fields[0].AsString()
+	}
+	values := make(map[string]string, len(fields))
+	for i := range fields {
+		values[fields[i].Key] = fields[i].AsString()
+	}
+	// marshaling a map of strings cannot fail
+	out, _ := json.Marshal(values)
+	return string(out)
+}
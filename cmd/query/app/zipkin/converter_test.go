@@ -0,0 +1,114 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+func TestFromDomainSpan(t *testing.T) {
+	start := time.Unix(1532000000, 0)
+	traceID := model.TraceID{High: 1, Low: 2}
+	span := &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.SpanID(3),
+		OperationName: "get",
+		References:    []model.SpanRef{model.NewChildOfRef(traceID, model.SpanID(4))},
+		Flags:         model.Flags(2),
+		StartTime:     start,
+		Duration:      10 * time.Millisecond,
+		Tags: model.KeyValues{
+			model.String("span.kind", "client"),
+			model.String("peer.service", "backend"),
+			model.Int64("peer.ipv4", 167772161),
+			model.Int64("peer.port", 8080),
+			model.Bool("error", true),
+		},
+		Logs: []model.Log{
+			{Timestamp: start, Fields: model.KeyValues{model.String("event", "retry")}},
+			{Timestamp: start, Fields: model.KeyValues{model.String("event", "error"), model.Int64("code", 500)}},
+		},
+		Process: model.NewProcess("frontend", []model.KeyValue{model.String("ip", "10.0.0.2")}),
+	}
+
+	assert.Equal(t, Span{
+		TraceID:   "00000000000000010000000000000002",
+		ID:        "0000000000000003",
+		ParentID:  "0000000000000004",
+		Name:      "get",
+		Kind:      "CLIENT",
+		Timestamp: 1532000000000000,
+		Duration:  10000,
+		Debug:     true,
+		LocalEndpoint: &Endpoint{
+			ServiceName: "frontend",
+			IPV4:        "10.0.0.2",
+		},
+		RemoteEndpoint: &Endpoint{
+			ServiceName: "backend",
+			IPV4:        "10.0.0.1",
+			Port:        8080,
+		},
+		Annotations: []Annotation{
+			{Timestamp: 1532000000000000, Value: "retry"},
+			{Timestamp: 1532000000000000, Value: `{"code":"500","event":"error"}`},
+		},
+		Tags: map[string]string{"error": "true"},
+	}, FromDomainSpan(span))
+}
+
+func TestFromDomainSpanMinimal(t *testing.T) {
+	span := &model.Span{
+		TraceID: model.TraceID{Low: 0xabc},
+		SpanID:  model.SpanID(1),
+		Tags: model.KeyValues{
+			model.Binary("peer.ipv6", []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}),
+		},
+	}
+	zSpan := FromDomainSpan(span)
+	assert.Equal(t, "0000000000000abc", zSpan.TraceID)
+	assert.Empty(t, zSpan.ParentID)
+	assert.Nil(t, zSpan.LocalEndpoint)
+	assert.Equal(t, &Endpoint{IPV6: "2001:db8::1"}, zSpan.RemoteEndpoint)
+
+	out, err := json.Marshal(FromDomain([]*model.Span{{TraceID: model.TraceID{Low: 1}, SpanID: model.SpanID(1), StartTime: time.Unix(0, 0)}}))
+	require.NoError(t, err)
+	assert.Equal(t, `[{"traceId":"0000000000000001","id":"0000000000000001"}]`, string(out))
+}
+
+func TestSetIP(t *testing.T) {
+	tests := []struct {
+		tag      model.KeyValue
+		expected Endpoint
+	}{
+		{tag: model.Int64("ip", 167772161), expected: Endpoint{IPV4: "10.0.0.1"}},
+		{tag: model.String("ip", "10.0.0.1"), expected: Endpoint{IPV4: "10.0.0.1"}},
+		{tag: model.String("ip", "::1"), expected: Endpoint{IPV6: "::1"}},
+		{tag: model.String("ip", "localhost"), expected: Endpoint{}},
+		{tag: model.Binary("ip", []byte{1, 2}), expected: Endpoint{}},
+	}
+	for _, test := range tests {
+		e := Endpoint{}
+		setIP(&e, test.tag)
+		assert.Equal(t, test.expected, e)
+	}
+}
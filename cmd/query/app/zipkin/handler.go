@@ -0,0 +1,240 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/adjuster"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+const (
+	traceIDParam         = "traceID"
+	serviceNameParam     = "serviceName"
+	spanNameParam        = "spanName"
+	annotationQueryParam = "annotationQuery"
+	minDurationParam     = "minDuration"
+	maxDurationParam     = "maxDuration"
+	endTsParam           = "endTs"
+	lookbackParam        = "lookback"
+	limitParam           = "limit"
+
+	defaultLookback = 24 * time.Hour
+	defaultLimit    = 10
+	// allSpanNames is the span name Zipkin clients send to not filter by span name
+	allSpanNames = "all"
+)
+
+var errServiceNameRequired = fmt.Errorf("parameter '%s' is required", serviceNameParam)
+
+// APIHandler serves a read-only subset of the Zipkin v2 API on top of a span reader,
+// so that Zipkin tooling can query the traces stored by Jaeger
+type APIHandler struct {
+	spanReader spanstore.Reader
+	adjuster   adjuster.Adjuster
+	logger     *zap.Logger
+	timeNow    func() time.Time
+}
+
+// NewAPIHandler returns a new APIHandler, which adjusts the traces with traceAdjuster before returning them,
+// like the Jaeger API handler does with its adjusters
+func NewAPIHandler(spanReader spanstore.Reader, traceAdjuster adjuster.Adjuster, logger *zap.Logger) *APIHandler {
+	return &APIHandler{
+		spanReader: spanReader,
+		adjuster:   traceAdjuster,
+		logger:     logger,
+		timeNow:    time.Now,
+	}
+}
+
+// RegisterRoutes registers the Zipkin v2 routes
+func (aH *APIHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc(fmt.Sprintf("/api/v2/trace/{%s}", traceIDParam), aH.getTrace).Methods(http.MethodGet)
+	router.HandleFunc("/api/v2/traces", aH.getTraces).Methods(http.MethodGet)
+	router.HandleFunc("/api/v2/services", aH.getServices).Methods(http.MethodGet)
+	router.HandleFunc("/api/v2/spans", aH.getSpanNames).Methods(http.MethodGet)
+}
+
+func (aH *APIHandler) getTrace(w http.ResponseWriter, r *http.Request) {
+	traceID, err := model.TraceIDFromString(mux.Vars(r)[traceIDParam])
+	if aH.handleError(w, err, http.StatusBadRequest) {
+		return
+	}
+	trace, err := aH.spanReader.GetTrace(traceID)
+	if err == spanstore.ErrTraceNotFound {
+		aH.handleError(w, fmt.Errorf("trace %s not found", traceID), http.StatusNotFound)
+		return
+	}
+	if aH.handleError(w, err, http.StatusInternalServerError) {
+		return
+	}
+	aH.writeJSON(w, FromDomain(aH.adjust(trace).Spans))
+}
+
+func (aH *APIHandler) getTraces(w http.ResponseWriter, r *http.Request) {
+	query, err := aH.parseTraceQuery(r)
+	if aH.handleError(w, err, http.StatusBadRequest) {
+		return
+	}
+	traces, err := aH.spanReader.FindTraces(query)
+	if aH.handleError(w, err, http.StatusInternalServerError) {
+		return
+	}
+	zTraces := make([][]Span, 0, len(traces))
+	for _, trace := range traces {
+		zTraces = append(zTraces, FromDomain(aH.adjust(trace).Spans))
+	}
+	aH.writeJSON(w, zTraces)
+}
+
+// adjust returns the adjusted trace. The Zipkin model cannot return the adjustment errors as warnings,
+// so they are only logged, and the trace is returned as adjusted until the error.
+func (aH *APIHandler) adjust(trace *model.Trace) *model.Trace {
+	adjusted, err := aH.adjuster.Adjust(trace)
+	if err != nil {
+		aH.logger.Warn("Failed to adjust trace", zap.Error(err))
+	}
+	if adjusted == nil {
+		return trace
+	}
+	return adjusted
+}
+
+func (aH *APIHandler) getServices(w http.ResponseWriter, r *http.Request) {
+	services, err := aH.spanReader.GetServices()
+	if aH.handleError(w, err, http.StatusInternalServerError) {
+		return
+	}
+	aH.writeJSON(w, sortedNames(services))
+}
+
+func (aH *APIHandler) getSpanNames(w http.ResponseWriter, r *http.Request) {
+	service := r.FormValue(serviceNameParam)
+	if service == "" {
+		aH.handleError(w, errServiceNameRequired, http.StatusBadRequest)
+		return
+	}
+	operations, err := aH.spanReader.GetOperations(service)
+	if aH.handleError(w, err, http.StatusInternalServerError) {
+		return
+	}
+	aH.writeJSON(w, sortedNames(operations))
+}
+
+// parseTraceQuery parses the parameters of /api/v2/traces, e.g.
+// ?serviceName=frontend&spanName=get&annotationQuery=http.method=GET and error&minDuration=1000&endTs=1532000000000&lookback=3600000&limit=10
+// The durations are in microseconds and the timestamps in milliseconds. Jaeger tags are key/value pairs,
+// so the annotation query only supports "key=value" terms. Unlike Zipkin, the span readers require the service name.
+func (aH *APIHandler) parseTraceQuery(r *http.Request) (*spanstore.TraceQueryParameters, error) {
+	service := r.FormValue(serviceNameParam)
+	if service == "" {
+		return nil, errServiceNameRequired
+	}
+	query := &spanstore.TraceQueryParameters{
+		ServiceName:  service,
+		NumTraces:    defaultLimit,
+		StartTimeMax: aH.timeNow(),
+	}
+	if spanName := r.FormValue(spanNameParam); spanName != allSpanNames {
+		query.OperationName = spanName
+	}
+	if annotationQuery := r.FormValue(annotationQueryParam); annotationQuery != "" {
+		query.Tags = make(map[string]string)
+		for _, term := range strings.Split(annotationQuery, " and ") {
+			kv := strings.SplitN(strings.TrimSpace(term), "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("unsupported annotation query term '%s', only key=value is supported", term)
+			}
+			query.Tags[kv[0]] = kv[1]
+		}
+	}
+	var err error
+	if query.DurationMin, err = parseMicroseconds(r, minDurationParam); err != nil {
+		return nil, err
+	}
+	if query.DurationMax, err = parseMicroseconds(r, maxDurationParam); err != nil {
+		return nil, err
+	}
+	if endTs := r.FormValue(endTsParam); endTs != "" {
+		millis, err := strconv.ParseInt(endTs, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse parameter '%s': %v", endTsParam, err)
+		}
+		query.StartTimeMax = time.Unix(0, millis*int64(time.Millisecond))
+	}
+	lookback := defaultLookback
+	if lookbackMillis := r.FormValue(lookbackParam); lookbackMillis != "" {
+		millis, err := strconv.ParseInt(lookbackMillis, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse parameter '%s': %v", lookbackParam, err)
+		}
+		lookback = time.Duration(millis) * time.Millisecond
+	}
+	query.StartTimeMin = query.StartTimeMax.Add(-lookback)
+	if limit := r.FormValue(limitParam); limit != "" {
+		if query.NumTraces, err = strconv.Atoi(limit); err != nil {
+			return nil, fmt.Errorf("cannot parse parameter '%s': %v", limitParam, err)
+		}
+	}
+	return query, nil
+}
+
+func parseMicroseconds(r *http.Request, param string) (time.Duration, error) {
+	value := r.FormValue(param)
+	if value == "" {
+		return 0, nil
+	}
+	micros, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse parameter '%s': %v", param, err)
+	}
+	return time.Duration(micros) * time.Microsecond, nil
+}
+
+func sortedNames(names []string) []string {
+	if names == nil {
+		names = []string{}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (aH *APIHandler) handleError(w http.ResponseWriter, err error, statusCode int) bool {
+	if err == nil {
+		return false
+	}
+	if statusCode == http.StatusInternalServerError {
+		aH.logger.Error("Zipkin HTTP handler, Internal Server Error", zap.Error(err))
+	}
+	http.Error(w, err.Error(), statusCode)
+	return true
+}
+
+func (aH *APIHandler) writeJSON(w http.ResponseWriter, response interface{}) {
+	resp, _ := json.Marshal(response)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/adjuster"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+	spanstoremocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
+)
+
+var (
+	mockTraceID = model.TraceID{Low: 0x123456}
+	mockTrace   = &model.Trace{
+		Spans: []*model.Span{
+			{
+				TraceID:       mockTraceID,
+				SpanID:        model.SpanID(1),
+				OperationName: "get",
+				StartTime:     time.Unix(1532000000, 0),
+				Duration:      time.Millisecond,
+				Process:       model.NewProcess("frontend", nil),
+			},
+		},
+	}
+	mockTraceJSON = `[{"traceId":"0000000000123456","id":"0000000000000001","name":"get","timestamp":1532000000000000,"duration":1000,"localEndpoint":{"serviceName":"frontend"}}]`
+)
+
+func initializeTestServer() (*httptest.Server, *spanstoremocks.Reader, *APIHandler) {
+	reader := &spanstoremocks.Reader{}
+	handler := NewAPIHandler(reader, adjuster.Sequence(), zap.NewNop())
+	handler.timeNow = func() time.Time {
+		return time.Unix(1532000000, 0)
+	}
+	r := mux.NewRouter()
+	handler.RegisterRoutes(r)
+	return httptest.NewServer(r), reader, handler
+}
+
+func get(t *testing.T, url string) (int, string) {
+	res, err := http.Get(url)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res.StatusCode, string(body)
+}
+
+func TestGetTrace(t *testing.T) {
+	server, reader, _ := initializeTestServer()
+	defer server.Close()
+	reader.On("GetTrace", mockTraceID).Return(mockTrace, nil)
+	reader.On("GetTrace", model.TraceID{Low: 1}).Return(nil, spanstore.ErrTraceNotFound)
+	reader.On("GetTrace", model.TraceID{Low: 2}).Return(nil, errors.New("storage down"))
+
+	code, body := get(t, server.URL+"/api/v2/trace/0000000000123456")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, mockTraceJSON, body)
+
+	code, body = get(t, server.URL+"/api/v2/trace/1")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "trace 1 not found\n", body)
+
+	code, _ = get(t, server.URL+"/api/v2/trace/2")
+	assert.Equal(t, http.StatusInternalServerError, code)
+
+	code, _ = get(t, server.URL+"/api/v2/trace/xyz")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestGetTraces(t *testing.T) {
+	server, reader, _ := initializeTestServer()
+	defer server.Close()
+	expectedQuery := &spanstore.TraceQueryParameters{
+		ServiceName:   "frontend",
+		OperationName: "get",
+		Tags:          map[string]string{"http.method": "GET", "error": "true"},
+		StartTimeMin:  time.Unix(1531996400, 0),
+		StartTimeMax:  time.Unix(1532000000, 0),
+		DurationMin:   time.Millisecond,
+		DurationMax:   time.Second,
+		NumTraces:     5,
+	}
+	reader.On("FindTraces", expectedQuery).Return([]*model.Trace{mockTrace}, nil)
+
+	code, body := get(t, server.URL+"/api/v2/traces?serviceName=frontend&spanName=get"+
+		"&annotationQuery=http.method%3DGET%20and%20error%3Dtrue&minDuration=1000&maxDuration=1000000"+
+		"&endTs=1532000000000&lookback=3600000&limit=5")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "["+mockTraceJSON+"]", body)
+}
+
+func TestAdjustTraces(t *testing.T) {
+	server, reader, handler := initializeTestServer()
+	defer server.Close()
+	reader.On("GetTrace", mockTraceID).Return(mockTrace, nil)
+	reader.On("FindTraces", mock.Anything).Return([]*model.Trace{mockTrace}, nil)
+	// the trace is returned as adjusted until the error
+	handler.adjuster = adjuster.Func(func(trace *model.Trace) (*model.Trace, error) {
+		span := *trace.Spans[0]
+		span.OperationName = "adjusted"
+		return &model.Trace{Spans: []*model.Span{&span}}, errors.New("clock skew")
+	})
+	adjustedJSON := strings.Replace(mockTraceJSON, `"name":"get"`, `"name":"adjusted"`, 1)
+
+	code, body := get(t, server.URL+"/api/v2/trace/0000000000123456")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, adjustedJSON, body)
+
+	code, body = get(t, server.URL+"/api/v2/traces?serviceName=frontend")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "["+adjustedJSON+"]", body)
+	assert.Equal(t, "get", mockTrace.Spans[0].OperationName)
+}
+
+func TestGetTracesDefaults(t *testing.T) {
+	server, reader, _ := initializeTestServer()
+	defer server.Close()
+	expectedQuery := &spanstore.TraceQueryParameters{
+		ServiceName:  "frontend",
+		StartTimeMin: time.Unix(1532000000, 0).Add(-defaultLookback),
+		StartTimeMax: time.Unix(1532000000, 0),
+		NumTraces:    defaultLimit,
+	}
+	reader.On("FindTraces", expectedQuery).Return(nil, nil)
+
+	code, body := get(t, server.URL+"/api/v2/traces?serviceName=frontend&spanName=all")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "[]", body)
+}
+
+func TestGetTracesErrors(t *testing.T) {
+	server, reader, _ := initializeTestServer()
+	defer server.Close()
+	reader.On("FindTraces", mock.Anything).Return(nil, errors.New("storage down"))
+
+	tests := []struct {
+		query string
+		code  int
+		body  string
+	}{
+		{query: "spanName=get", code: http.StatusBadRequest, body: "parameter 'serviceName' is required\n"},
+		{query: "serviceName=frontend&annotationQuery=error", code: http.StatusBadRequest, body: "unsupported annotation query term 'error', only key=value is supported\n"},
+		{query: "serviceName=frontend&minDuration=x", code: http.StatusBadRequest},
+		{query: "serviceName=frontend&maxDuration=x", code: http.StatusBadRequest},
+		{query: "serviceName=frontend&endTs=x", code: http.StatusBadRequest},
+		{query: "serviceName=frontend&lookback=x", code: http.StatusBadRequest},
+		{query: "serviceName=frontend&limit=x", code: http.StatusBadRequest},
+		{query: "serviceName=frontend", code: http.StatusInternalServerError, body: "storage down\n"},
+	}
+	for _, test := range tests {
+		code, body := get(t, server.URL+"/api/v2/traces?"+test.query)
+		assert.Equal(t, test.code, code, test.query)
+		if test.body != "" {
+			assert.Equal(t, test.body, body, test.query)
+		}
+	}
+}
+
+func TestGetServices(t *testing.T) {
+	server, reader, _ := initializeTestServer()
+	defer server.Close()
+	reader.On("GetServices").Return([]string{"frontend", "backend"}, nil).Once()
+	reader.On("GetServices").Return(nil, errors.New("storage down")).Once()
+
+	code, body := get(t, server.URL+"/api/v2/services")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `["backend","frontend"]`, body)
+
+	code, _ = get(t, server.URL+"/api/v2/services")
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestGetSpanNames(t *testing.T) {
+	server, reader, _ := initializeTestServer()
+	defer server.Close()
+	reader.On("GetOperations", "frontend").Return(nil, nil)
+	reader.On("GetOperations", "backend").Return(nil, errors.New("storage down"))
+
+	code, body := get(t, server.URL+"/api/v2/spans?serviceName=frontend")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `[]`, body)
+
+	code, _ = get(t, server.URL+"/api/v2/spans?serviceName=backend")
+	assert.Equal(t, http.StatusInternalServerError, code)
+
+	code, body = get(t, server.URL+"/api/v2/spans")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "parameter 'serviceName' is required\n", body)
+}
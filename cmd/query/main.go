@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -28,10 +29,14 @@ import (
 	"github.com/spf13/viper"
 	jaegerClientConfig "github.com/uber/jaeger-client-go/config"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/jaegertracing/jaeger/cmd/env"
 	"github.com/jaegertracing/jaeger/cmd/flags"
 	"github.com/jaegertracing/jaeger/cmd/query/app"
+	"github.com/jaegertracing/jaeger/cmd/query/app/zipkin"
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/adjuster"
 	"github.com/jaegertracing/jaeger/pkg/config"
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	pMetrics "github.com/jaegertracing/jaeger/pkg/metrics"
@@ -39,6 +44,7 @@ import (
 	"github.com/jaegertracing/jaeger/pkg/version"
 	"github.com/jaegertracing/jaeger/plugin/storage"
 	istorage "github.com/jaegertracing/jaeger/storage"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
 func main() {
@@ -96,6 +102,7 @@ func main() {
 			if err := storageFactory.Initialize(baseFactory, logger); err != nil {
 				logger.Fatal("Failed to init storage factory", zap.Error(err))
 			}
+			defer storageFactory.Close()
 			spanReader, err := storageFactory.CreateSpanReader()
 			if err != nil {
 				logger.Fatal("Failed to create span reader", zap.Error(err))
@@ -109,7 +116,12 @@ func main() {
 				app.HandlerOptions.Logger(logger),
 				app.HandlerOptions.Tracer(tracer),
 			}
-			apiHandlerOptions = append(apiHandlerOptions, archiveOptions(storageFactory, logger)...)
+			archiveReader, archiveWriter := archiveStorage(storageFactory, logger)
+			if archiveReader != nil {
+				apiHandlerOptions = append(apiHandlerOptions,
+					app.HandlerOptions.ArchiveSpanReader(archiveReader),
+					app.HandlerOptions.ArchiveSpanWriter(archiveWriter))
+			}
 			apiHandler := app.NewAPIHandler(
 				spanReader,
 				dependencyReader,
@@ -119,7 +131,16 @@ func main() {
 				r = r.PathPrefix(queryOpts.BasePath).Subrouter()
 			}
 			apiHandler.RegisterRoutes(r)
+			if queryOpts.ZipkinAPI {
+				zipkin.NewAPIHandler(spanReader, adjuster.Sequence(app.StandardAdjusters...), logger).RegisterRoutes(r)
+			}
 			app.RegisterStaticHandler(r, logger, queryOpts)
+			if queryOpts.AdminAPI {
+				if purger := createPurger(storageFactory, logger); purger != nil {
+					logger.Info("Registering admin API with the health check server", zap.String("route", app.AdminRoutePrefix))
+					hc.Handle(app.AdminRoutePrefix, app.NewAdminHandler(purger, logger))
+				}
+			}
 
 			if h := mBldr.Handler(); h != nil {
 				logger.Info("Registering metrics handler with HTTP server", zap.String("route", mBldr.HTTPRoute))
@@ -138,6 +159,28 @@ func main() {
 				hc.Set(healthcheck.Unavailable)
 			}()
 
+			if queryOpts.GRPCPort != 0 {
+				grpcHandler := app.NewGRPCHandler(spanReader, dependencyReader, app.GRPCHandlerOptions{
+					ArchiveSpanReader: archiveReader,
+					Logger:            logger,
+				})
+				grpcServer := grpc.NewServer()
+				model.RegisterQueryServiceV2Server(grpcServer, grpcHandler)
+				grpcPortStr := ":" + strconv.Itoa(queryOpts.GRPCPort)
+				lis, err := net.Listen("tcp", grpcPortStr)
+				if err != nil {
+					logger.Fatal("Failed to listen on gRPC port", zap.Error(err))
+				}
+				go func() {
+					logger.Info("Starting jaeger-query gRPC server", zap.Int("port", queryOpts.GRPCPort))
+					if err := grpcServer.Serve(lis); err != nil {
+						logger.Fatal("Could not launch gRPC service", zap.Error(err))
+					}
+					hc.Set(healthcheck.Unavailable)
+				}()
+				defer grpcServer.Stop()
+			}
+
 			hc.Ready()
 
 			select {
@@ -169,32 +212,47 @@ func main() {
 	}
 }
 
-func archiveOptions(storageFactory istorage.Factory, logger *zap.Logger) []app.HandlerOption {
+func archiveStorage(storageFactory istorage.Factory, logger *zap.Logger) (spanstore.Reader, spanstore.Writer) {
 	archiveFactory, ok := storageFactory.(istorage.ArchiveFactory)
 	if !ok {
 		logger.Info("Archive storage not supported by the factory")
-		return nil
+		return nil, nil
 	}
 	reader, err := archiveFactory.CreateArchiveSpanReader()
 	if err == istorage.ErrArchiveStorageNotConfigured || err == istorage.ErrArchiveStorageNotSupported {
 		logger.Info("Archive storage not created", zap.String("reason", err.Error()))
-		return nil
+		return nil, nil
 	}
 	if err != nil {
 		logger.Error("Cannot init archive storage reader", zap.Error(err))
-		return nil
+		return nil, nil
 	}
 	writer, err := archiveFactory.CreateArchiveSpanWriter()
 	if err == istorage.ErrArchiveStorageNotConfigured || err == istorage.ErrArchiveStorageNotSupported {
 		logger.Info("Archive storage not created", zap.String("reason", err.Error()))
-		return nil
+		return nil, nil
 	}
 	if err != nil {
 		logger.Error("Cannot init archive storage writer", zap.Error(err))
+		return nil, nil
+	}
+	return reader, writer
+}
+
+func createPurger(storageFactory istorage.Factory, logger *zap.Logger) spanstore.Purger {
+	purgerFactory, ok := storageFactory.(istorage.PurgerFactory)
+	if !ok {
+		logger.Info("Purging traces not supported by the factory")
 		return nil
 	}
-	return []app.HandlerOption{
-		app.HandlerOptions.ArchiveSpanReader(reader),
-		app.HandlerOptions.ArchiveSpanWriter(writer),
+	purger, err := purgerFactory.CreatePurger()
+	if err == istorage.ErrPurgerNotSupported {
+		logger.Info("Purger not created", zap.String("reason", err.Error()))
+		return nil
+	}
+	if err != nil {
+		logger.Error("Cannot init purger", zap.Error(err))
+		return nil
 	}
+	return purger
 }
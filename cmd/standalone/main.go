@@ -34,16 +34,21 @@ import (
 	"github.com/uber/tchannel-go"
 	"github.com/uber/tchannel-go/thrift"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	agentApp "github.com/jaegertracing/jaeger/cmd/agent/app"
 	basic "github.com/jaegertracing/jaeger/cmd/builder"
 	collectorApp "github.com/jaegertracing/jaeger/cmd/collector/app"
 	collector "github.com/jaegertracing/jaeger/cmd/collector/app/builder"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/zipkin"
 	"github.com/jaegertracing/jaeger/cmd/env"
 	"github.com/jaegertracing/jaeger/cmd/flags"
 	queryApp "github.com/jaegertracing/jaeger/cmd/query/app"
+	queryZipkin "github.com/jaegertracing/jaeger/cmd/query/app/zipkin"
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/adjuster"
 	"github.com/jaegertracing/jaeger/pkg/config"
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	pMetrics "github.com/jaegertracing/jaeger/pkg/metrics"
@@ -51,6 +56,7 @@ import (
 	"github.com/jaegertracing/jaeger/pkg/version"
 	ss "github.com/jaegertracing/jaeger/plugin/sampling/strategystore"
 	"github.com/jaegertracing/jaeger/plugin/storage"
+	istorage "github.com/jaegertracing/jaeger/storage"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 	jc "github.com/jaegertracing/jaeger/thrift-gen/jaeger"
@@ -118,25 +124,45 @@ func main() {
 			if err != nil {
 				logger.Fatal("Failed to create dependency reader", zap.Error(err))
 			}
-			samplingHandler := initializeSamplingHandler(strategyStoreFactory, v, metricsFactory, logger)
+			strategyStore, aggregator := initializeSampling(strategyStoreFactory, storageFactory, v, metricsFactory, logger)
 
 			aOpts := new(agentApp.Builder).InitFromViper(v)
 			cOpts := new(collector.CollectorOptions).InitFromViper(v)
+			if err := cOpts.Validate(); err != nil {
+				logger.Fatal("Invalid collector options", zap.Error(err))
+			}
 			qOpts := new(queryApp.QueryOptions).InitFromViper(v)
 
 			startAgent(aOpts, cOpts, logger, metricsFactory)
-			startCollector(cOpts, spanWriter, logger, metricsFactory, samplingHandler, hc)
-			startQuery(qOpts, spanReader, dependencyReader, logger, metricsFactory, mBldr, hc)
+			startCollector(cOpts, spanWriter, logger, metricsFactory, sampling.NewHandler(strategyStore), aggregator, hc)
+			queryGRPCServer := startQuery(qOpts, spanReader, dependencyReader, logger, metricsFactory, mBldr, hc)
+			if qOpts.AdminAPI {
+				startAdminAPI(storageFactory, logger, hc)
+			}
 			hc.Ready()
 
 			select {
 			case <-signalsChannel:
+				if aggregator != nil {
+					aggregator.Stop()
+				}
+				if closer, ok := strategyStore.(io.Closer); ok {
+					if err := closer.Close(); err != nil {
+						logger.Error("Failed to close sampling strategy store", zap.Error(err))
+					}
+				}
+				if queryGRPCServer != nil {
+					queryGRPCServer.Stop()
+				}
 				if closer, ok := spanWriter.(io.Closer); ok {
 					err := closer.Close()
 					if err != nil {
 						logger.Error("Failed to close span writer", zap.Error(err))
 					}
 				}
+				if err := storageFactory.Close(); err != nil {
+					logger.Error("Failed to close storage factory", zap.Error(err))
+				}
 
 				logger.Info("Jaeger Standalone is finishing")
 			}
@@ -174,12 +200,7 @@ func startAgent(
 	logger *zap.Logger,
 	baseFactory metrics.Factory,
 ) {
-	metricsFactory := baseFactory.Namespace("agent", nil)
-
-	if len(b.CollectorHostPorts) == 0 {
-		b.CollectorHostPorts = append(b.CollectorHostPorts, fmt.Sprintf("127.0.0.1:%d", cOpts.CollectorPort))
-	}
-	agent, err := b.WithMetricsFactory(metricsFactory).CreateAgent(logger)
+	agent, err := createAgent(b, cOpts, logger, baseFactory)
 	if err != nil {
 		logger.Fatal("Unable to initialize Jaeger Agent", zap.Error(err))
 	}
@@ -190,12 +211,31 @@ func startAgent(
 	}
 }
 
+// createAgent creates the agent, connected to the local collector unless other collectors are configured
+func createAgent(
+	b *agentApp.Builder,
+	cOpts *collector.CollectorOptions,
+	logger *zap.Logger,
+	baseFactory metrics.Factory,
+) (*agentApp.Agent, error) {
+	metricsFactory := baseFactory.Namespace("agent", nil)
+
+	if len(b.CollectorHostPorts) == 0 {
+		b.CollectorHostPorts = append(b.CollectorHostPorts, fmt.Sprintf("127.0.0.1:%d", cOpts.CollectorPort))
+	}
+	if len(b.GRPC.CollectorHostPorts) == 0 && cOpts.CollectorGRPCPort != 0 {
+		b.GRPC.CollectorHostPorts = append(b.GRPC.CollectorHostPorts, fmt.Sprintf("127.0.0.1:%d", cOpts.CollectorGRPCPort))
+	}
+	return b.WithMetricsFactory(metricsFactory).CreateAgent(logger)
+}
+
 func startCollector(
 	cOpts *collector.CollectorOptions,
 	spanWriter spanstore.Writer,
 	logger *zap.Logger,
 	baseFactory metrics.Factory,
 	samplingHandler sampling.Handler,
+	aggregator strategystore.Aggregator,
 	hc *healthcheck.HealthCheck,
 ) {
 	metricsFactory := baseFactory.Namespace("collector", nil)
@@ -209,12 +249,13 @@ func startCollector(
 	if err != nil {
 		logger.Fatal("Unable to set up builder", zap.Error(err))
 	}
+	spanBuilder.WithAggregator(aggregator)
 	ch, err := tchannel.NewChannel("jaeger-collector", &tchannel.ChannelOptions{})
 	if err != nil {
 		logger.Fatal("Unable to create new TChannel", zap.Error(err))
 	}
 	server := thrift.NewServer(ch)
-	zipkinSpansHandler, jaegerBatchesHandler := spanBuilder.BuildHandlers()
+	zipkinSpansHandler, jaegerBatchesHandler, grpcHandler := spanBuilder.BuildHandlers()
 	server.Register(jc.NewTChanCollectorServer(jaegerBatchesHandler))
 	server.Register(zc.NewTChanZipkinCollectorServer(zipkinSpansHandler))
 	server.Register(sc.NewTChanSamplingManagerServer(samplingHandler))
@@ -226,9 +267,28 @@ func startCollector(
 	ch.Serve(listener)
 	logger.Info("Starting jaeger-collector TChannel server", zap.Int("port", cOpts.CollectorPort))
 
+	if cOpts.CollectorGRPCPort != 0 {
+		grpcServer := grpc.NewServer()
+		model.RegisterCollectorServiceV2Server(grpcServer, grpcHandler)
+		grpcListener, err := net.Listen("tcp", ":"+strconv.Itoa(cOpts.CollectorGRPCPort))
+		if err != nil {
+			logger.Fatal("Unable to start listening on collector gRPC port", zap.Error(err))
+		}
+		logger.Info("Starting jaeger-collector gRPC server", zap.Int("grpc-port", cOpts.CollectorGRPCPort))
+		go func() {
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				logger.Fatal("Could not launch jaeger-collector gRPC server", zap.Error(err))
+			}
+			hc.Set(healthcheck.Unavailable)
+		}()
+	}
+
 	r := mux.NewRouter()
 	apiHandler := collectorApp.NewAPIHandler(jaegerBatchesHandler)
 	apiHandler.RegisterRoutes(r)
+	if err := grpcHandler.RegisterGatewayRoutes(r); err != nil {
+		logger.Fatal("Unable to register gRPC gateway routes", zap.Error(err))
+	}
 	httpPortStr := ":" + strconv.Itoa(cOpts.CollectorHTTPPort)
 	recoveryHandler := recoveryhandler.NewRecoveryHandler(logger, true)
 
@@ -262,6 +322,21 @@ func startZipkinHTTPAPI(
 	}
 }
 
+// startAdminAPI serves the admin API on the health check port, unless the span storage cannot delete traces
+func startAdminAPI(storageFactory *storage.Factory, logger *zap.Logger, hc *healthcheck.HealthCheck) {
+	purger, err := storageFactory.CreatePurger()
+	if err == istorage.ErrPurgerNotSupported {
+		logger.Info("Purger not created", zap.String("reason", err.Error()))
+		return
+	}
+	if err != nil {
+		logger.Error("Cannot init purger", zap.Error(err))
+		return
+	}
+	logger.Info("Registering admin API with the health check server", zap.String("route", queryApp.AdminRoutePrefix))
+	hc.Handle(queryApp.AdminRoutePrefix, queryApp.NewAdminHandler(purger, logger))
+}
+
 func startQuery(
 	qOpts *queryApp.QueryOptions,
 	spanReader spanstore.Reader,
@@ -270,7 +345,7 @@ func startQuery(
 	baseFactory metrics.Factory,
 	metricsBuilder *pMetrics.Builder,
 	hc *healthcheck.HealthCheck,
-) {
+) *grpc.Server {
 	tracer, closer, err := jaegerClientConfig.Configuration{
 		Sampler: &jaegerClientConfig.SamplerConfig{
 			Type:  "const",
@@ -281,17 +356,23 @@ func startQuery(
 	if err != nil {
 		logger.Fatal("Failed to initialize tracer", zap.Error(err))
 	}
+	apiHandlerOptions := []queryApp.HandlerOption{
+		queryApp.HandlerOptions.Logger(logger),
+		queryApp.HandlerOptions.Tracer(tracer),
+	}
 	apiHandler := queryApp.NewAPIHandler(
 		spanReader,
 		depReader,
-		queryApp.HandlerOptions.Logger(logger),
-		queryApp.HandlerOptions.Tracer(tracer))
+		apiHandlerOptions...)
 
 	r := mux.NewRouter()
 	if qOpts.BasePath != "/" {
 		r = r.PathPrefix(qOpts.BasePath).Subrouter()
 	}
 	apiHandler.RegisterRoutes(r)
+	if qOpts.ZipkinAPI {
+		queryZipkin.NewAPIHandler(spanReader, adjuster.Sequence(queryApp.StandardAdjusters...), logger).RegisterRoutes(r)
+	}
 	queryApp.RegisterStaticHandler(r, logger, qOpts)
 
 	if h := metricsBuilder.Handler(); h != nil {
@@ -309,21 +390,51 @@ func startQuery(
 		}
 		hc.Set(healthcheck.Unavailable)
 	}()
+
+	if qOpts.GRPCPort == 0 {
+		return nil
+	}
+	grpcServer := grpc.NewServer()
+	model.RegisterQueryServiceV2Server(grpcServer, queryApp.NewGRPCHandler(
+		spanReader,
+		depReader,
+		queryApp.GRPCHandlerOptions{Logger: logger}))
+	grpcPortStr := ":" + strconv.Itoa(qOpts.GRPCPort)
+	lis, err := net.Listen("tcp", grpcPortStr)
+	if err != nil {
+		logger.Fatal("Failed to listen on jaeger-query gRPC port", zap.Error(err))
+	}
+	logger.Info("Starting jaeger-query gRPC server", zap.Int("port", qOpts.GRPCPort))
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			logger.Fatal("Could not launch jaeger-query gRPC service", zap.Error(err))
+		}
+		hc.Set(healthcheck.Unavailable)
+	}()
+	return grpcServer
 }
 
-func initializeSamplingHandler(
+func initializeSampling(
 	samplingStrategyStoreFactory *ss.Factory,
+	storageFactory *storage.Factory,
 	v *viper.Viper,
 	metricsFactory metrics.Factory,
 	logger *zap.Logger,
-) sampling.Handler {
+) (strategystore.StrategyStore, strategystore.Aggregator) {
 	samplingStrategyStoreFactory.InitFromViper(v)
-	if err := samplingStrategyStoreFactory.Initialize(metricsFactory, logger); err != nil {
+	if err := samplingStrategyStoreFactory.Initialize(metricsFactory, storageFactory, logger); err != nil {
 		logger.Fatal("Failed to init sampling strategy store factory", zap.Error(err))
 	}
 	strategyStore, err := samplingStrategyStoreFactory.CreateStrategyStore()
 	if err != nil {
 		logger.Fatal("Failed to create sampling strategy store", zap.Error(err))
 	}
-	return sampling.NewHandler(strategyStore)
+	aggregator, err := samplingStrategyStoreFactory.CreateAggregator()
+	if err != nil {
+		logger.Fatal("Failed to create sampling aggregator", zap.Error(err))
+	}
+	if aggregator != nil {
+		aggregator.Start()
+	}
+	return strategyStore, aggregator
 }
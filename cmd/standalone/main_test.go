@@ -0,0 +1,48 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	agentApp "github.com/jaegertracing/jaeger/cmd/agent/app"
+	collector "github.com/jaegertracing/jaeger/cmd/collector/app/builder"
+)
+
+func TestCreateAgentWithGRPCReporter(t *testing.T) {
+	b := &agentApp.Builder{ReporterType: "grpc"}
+	cOpts := &collector.CollectorOptions{CollectorPort: 14267, CollectorGRPCPort: 14250}
+	agent, err := createAgent(b, cOpts, zap.NewNop(), metrics.NullFactory)
+	require.NoError(t, err)
+	assert.NotNil(t, agent)
+	assert.Equal(t, []string{"127.0.0.1:14267"}, b.CollectorHostPorts)
+	assert.Equal(t, []string{"127.0.0.1:14250"}, b.GRPC.CollectorHostPorts)
+}
+
+func TestCreateAgentKeepsConfiguredCollectors(t *testing.T) {
+	b := &agentApp.Builder{ReporterType: "grpc"}
+	b.CollectorHostPorts = []string{"collector:14267"}
+	b.GRPC.CollectorHostPorts = []string{"collector:14250"}
+	cOpts := &collector.CollectorOptions{CollectorPort: 14267, CollectorGRPCPort: 14250}
+	_, err := createAgent(b, cOpts, zap.NewNop(), metrics.NullFactory)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"collector:14267"}, b.CollectorHostPorts)
+	assert.Equal(t, []string{"collector:14250"}, b.GRPC.CollectorHostPorts)
+}
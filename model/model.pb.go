@@ -1,48 +1,28 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: model.proto
 
-/*
-	Package model is a generated protocol buffer package.
-
-	It is generated from these files:
-		model.proto
-
-	It has these top-level messages:
-		KeyValue
-		Log
-		SpanRef
-		Process
-		Span
-		Trace
-		Batch
-		PostSpansRequest
-		PostSpansResponse
-		GetTraceRequest
-		GetTraceResponse
-*/
 package model
 
-import proto "github.com/gogo/protobuf/proto"
-import golang_proto "github.com/golang/protobuf/proto"
-import fmt "fmt"
-import math "math"
-import _ "github.com/gogo/protobuf/types"
-import _ "github.com/gogo/protobuf/types"
-import _ "github.com/gogo/googleapis/google/api"
-import _ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
-import _ "github.com/gogo/protobuf/gogoproto"
-
-import time "time"
-
-import bytes "bytes"
-
-import context "golang.org/x/net/context"
-import grpc "google.golang.org/grpc"
-
-import binary "encoding/binary"
-import types "github.com/gogo/protobuf/types"
-
-import io "io"
+import (
+	bytes "bytes"
+	context "context"
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	_ "github.com/gogo/googleapis/google/api"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	types "github.com/gogo/protobuf/types"
+	golang_proto "github.com/golang/protobuf/proto"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -55,7 +35,7 @@ var _ = time.Kitchen
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type ValueType int32
 
@@ -74,6 +54,7 @@ var ValueType_name = map[int32]string{
 	3: "FLOAT64",
 	4: "BINARY",
 }
+
 var ValueType_value = map[string]int32{
 	"STRING":  0,
 	"BOOL":    1,
@@ -85,7 +66,10 @@ var ValueType_value = map[string]int32{
 func (x ValueType) String() string {
 	return proto.EnumName(ValueType_name, int32(x))
 }
-func (ValueType) EnumDescriptor() ([]byte, []int) { return fileDescriptorModel, []int{0} }
+
+func (ValueType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{0}
+}
 
 type SpanRefType int32
 
@@ -98,6 +82,7 @@ var SpanRefType_name = map[int32]string{
 	0: "CHILD_OF",
 	1: "FOLLOWS_FROM",
 }
+
 var SpanRefType_value = map[string]int32{
 	"CHILD_OF":     0,
 	"FOLLOWS_FROM": 1,
@@ -106,7 +91,10 @@ var SpanRefType_value = map[string]int32{
 func (x SpanRefType) String() string {
 	return proto.EnumName(SpanRefType_name, int32(x))
 }
-func (SpanRefType) EnumDescriptor() ([]byte, []int) { return fileDescriptorModel, []int{1} }
+
+func (SpanRefType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{1}
+}
 
 type KeyValue struct {
 	Key      string    `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -118,10 +106,38 @@ type KeyValue struct {
 	VBinary  []byte    `protobuf:"bytes,7,opt,name=v_binary,json=vBinary,proto3" json:"v_binary,omitempty"`
 }
 
-func (m *KeyValue) Reset()                    { *m = KeyValue{} }
-func (m *KeyValue) String() string            { return proto.CompactTextString(m) }
-func (*KeyValue) ProtoMessage()               {}
-func (*KeyValue) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{0} }
+func (m *KeyValue) Reset()         { *m = KeyValue{} }
+func (m *KeyValue) String() string { return proto.CompactTextString(m) }
+func (*KeyValue) ProtoMessage()    {}
+func (*KeyValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{0}
+}
+func (m *KeyValue) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeyValue.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValue.Merge(m, src)
+}
+func (m *KeyValue) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyValue proto.InternalMessageInfo
 
 func (m *KeyValue) GetKey() string {
 	if m != nil {
@@ -173,14 +189,42 @@ func (m *KeyValue) GetVBinary() []byte {
 }
 
 type Log struct {
-	Timestamp time.Time  `protobuf:"bytes,1,opt,name=timestamp,stdtime" json:"timestamp"`
-	Fields    []KeyValue `protobuf:"bytes,2,rep,name=fields" json:"fields"`
+	Timestamp time.Time  `protobuf:"bytes,1,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Fields    []KeyValue `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields"`
+}
+
+func (m *Log) Reset()         { *m = Log{} }
+func (m *Log) String() string { return proto.CompactTextString(m) }
+func (*Log) ProtoMessage()    {}
+func (*Log) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{1}
+}
+func (m *Log) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Log) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Log.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Log) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Log.Merge(m, src)
+}
+func (m *Log) XXX_Size() int {
+	return m.Size()
+}
+func (m *Log) XXX_DiscardUnknown() {
+	xxx_messageInfo_Log.DiscardUnknown(m)
 }
 
-func (m *Log) Reset()                    { *m = Log{} }
-func (m *Log) String() string            { return proto.CompactTextString(m) }
-func (*Log) ProtoMessage()               {}
-func (*Log) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{1} }
+var xxx_messageInfo_Log proto.InternalMessageInfo
 
 func (m *Log) GetTimestamp() time.Time {
 	if m != nil {
@@ -202,10 +246,38 @@ type SpanRef struct {
 	RefType SpanRefType `protobuf:"varint,3,opt,name=ref_type,json=refType,proto3,enum=jaeger.model.SpanRefType" json:"ref_type,omitempty"`
 }
 
-func (m *SpanRef) Reset()                    { *m = SpanRef{} }
-func (m *SpanRef) String() string            { return proto.CompactTextString(m) }
-func (*SpanRef) ProtoMessage()               {}
-func (*SpanRef) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{2} }
+func (m *SpanRef) Reset()         { *m = SpanRef{} }
+func (m *SpanRef) String() string { return proto.CompactTextString(m) }
+func (*SpanRef) ProtoMessage()    {}
+func (*SpanRef) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{2}
+}
+func (m *SpanRef) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SpanRef) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SpanRef.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SpanRef) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpanRef.Merge(m, src)
+}
+func (m *SpanRef) XXX_Size() int {
+	return m.Size()
+}
+func (m *SpanRef) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpanRef.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpanRef proto.InternalMessageInfo
 
 func (m *SpanRef) GetRefType() SpanRefType {
 	if m != nil {
@@ -216,13 +288,41 @@ func (m *SpanRef) GetRefType() SpanRefType {
 
 type Process struct {
 	ServiceName string     `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
-	Tags        []KeyValue `protobuf:"bytes,2,rep,name=tags" json:"tags"`
+	Tags        []KeyValue `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags"`
+}
+
+func (m *Process) Reset()         { *m = Process{} }
+func (m *Process) String() string { return proto.CompactTextString(m) }
+func (*Process) ProtoMessage()    {}
+func (*Process) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{3}
+}
+func (m *Process) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Process) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Process.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Process) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Process.Merge(m, src)
+}
+func (m *Process) XXX_Size() int {
+	return m.Size()
+}
+func (m *Process) XXX_DiscardUnknown() {
+	xxx_messageInfo_Process.DiscardUnknown(m)
 }
 
-func (m *Process) Reset()                    { *m = Process{} }
-func (m *Process) String() string            { return proto.CompactTextString(m) }
-func (*Process) ProtoMessage()               {}
-func (*Process) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{3} }
+var xxx_messageInfo_Process proto.InternalMessageInfo
 
 func (m *Process) GetServiceName() string {
 	if m != nil {
@@ -242,21 +342,49 @@ type Span struct {
 	TraceID       TraceID       `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3,customtype=TraceID" json:"trace_id"`
 	SpanID        SpanID        `protobuf:"bytes,2,opt,name=span_id,json=spanId,proto3,customtype=SpanID" json:"span_id"`
 	OperationName string        `protobuf:"bytes,3,opt,name=operation_name,json=operationName,proto3" json:"operation_name,omitempty"`
-	References    []SpanRef     `protobuf:"bytes,4,rep,name=references" json:"references"`
+	References    []SpanRef     `protobuf:"bytes,4,rep,name=references,proto3" json:"references"`
 	Flags         Flags         `protobuf:"varint,5,opt,name=flags,proto3,customtype=Flags" json:"flags"`
-	StartTime     time.Time     `protobuf:"bytes,6,opt,name=start_time,json=startTime,stdtime" json:"start_time"`
-	Duration      time.Duration `protobuf:"bytes,7,opt,name=duration,stdduration" json:"duration"`
-	Tags          []KeyValue    `protobuf:"bytes,8,rep,name=tags" json:"tags"`
-	Logs          []Log         `protobuf:"bytes,9,rep,name=logs" json:"logs"`
-	Process       *Process      `protobuf:"bytes,10,opt,name=process" json:"process,omitempty"`
+	StartTime     time.Time     `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time"`
+	Duration      time.Duration `protobuf:"bytes,7,opt,name=duration,proto3,stdduration" json:"duration"`
+	Tags          []KeyValue    `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags"`
+	Logs          []Log         `protobuf:"bytes,9,rep,name=logs,proto3" json:"logs"`
+	Process       *Process      `protobuf:"bytes,10,opt,name=process,proto3" json:"process,omitempty"`
 	ProcessID     string        `protobuf:"bytes,11,opt,name=process_id,json=processId,proto3" json:"process_id,omitempty"`
-	Warnings      []string      `protobuf:"bytes,12,rep,name=warnings" json:"warnings,omitempty"`
+	Warnings      []string      `protobuf:"bytes,12,rep,name=warnings,proto3" json:"warnings,omitempty"`
+}
+
+func (m *Span) Reset()         { *m = Span{} }
+func (m *Span) String() string { return proto.CompactTextString(m) }
+func (*Span) ProtoMessage()    {}
+func (*Span) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{4}
+}
+func (m *Span) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Span) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Span.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Span) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Span.Merge(m, src)
+}
+func (m *Span) XXX_Size() int {
+	return m.Size()
+}
+func (m *Span) XXX_DiscardUnknown() {
+	xxx_messageInfo_Span.DiscardUnknown(m)
 }
 
-func (m *Span) Reset()                    { *m = Span{} }
-func (m *Span) String() string            { return proto.CompactTextString(m) }
-func (*Span) ProtoMessage()               {}
-func (*Span) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{4} }
+var xxx_messageInfo_Span proto.InternalMessageInfo
 
 func (m *Span) GetOperationName() string {
 	if m != nil {
@@ -322,15 +450,43 @@ func (m *Span) GetWarnings() []string {
 }
 
 type Trace struct {
-	Spans      []*Span                `protobuf:"bytes,1,rep,name=spans" json:"spans,omitempty"`
-	ProcessMap []Trace_ProcessMapping `protobuf:"bytes,2,rep,name=process_map,json=processMap" json:"process_map"`
-	Warnings   []string               `protobuf:"bytes,3,rep,name=warnings" json:"warnings,omitempty"`
+	Spans      []*Span                `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`
+	ProcessMap []Trace_ProcessMapping `protobuf:"bytes,2,rep,name=process_map,json=processMap,proto3" json:"process_map"`
+	Warnings   []string               `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+}
+
+func (m *Trace) Reset()         { *m = Trace{} }
+func (m *Trace) String() string { return proto.CompactTextString(m) }
+func (*Trace) ProtoMessage()    {}
+func (*Trace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{5}
+}
+func (m *Trace) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Trace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Trace.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Trace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Trace.Merge(m, src)
+}
+func (m *Trace) XXX_Size() int {
+	return m.Size()
+}
+func (m *Trace) XXX_DiscardUnknown() {
+	xxx_messageInfo_Trace.DiscardUnknown(m)
 }
 
-func (m *Trace) Reset()                    { *m = Trace{} }
-func (m *Trace) String() string            { return proto.CompactTextString(m) }
-func (*Trace) ProtoMessage()               {}
-func (*Trace) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{5} }
+var xxx_messageInfo_Trace proto.InternalMessageInfo
 
 func (m *Trace) GetSpans() []*Span {
 	if m != nil {
@@ -355,13 +511,41 @@ func (m *Trace) GetWarnings() []string {
 
 type Trace_ProcessMapping struct {
 	ProcessID string  `protobuf:"bytes,1,opt,name=process_id,json=processId,proto3" json:"process_id,omitempty"`
-	Process   Process `protobuf:"bytes,2,opt,name=process" json:"process"`
+	Process   Process `protobuf:"bytes,2,opt,name=process,proto3" json:"process"`
+}
+
+func (m *Trace_ProcessMapping) Reset()         { *m = Trace_ProcessMapping{} }
+func (m *Trace_ProcessMapping) String() string { return proto.CompactTextString(m) }
+func (*Trace_ProcessMapping) ProtoMessage()    {}
+func (*Trace_ProcessMapping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{5, 0}
+}
+func (m *Trace_ProcessMapping) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Trace_ProcessMapping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Trace_ProcessMapping.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Trace_ProcessMapping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Trace_ProcessMapping.Merge(m, src)
+}
+func (m *Trace_ProcessMapping) XXX_Size() int {
+	return m.Size()
+}
+func (m *Trace_ProcessMapping) XXX_DiscardUnknown() {
+	xxx_messageInfo_Trace_ProcessMapping.DiscardUnknown(m)
 }
 
-func (m *Trace_ProcessMapping) Reset()                    { *m = Trace_ProcessMapping{} }
-func (m *Trace_ProcessMapping) String() string            { return proto.CompactTextString(m) }
-func (*Trace_ProcessMapping) ProtoMessage()               {}
-func (*Trace_ProcessMapping) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{5, 0} }
+var xxx_messageInfo_Trace_ProcessMapping proto.InternalMessageInfo
 
 func (m *Trace_ProcessMapping) GetProcessID() string {
 	if m != nil {
@@ -378,14 +562,42 @@ func (m *Trace_ProcessMapping) GetProcess() Process {
 }
 
 type Batch struct {
-	Spans   []*Span `protobuf:"bytes,1,rep,name=spans" json:"spans,omitempty"`
-	Process Process `protobuf:"bytes,2,opt,name=process" json:"process"`
+	Spans   []*Span `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`
+	Process Process `protobuf:"bytes,2,opt,name=process,proto3" json:"process"`
+}
+
+func (m *Batch) Reset()         { *m = Batch{} }
+func (m *Batch) String() string { return proto.CompactTextString(m) }
+func (*Batch) ProtoMessage()    {}
+func (*Batch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{6}
+}
+func (m *Batch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Batch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Batch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Batch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Batch.Merge(m, src)
+}
+func (m *Batch) XXX_Size() int {
+	return m.Size()
+}
+func (m *Batch) XXX_DiscardUnknown() {
+	xxx_messageInfo_Batch.DiscardUnknown(m)
 }
 
-func (m *Batch) Reset()                    { *m = Batch{} }
-func (m *Batch) String() string            { return proto.CompactTextString(m) }
-func (*Batch) ProtoMessage()               {}
-func (*Batch) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{6} }
+var xxx_messageInfo_Batch proto.InternalMessageInfo
 
 func (m *Batch) GetSpans() []*Span {
 	if m != nil {
@@ -402,13 +614,41 @@ func (m *Batch) GetProcess() Process {
 }
 
 type PostSpansRequest struct {
-	Batch *Batch `protobuf:"bytes,1,opt,name=batch" json:"batch,omitempty"`
+	Batch *Batch `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+}
+
+func (m *PostSpansRequest) Reset()         { *m = PostSpansRequest{} }
+func (m *PostSpansRequest) String() string { return proto.CompactTextString(m) }
+func (*PostSpansRequest) ProtoMessage()    {}
+func (*PostSpansRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{7}
+}
+func (m *PostSpansRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PostSpansRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PostSpansRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PostSpansRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostSpansRequest.Merge(m, src)
+}
+func (m *PostSpansRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PostSpansRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostSpansRequest.DiscardUnknown(m)
 }
 
-func (m *PostSpansRequest) Reset()                    { *m = PostSpansRequest{} }
-func (m *PostSpansRequest) String() string            { return proto.CompactTextString(m) }
-func (*PostSpansRequest) ProtoMessage()               {}
-func (*PostSpansRequest) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{7} }
+var xxx_messageInfo_PostSpansRequest proto.InternalMessageInfo
 
 func (m *PostSpansRequest) GetBatch() *Batch {
 	if m != nil {
@@ -421,10 +661,38 @@ type PostSpansResponse struct {
 	Ok bool `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
 }
 
-func (m *PostSpansResponse) Reset()                    { *m = PostSpansResponse{} }
-func (m *PostSpansResponse) String() string            { return proto.CompactTextString(m) }
-func (*PostSpansResponse) ProtoMessage()               {}
-func (*PostSpansResponse) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{8} }
+func (m *PostSpansResponse) Reset()         { *m = PostSpansResponse{} }
+func (m *PostSpansResponse) String() string { return proto.CompactTextString(m) }
+func (*PostSpansResponse) ProtoMessage()    {}
+func (*PostSpansResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{8}
+}
+func (m *PostSpansResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PostSpansResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PostSpansResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PostSpansResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostSpansResponse.Merge(m, src)
+}
+func (m *PostSpansResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PostSpansResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostSpansResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PostSpansResponse proto.InternalMessageInfo
 
 func (m *PostSpansResponse) GetOk() bool {
 	if m != nil {
@@ -433,14 +701,102 @@ func (m *PostSpansResponse) GetOk() bool {
 	return false
 }
 
+type DependencyLink struct {
+	Parent    string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent"`
+	Child     string `protobuf:"bytes,2,opt,name=child,proto3" json:"child"`
+	CallCount uint64 `protobuf:"varint,3,opt,name=call_count,json=callCount,proto3" json:"callCount"`
+}
+
+func (m *DependencyLink) Reset()         { *m = DependencyLink{} }
+func (m *DependencyLink) String() string { return proto.CompactTextString(m) }
+func (*DependencyLink) ProtoMessage()    {}
+func (*DependencyLink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{9}
+}
+func (m *DependencyLink) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DependencyLink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DependencyLink.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DependencyLink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DependencyLink.Merge(m, src)
+}
+func (m *DependencyLink) XXX_Size() int {
+	return m.Size()
+}
+func (m *DependencyLink) XXX_DiscardUnknown() {
+	xxx_messageInfo_DependencyLink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DependencyLink proto.InternalMessageInfo
+
+func (m *DependencyLink) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *DependencyLink) GetChild() string {
+	if m != nil {
+		return m.Child
+	}
+	return ""
+}
+
+func (m *DependencyLink) GetCallCount() uint64 {
+	if m != nil {
+		return m.CallCount
+	}
+	return 0
+}
+
 type GetTraceRequest struct {
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (m *GetTraceRequest) Reset()                    { *m = GetTraceRequest{} }
-func (m *GetTraceRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetTraceRequest) ProtoMessage()               {}
-func (*GetTraceRequest) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{9} }
+func (m *GetTraceRequest) Reset()         { *m = GetTraceRequest{} }
+func (m *GetTraceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTraceRequest) ProtoMessage()    {}
+func (*GetTraceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{10}
+}
+func (m *GetTraceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetTraceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetTraceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetTraceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTraceRequest.Merge(m, src)
+}
+func (m *GetTraceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetTraceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTraceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTraceRequest proto.InternalMessageInfo
 
 func (m *GetTraceRequest) GetId() string {
 	if m != nil {
@@ -450,13 +806,41 @@ func (m *GetTraceRequest) GetId() string {
 }
 
 type GetTraceResponse struct {
-	Trace *Trace `protobuf:"bytes,1,opt,name=trace" json:"trace,omitempty"`
+	Trace *Trace `protobuf:"bytes,1,opt,name=trace,proto3" json:"trace,omitempty"`
+}
+
+func (m *GetTraceResponse) Reset()         { *m = GetTraceResponse{} }
+func (m *GetTraceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTraceResponse) ProtoMessage()    {}
+func (*GetTraceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{11}
+}
+func (m *GetTraceResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetTraceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetTraceResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetTraceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTraceResponse.Merge(m, src)
+}
+func (m *GetTraceResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetTraceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTraceResponse.DiscardUnknown(m)
 }
 
-func (m *GetTraceResponse) Reset()                    { *m = GetTraceResponse{} }
-func (m *GetTraceResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetTraceResponse) ProtoMessage()               {}
-func (*GetTraceResponse) Descriptor() ([]byte, []int) { return fileDescriptorModel, []int{10} }
+var xxx_messageInfo_GetTraceResponse proto.InternalMessageInfo
 
 func (m *GetTraceResponse) GetTrace() *Trace {
 	if m != nil {
@@ -465,82 +849,670 @@ func (m *GetTraceResponse) GetTrace() *Trace {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*KeyValue)(nil), "jaeger.model.KeyValue")
-	golang_proto.RegisterType((*KeyValue)(nil), "jaeger.model.KeyValue")
-	proto.RegisterType((*Log)(nil), "jaeger.model.Log")
-	golang_proto.RegisterType((*Log)(nil), "jaeger.model.Log")
-	proto.RegisterType((*SpanRef)(nil), "jaeger.model.SpanRef")
-	golang_proto.RegisterType((*SpanRef)(nil), "jaeger.model.SpanRef")
-	proto.RegisterType((*Process)(nil), "jaeger.model.Process")
-	golang_proto.RegisterType((*Process)(nil), "jaeger.model.Process")
-	proto.RegisterType((*Span)(nil), "jaeger.model.Span")
-	golang_proto.RegisterType((*Span)(nil), "jaeger.model.Span")
-	proto.RegisterType((*Trace)(nil), "jaeger.model.Trace")
-	golang_proto.RegisterType((*Trace)(nil), "jaeger.model.Trace")
-	proto.RegisterType((*Trace_ProcessMapping)(nil), "jaeger.model.Trace.ProcessMapping")
-	golang_proto.RegisterType((*Trace_ProcessMapping)(nil), "jaeger.model.Trace.ProcessMapping")
-	proto.RegisterType((*Batch)(nil), "jaeger.model.Batch")
-	golang_proto.RegisterType((*Batch)(nil), "jaeger.model.Batch")
-	proto.RegisterType((*PostSpansRequest)(nil), "jaeger.model.PostSpansRequest")
-	golang_proto.RegisterType((*PostSpansRequest)(nil), "jaeger.model.PostSpansRequest")
-	proto.RegisterType((*PostSpansResponse)(nil), "jaeger.model.PostSpansResponse")
-	golang_proto.RegisterType((*PostSpansResponse)(nil), "jaeger.model.PostSpansResponse")
-	proto.RegisterType((*GetTraceRequest)(nil), "jaeger.model.GetTraceRequest")
-	golang_proto.RegisterType((*GetTraceRequest)(nil), "jaeger.model.GetTraceRequest")
-	proto.RegisterType((*GetTraceResponse)(nil), "jaeger.model.GetTraceResponse")
-	golang_proto.RegisterType((*GetTraceResponse)(nil), "jaeger.model.GetTraceResponse")
-	proto.RegisterEnum("jaeger.model.ValueType", ValueType_name, ValueType_value)
-	golang_proto.RegisterEnum("jaeger.model.ValueType", ValueType_name, ValueType_value)
-	proto.RegisterEnum("jaeger.model.SpanRefType", SpanRefType_name, SpanRefType_value)
-	golang_proto.RegisterEnum("jaeger.model.SpanRefType", SpanRefType_name, SpanRefType_value)
+type TraceQueryParameters struct {
+	ServiceName   string            `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	OperationName string            `protobuf:"bytes,2,opt,name=operation_name,json=operationName,proto3" json:"operation_name,omitempty"`
+	Tags          map[string]string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	StartTimeMin  *types.Timestamp  `protobuf:"bytes,4,opt,name=start_time_min,json=startTimeMin,proto3" json:"start_time_min,omitempty"`
+	StartTimeMax  *types.Timestamp  `protobuf:"bytes,5,opt,name=start_time_max,json=startTimeMax,proto3" json:"start_time_max,omitempty"`
+	DurationMin   *types.Duration   `protobuf:"bytes,6,opt,name=duration_min,json=durationMin,proto3" json:"duration_min,omitempty"`
+	DurationMax   *types.Duration   `protobuf:"bytes,7,opt,name=duration_max,json=durationMax,proto3" json:"duration_max,omitempty"`
+	NumTraces     int32             `protobuf:"varint,8,opt,name=num_traces,json=numTraces,proto3" json:"num_traces,omitempty"`
 }
-func (this *KeyValue) Compare(that interface{}) int {
-	if that == nil {
-		if this == nil {
-			return 0
+
+func (m *TraceQueryParameters) Reset()         { *m = TraceQueryParameters{} }
+func (m *TraceQueryParameters) String() string { return proto.CompactTextString(m) }
+func (*TraceQueryParameters) ProtoMessage()    {}
+func (*TraceQueryParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{12}
+}
+func (m *TraceQueryParameters) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraceQueryParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraceQueryParameters.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		return 1
+		return b[:n], nil
 	}
+}
+func (m *TraceQueryParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceQueryParameters.Merge(m, src)
+}
+func (m *TraceQueryParameters) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraceQueryParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceQueryParameters.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*KeyValue)
-	if !ok {
-		that2, ok := that.(KeyValue)
-		if ok {
-			that1 = &that2
-		} else {
-			return 1
-		}
+var xxx_messageInfo_TraceQueryParameters proto.InternalMessageInfo
+
+func (m *TraceQueryParameters) GetServiceName() string {
+	if m != nil {
+		return m.ServiceName
 	}
-	if that1 == nil {
-		if this == nil {
-			return 0
-		}
-		return 1
-	} else if this == nil {
-		return -1
+	return ""
+}
+
+func (m *TraceQueryParameters) GetOperationName() string {
+	if m != nil {
+		return m.OperationName
 	}
-	if this.Key != that1.Key {
-		if this.Key < that1.Key {
-			return -1
-		}
-		return 1
+	return ""
+}
+
+func (m *TraceQueryParameters) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
 	}
-	if this.VType != that1.VType {
-		if this.VType < that1.VType {
-			return -1
-		}
-		return 1
+	return nil
+}
+
+func (m *TraceQueryParameters) GetStartTimeMin() *types.Timestamp {
+	if m != nil {
+		return m.StartTimeMin
 	}
-	if this.VStr != that1.VStr {
-		if this.VStr < that1.VStr {
-			return -1
-		}
-		return 1
+	return nil
+}
+
+func (m *TraceQueryParameters) GetStartTimeMax() *types.Timestamp {
+	if m != nil {
+		return m.StartTimeMax
 	}
-	if this.VBool != that1.VBool {
-		if !this.VBool {
-			return -1
+	return nil
+}
+
+func (m *TraceQueryParameters) GetDurationMin() *types.Duration {
+	if m != nil {
+		return m.DurationMin
+	}
+	return nil
+}
+
+func (m *TraceQueryParameters) GetDurationMax() *types.Duration {
+	if m != nil {
+		return m.DurationMax
+	}
+	return nil
+}
+
+func (m *TraceQueryParameters) GetNumTraces() int32 {
+	if m != nil {
+		return m.NumTraces
+	}
+	return 0
+}
+
+type FindTracesRequest struct {
+	Query *TraceQueryParameters `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (m *FindTracesRequest) Reset()         { *m = FindTracesRequest{} }
+func (m *FindTracesRequest) String() string { return proto.CompactTextString(m) }
+func (*FindTracesRequest) ProtoMessage()    {}
+func (*FindTracesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{13}
+}
+func (m *FindTracesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FindTracesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FindTracesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FindTracesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindTracesRequest.Merge(m, src)
+}
+func (m *FindTracesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FindTracesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindTracesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindTracesRequest proto.InternalMessageInfo
+
+func (m *FindTracesRequest) GetQuery() *TraceQueryParameters {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+// SpansResponseChunk is a portion of the result of FindTraces.
+// Each chunk carries the spans of exactly one trace.
+type SpansResponseChunk struct {
+	Spans []*Span `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`
+}
+
+func (m *SpansResponseChunk) Reset()         { *m = SpansResponseChunk{} }
+func (m *SpansResponseChunk) String() string { return proto.CompactTextString(m) }
+func (*SpansResponseChunk) ProtoMessage()    {}
+func (*SpansResponseChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{14}
+}
+func (m *SpansResponseChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SpansResponseChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SpansResponseChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SpansResponseChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpansResponseChunk.Merge(m, src)
+}
+func (m *SpansResponseChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *SpansResponseChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpansResponseChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpansResponseChunk proto.InternalMessageInfo
+
+func (m *SpansResponseChunk) GetSpans() []*Span {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
+type GetServicesRequest struct {
+}
+
+func (m *GetServicesRequest) Reset()         { *m = GetServicesRequest{} }
+func (m *GetServicesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetServicesRequest) ProtoMessage()    {}
+func (*GetServicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{15}
+}
+func (m *GetServicesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetServicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetServicesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetServicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServicesRequest.Merge(m, src)
+}
+func (m *GetServicesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetServicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServicesRequest proto.InternalMessageInfo
+
+type GetServicesResponse struct {
+	Services []string `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+}
+
+func (m *GetServicesResponse) Reset()         { *m = GetServicesResponse{} }
+func (m *GetServicesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetServicesResponse) ProtoMessage()    {}
+func (*GetServicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{16}
+}
+func (m *GetServicesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetServicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetServicesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetServicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServicesResponse.Merge(m, src)
+}
+func (m *GetServicesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetServicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServicesResponse proto.InternalMessageInfo
+
+func (m *GetServicesResponse) GetServices() []string {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+type GetOperationsRequest struct {
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+}
+
+func (m *GetOperationsRequest) Reset()         { *m = GetOperationsRequest{} }
+func (m *GetOperationsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOperationsRequest) ProtoMessage()    {}
+func (*GetOperationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{17}
+}
+func (m *GetOperationsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetOperationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetOperationsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetOperationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperationsRequest.Merge(m, src)
+}
+func (m *GetOperationsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetOperationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOperationsRequest proto.InternalMessageInfo
+
+func (m *GetOperationsRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+type GetOperationsResponse struct {
+	Operations []string `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (m *GetOperationsResponse) Reset()         { *m = GetOperationsResponse{} }
+func (m *GetOperationsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOperationsResponse) ProtoMessage()    {}
+func (*GetOperationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{18}
+}
+func (m *GetOperationsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetOperationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetOperationsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetOperationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperationsResponse.Merge(m, src)
+}
+func (m *GetOperationsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetOperationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOperationsResponse proto.InternalMessageInfo
+
+func (m *GetOperationsResponse) GetOperations() []string {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+type GetDependenciesRequest struct {
+	EndTime  *types.Timestamp `protobuf:"bytes,1,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Lookback *types.Duration  `protobuf:"bytes,2,opt,name=lookback,proto3" json:"lookback,omitempty"`
+}
+
+func (m *GetDependenciesRequest) Reset()         { *m = GetDependenciesRequest{} }
+func (m *GetDependenciesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDependenciesRequest) ProtoMessage()    {}
+func (*GetDependenciesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{19}
+}
+func (m *GetDependenciesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetDependenciesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetDependenciesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetDependenciesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDependenciesRequest.Merge(m, src)
+}
+func (m *GetDependenciesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetDependenciesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDependenciesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDependenciesRequest proto.InternalMessageInfo
+
+func (m *GetDependenciesRequest) GetEndTime() *types.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *GetDependenciesRequest) GetLookback() *types.Duration {
+	if m != nil {
+		return m.Lookback
+	}
+	return nil
+}
+
+type GetDependenciesResponse struct {
+	Dependencies []*DependencyLink `protobuf:"bytes,1,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+}
+
+func (m *GetDependenciesResponse) Reset()         { *m = GetDependenciesResponse{} }
+func (m *GetDependenciesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDependenciesResponse) ProtoMessage()    {}
+func (*GetDependenciesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{20}
+}
+func (m *GetDependenciesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetDependenciesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetDependenciesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetDependenciesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDependenciesResponse.Merge(m, src)
+}
+func (m *GetDependenciesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetDependenciesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDependenciesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDependenciesResponse proto.InternalMessageInfo
+
+func (m *GetDependenciesResponse) GetDependencies() []*DependencyLink {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("jaeger.model.ValueType", ValueType_name, ValueType_value)
+	golang_proto.RegisterEnum("jaeger.model.ValueType", ValueType_name, ValueType_value)
+	proto.RegisterEnum("jaeger.model.SpanRefType", SpanRefType_name, SpanRefType_value)
+	golang_proto.RegisterEnum("jaeger.model.SpanRefType", SpanRefType_name, SpanRefType_value)
+	proto.RegisterType((*KeyValue)(nil), "jaeger.model.KeyValue")
+	golang_proto.RegisterType((*KeyValue)(nil), "jaeger.model.KeyValue")
+	proto.RegisterType((*Log)(nil), "jaeger.model.Log")
+	golang_proto.RegisterType((*Log)(nil), "jaeger.model.Log")
+	proto.RegisterType((*SpanRef)(nil), "jaeger.model.SpanRef")
+	golang_proto.RegisterType((*SpanRef)(nil), "jaeger.model.SpanRef")
+	proto.RegisterType((*Process)(nil), "jaeger.model.Process")
+	golang_proto.RegisterType((*Process)(nil), "jaeger.model.Process")
+	proto.RegisterType((*Span)(nil), "jaeger.model.Span")
+	golang_proto.RegisterType((*Span)(nil), "jaeger.model.Span")
+	proto.RegisterType((*Trace)(nil), "jaeger.model.Trace")
+	golang_proto.RegisterType((*Trace)(nil), "jaeger.model.Trace")
+	proto.RegisterType((*Trace_ProcessMapping)(nil), "jaeger.model.Trace.ProcessMapping")
+	golang_proto.RegisterType((*Trace_ProcessMapping)(nil), "jaeger.model.Trace.ProcessMapping")
+	proto.RegisterType((*Batch)(nil), "jaeger.model.Batch")
+	golang_proto.RegisterType((*Batch)(nil), "jaeger.model.Batch")
+	proto.RegisterType((*PostSpansRequest)(nil), "jaeger.model.PostSpansRequest")
+	golang_proto.RegisterType((*PostSpansRequest)(nil), "jaeger.model.PostSpansRequest")
+	proto.RegisterType((*PostSpansResponse)(nil), "jaeger.model.PostSpansResponse")
+	golang_proto.RegisterType((*PostSpansResponse)(nil), "jaeger.model.PostSpansResponse")
+	proto.RegisterType((*DependencyLink)(nil), "jaeger.model.DependencyLink")
+	golang_proto.RegisterType((*DependencyLink)(nil), "jaeger.model.DependencyLink")
+	proto.RegisterType((*GetTraceRequest)(nil), "jaeger.model.GetTraceRequest")
+	golang_proto.RegisterType((*GetTraceRequest)(nil), "jaeger.model.GetTraceRequest")
+	proto.RegisterType((*GetTraceResponse)(nil), "jaeger.model.GetTraceResponse")
+	golang_proto.RegisterType((*GetTraceResponse)(nil), "jaeger.model.GetTraceResponse")
+	proto.RegisterType((*TraceQueryParameters)(nil), "jaeger.model.TraceQueryParameters")
+	golang_proto.RegisterType((*TraceQueryParameters)(nil), "jaeger.model.TraceQueryParameters")
+	proto.RegisterMapType((map[string]string)(nil), "jaeger.model.TraceQueryParameters.TagsEntry")
+	golang_proto.RegisterMapType((map[string]string)(nil), "jaeger.model.TraceQueryParameters.TagsEntry")
+	proto.RegisterType((*FindTracesRequest)(nil), "jaeger.model.FindTracesRequest")
+	golang_proto.RegisterType((*FindTracesRequest)(nil), "jaeger.model.FindTracesRequest")
+	proto.RegisterType((*SpansResponseChunk)(nil), "jaeger.model.SpansResponseChunk")
+	golang_proto.RegisterType((*SpansResponseChunk)(nil), "jaeger.model.SpansResponseChunk")
+	proto.RegisterType((*GetServicesRequest)(nil), "jaeger.model.GetServicesRequest")
+	golang_proto.RegisterType((*GetServicesRequest)(nil), "jaeger.model.GetServicesRequest")
+	proto.RegisterType((*GetServicesResponse)(nil), "jaeger.model.GetServicesResponse")
+	golang_proto.RegisterType((*GetServicesResponse)(nil), "jaeger.model.GetServicesResponse")
+	proto.RegisterType((*GetOperationsRequest)(nil), "jaeger.model.GetOperationsRequest")
+	golang_proto.RegisterType((*GetOperationsRequest)(nil), "jaeger.model.GetOperationsRequest")
+	proto.RegisterType((*GetOperationsResponse)(nil), "jaeger.model.GetOperationsResponse")
+	golang_proto.RegisterType((*GetOperationsResponse)(nil), "jaeger.model.GetOperationsResponse")
+	proto.RegisterType((*GetDependenciesRequest)(nil), "jaeger.model.GetDependenciesRequest")
+	golang_proto.RegisterType((*GetDependenciesRequest)(nil), "jaeger.model.GetDependenciesRequest")
+	proto.RegisterType((*GetDependenciesResponse)(nil), "jaeger.model.GetDependenciesResponse")
+	golang_proto.RegisterType((*GetDependenciesResponse)(nil), "jaeger.model.GetDependenciesResponse")
+}
+
+func init() { proto.RegisterFile("model.proto", fileDescriptor_4c16552f9fdb66d8) }
+func init() { golang_proto.RegisterFile("model.proto", fileDescriptor_4c16552f9fdb66d8) }
+
+var fileDescriptor_4c16552f9fdb66d8 = []byte{
+	// 1660 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcb, 0x73, 0x1b, 0x49,
+	0x19, 0xf7, 0x8c, 0x34, 0xd2, 0xcc, 0x27, 0xd9, 0x28, 0x9d, 0xd7, 0x44, 0xbb, 0x2b, 0x29, 0x13,
+	0xb6, 0xd0, 0x86, 0xb5, 0x94, 0x88, 0x64, 0x77, 0x2b, 0xbc, 0x12, 0xd9, 0x6b, 0x23, 0xb0, 0xe3,
+	0xd0, 0x76, 0x2d, 0xc5, 0x52, 0x85, 0x68, 0x4b, 0xad, 0xf1, 0x60, 0x69, 0x7a, 0x76, 0x66, 0xa4,
+	0x44, 0x05, 0x14, 0x14, 0x54, 0x71, 0x65, 0x0b, 0x2e, 0x1c, 0xe1, 0xc8, 0x7f, 0xc1, 0x71, 0x8f,
+	0x5b, 0xb5, 0x17, 0xe0, 0x60, 0xc0, 0xe6, 0xb0, 0x95, 0x3f, 0x81, 0x13, 0xd5, 0x3d, 0x3d, 0x92,
+	0x46, 0x52, 0x62, 0x87, 0xc3, 0x9e, 0xa6, 0xbf, 0xaf, 0x7f, 0xdf, 0xfb, 0x31, 0x33, 0x90, 0x1b,
+	0xb0, 0x2e, 0xed, 0xd7, 0x3c, 0x9f, 0x85, 0x0c, 0xe5, 0x7f, 0x4a, 0xa8, 0x4d, 0xfd, 0x9a, 0xe0,
+	0x15, 0xcb, 0x36, 0x63, 0x76, 0x9f, 0xd6, 0xc5, 0xdd, 0xe1, 0xb0, 0x57, 0x0f, 0x9d, 0x01, 0x0d,
+	0x42, 0x32, 0xf0, 0x22, 0x78, 0xb1, 0x34, 0x0f, 0xe8, 0x0e, 0x7d, 0x12, 0x3a, 0xcc, 0x95, 0xf7,
+	0xaf, 0xcb, 0x7b, 0xe2, 0x39, 0x75, 0xe2, 0xba, 0x2c, 0x14, 0x97, 0x81, 0xbc, 0xdd, 0xb7, 0x9d,
+	0xf0, 0x68, 0x78, 0x58, 0xeb, 0xb0, 0x41, 0xdd, 0xf6, 0xbd, 0xce, 0x3a, 0xed, 0xb0, 0x60, 0x1c,
+	0x84, 0x54, 0x92, 0x36, 0x09, 0xe9, 0x53, 0x32, 0x8e, 0xb4, 0x77, 0xd6, 0x6d, 0xea, 0xae, 0x07,
+	0x4f, 0x89, 0x6d, 0x53, 0xbf, 0xce, 0x3c, 0xa1, 0x68, 0x89, 0xd2, 0xf5, 0x59, 0xa5, 0xcc, 0x66,
+	0x53, 0xdf, 0x38, 0x25, 0x08, 0x71, 0x8a, 0xe0, 0xd6, 0x67, 0x0a, 0xe8, 0xdf, 0xa3, 0xe3, 0x0f,
+	0x48, 0x7f, 0x48, 0x51, 0x01, 0x52, 0xc7, 0x74, 0x6c, 0x2a, 0x15, 0xa5, 0x6a, 0x60, 0x7e, 0x44,
+	0x35, 0xc8, 0x8c, 0xda, 0xe1, 0xd8, 0xa3, 0xa6, 0x5a, 0x51, 0xaa, 0x6b, 0x8d, 0xeb, 0xb5, 0xd9,
+	0x04, 0xd5, 0x84, 0xd8, 0xc1, 0xd8, 0xa3, 0x58, 0x1b, 0xf1, 0x07, 0xba, 0x0c, 0xda, 0xa8, 0x1d,
+	0x84, 0xbe, 0x99, 0x12, 0x3a, 0xd2, 0xa3, 0xfd, 0xd0, 0x47, 0x57, 0xb9, 0x92, 0x43, 0xc6, 0xfa,
+	0x66, 0xba, 0xa2, 0x54, 0x75, 0xac, 0x8d, 0x9a, 0x8c, 0xf5, 0xd1, 0x75, 0xc8, 0x8e, 0xda, 0x8e,
+	0x1b, 0xbe, 0x73, 0xcf, 0xd4, 0x2a, 0x4a, 0x35, 0x85, 0x33, 0xa3, 0x16, 0xa7, 0xd0, 0x6b, 0x60,
+	0x8c, 0xda, 0xbd, 0x3e, 0x23, 0xfc, 0x2a, 0x53, 0x51, 0xaa, 0x0a, 0xd6, 0x47, 0x5b, 0x11, 0x8d,
+	0x6e, 0x80, 0x3e, 0x6a, 0x1f, 0x3a, 0x2e, 0xf1, 0xc7, 0x66, 0xb6, 0xa2, 0x54, 0xf3, 0x38, 0x3b,
+	0x6a, 0x0a, 0xf2, 0x81, 0xfe, 0xf9, 0x9f, 0xca, 0xca, 0xe7, 0x7f, 0x2e, 0x2b, 0xd6, 0x2f, 0x21,
+	0xb5, 0xc3, 0x6c, 0xd4, 0x04, 0x63, 0x52, 0x31, 0x11, 0x55, 0xae, 0x51, 0xac, 0x45, 0x25, 0xa9,
+	0xc5, 0x69, 0xa9, 0x1d, 0xc4, 0x88, 0xa6, 0xfe, 0xc9, 0x49, 0x79, 0xe5, 0xe3, 0x7f, 0x96, 0x15,
+	0x3c, 0x15, 0x43, 0xf7, 0x20, 0xd3, 0x73, 0x68, 0xbf, 0x1b, 0x98, 0x6a, 0x25, 0x55, 0xcd, 0x35,
+	0xae, 0x25, 0x33, 0x10, 0xe7, 0xae, 0x99, 0xe6, 0xc2, 0x58, 0x62, 0xad, 0xbf, 0x28, 0x90, 0xdd,
+	0xf7, 0x88, 0x8b, 0x69, 0x0f, 0xdd, 0x07, 0x3d, 0xf4, 0x49, 0x87, 0xb6, 0x9d, 0xae, 0x70, 0x22,
+	0xdf, 0x2c, 0x72, 0xec, 0x3f, 0x4e, 0xca, 0xd9, 0x03, 0xce, 0x6f, 0x6d, 0x9e, 0x4e, 0x8f, 0x38,
+	0x2b, 0xb0, 0xad, 0x2e, 0xba, 0x0b, 0xd9, 0xc0, 0x23, 0x2e, 0x97, 0x52, 0x85, 0x94, 0x29, 0xa5,
+	0x32, 0x5c, 0xb1, 0x10, 0x92, 0x27, 0x9c, 0xe1, 0xc0, 0x56, 0x17, 0xdd, 0x03, 0xdd, 0xa7, 0xbd,
+	0xa8, 0x5e, 0x29, 0x51, 0xaf, 0x1b, 0x49, 0x6f, 0xa5, 0x4b, 0xa2, 0x62, 0x59, 0x3f, 0x3a, 0x58,
+	0x3f, 0x86, 0xec, 0x13, 0x9f, 0x75, 0x68, 0x10, 0xa0, 0x9b, 0x90, 0x0f, 0xa8, 0x3f, 0x72, 0x3a,
+	0xb4, 0xed, 0x92, 0x01, 0x95, 0x9d, 0x90, 0x93, 0xbc, 0xc7, 0x64, 0x40, 0xd1, 0x1d, 0x48, 0x87,
+	0xc4, 0xbe, 0x58, 0x36, 0x04, 0xd2, 0xfa, 0x7b, 0x1a, 0xd2, 0xdc, 0xf0, 0x17, 0x98, 0x88, 0x37,
+	0x61, 0x8d, 0x79, 0x34, 0x1a, 0xc5, 0x28, 0x92, 0xa8, 0x1f, 0x57, 0x27, 0x5c, 0x11, 0xcb, 0xd7,
+	0x01, 0x7c, 0xda, 0xa3, 0x3e, 0x75, 0x3b, 0x34, 0x30, 0xd3, 0x22, 0xa2, 0xab, 0x4b, 0x33, 0x26,
+	0x03, 0x9a, 0x81, 0xa3, 0x5b, 0xa0, 0xf5, 0xfa, 0x3c, 0x13, 0xbc, 0x79, 0x57, 0x9b, 0xab, 0xd2,
+	0x29, 0x6d, 0x8b, 0x33, 0x71, 0x74, 0x87, 0x36, 0x00, 0x82, 0x90, 0xf8, 0x61, 0x9b, 0x37, 0x94,
+	0x99, 0x79, 0x95, 0x16, 0x14, 0x72, 0xfc, 0x06, 0x7d, 0x1b, 0xf4, 0x78, 0xaf, 0x88, 0x96, 0xcf,
+	0x35, 0x6e, 0x2c, 0xa8, 0xd8, 0x94, 0x80, 0x48, 0xc3, 0x1f, 0xb9, 0x86, 0x89, 0xd0, 0xa4, 0x66,
+	0xfa, 0x45, 0x6b, 0x86, 0xbe, 0x0a, 0xe9, 0x3e, 0xb3, 0x03, 0xd3, 0x10, 0x12, 0x97, 0x92, 0x12,
+	0x3b, 0xcc, 0x8e, 0xc1, 0x1c, 0x84, 0xea, 0x90, 0xf5, 0xa2, 0x06, 0x32, 0xa1, 0xa2, 0x2c, 0xe6,
+	0x50, 0x76, 0x17, 0x8e, 0x51, 0xe8, 0x6d, 0x00, 0x79, 0xe4, 0x45, 0xcd, 0xf1, 0xd2, 0x34, 0x57,
+	0x4f, 0x4f, 0xca, 0x86, 0x44, 0xb6, 0x36, 0xb1, 0x21, 0x01, 0xad, 0x2e, 0x2a, 0x82, 0xfe, 0x94,
+	0xf8, 0xae, 0xe3, 0xda, 0x81, 0x99, 0xaf, 0xa4, 0xaa, 0x06, 0x9e, 0xd0, 0xd6, 0xef, 0x54, 0xd0,
+	0x44, 0xc3, 0xa0, 0x2a, 0x68, 0xbc, 0xf8, 0x81, 0xa9, 0x08, 0x97, 0xd1, 0x92, 0x32, 0x46, 0x00,
+	0xd4, 0x82, 0x5c, 0x6c, 0x7d, 0x40, 0x3c, 0xd9, 0xc8, 0x56, 0x12, 0x2f, 0x74, 0xc6, 0x8e, 0xef,
+	0x12, 0xcf, 0x73, 0xdc, 0x38, 0xe6, 0xd8, 0xf5, 0x5d, 0xe2, 0x25, 0x5c, 0x4b, 0x25, 0x5d, 0x2b,
+	0x0e, 0x61, 0x2d, 0x29, 0x3f, 0x17, 0xb6, 0x72, 0x4e, 0xd8, 0xf7, 0xa7, 0x59, 0x55, 0x5f, 0x92,
+	0x55, 0xe9, 0x55, 0x8c, 0xb5, 0x8e, 0x40, 0x6b, 0x92, 0xb0, 0x73, 0xf4, 0x0a, 0x09, 0xf9, 0x3f,
+	0x2d, 0x7d, 0x13, 0x0a, 0x4f, 0x58, 0x10, 0x72, 0x4d, 0x01, 0xa6, 0x1f, 0x0d, 0x69, 0x10, 0xa2,
+	0xb7, 0x40, 0x3b, 0xe4, 0xd6, 0xe5, 0xb6, 0xbd, 0x9c, 0x54, 0x24, 0x1c, 0xc3, 0x11, 0xc2, 0xba,
+	0x05, 0x97, 0x66, 0xc4, 0x03, 0x8f, 0xb9, 0x01, 0x45, 0x6b, 0xa0, 0xb2, 0x63, 0x21, 0xac, 0x63,
+	0x95, 0x1d, 0x5b, 0xbf, 0x51, 0x60, 0x6d, 0x93, 0x7a, 0xd4, 0xed, 0x52, 0xb7, 0x33, 0xde, 0x71,
+	0xdc, 0x63, 0x64, 0x41, 0xc6, 0x23, 0x3e, 0x75, 0x43, 0x99, 0x41, 0x78, 0x7e, 0x52, 0x96, 0x1c,
+	0x2c, 0x9f, 0xa8, 0x0c, 0x5a, 0xe7, 0xc8, 0xe9, 0x47, 0x0b, 0xc3, 0x68, 0x1a, 0xcf, 0x4f, 0xca,
+	0x11, 0x03, 0x47, 0x0f, 0x5e, 0x8a, 0x0e, 0xe9, 0xf7, 0xdb, 0x1d, 0x36, 0x74, 0x43, 0xb1, 0x1c,
+	0xd2, 0xcd, 0xd5, 0xe7, 0x27, 0x65, 0x83, 0x73, 0x37, 0x38, 0x13, 0x4f, 0x8f, 0xd6, 0x4d, 0xf8,
+	0xd2, 0x36, 0x0d, 0x45, 0x4f, 0xc4, 0x81, 0xae, 0x81, 0x1a, 0xd7, 0x10, 0xab, 0x4e, 0x97, 0x27,
+	0x63, 0x0a, 0x91, 0xc1, 0xbc, 0x05, 0x9a, 0xd8, 0x61, 0xcb, 0x93, 0x11, 0x61, 0x23, 0x84, 0xf5,
+	0xdf, 0x14, 0x5c, 0x11, 0x8c, 0xef, 0x0f, 0xa9, 0x3f, 0x7e, 0x42, 0x7c, 0x32, 0xa0, 0x21, 0xf5,
+	0x2f, 0xb4, 0x91, 0x17, 0x97, 0x9d, 0xba, 0x6c, 0xd9, 0x3d, 0x94, 0x4b, 0x20, 0x25, 0xda, 0xe1,
+	0xed, 0x25, 0xce, 0xcc, 0xd9, 0xae, 0x1d, 0x10, 0x3b, 0x78, 0xdf, 0x0d, 0xfd, 0xb1, 0x5c, 0x0a,
+	0x0f, 0x61, 0x6d, 0xba, 0xcc, 0xda, 0x03, 0xc7, 0x35, 0xd3, 0xe7, 0x2d, 0x34, 0x9c, 0x9f, 0xac,
+	0xb1, 0x5d, 0xc7, 0x9d, 0xd7, 0x40, 0x9e, 0x99, 0xda, 0xab, 0x68, 0x20, 0xcf, 0xd0, 0x37, 0x20,
+	0x1f, 0xaf, 0x35, 0xe1, 0x41, 0xe6, 0x9c, 0x7d, 0x88, 0x73, 0x31, 0x9c, 0xdb, 0x4f, 0x48, 0x93,
+	0x67, 0x66, 0xf6, 0xe2, 0xd2, 0xe4, 0x19, 0x7a, 0x03, 0xc0, 0x1d, 0x0e, 0xda, 0xa2, 0x62, 0x7c,
+	0x99, 0x2a, 0x55, 0x0d, 0x1b, 0xee, 0x70, 0x20, 0x92, 0x17, 0x14, 0xdf, 0x05, 0x63, 0x92, 0xb1,
+	0x25, 0x9f, 0x52, 0x57, 0x40, 0x1b, 0xf1, 0x3d, 0x2b, 0xab, 0x13, 0x11, 0x0f, 0xd4, 0xf7, 0x14,
+	0x6b, 0x17, 0x2e, 0x6d, 0x39, 0x6e, 0x37, 0x52, 0x13, 0x37, 0xd8, 0x7b, 0xa0, 0x7d, 0xc4, 0xeb,
+	0x21, 0x9b, 0xc7, 0x3a, 0xbf, 0x5e, 0x38, 0x12, 0xb0, 0xbe, 0x05, 0x28, 0x31, 0x54, 0x1b, 0x47,
+	0x43, 0xf7, 0xf8, 0xe2, 0xeb, 0xc0, 0xba, 0x02, 0x68, 0x9b, 0x86, 0xfb, 0x51, 0x87, 0xc5, 0xfe,
+	0x58, 0x77, 0xe1, 0x72, 0x82, 0x2b, 0x7b, 0xbc, 0x08, 0xba, 0xec, 0xc5, 0x48, 0xb3, 0x81, 0x27,
+	0xb4, 0x75, 0x07, 0xae, 0x6c, 0xd3, 0x70, 0x2f, 0xee, 0xc2, 0x49, 0x68, 0x26, 0x64, 0x25, 0x46,
+	0xe6, 0x27, 0x26, 0xad, 0x77, 0xe1, 0xea, 0x9c, 0x84, 0x34, 0x53, 0x02, 0x98, 0x74, 0x73, 0x6c,
+	0x68, 0x86, 0x63, 0xfd, 0x56, 0x81, 0x6b, 0xdb, 0x34, 0x9c, 0xac, 0x0a, 0x67, 0x9a, 0xc8, 0xfb,
+	0xa0, 0x53, 0xb7, 0x1b, 0xbd, 0x80, 0xcf, 0xfd, 0x06, 0xc4, 0x59, 0xea, 0x76, 0x39, 0xc5, 0xc5,
+	0xfa, 0x8c, 0x1d, 0x1f, 0x92, 0xce, 0xb1, 0xa9, 0x9e, 0xd7, 0x26, 0x13, 0xa8, 0xf5, 0x23, 0xb8,
+	0xbe, 0xe0, 0x87, 0x8c, 0xe1, 0x21, 0xe4, 0xbb, 0x33, 0x7c, 0x59, 0x88, 0xd7, 0x93, 0x85, 0x48,
+	0x2e, 0x3b, 0x9c, 0x90, 0xb8, 0xfd, 0x3e, 0x18, 0x93, 0x2f, 0x6e, 0x04, 0x90, 0xd9, 0x3f, 0xc0,
+	0xad, 0xc7, 0xdb, 0x85, 0x15, 0xa4, 0x43, 0xba, 0xb9, 0xb7, 0xb7, 0x53, 0x50, 0x90, 0x01, 0x5a,
+	0xeb, 0xf1, 0xc1, 0x3b, 0xf7, 0x0a, 0x2a, 0xca, 0x41, 0x76, 0x6b, 0x67, 0xef, 0x11, 0x27, 0x52,
+	0x1c, 0xdd, 0x6c, 0x3d, 0x7e, 0x84, 0x7f, 0x58, 0x48, 0xdf, 0x5e, 0x87, 0xdc, 0xcc, 0x87, 0x20,
+	0xca, 0x83, 0xbe, 0xf1, 0x9d, 0xd6, 0xce, 0x66, 0x7b, 0x6f, 0xab, 0xb0, 0x82, 0x0a, 0x90, 0xdf,
+	0xda, 0xdb, 0xd9, 0xd9, 0xfb, 0xc1, 0x7e, 0x7b, 0x0b, 0xef, 0xed, 0x16, 0x94, 0xc6, 0xcf, 0x01,
+	0x6d, 0xb0, 0x7e, 0x9f, 0x76, 0x42, 0xe6, 0xcb, 0xfa, 0x7f, 0xd0, 0x40, 0x3d, 0x30, 0x26, 0xeb,
+	0x1b, 0x95, 0xe6, 0x5e, 0x18, 0x73, 0xaf, 0x85, 0x62, 0xf9, 0x85, 0xf7, 0x51, 0x6e, 0x2c, 0xf3,
+	0xd7, 0x9f, 0xfd, 0xe7, 0x0f, 0x2a, 0x7a, 0xa0, 0xdc, 0xb6, 0x56, 0xc5, 0xdf, 0xd2, 0xa8, 0x51,
+	0x17, 0xdd, 0xd8, 0xf8, 0x77, 0x0a, 0xd6, 0x44, 0xa3, 0xcf, 0x9a, 0xd6, 0xe3, 0x5d, 0x8b, 0xde,
+	0x48, 0x6a, 0x9e, 0x5b, 0xd3, 0xc5, 0xd2, 0x8b, 0xae, 0xa5, 0xdd, 0xd7, 0x84, 0xdd, 0xab, 0xe8,
+	0x72, 0x6c, 0x34, 0x1a, 0xee, 0xfa, 0xcf, 0x9c, 0xee, 0x2f, 0xd0, 0x3e, 0xc0, 0x74, 0x2e, 0xd1,
+	0x5c, 0x0c, 0x0b, 0x13, 0x5b, 0xac, 0x2c, 0x8e, 0x54, 0x72, 0x06, 0xad, 0x95, 0x3b, 0x0a, 0x3a,
+	0x80, 0xdc, 0xcc, 0x1c, 0xa1, 0xca, 0x82, 0x83, 0x73, 0x83, 0x57, 0xbc, 0xf9, 0x12, 0x84, 0x8c,
+	0x62, 0x05, 0x7d, 0x08, 0xab, 0x89, 0xc1, 0x41, 0xd6, 0x82, 0xd4, 0xc2, 0x1c, 0x16, 0x6f, 0xbd,
+	0x14, 0x33, 0xd1, 0xfd, 0x13, 0xf1, 0xf6, 0x9b, 0x6d, 0x69, 0xf4, 0xe5, 0x05, 0xc9, 0x25, 0x93,
+	0x57, 0x7c, 0xf3, 0x1c, 0x54, 0x6c, 0xa1, 0xf9, 0x2b, 0xe5, 0xf7, 0x8f, 0x9a, 0xb7, 0x55, 0x45,
+	0x45, 0x5a, 0x23, 0x75, 0xb7, 0x76, 0xc7, 0xbf, 0x8f, 0xbe, 0x72, 0x14, 0x86, 0x5e, 0xf0, 0xa0,
+	0x5e, 0x9f, 0xf9, 0x9d, 0x8d, 0xb4, 0xf1, 0x0a, 0x39, 0xae, 0x2d, 0x29, 0x80, 0xef, 0x8a, 0x67,
+	0xe5, 0xd1, 0x93, 0xd6, 0x27, 0xa7, 0x25, 0xe5, 0xd3, 0xd3, 0x92, 0xf2, 0xaf, 0xd3, 0x92, 0xf2,
+	0xf1, 0x59, 0x69, 0xe5, 0xaf, 0x67, 0x25, 0xe5, 0xd3, 0xb3, 0xd2, 0xca, 0xdf, 0xce, 0x4a, 0x2b,
+	0x70, 0xcd, 0x61, 0xb5, 0x84, 0x82, 0xc8, 0xab, 0x0f, 0x35, 0xf1, 0x38, 0xcc, 0x88, 0xa1, 0xfe,
+	0xda, 0xff, 0x06, 0x00, 0xe4, 0x77, 0xdb, 0xfe, 0x0e, 0x10, 0x00, 0x00,
+}
+
+func (this *KeyValue) Compare(that interface{}) int {
+	if that == nil {
+		if this == nil {
+			return 0
+		}
+		return 1
+	}
+
+	that1, ok := that.(*KeyValue)
+	if !ok {
+		that2, ok := that.(KeyValue)
+		if ok {
+			that1 = &that2
+		} else {
+			return 1
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return 0
+		}
+		return 1
+	} else if this == nil {
+		return -1
+	}
+	if this.Key != that1.Key {
+		if this.Key < that1.Key {
+			return -1
+		}
+		return 1
+	}
+	if this.VType != that1.VType {
+		if this.VType < that1.VType {
+			return -1
+		}
+		return 1
+	}
+	if this.VStr != that1.VStr {
+		if this.VStr < that1.VStr {
+			return -1
+		}
+		return 1
+	}
+	if this.VBool != that1.VBool {
+		if !this.VBool {
+			return -1
 		}
 		return 1
 	}
@@ -563,10 +1535,7 @@ func (this *KeyValue) Compare(that interface{}) int {
 }
 func (this *KeyValue) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*KeyValue)
@@ -579,10 +1548,7 @@ func (this *KeyValue) Equal(that interface{}) bool {
 		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -618,8 +1584,9 @@ var _ grpc.ClientConn
 // is compatible with the grpc package it is being compiled against.
 const _ = grpc.SupportPackageIsVersion4
 
-// Client API for CollectorServiceV2 service
-
+// CollectorServiceV2Client is the client API for CollectorServiceV2 service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type CollectorServiceV2Client interface {
 	PostSpans(ctx context.Context, in *PostSpansRequest, opts ...grpc.CallOption) (*PostSpansResponse, error)
 }
@@ -634,19 +1601,26 @@ func NewCollectorServiceV2Client(cc *grpc.ClientConn) CollectorServiceV2Client {
 
 func (c *collectorServiceV2Client) PostSpans(ctx context.Context, in *PostSpansRequest, opts ...grpc.CallOption) (*PostSpansResponse, error) {
 	out := new(PostSpansResponse)
-	err := grpc.Invoke(ctx, "/jaeger.model.CollectorServiceV2/PostSpans", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/jaeger.model.CollectorServiceV2/PostSpans", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for CollectorServiceV2 service
-
+// CollectorServiceV2Server is the server API for CollectorServiceV2 service.
 type CollectorServiceV2Server interface {
 	PostSpans(context.Context, *PostSpansRequest) (*PostSpansResponse, error)
 }
 
+// UnimplementedCollectorServiceV2Server can be embedded to have forward compatible implementations.
+type UnimplementedCollectorServiceV2Server struct {
+}
+
+func (*UnimplementedCollectorServiceV2Server) PostSpans(ctx context.Context, req *PostSpansRequest) (*PostSpansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostSpans not implemented")
+}
+
 func RegisterCollectorServiceV2Server(s *grpc.Server, srv CollectorServiceV2Server) {
 	s.RegisterService(&_CollectorServiceV2_serviceDesc, srv)
 }
@@ -682,10 +1656,15 @@ var _CollectorServiceV2_serviceDesc = grpc.ServiceDesc{
 	Metadata: "model.proto",
 }
 
-// Client API for QueryServiceV2 service
-
+// QueryServiceV2Client is the client API for QueryServiceV2 service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type QueryServiceV2Client interface {
 	GetTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (*GetTraceResponse, error)
+	FindTraces(ctx context.Context, in *FindTracesRequest, opts ...grpc.CallOption) (QueryServiceV2_FindTracesClient, error)
+	GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error)
+	GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error)
+	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error)
 }
 
 type queryServiceV2Client struct {
@@ -698,17 +1677,99 @@ func NewQueryServiceV2Client(cc *grpc.ClientConn) QueryServiceV2Client {
 
 func (c *queryServiceV2Client) GetTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (*GetTraceResponse, error) {
 	out := new(GetTraceResponse)
-	err := grpc.Invoke(ctx, "/jaeger.model.QueryServiceV2/GetTrace", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/jaeger.model.QueryServiceV2/GetTrace", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for QueryServiceV2 service
-
+func (c *queryServiceV2Client) FindTraces(ctx context.Context, in *FindTracesRequest, opts ...grpc.CallOption) (QueryServiceV2_FindTracesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_QueryServiceV2_serviceDesc.Streams[0], "/jaeger.model.QueryServiceV2/FindTraces", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryServiceV2FindTracesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QueryServiceV2_FindTracesClient interface {
+	Recv() (*SpansResponseChunk, error)
+	grpc.ClientStream
+}
+
+type queryServiceV2FindTracesClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryServiceV2FindTracesClient) Recv() (*SpansResponseChunk, error) {
+	m := new(SpansResponseChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *queryServiceV2Client) GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error) {
+	out := new(GetServicesResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.model.QueryServiceV2/GetServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceV2Client) GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error) {
+	out := new(GetOperationsResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.model.QueryServiceV2/GetOperations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceV2Client) GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error) {
+	out := new(GetDependenciesResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.model.QueryServiceV2/GetDependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QueryServiceV2Server is the server API for QueryServiceV2 service.
 type QueryServiceV2Server interface {
 	GetTrace(context.Context, *GetTraceRequest) (*GetTraceResponse, error)
+	FindTraces(*FindTracesRequest, QueryServiceV2_FindTracesServer) error
+	GetServices(context.Context, *GetServicesRequest) (*GetServicesResponse, error)
+	GetOperations(context.Context, *GetOperationsRequest) (*GetOperationsResponse, error)
+	GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error)
+}
+
+// UnimplementedQueryServiceV2Server can be embedded to have forward compatible implementations.
+type UnimplementedQueryServiceV2Server struct {
+}
+
+func (*UnimplementedQueryServiceV2Server) GetTrace(ctx context.Context, req *GetTraceRequest) (*GetTraceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrace not implemented")
+}
+func (*UnimplementedQueryServiceV2Server) FindTraces(req *FindTracesRequest, srv QueryServiceV2_FindTracesServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindTraces not implemented")
+}
+func (*UnimplementedQueryServiceV2Server) GetServices(ctx context.Context, req *GetServicesRequest) (*GetServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServices not implemented")
+}
+func (*UnimplementedQueryServiceV2Server) GetOperations(ctx context.Context, req *GetOperationsRequest) (*GetOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperations not implemented")
+}
+func (*UnimplementedQueryServiceV2Server) GetDependencies(ctx context.Context, req *GetDependenciesRequest) (*GetDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDependencies not implemented")
 }
 
 func RegisterQueryServiceV2Server(s *grpc.Server, srv QueryServiceV2Server) {
@@ -733,6 +1794,81 @@ func _QueryServiceV2_GetTrace_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueryServiceV2_FindTraces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindTracesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServiceV2Server).FindTraces(m, &queryServiceV2FindTracesServer{stream})
+}
+
+type QueryServiceV2_FindTracesServer interface {
+	Send(*SpansResponseChunk) error
+	grpc.ServerStream
+}
+
+type queryServiceV2FindTracesServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryServiceV2FindTracesServer) Send(m *SpansResponseChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QueryServiceV2_GetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceV2Server).GetServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.model.QueryServiceV2/GetServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceV2Server).GetServices(ctx, req.(*GetServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryServiceV2_GetOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceV2Server).GetOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.model.QueryServiceV2/GetOperations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceV2Server).GetOperations(ctx, req.(*GetOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryServiceV2_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceV2Server).GetDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.model.QueryServiceV2/GetDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceV2Server).GetDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _QueryServiceV2_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "jaeger.model.QueryServiceV2",
 	HandlerType: (*QueryServiceV2Server)(nil),
@@ -741,15 +1877,33 @@ var _QueryServiceV2_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTrace",
 			Handler:    _QueryServiceV2_GetTrace_Handler,
 		},
+		{
+			MethodName: "GetServices",
+			Handler:    _QueryServiceV2_GetServices_Handler,
+		},
+		{
+			MethodName: "GetOperations",
+			Handler:    _QueryServiceV2_GetOperations_Handler,
+		},
+		{
+			MethodName: "GetDependencies",
+			Handler:    _QueryServiceV2_GetDependencies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FindTraces",
+			Handler:       _QueryServiceV2_FindTraces_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "model.proto",
 }
 
 func (m *KeyValue) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -757,61 +1911,69 @@ func (m *KeyValue) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *KeyValue) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Key) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
+	if len(m.VBinary) > 0 {
+		i -= len(m.VBinary)
+		copy(dAtA[i:], m.VBinary)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.VBinary)))
+		i--
+		dAtA[i] = 0x3a
 	}
-	if m.VType != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(m.VType))
+	if m.VFloat64 != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.VFloat64))))
+		i--
+		dAtA[i] = 0x31
 	}
-	if len(m.VStr) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(len(m.VStr)))
-		i += copy(dAtA[i:], m.VStr)
+	if m.VInt64 != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.VInt64))
+		i--
+		dAtA[i] = 0x28
 	}
 	if m.VBool {
-		dAtA[i] = 0x20
-		i++
+		i--
 		if m.VBool {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
-		i++
+		i--
+		dAtA[i] = 0x20
 	}
-	if m.VInt64 != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(m.VInt64))
+	if len(m.VStr) > 0 {
+		i -= len(m.VStr)
+		copy(dAtA[i:], m.VStr)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.VStr)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.VFloat64 != 0 {
-		dAtA[i] = 0x31
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.VFloat64))))
-		i += 8
+	if m.VType != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.VType))
+		i--
+		dAtA[i] = 0x10
 	}
-	if len(m.VBinary) > 0 {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(len(m.VBinary)))
-		i += copy(dAtA[i:], m.VBinary)
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *Log) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -819,37 +1981,44 @@ func (m *Log) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *Log) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Log) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintModel(dAtA, i, uint64(types.SizeOfStdTime(m.Timestamp)))
-	n1, err := types.StdTimeMarshalTo(m.Timestamp, dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n1
 	if len(m.Fields) > 0 {
-		for _, msg := range m.Fields {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintModel(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Fields[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0x12
 		}
 	}
-	return i, nil
+	n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintModel(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
 func (m *SpanRef) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -857,38 +2026,47 @@ func (m *SpanRef) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *SpanRef) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SpanRef) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintModel(dAtA, i, uint64(m.TraceID.Size()))
-	n2, err := m.TraceID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n2
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintModel(dAtA, i, uint64(m.SpanID.Size()))
-	n3, err := m.SpanID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n3
 	if m.RefType != 0 {
-		dAtA[i] = 0x18
-		i++
 		i = encodeVarintModel(dAtA, i, uint64(m.RefType))
+		i--
+		dAtA[i] = 0x18
+	}
+	{
+		size := m.SpanID.Size()
+		i -= size
+		if _, err := m.SpanID.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintModel(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size := m.TraceID.Size()
+		i -= size
+		if _, err := m.TraceID.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintModel(dAtA, i, uint64(size))
 	}
-	return i, nil
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
 func (m *Process) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -896,35 +2074,43 @@ func (m *Process) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *Process) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Process) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.ServiceName) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(len(m.ServiceName)))
-		i += copy(dAtA[i:], m.ServiceName)
-	}
 	if len(m.Tags) > 0 {
-		for _, msg := range m.Tags {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintModel(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Tags[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0x12
 		}
 	}
-	return i, nil
+	if len(m.ServiceName) > 0 {
+		i -= len(m.ServiceName)
+		copy(dAtA[i:], m.ServiceName)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.ServiceName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
 func (m *Span) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -932,127 +2118,140 @@ func (m *Span) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *Span) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Span) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintModel(dAtA, i, uint64(m.TraceID.Size()))
-	n4, err := m.TraceID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n4
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintModel(dAtA, i, uint64(m.SpanID.Size()))
-	n5, err := m.SpanID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n5
-	if len(m.OperationName) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(len(m.OperationName)))
-		i += copy(dAtA[i:], m.OperationName)
-	}
-	if len(m.References) > 0 {
-		for _, msg := range m.References {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintModel(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if len(m.Warnings) > 0 {
+		for iNdEx := len(m.Warnings) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Warnings[iNdEx])
+			copy(dAtA[i:], m.Warnings[iNdEx])
+			i = encodeVarintModel(dAtA, i, uint64(len(m.Warnings[iNdEx])))
+			i--
+			dAtA[i] = 0x62
 		}
 	}
-	if m.Flags != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(m.Flags))
-	}
-	dAtA[i] = 0x32
-	i++
-	i = encodeVarintModel(dAtA, i, uint64(types.SizeOfStdTime(m.StartTime)))
-	n6, err := types.StdTimeMarshalTo(m.StartTime, dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n6
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintModel(dAtA, i, uint64(types.SizeOfStdDuration(m.Duration)))
-	n7, err := types.StdDurationMarshalTo(m.Duration, dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.ProcessID) > 0 {
+		i -= len(m.ProcessID)
+		copy(dAtA[i:], m.ProcessID)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.ProcessID)))
+		i--
+		dAtA[i] = 0x5a
 	}
-	i += n7
-	if len(m.Tags) > 0 {
-		for _, msg := range m.Tags {
-			dAtA[i] = 0x42
-			i++
-			i = encodeVarintModel(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
+	if m.Process != nil {
+		{
+			size, err := m.Process.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
-			i += n
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x52
 	}
 	if len(m.Logs) > 0 {
-		for _, msg := range m.Logs {
-			dAtA[i] = 0x4a
-			i++
-			i = encodeVarintModel(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0x4a
 		}
 	}
-	if m.Process != nil {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(m.Process.Size()))
-		n8, err := m.Process.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Tags[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
 		}
-		i += n8
 	}
-	if len(m.ProcessID) > 0 {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(len(m.ProcessID)))
-		i += copy(dAtA[i:], m.ProcessID)
+	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Duration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration):])
+	if err3 != nil {
+		return 0, err3
 	}
-	if len(m.Warnings) > 0 {
-		for _, s := range m.Warnings {
-			dAtA[i] = 0x62
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	i -= n3
+	i = encodeVarintModel(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x3a
+	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime):])
+	if err4 != nil {
+		return 0, err4
 	}
-	return i, nil
+	i -= n4
+	i = encodeVarintModel(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x32
+	if m.Flags != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.Flags))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.References) > 0 {
+		for iNdEx := len(m.References) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.References[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.OperationName) > 0 {
+		i -= len(m.OperationName)
+		copy(dAtA[i:], m.OperationName)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.OperationName)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	{
+		size := m.SpanID.Size()
+		i -= size
+		if _, err := m.SpanID.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintModel(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size := m.TraceID.Size()
+		i -= size
+		if _, err := m.TraceID.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintModel(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
 func (m *Trace) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1060,56 +2259,59 @@ func (m *Trace) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *Trace) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Trace) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Spans) > 0 {
-		for _, msg := range m.Spans {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintModel(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if len(m.Warnings) > 0 {
+		for iNdEx := len(m.Warnings) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Warnings[iNdEx])
+			copy(dAtA[i:], m.Warnings[iNdEx])
+			i = encodeVarintModel(dAtA, i, uint64(len(m.Warnings[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
 		}
 	}
 	if len(m.ProcessMap) > 0 {
-		for _, msg := range m.ProcessMap {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintModel(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.ProcessMap) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ProcessMap[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0x12
 		}
 	}
-	if len(m.Warnings) > 0 {
-		for _, s := range m.Warnings {
-			dAtA[i] = 0x1a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
+	if len(m.Spans) > 0 {
+		for iNdEx := len(m.Spans) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Spans[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
 			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *Trace_ProcessMapping) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1117,31 +2319,39 @@ func (m *Trace_ProcessMapping) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *Trace_ProcessMapping) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Trace_ProcessMapping) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.ProcessID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(len(m.ProcessID)))
-		i += copy(dAtA[i:], m.ProcessID)
+	{
+		size, err := m.Process.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintModel(dAtA, i, uint64(size))
 	}
+	i--
 	dAtA[i] = 0x12
-	i++
-	i = encodeVarintModel(dAtA, i, uint64(m.Process.Size()))
-	n9, err := m.Process.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.ProcessID) > 0 {
+		i -= len(m.ProcessID)
+		copy(dAtA[i:], m.ProcessID)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.ProcessID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	i += n9
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *Batch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1149,37 +2359,46 @@ func (m *Batch) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *Batch) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Batch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Spans) > 0 {
-		for _, msg := range m.Spans {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintModel(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	{
+		size, err := m.Process.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintModel(dAtA, i, uint64(size))
 	}
+	i--
 	dAtA[i] = 0x12
-	i++
-	i = encodeVarintModel(dAtA, i, uint64(m.Process.Size()))
-	n10, err := m.Process.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Spans) > 0 {
+		for iNdEx := len(m.Spans) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Spans[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	i += n10
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *PostSpansRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1187,27 +2406,34 @@ func (m *PostSpansRequest) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *PostSpansRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PostSpansRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
 	if m.Batch != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(m.Batch.Size()))
-		n11, err := m.Batch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		{
+			size, err := m.Batch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
 		}
-		i += n11
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *PostSpansResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1215,27 +2441,74 @@ func (m *PostSpansResponse) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *PostSpansResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PostSpansResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
 	if m.Ok {
-		dAtA[i] = 0x8
-		i++
+		i--
 		if m.Ok {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
-		i++
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DependencyLink) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DependencyLink) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DependencyLink) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CallCount != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.CallCount))
+		i--
+		dAtA[i] = 0x18
 	}
-	return i, nil
+	if len(m.Child) > 0 {
+		i -= len(m.Child)
+		copy(dAtA[i:], m.Child)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.Child)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Parent) > 0 {
+		i -= len(m.Parent)
+		copy(dAtA[i:], m.Parent)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.Parent)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
 func (m *GetTraceRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1243,23 +2516,29 @@ func (m *GetTraceRequest) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetTraceRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTraceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
 	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
-		i++
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
 		i = encodeVarintModel(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *GetTraceResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1267,97 +2546,500 @@ func (m *GetTraceResponse) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *GetTraceResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTraceResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
 	if m.Trace != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintModel(dAtA, i, uint64(m.Trace.Size()))
-		n12, err := m.Trace.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		{
+			size, err := m.Trace.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
 		}
-		i += n12
+		i--
+		dAtA[i] = 0xa
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
-func encodeVarintModel(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *TraceQueryParameters) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *KeyValue) Size() (n int) {
+
+func (m *TraceQueryParameters) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TraceQueryParameters) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovModel(uint64(l))
+	if m.NumTraces != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.NumTraces))
+		i--
+		dAtA[i] = 0x40
 	}
-	if m.VType != 0 {
-		n += 1 + sovModel(uint64(m.VType))
+	if m.DurationMax != nil {
+		{
+			size, err := m.DurationMax.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
 	}
-	l = len(m.VStr)
-	if l > 0 {
-		n += 1 + l + sovModel(uint64(l))
+	if m.DurationMin != nil {
+		{
+			size, err := m.DurationMin.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
 	}
-	if m.VBool {
-		n += 2
+	if m.StartTimeMax != nil {
+		{
+			size, err := m.StartTimeMax.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.VInt64 != 0 {
-		n += 1 + sovModel(uint64(m.VInt64))
+	if m.StartTimeMin != nil {
+		{
+			size, err := m.StartTimeMin.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
 	}
-	if m.VFloat64 != 0 {
-		n += 9
+	if len(m.Tags) > 0 {
+		for k := range m.Tags {
+			v := m.Tags[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintModel(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintModel(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintModel(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	l = len(m.VBinary)
-	if l > 0 {
-		n += 1 + l + sovModel(uint64(l))
+	if len(m.OperationName) > 0 {
+		i -= len(m.OperationName)
+		copy(dAtA[i:], m.OperationName)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.OperationName)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if len(m.ServiceName) > 0 {
+		i -= len(m.ServiceName)
+		copy(dAtA[i:], m.ServiceName)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.ServiceName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *Log) Size() (n int) {
+func (m *FindTracesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FindTracesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FindTracesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovModel(uint64(l))
-	if len(m.Fields) > 0 {
-		for _, e := range m.Fields {
-			l = e.Size()
-			n += 1 + l + sovModel(uint64(l))
+	if m.Query != nil {
+		{
+			size, err := m.Query.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *SpanRef) Size() (n int) {
-	var l int
-	_ = l
-	l = m.TraceID.Size()
-	n += 1 + l + sovModel(uint64(l))
-	l = m.SpanID.Size()
-	n += 1 + l + sovModel(uint64(l))
-	if m.RefType != 0 {
-		n += 1 + sovModel(uint64(m.RefType))
+func (m *SpansResponseChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Process) Size() (n int) {
+func (m *SpansResponseChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SpansResponseChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ServiceName)
-	if l > 0 {
-		n += 1 + l + sovModel(uint64(l))
+	if len(m.Spans) > 0 {
+		for iNdEx := len(m.Spans) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Spans[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	if len(m.Tags) > 0 {
+	return len(dAtA) - i, nil
+}
+
+func (m *GetServicesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetServicesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetServicesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetServicesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetServicesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetServicesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Services[iNdEx])
+			copy(dAtA[i:], m.Services[iNdEx])
+			i = encodeVarintModel(dAtA, i, uint64(len(m.Services[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetOperationsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetOperationsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetOperationsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Service) > 0 {
+		i -= len(m.Service)
+		copy(dAtA[i:], m.Service)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.Service)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetOperationsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetOperationsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetOperationsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Operations) > 0 {
+		for iNdEx := len(m.Operations) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Operations[iNdEx])
+			copy(dAtA[i:], m.Operations[iNdEx])
+			i = encodeVarintModel(dAtA, i, uint64(len(m.Operations[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetDependenciesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetDependenciesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetDependenciesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Lookback != nil {
+		{
+			size, err := m.Lookback.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.EndTime != nil {
+		{
+			size, err := m.EndTime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetDependenciesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetDependenciesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetDependenciesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Dependencies) > 0 {
+		for iNdEx := len(m.Dependencies) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Dependencies[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintModel(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintModel(dAtA []byte, offset int, v uint64) int {
+	offset -= sovModel(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *KeyValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if m.VType != 0 {
+		n += 1 + sovModel(uint64(m.VType))
+	}
+	l = len(m.VStr)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if m.VBool {
+		n += 2
+	}
+	if m.VInt64 != 0 {
+		n += 1 + sovModel(uint64(m.VInt64))
+	}
+	if m.VFloat64 != 0 {
+		n += 9
+	}
+	l = len(m.VBinary)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	return n
+}
+
+func (m *Log) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovModel(uint64(l))
+	if len(m.Fields) > 0 {
+		for _, e := range m.Fields {
+			l = e.Size()
+			n += 1 + l + sovModel(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SpanRef) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.TraceID.Size()
+	n += 1 + l + sovModel(uint64(l))
+	l = m.SpanID.Size()
+	n += 1 + l + sovModel(uint64(l))
+	if m.RefType != 0 {
+		n += 1 + sovModel(uint64(m.RefType))
+	}
+	return n
+}
+
+func (m *Process) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ServiceName)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if len(m.Tags) > 0 {
 		for _, e := range m.Tags {
 			l = e.Size()
 			n += 1 + l + sovModel(uint64(l))
@@ -1367,6 +3049,9 @@ func (m *Process) Size() (n int) {
 }
 
 func (m *Span) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	l = m.TraceID.Size()
@@ -1386,9 +3071,9 @@ func (m *Span) Size() (n int) {
 	if m.Flags != 0 {
 		n += 1 + sovModel(uint64(m.Flags))
 	}
-	l = types.SizeOfStdTime(m.StartTime)
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
 	n += 1 + l + sovModel(uint64(l))
-	l = types.SizeOfStdDuration(m.Duration)
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration)
 	n += 1 + l + sovModel(uint64(l))
 	if len(m.Tags) > 0 {
 		for _, e := range m.Tags {
@@ -1420,6 +3105,9 @@ func (m *Span) Size() (n int) {
 }
 
 func (m *Trace) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	if len(m.Spans) > 0 {
@@ -1444,6 +3132,9 @@ func (m *Trace) Size() (n int) {
 }
 
 func (m *Trace_ProcessMapping) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	l = len(m.ProcessID)
@@ -1456,6 +3147,9 @@ func (m *Trace_ProcessMapping) Size() (n int) {
 }
 
 func (m *Batch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	if len(m.Spans) > 0 {
@@ -1470,6 +3164,9 @@ func (m *Batch) Size() (n int) {
 }
 
 func (m *PostSpansRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	if m.Batch != nil {
@@ -1480,6 +3177,9 @@ func (m *PostSpansRequest) Size() (n int) {
 }
 
 func (m *PostSpansResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	if m.Ok {
@@ -1488,7 +3188,30 @@ func (m *PostSpansResponse) Size() (n int) {
 	return n
 }
 
+func (m *DependencyLink) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Parent)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	l = len(m.Child)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if m.CallCount != 0 {
+		n += 1 + sovModel(uint64(m.CallCount))
+	}
+	return n
+}
+
 func (m *GetTraceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	l = len(m.Id)
@@ -1499,6 +3222,9 @@ func (m *GetTraceRequest) Size() (n int) {
 }
 
 func (m *GetTraceResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	if m.Trace != nil {
@@ -1508,21 +3234,170 @@ func (m *GetTraceResponse) Size() (n int) {
 	return n
 }
 
-func sovModel(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *TraceQueryParameters) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ServiceName)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	l = len(m.OperationName)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for k, v := range m.Tags {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovModel(uint64(len(k))) + 1 + len(v) + sovModel(uint64(len(v)))
+			n += mapEntrySize + 1 + sovModel(uint64(mapEntrySize))
 		}
 	}
+	if m.StartTimeMin != nil {
+		l = m.StartTimeMin.Size()
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if m.StartTimeMax != nil {
+		l = m.StartTimeMax.Size()
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if m.DurationMin != nil {
+		l = m.DurationMin.Size()
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if m.DurationMax != nil {
+		l = m.DurationMax.Size()
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if m.NumTraces != 0 {
+		n += 1 + sovModel(uint64(m.NumTraces))
+	}
 	return n
 }
-func sozModel(x uint64) (n int) {
-	return sovModel(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *FindTracesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovModel(uint64(l))
+	}
+	return n
 }
-func (m *KeyValue) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
+
+func (m *SpansResponseChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Spans) > 0 {
+		for _, e := range m.Spans {
+			l = e.Size()
+			n += 1 + l + sovModel(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetServicesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetServicesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for _, s := range m.Services {
+			l = len(s)
+			n += 1 + l + sovModel(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetOperationsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Service)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	return n
+}
+
+func (m *GetOperationsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Operations) > 0 {
+		for _, s := range m.Operations {
+			l = len(s)
+			n += 1 + l + sovModel(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetDependenciesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EndTime != nil {
+		l = m.EndTime.Size()
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if m.Lookback != nil {
+		l = m.Lookback.Size()
+		n += 1 + l + sovModel(uint64(l))
+	}
+	return n
+}
+
+func (m *GetDependenciesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Dependencies) > 0 {
+		for _, e := range m.Dependencies {
+			l = e.Size()
+			n += 1 + l + sovModel(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovModel(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozModel(x uint64) (n int) {
+	return sovModel(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *KeyValue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
 		preIndex := iNdEx
@@ -1536,7 +3411,7 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1564,7 +3439,7 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1574,6 +3449,9 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1593,7 +3471,7 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.VType |= (ValueType(b) & 0x7F) << shift
+				m.VType |= ValueType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1612,7 +3490,7 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1622,6 +3500,9 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1641,17 +3522,1199 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.VBool = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VInt64", wireType)
+			}
+			m.VInt64 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VInt64 |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VFloat64", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.VFloat64 = float64(math.Float64frombits(v))
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VBinary", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VBinary = append(m.VBinary[:0], dAtA[iNdEx:postIndex]...)
+			if m.VBinary == nil {
+				m.VBinary = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Log) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Log: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Log: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.VBool = bool(v != 0)
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VInt64", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, KeyValue{})
+			if err := m.Fields[len(m.Fields)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SpanRef) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SpanRef: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SpanRef: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TraceID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SpanID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefType", wireType)
+			}
+			m.RefType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RefType |= SpanRefType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Process) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Process: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Process: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServiceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, KeyValue{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Span) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Span: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Span: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TraceID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SpanID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OperationName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OperationName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field References", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.References = append(m.References, SpanRef{})
+			if err := m.References[len(m.References)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			m.Flags = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Flags |= Flags(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Duration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, KeyValue{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, Log{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Process", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Process == nil {
+				m.Process = &Process{}
+			}
+			if err := m.Process.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProcessID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Warnings", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Warnings = append(m.Warnings, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Trace) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Trace: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Trace: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Spans = append(m.Spans, &Span{})
+			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessMap", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProcessMap = append(m.ProcessMap, Trace_ProcessMapping{})
+			if err := m.ProcessMap[len(m.ProcessMap)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Warnings", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Warnings = append(m.Warnings, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Trace_ProcessMapping) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProcessMapping: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProcessMapping: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProcessID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Process", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Process.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Batch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.VInt64 = 0
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Batch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Batch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -1661,27 +4724,31 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.VInt64 |= (int64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VFloat64", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthModel
 			}
-			var v uint64
-			if (iNdEx + 8) > l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.VFloat64 = float64(math.Float64frombits(v))
-		case 7:
+			m.Spans = append(m.Spans, &Span{})
+			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VBinary", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Process", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -1691,21 +4758,23 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.VBinary = append(m.VBinary[:0], dAtA[iNdEx:postIndex]...)
-			if m.VBinary == nil {
-				m.VBinary = []byte{}
+			if err := m.Process.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -1714,7 +4783,7 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -1729,7 +4798,7 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Log) Unmarshal(dAtA []byte) error {
+func (m *PostSpansRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1744,7 +4813,7 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1752,15 +4821,15 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Log: wiretype end group for non-group")
+			return fmt.Errorf("proto: PostSpansRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Log: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PostSpansRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1772,7 +4841,7 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1781,18 +4850,74 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+			if m.Batch == nil {
+				m.Batch = &Batch{}
+			}
+			if err := m.Batch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PostSpansResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PostSpansResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PostSpansResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ok", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -1802,30 +4927,19 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthModel
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Fields = append(m.Fields, KeyValue{})
-			if err := m.Fields[len(m.Fields)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Ok = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipModel(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -1840,7 +4954,7 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SpanRef) Unmarshal(dAtA []byte) error {
+func (m *DependencyLink) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1855,7 +4969,7 @@ func (m *SpanRef) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1863,17 +4977,17 @@ func (m *SpanRef) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SpanRef: wiretype end group for non-group")
+			return fmt.Errorf("proto: DependencyLink: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SpanRef: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DependencyLink: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TraceID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -1883,27 +4997,29 @@ func (m *SpanRef) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.TraceID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Parent = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SpanID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Child", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -1913,27 +5029,29 @@ func (m *SpanRef) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.SpanID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Child = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RefType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CallCount", wireType)
 			}
-			m.RefType = 0
+			m.CallCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -1943,7 +5061,7 @@ func (m *SpanRef) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RefType |= (SpanRefType(b) & 0x7F) << shift
+				m.CallCount |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1954,7 +5072,7 @@ func (m *SpanRef) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -1969,7 +5087,7 @@ func (m *SpanRef) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Process) Unmarshal(dAtA []byte) error {
+func (m *GetTraceRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1984,7 +5102,7 @@ func (m *Process) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1992,15 +5110,15 @@ func (m *Process) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Process: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetTraceRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Process: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetTraceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2012,7 +5130,7 @@ func (m *Process) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2022,14 +5140,67 @@ func (m *Process) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ServiceName = string(dAtA[iNdEx:postIndex])
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetTraceResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetTraceResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetTraceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2041,7 +5212,7 @@ func (m *Process) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2050,11 +5221,16 @@ func (m *Process) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = append(m.Tags, KeyValue{})
-			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Trace == nil {
+				m.Trace = &Trace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2064,7 +5240,7 @@ func (m *Process) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -2079,7 +5255,7 @@ func (m *Process) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Span) Unmarshal(dAtA []byte) error {
+func (m *TraceQueryParameters) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2094,7 +5270,7 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2102,17 +5278,17 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Span: wiretype end group for non-group")
+			return fmt.Errorf("proto: TraceQueryParameters: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Span: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TraceQueryParameters: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TraceID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceName", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -2122,53 +5298,25 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.TraceID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SpanID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowModel
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.SpanID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ServiceName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field OperationName", wireType)
 			}
@@ -2182,7 +5330,7 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2192,14 +5340,17 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
 			m.OperationName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field References", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2211,7 +5362,7 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2220,36 +5371,113 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.References = append(m.References, SpanRef{})
-			if err := m.References[len(m.References)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			if m.Tags == nil {
+				m.Tags = make(map[string]string)
 			}
-			m.Flags = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowModel
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowModel
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Flags |= (Flags(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowModel
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthModel
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthModel
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowModel
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthModel
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthModel
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipModel(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthModel
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
 				}
 			}
-		case 6:
+			m.Tags[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimeMin", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2261,7 +5489,7 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2270,46 +5498,22 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowModel
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
+			if postIndex < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := types.StdDurationUnmarshal(&m.Duration, dAtA[iNdEx:postIndex]); err != nil {
+			if m.StartTimeMin == nil {
+				m.StartTimeMin = &types.Timestamp{}
+			}
+			if err := m.StartTimeMin.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimeMax", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2321,7 +5525,7 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2330,17 +5534,22 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = append(m.Tags, KeyValue{})
-			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.StartTimeMax == nil {
+				m.StartTimeMax = &types.Timestamp{}
+			}
+			if err := m.StartTimeMax.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DurationMin", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2352,7 +5561,7 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2361,17 +5570,22 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, Log{})
-			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.DurationMin == nil {
+				m.DurationMin = &types.Duration{}
+			}
+			if err := m.DurationMin.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 10:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Process", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DurationMax", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2383,7 +5597,7 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2392,50 +5606,24 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Process == nil {
-				m.Process = &Process{}
+			if m.DurationMax == nil {
+				m.DurationMax = &types.Duration{}
 			}
-			if err := m.Process.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.DurationMax.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProcessID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowModel
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthModel
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ProcessID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 12:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Warnings", wireType)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumTraces", wireType)
 			}
-			var stringLen uint64
+			m.NumTraces = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -2445,28 +5633,18 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.NumTraces |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthModel
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Warnings = append(m.Warnings, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipModel(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -2481,7 +5659,7 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Trace) Unmarshal(dAtA []byte) error {
+func (m *FindTracesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2496,7 +5674,7 @@ func (m *Trace) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2504,46 +5682,15 @@ func (m *Trace) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Trace: wiretype end group for non-group")
+			return fmt.Errorf("proto: FindTracesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Trace: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FindTracesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowModel
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthModel
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Spans = append(m.Spans, &Span{})
-			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProcessMap", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2555,7 +5702,7 @@ func (m *Trace) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2564,42 +5711,18 @@ func (m *Trace) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ProcessMap = append(m.ProcessMap, Trace_ProcessMapping{})
-			if err := m.ProcessMap[len(m.ProcessMap)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Warnings", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowModel
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if postIndex < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Warnings = append(m.Warnings, string(dAtA[iNdEx:postIndex]))
+			if m.Query == nil {
+				m.Query = &TraceQueryParameters{}
+			}
+			if err := m.Query.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2607,7 +5730,7 @@ func (m *Trace) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -2622,7 +5745,7 @@ func (m *Trace) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Trace_ProcessMapping) Unmarshal(dAtA []byte) error {
+func (m *SpansResponseChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2637,7 +5760,7 @@ func (m *Trace_ProcessMapping) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2645,44 +5768,15 @@ func (m *Trace_ProcessMapping) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProcessMapping: wiretype end group for non-group")
+			return fmt.Errorf("proto: SpansResponseChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProcessMapping: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SpansResponseChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProcessID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowModel
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthModel
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ProcessID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Process", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2694,7 +5788,7 @@ func (m *Trace_ProcessMapping) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2703,10 +5797,14 @@ func (m *Trace_ProcessMapping) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Process.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Spans = append(m.Spans, &Span{})
+			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2716,7 +5814,7 @@ func (m *Trace_ProcessMapping) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -2731,7 +5829,7 @@ func (m *Trace_ProcessMapping) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Batch) Unmarshal(dAtA []byte) error {
+func (m *GetServicesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2746,7 +5844,7 @@ func (m *Batch) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2754,48 +5852,67 @@ func (m *Batch) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Batch: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetServicesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Batch: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetServicesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowModel
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Spans = append(m.Spans, &Span{})
-			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetServicesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
 			}
-			iNdEx = postIndex
-		case 2:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetServicesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetServicesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Process", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -2805,21 +5922,23 @@ func (m *Batch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Process.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Services = append(m.Services, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2827,7 +5946,7 @@ func (m *Batch) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -2842,7 +5961,7 @@ func (m *Batch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PostSpansRequest) Unmarshal(dAtA []byte) error {
+func (m *GetOperationsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2857,7 +5976,7 @@ func (m *PostSpansRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2865,17 +5984,17 @@ func (m *PostSpansRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PostSpansRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperationsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PostSpansRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperationsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -2885,24 +6004,23 @@ func (m *PostSpansRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Batch == nil {
-				m.Batch = &Batch{}
-			}
-			if err := m.Batch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Service = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2910,7 +6028,7 @@ func (m *PostSpansRequest) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -2925,7 +6043,7 @@ func (m *PostSpansRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PostSpansResponse) Unmarshal(dAtA []byte) error {
+func (m *GetOperationsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2940,7 +6058,7 @@ func (m *PostSpansResponse) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2948,17 +6066,17 @@ func (m *PostSpansResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PostSpansResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperationsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PostSpansResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperationsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ok", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operations", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -2968,19 +6086,31 @@ func (m *PostSpansResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Ok = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operations = append(m.Operations, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipModel(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -2995,7 +6125,7 @@ func (m *PostSpansResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetTraceRequest) Unmarshal(dAtA []byte) error {
+func (m *GetDependenciesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3010,7 +6140,7 @@ func (m *GetTraceRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -3018,17 +6148,17 @@ func (m *GetTraceRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetTraceRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetDependenciesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetTraceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetDependenciesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTime", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowModel
@@ -3038,20 +6168,63 @@ func (m *GetTraceRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthModel
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			if m.EndTime == nil {
+				m.EndTime = &types.Timestamp{}
+			}
+			if err := m.EndTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lookback", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Lookback == nil {
+				m.Lookback = &types.Duration{}
+			}
+			if err := m.Lookback.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3059,7 +6232,7 @@ func (m *GetTraceRequest) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -3074,7 +6247,7 @@ func (m *GetTraceRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetTraceResponse) Unmarshal(dAtA []byte) error {
+func (m *GetDependenciesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3089,7 +6262,7 @@ func (m *GetTraceResponse) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -3097,15 +6270,15 @@ func (m *GetTraceResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetTraceResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetDependenciesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetTraceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetDependenciesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Dependencies", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3117,7 +6290,7 @@ func (m *GetTraceResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3126,13 +6299,14 @@ func (m *GetTraceResponse) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthModel
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Trace == nil {
-				m.Trace = &Trace{}
-			}
-			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Dependencies = append(m.Dependencies, &DependencyLink{})
+			if err := m.Dependencies[len(m.Dependencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3142,7 +6316,7 @@ func (m *GetTraceResponse) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthModel
 			}
 			if (iNdEx + skippy) > l {
@@ -3160,6 +6334,7 @@ func (m *GetTraceResponse) Unmarshal(dAtA []byte) error {
 func skipModel(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -3191,10 +6366,8 @@ func skipModel(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -3211,134 +6384,34 @@ func skipModel(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			iNdEx += length
 			if length < 0 {
 				return 0, ErrInvalidLengthModel
 			}
-			return iNdEx, nil
+			iNdEx += length
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowModel
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipModel(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupModel
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthModel
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthModel = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowModel   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthModel        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowModel          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupModel = fmt.Errorf("proto: unexpected end of group")
 )
-
-func init() { proto.RegisterFile("model.proto", fileDescriptorModel) }
-func init() { golang_proto.RegisterFile("model.proto", fileDescriptorModel) }
-
-var fileDescriptorModel = []byte{
-	// 1173 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x41, 0x73, 0xdb, 0xc4,
-	0x17, 0xaf, 0x64, 0xcb, 0x96, 0x9f, 0x1d, 0xff, 0xdd, 0xed, 0xb4, 0x55, 0xdc, 0x3f, 0xb6, 0xab,
-	0x0e, 0x83, 0x1b, 0x88, 0xdd, 0x9a, 0xa4, 0x87, 0x30, 0x0c, 0x13, 0x25, 0xb8, 0x08, 0x9c, 0x38,
-	0xc8, 0x99, 0x32, 0x70, 0xc0, 0x23, 0xdb, 0x6b, 0x45, 0xc4, 0xd6, 0x0a, 0x69, 0xad, 0xe0, 0x01,
-	0x86, 0x19, 0xbe, 0x00, 0x0c, 0x5c, 0x38, 0xc2, 0x91, 0x6f, 0xc1, 0xb1, 0x47, 0x66, 0x7a, 0x82,
-	0x43, 0x60, 0x02, 0x87, 0x7e, 0x0c, 0x66, 0x57, 0x92, 0x63, 0x9b, 0x14, 0x02, 0x07, 0x4e, 0xde,
-	0xb7, 0xef, 0xf7, 0x7e, 0xef, 0xbd, 0xdf, 0x7b, 0xd9, 0x08, 0xb2, 0x63, 0x32, 0xc0, 0xa3, 0x9a,
-	0xeb, 0x11, 0x4a, 0x50, 0xee, 0x03, 0x13, 0x5b, 0xd8, 0xab, 0xf1, 0xbb, 0x62, 0xd9, 0x22, 0xc4,
-	0x1a, 0xe1, 0x3a, 0xf7, 0xf5, 0x26, 0xc3, 0x3a, 0xb5, 0xc7, 0xd8, 0xa7, 0xe6, 0xd8, 0x0d, 0xe1,
-	0xc5, 0xd2, 0x32, 0x60, 0x30, 0xf1, 0x4c, 0x6a, 0x13, 0x27, 0xf2, 0xff, 0x3f, 0xf2, 0x9b, 0xae,
-	0x5d, 0x37, 0x1d, 0x87, 0x50, 0xee, 0xf4, 0x23, 0x6f, 0xc7, 0xb2, 0xe9, 0xd1, 0xa4, 0x57, 0xeb,
-	0x93, 0x71, 0xdd, 0xf2, 0xdc, 0xfe, 0x3a, 0xee, 0x13, 0x7f, 0xea, 0x53, 0x1c, 0x99, 0x96, 0x49,
-	0xf1, 0x89, 0x39, 0x0d, 0xd9, 0xfb, 0xeb, 0x16, 0x76, 0xd6, 0xfd, 0x13, 0xd3, 0xb2, 0xb0, 0x57,
-	0x27, 0x2e, 0x27, 0xba, 0x80, 0x74, 0x7d, 0x9e, 0x94, 0x58, 0xe4, 0xbc, 0x36, 0x66, 0x71, 0x83,
-	0x9f, 0x42, 0xb8, 0xfa, 0x44, 0x00, 0xf9, 0x2d, 0x3c, 0x7d, 0x64, 0x8e, 0x26, 0x18, 0x15, 0x20,
-	0x71, 0x8c, 0xa7, 0x8a, 0x50, 0x11, 0xaa, 0x19, 0x83, 0x1d, 0x51, 0x0d, 0x52, 0x41, 0x97, 0x4e,
-	0x5d, 0xac, 0x88, 0x15, 0xa1, 0x9a, 0x6f, 0xdc, 0xac, 0xcd, 0x0b, 0x54, 0xe3, 0x61, 0x87, 0x53,
-	0x17, 0x1b, 0x52, 0xc0, 0x7e, 0xd0, 0x35, 0x90, 0x82, 0xae, 0x4f, 0x3d, 0x25, 0xc1, 0x39, 0x92,
-	0x41, 0x87, 0x7a, 0xe8, 0x3a, 0x23, 0xe9, 0x11, 0x32, 0x52, 0x92, 0x15, 0xa1, 0x2a, 0x1b, 0x52,
-	0xa0, 0x11, 0x32, 0x42, 0x37, 0x21, 0x1d, 0x74, 0x6d, 0x87, 0x3e, 0xd8, 0x50, 0xa4, 0x8a, 0x50,
-	0x4d, 0x18, 0xa9, 0x40, 0x67, 0x16, 0xba, 0x05, 0x99, 0xa0, 0x3b, 0x1c, 0x11, 0x93, 0xb9, 0x52,
-	0x15, 0xa1, 0x2a, 0x18, 0x72, 0xd0, 0x0c, 0x6d, 0xb4, 0x0a, 0x72, 0xd0, 0xed, 0xd9, 0x8e, 0xe9,
-	0x4d, 0x95, 0x74, 0x45, 0xa8, 0xe6, 0x8c, 0x74, 0xa0, 0x71, 0x73, 0x4b, 0x7e, 0xfa, 0x6d, 0x59,
-	0x78, 0xfa, 0x5d, 0x59, 0x50, 0x3f, 0x83, 0x44, 0x8b, 0x58, 0x48, 0x83, 0xcc, 0x6c, 0x62, 0xbc,
-	0xab, 0x6c, 0xa3, 0x58, 0x0b, 0x47, 0x52, 0x8b, 0x65, 0xa9, 0x1d, 0xc6, 0x08, 0x4d, 0x7e, 0x7c,
-	0x5a, 0xbe, 0xf2, 0xe5, 0x2f, 0x65, 0xc1, 0x38, 0x0f, 0x43, 0x1b, 0x90, 0x1a, 0xda, 0x78, 0x34,
-	0xf0, 0x15, 0xb1, 0x92, 0xa8, 0x66, 0x1b, 0x37, 0x16, 0x15, 0x88, 0xb5, 0xd3, 0x92, 0x2c, 0xd8,
-	0x88, 0xb0, 0xea, 0xf7, 0x02, 0xa4, 0x3b, 0xae, 0xe9, 0x18, 0x78, 0x88, 0x36, 0x41, 0xa6, 0x9e,
-	0xd9, 0xc7, 0x5d, 0x7b, 0xc0, 0x8b, 0xc8, 0x69, 0x45, 0x86, 0xfd, 0xf9, 0xb4, 0x9c, 0x3e, 0x64,
-	0xf7, 0xfa, 0xee, 0xd9, 0xf9, 0xd1, 0x48, 0x73, 0xac, 0x3e, 0x40, 0xf7, 0x21, 0xed, 0xbb, 0xa6,
-	0xc3, 0xa2, 0x44, 0x1e, 0xa5, 0x44, 0x51, 0x29, 0x46, 0xcc, 0x83, 0xa2, 0x93, 0x91, 0x62, 0x40,
-	0x7d, 0x80, 0x36, 0x40, 0xf6, 0xf0, 0x30, 0x9c, 0x57, 0x82, 0xcf, 0x6b, 0x75, 0xb1, 0xda, 0xa8,
-	0x24, 0x3e, 0xb1, 0xb4, 0x17, 0x1e, 0xd4, 0xf7, 0x21, 0x7d, 0xe0, 0x91, 0x3e, 0xf6, 0x7d, 0x74,
-	0x1b, 0x72, 0x3e, 0xf6, 0x02, 0xbb, 0x8f, 0xbb, 0x8e, 0x39, 0xc6, 0xd1, 0x26, 0x64, 0xa3, 0xbb,
-	0x7d, 0x73, 0x8c, 0xd1, 0x3d, 0x48, 0x52, 0xd3, 0xba, 0x9c, 0x1a, 0x1c, 0xa9, 0xfe, 0x94, 0x84,
-	0x24, 0x4b, 0xfc, 0x1f, 0x0a, 0xf1, 0x3c, 0xe4, 0x89, 0x8b, 0xc3, 0x3f, 0xc5, 0xb0, 0x93, 0x70,
-	0x1f, 0x57, 0x66, 0xb7, 0xbc, 0x97, 0x57, 0x00, 0x3c, 0x3c, 0xc4, 0x1e, 0x76, 0xfa, 0xd8, 0x57,
-	0x92, 0xbc, 0xa3, 0xeb, 0x17, 0x2a, 0x16, 0x35, 0x34, 0x07, 0x47, 0x77, 0x40, 0x1a, 0x8e, 0x98,
-	0x12, 0x6c, 0x79, 0x57, 0xb4, 0x95, 0xa8, 0x28, 0xa9, 0xc9, 0x2e, 0x8d, 0xd0, 0x87, 0x76, 0x00,
-	0x7c, 0x6a, 0x7a, 0xb4, 0xcb, 0x16, 0x8a, 0xef, 0xf2, 0xa5, 0x57, 0x90, 0xc7, 0x31, 0x0f, 0x7a,
-	0x0d, 0xe4, 0xf8, 0x5d, 0xe1, 0x2b, 0x9f, 0x6d, 0xac, 0xfe, 0x89, 0x62, 0x37, 0x02, 0x84, 0x0c,
-	0xdf, 0x30, 0x86, 0x59, 0xd0, 0x6c, 0x66, 0xf2, 0x65, 0x67, 0x86, 0x5e, 0x84, 0xe4, 0x88, 0x58,
-	0xbe, 0x92, 0xe1, 0x11, 0x57, 0x17, 0x23, 0x5a, 0xc4, 0x8a, 0xc1, 0x0c, 0x84, 0xea, 0x90, 0x76,
-	0xc3, 0x05, 0x52, 0x80, 0x97, 0xb7, 0xa4, 0x61, 0xb4, 0x5d, 0x46, 0x8c, 0x42, 0x2f, 0x01, 0x44,
-	0x47, 0x36, 0xd4, 0x2c, 0x1b, 0x8d, 0xb6, 0x72, 0x76, 0x5a, 0xce, 0x44, 0x48, 0x7d, 0xd7, 0xc8,
-	0x44, 0x00, 0x7d, 0x80, 0x8a, 0x20, 0x9f, 0x98, 0x9e, 0x63, 0x3b, 0x96, 0xaf, 0xe4, 0x2a, 0x89,
-	0x6a, 0xc6, 0x98, 0xd9, 0xea, 0x17, 0x22, 0x48, 0x7c, 0x61, 0x50, 0x15, 0x24, 0x36, 0x7c, 0x5f,
-	0x11, 0x78, 0xc9, 0xe8, 0x82, 0x31, 0x86, 0x00, 0xa4, 0x43, 0x36, 0xce, 0x3e, 0x36, 0xdd, 0x68,
-	0x91, 0xd5, 0x45, 0x3c, 0xe7, 0x8c, 0x0b, 0xdf, 0x33, 0x5d, 0xd7, 0x76, 0xe2, 0x9e, 0xe3, 0xd2,
-	0xf7, 0x4c, 0x77, 0xa1, 0xb4, 0xc4, 0x62, 0x69, 0xc5, 0x09, 0xe4, 0x17, 0xe3, 0x97, 0xda, 0x16,
-	0xfe, 0xa6, 0xed, 0xcd, 0x73, 0x55, 0xc5, 0xbf, 0x50, 0x35, 0xaa, 0x2a, 0xc6, 0xaa, 0x47, 0x20,
-	0x69, 0x26, 0xed, 0x1f, 0xfd, 0x03, 0x41, 0xfe, 0x65, 0xa6, 0x57, 0xa1, 0x70, 0x40, 0x7c, 0xca,
-	0x98, 0x7c, 0x03, 0x7f, 0x38, 0xc1, 0x3e, 0x45, 0x77, 0x41, 0xea, 0xb1, 0xec, 0xd1, 0x6b, 0x7b,
-	0x6d, 0x91, 0x88, 0x17, 0x66, 0x84, 0x08, 0xf5, 0x0e, 0x5c, 0x9d, 0x0b, 0xf7, 0x5d, 0xe2, 0xf8,
-	0x18, 0xe5, 0x41, 0x24, 0xc7, 0x3c, 0x58, 0x36, 0x44, 0x72, 0xac, 0xde, 0x86, 0xff, 0x3d, 0xc4,
-	0x94, 0x4f, 0x23, 0x4e, 0x91, 0x07, 0x31, 0x56, 0xcf, 0x10, 0xed, 0x01, 0x2b, 0xe3, 0x1c, 0x12,
-	0xd1, 0xdc, 0x05, 0x89, 0xbf, 0x1e, 0x17, 0x97, 0x11, 0x62, 0x43, 0xc4, 0xda, 0xeb, 0x90, 0x99,
-	0xfd, 0x17, 0x43, 0x00, 0xa9, 0xce, 0xa1, 0xa1, 0xef, 0x3f, 0x2c, 0x5c, 0x41, 0x32, 0x24, 0xb5,
-	0x76, 0xbb, 0x55, 0x10, 0x50, 0x06, 0x24, 0x7d, 0xff, 0xf0, 0xc1, 0x46, 0x41, 0x44, 0x59, 0x48,
-	0x37, 0x5b, 0xed, 0x6d, 0x66, 0x24, 0x18, 0x5a, 0xd3, 0xf7, 0xb7, 0x8d, 0x77, 0x0b, 0xc9, 0xb5,
-	0x75, 0xc8, 0xce, 0x3d, 0xae, 0x28, 0x07, 0xf2, 0xce, 0x1b, 0x7a, 0x6b, 0xb7, 0xdb, 0x6e, 0x16,
-	0xae, 0xa0, 0x02, 0xe4, 0x9a, 0xed, 0x56, 0xab, 0xfd, 0x4e, 0xa7, 0xdb, 0x34, 0xda, 0x7b, 0x05,
-	0xa1, 0xf1, 0x09, 0xa0, 0x1d, 0x32, 0x1a, 0xe1, 0x3e, 0x25, 0x5e, 0x27, 0x7c, 0x5d, 0x1f, 0x35,
-	0xd0, 0x10, 0x32, 0x33, 0x49, 0x50, 0x69, 0x69, 0x08, 0x4b, 0x52, 0x17, 0xcb, 0xcf, 0xf4, 0x87,
-	0x22, 0xa8, 0xca, 0xe7, 0x4f, 0x7e, 0xff, 0x5a, 0x44, 0xea, 0x0a, 0xff, 0xfc, 0x08, 0x1a, 0x75,
-	0x3e, 0xed, 0x2d, 0x61, 0xad, 0xf1, 0x11, 0xe4, 0xdf, 0x9e, 0x60, 0x6f, 0x3a, 0x9f, 0x59, 0x8e,
-	0x45, 0x44, 0xcf, 0x2d, 0x12, 0x2f, 0xe9, 0x5f, 0x2c, 0x3d, 0xcb, 0x1d, 0xa5, 0xbd, 0xc5, 0xd3,
-	0x5e, 0x47, 0xd7, 0xe2, 0xb4, 0x5c, 0x67, 0xbf, 0xfe, 0xb1, 0x3d, 0xf8, 0x54, 0xa3, 0x5f, 0x6d,
-	0x6b, 0x48, 0x6a, 0x24, 0xee, 0xd7, 0xee, 0xad, 0x89, 0x82, 0xe8, 0x6d, 0x02, 0xbc, 0xc9, 0xd9,
-	0x2a, 0xdb, 0x07, 0x3a, 0x7a, 0xe1, 0x88, 0x52, 0xd7, 0xdf, 0xaa, 0xd7, 0xe7, 0xbe, 0x61, 0xc2,
-	0x64, 0x8c, 0xc3, 0x76, 0xac, 0xc8, 0x7a, 0x7c, 0x56, 0x12, 0x7e, 0x3c, 0x2b, 0x09, 0xbf, 0x9e,
-	0x95, 0x84, 0x1f, 0x7e, 0x2b, 0x09, 0x70, 0xc3, 0x26, 0xb5, 0x05, 0x60, 0x58, 0xdc, 0x7b, 0x12,
-	0xff, 0xe9, 0xa5, 0xf8, 0x33, 0xf9, 0xf2, 0x1f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xe1, 0x29, 0x88,
-	0x10, 0xeb, 0x09, 0x00, 0x00,
-}
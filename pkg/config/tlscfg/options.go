@@ -0,0 +1,59 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlscfg
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// Options describes the configuration properties of a TLS client connection
+type Options struct {
+	Enabled        bool
+	CaPath         string
+	CertPath       string
+	KeyPath        string
+	ServerName     string
+	SkipHostVerify bool
+}
+
+// Config loads the CA bundle and the client key pair, if configured, into a new tls.Config
+func (o Options) Config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.SkipHostVerify,
+	}
+	if o.CaPath != "" {
+		caCert, err := ioutil.ReadFile(o.CaPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read TLS CA file")
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.Errorf("failed to parse TLS CA file %s", o.CaPath)
+		}
+	}
+	if o.CertPath != "" || o.KeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertPath, o.KeyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load TLS client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
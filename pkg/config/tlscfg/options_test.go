@@ -0,0 +1,75 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlscfg
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caFile, err := ioutil.TempFile("", "tls-ca")
+	require.NoError(t, err)
+	defer os.Remove(caFile.Name())
+	require.NoError(t, pem.Encode(caFile, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	require.NoError(t, caFile.Close())
+
+	get := func(options Options) error {
+		cfg, err := options.Config()
+		require.NoError(t, err)
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+		_, err = client.Get(server.URL)
+		return err
+	}
+	assert.Error(t, get(Options{Enabled: true}), "the test server certificate is not trusted by default")
+	assert.NoError(t, get(Options{Enabled: true, CaPath: caFile.Name()}))
+	assert.NoError(t, get(Options{Enabled: true, SkipHostVerify: true}))
+
+	cfg, err := Options{ServerName: "example.com"}.Config()
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", cfg.ServerName)
+}
+
+func TestConfigErrors(t *testing.T) {
+	notPEM, err := ioutil.TempFile("", "tls-ca")
+	require.NoError(t, err)
+	defer os.Remove(notPEM.Name())
+	notPEM.WriteString("not a certificate")
+	notPEM.Close()
+
+	testCases := []struct {
+		options  Options
+		expected string
+	}{
+		{options: Options{CaPath: "/does/not/exist"}, expected: "failed to read TLS CA file"},
+		{options: Options{CaPath: notPEM.Name()}, expected: "failed to parse TLS CA file"},
+		{options: Options{CertPath: "/does/not/exist", KeyPath: "/does/not/exist"}, expected: "failed to load TLS client certificate"},
+	}
+	for _, testCase := range testCases {
+		_, err := testCase.options.Config()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testCase.expected)
+	}
+}
@@ -28,6 +28,8 @@ type Client interface {
 	Index() IndexService
 	Search(indices ...string) SearchService
 	MultiSearch() MultiSearchService
+	DeleteByQuery(indices ...string) DeleteByQueryService
+	Refresh(indices ...string) RefreshService
 	io.Closer
 }
 
@@ -67,3 +69,15 @@ type MultiSearchService interface {
 	Index(indices ...string) MultiSearchService
 	Do(ctx context.Context) (*elastic.MultiSearchResult, error)
 }
+
+// DeleteByQueryService is an abstraction for elastic.DeleteByQueryService
+type DeleteByQueryService interface {
+	Query(query elastic.Query) DeleteByQueryService
+	IgnoreUnavailable(ignoreUnavailable bool) DeleteByQueryService
+	Do(ctx context.Context) (*elastic.BulkIndexByScrollResponse, error)
+}
+
+// RefreshService is an abstraction for elastic.RefreshService
+type RefreshService interface {
+	Do(ctx context.Context) (*elastic.RefreshResult, error)
+}
@@ -16,6 +16,9 @@ package config
 
 import (
 	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
 	"sync"
 	"time"
 
@@ -24,23 +27,27 @@ import (
 	"go.uber.org/zap"
 	"gopkg.in/olivere/elastic.v5"
 
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
 	"github.com/jaegertracing/jaeger/pkg/es"
 	storageMetrics "github.com/jaegertracing/jaeger/storage/spanstore/metrics"
 )
 
 // Configuration describes the configuration properties needed to connect to an ElasticSearch cluster
 type Configuration struct {
-	Servers           []string
-	Username          string
-	Password          string
-	Sniffer           bool          // https://github.com/olivere/elastic/wiki/Sniffing
-	MaxSpanAge        time.Duration `yaml:"max_span_age"` // configures the maximum lookback on span reads
-	NumShards         int64         `yaml:"shards"`
-	NumReplicas       int64         `yaml:"replicas"`
-	BulkSize          int
-	BulkWorkers       int
-	BulkActions       int
-	BulkFlushInterval time.Duration
+	Servers             []string
+	Username            string
+	Password            string
+	Sniffer             bool          // https://github.com/olivere/elastic/wiki/Sniffing
+	MaxSpanAge          time.Duration `yaml:"max_span_age"` // configures the maximum lookback on span reads
+	NumShards           int64         `yaml:"shards"`
+	NumReplicas         int64         `yaml:"replicas"`
+	BulkSize            int
+	BulkWorkers         int
+	BulkActions         int
+	BulkFlushInterval   time.Duration
+	IndexPrefix         string `yaml:"index_prefix"`
+	UseReadWriteAliases bool   `yaml:"use_aliases"` // read and write through aliases managed by jaeger-es-rollover
+	TLS                 tlscfg.Options
 }
 
 // ClientBuilder creates new es.Client
@@ -49,6 +56,8 @@ type ClientBuilder interface {
 	GetNumShards() int64
 	GetNumReplicas() int64
 	GetMaxSpanAge() time.Duration
+	GetIndexPrefix() string
+	GetUseReadWriteAliases() bool
 }
 
 // NewClient creates a new ElasticSearch client
@@ -56,7 +65,11 @@ func (c *Configuration) NewClient(logger *zap.Logger, metricsFactory metrics.Fac
 	if len(c.Servers) < 1 {
 		return nil, errors.New("No servers specified")
 	}
-	rawClient, err := elastic.NewClient(c.GetConfigs()...)
+	options, err := c.GetConfigs()
+	if err != nil {
+		return nil, err
+	}
+	rawClient, err := elastic.NewClient(options...)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +154,15 @@ func (c *Configuration) ApplyDefaults(source *Configuration) {
 	if c.BulkFlushInterval == 0 {
 		c.BulkFlushInterval = source.BulkFlushInterval
 	}
+	if c.IndexPrefix == "" {
+		c.IndexPrefix = source.IndexPrefix
+	}
+	if c.UseReadWriteAliases == false {
+		c.UseReadWriteAliases = source.UseReadWriteAliases
+	}
+	if c.TLS.Enabled == false {
+		c.TLS = source.TLS
+	}
 }
 
 // GetNumShards returns number of shards from Configuration
@@ -158,11 +180,48 @@ func (c *Configuration) GetMaxSpanAge() time.Duration {
 	return c.MaxSpanAge
 }
 
+// GetIndexPrefix returns index prefix
+func (c *Configuration) GetIndexPrefix() string {
+	return c.IndexPrefix
+}
+
+// GetUseReadWriteAliases indicates whether read and write aliases should be used instead of daily indices
+func (c *Configuration) GetUseReadWriteAliases() bool {
+	return c.UseReadWriteAliases
+}
+
 // GetConfigs wraps the configs to feed to the ElasticSearch client init
-func (c *Configuration) GetConfigs() []elastic.ClientOptionFunc {
+func (c *Configuration) GetConfigs() ([]elastic.ClientOptionFunc, error) {
 	options := make([]elastic.ClientOptionFunc, 3)
 	options[0] = elastic.SetURL(c.Servers...)
 	options[1] = elastic.SetBasicAuth(c.Username, c.Password)
 	options[2] = elastic.SetSniff(c.Sniffer)
-	return options
+	if c.TLS.Enabled {
+		tlsConfig, err := c.TLS.Config()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, elastic.SetHttpClient(&http.Client{Transport: newTransport(tlsConfig)}))
+		// the sniffer builds the URLs of the discovered nodes with this scheme
+		options = append(options, elastic.SetScheme("https"))
+	}
+	return options, nil
+}
+
+// newTransport returns a transport with the same settings as http.DefaultTransport and the given TLS configuration.
+// http.Transport cannot be copied since it holds mutexes, and Transport.Clone is not available in Go 1.10.
+func newTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			DualStack: true,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
 }
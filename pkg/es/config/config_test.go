@@ -0,0 +1,68 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/olivere/elastic.v5"
+
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
+)
+
+func TestGetConfigs(t *testing.T) {
+	c := &Configuration{Servers: []string{"http://localhost:9200"}}
+	options, err := c.GetConfigs()
+	require.NoError(t, err)
+	assert.Len(t, options, 3)
+
+	c.TLS = tlscfg.Options{Enabled: true}
+	options, err = c.GetConfigs()
+	require.NoError(t, err)
+	assert.Len(t, options, 5, "TLS adds a custom http client and the https scheme")
+
+	c.TLS = tlscfg.Options{Enabled: true, CaPath: "/does/not/exist"}
+	_, err = c.GetConfigs()
+	assert.Error(t, err)
+}
+
+func TestGetConfigsTLSWithSniffer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_nodes/http" {
+			fmt.Fprintf(w, `{"nodes": {"node1": {"http": {"publish_address": %q}}}}`, r.Host)
+		}
+	}))
+	defer server.Close()
+
+	c := &Configuration{
+		Servers: []string{server.URL},
+		Sniffer: true,
+		TLS:     tlscfg.Options{Enabled: true, SkipHostVerify: true},
+	}
+	options, err := c.GetConfigs()
+	require.NoError(t, err)
+	client, err := elastic.NewClient(options...)
+	require.NoError(t, err, "the sniffed nodes must be reached over https")
+	defer client.Stop()
+	exists, err := client.IndexExists("jaeger-span-1995-04-21").Do(context.Background())
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
@@ -54,6 +54,28 @@ func (_m *Client) CreateIndex(index string) es.IndicesCreateService {
 	return r0
 }
 
+// DeleteByQuery provides a mock function with given fields: indices
+func (_m *Client) DeleteByQuery(indices ...string) es.DeleteByQueryService {
+	_va := make([]interface{}, len(indices))
+	for _i := range indices {
+		_va[_i] = indices[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 es.DeleteByQueryService
+	if rf, ok := ret.Get(0).(func(...string) es.DeleteByQueryService); ok {
+		r0 = rf(indices...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(es.DeleteByQueryService)
+		}
+	}
+
+	return r0
+}
+
 // Index provides a mock function with given fields:
 func (_m *Client) Index() es.IndexService {
 	ret := _m.Called()
@@ -102,6 +124,28 @@ func (_m *Client) MultiSearch() es.MultiSearchService {
 	return r0
 }
 
+// Refresh provides a mock function with given fields: indices
+func (_m *Client) Refresh(indices ...string) es.RefreshService {
+	_va := make([]interface{}, len(indices))
+	for _i := range indices {
+		_va[_i] = indices[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 es.RefreshService
+	if rf, ok := ret.Get(0).(func(...string) es.RefreshService); ok {
+		r0 = rf(indices...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(es.RefreshService)
+		}
+	}
+
+	return r0
+}
+
 // Search provides a mock function with given fields: indices
 func (_m *Client) Search(indices ...string) es.SearchService {
 	_va := make([]interface{}, len(indices))
@@ -0,0 +1,82 @@
+// Code generated by mockery v1.0.0
+
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mocks
+
+import context "context"
+import elastic "gopkg.in/olivere/elastic.v5"
+import es "github.com/jaegertracing/jaeger/pkg/es"
+import mock "github.com/stretchr/testify/mock"
+
+// DeleteByQueryService is an autogenerated mock type for the DeleteByQueryService type
+type DeleteByQueryService struct {
+	mock.Mock
+}
+
+// Do provides a mock function with given fields: ctx
+func (_m *DeleteByQueryService) Do(ctx context.Context) (*elastic.BulkIndexByScrollResponse, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *elastic.BulkIndexByScrollResponse
+	if rf, ok := ret.Get(0).(func(context.Context) *elastic.BulkIndexByScrollResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elastic.BulkIndexByScrollResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IgnoreUnavailable provides a mock function with given fields: ignoreUnavailable
+func (_m *DeleteByQueryService) IgnoreUnavailable(ignoreUnavailable bool) es.DeleteByQueryService {
+	ret := _m.Called(ignoreUnavailable)
+
+	var r0 es.DeleteByQueryService
+	if rf, ok := ret.Get(0).(func(bool) es.DeleteByQueryService); ok {
+		r0 = rf(ignoreUnavailable)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(es.DeleteByQueryService)
+		}
+	}
+
+	return r0
+}
+
+// Query provides a mock function with given fields: query
+func (_m *DeleteByQueryService) Query(query elastic.Query) es.DeleteByQueryService {
+	ret := _m.Called(query)
+
+	var r0 es.DeleteByQueryService
+	if rf, ok := ret.Get(0).(func(elastic.Query) es.DeleteByQueryService); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(es.DeleteByQueryService)
+		}
+	}
+
+	return r0
+}
@@ -59,6 +59,16 @@ func (c ESClient) MultiSearch() MultiSearchService {
 	return WrapESMultiSearchService(c.client.MultiSearch())
 }
 
+// DeleteByQuery calls this function to internal client.
+func (c ESClient) DeleteByQuery(indices ...string) DeleteByQueryService {
+	return WrapESDeleteByQueryService(c.client.DeleteByQuery(indices...))
+}
+
+// Refresh calls this function to internal client.
+func (c ESClient) Refresh(indices ...string) RefreshService {
+	return WrapESRefreshService(c.client.Refresh(indices...))
+}
+
 // Close closes ESClient and flushes all data to the storage.
 func (c ESClient) Close() error {
 	return c.bulkService.Close()
@@ -207,3 +217,47 @@ func (s ESMultiSearchService) Index(indices ...string) MultiSearchService {
 func (s ESMultiSearchService) Do(ctx context.Context) (*elastic.MultiSearchResult, error) {
 	return s.multiSearchService.Do(ctx)
 }
+
+// ---
+
+// ESDeleteByQueryService is a wrapper around elastic.DeleteByQueryService
+type ESDeleteByQueryService struct {
+	deleteByQueryService *elastic.DeleteByQueryService
+}
+
+// WrapESDeleteByQueryService creates an ESDeleteByQueryService out of *elastic.DeleteByQueryService.
+func WrapESDeleteByQueryService(deleteByQueryService *elastic.DeleteByQueryService) ESDeleteByQueryService {
+	return ESDeleteByQueryService{deleteByQueryService: deleteByQueryService}
+}
+
+// Query calls this function to internal service.
+func (s ESDeleteByQueryService) Query(query elastic.Query) DeleteByQueryService {
+	return WrapESDeleteByQueryService(s.deleteByQueryService.Query(query))
+}
+
+// IgnoreUnavailable calls this function to internal service.
+func (s ESDeleteByQueryService) IgnoreUnavailable(ignoreUnavailable bool) DeleteByQueryService {
+	return WrapESDeleteByQueryService(s.deleteByQueryService.IgnoreUnavailable(ignoreUnavailable))
+}
+
+// Do calls this function to internal service.
+func (s ESDeleteByQueryService) Do(ctx context.Context) (*elastic.BulkIndexByScrollResponse, error) {
+	return s.deleteByQueryService.Do(ctx)
+}
+
+// ---
+
+// ESRefreshService is a wrapper around elastic.RefreshService
+type ESRefreshService struct {
+	refreshService *elastic.RefreshService
+}
+
+// WrapESRefreshService creates an ESRefreshService out of *elastic.RefreshService.
+func WrapESRefreshService(refreshService *elastic.RefreshService) ESRefreshService {
+	return ESRefreshService{refreshService: refreshService}
+}
+
+// Do calls this function to internal service.
+func (s ESRefreshService) Do(ctx context.Context) (*elastic.RefreshResult, error) {
+	return s.refreshService.Do(ctx)
+}
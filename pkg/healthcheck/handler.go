@@ -56,6 +56,7 @@ type HealthCheck struct {
 	state   int32 // atomic, keep at the top to be word-aligned
 	logger  *zap.Logger
 	mapping map[Status]int
+	mux     *http.ServeMux
 	server  *http.Server
 }
 
@@ -77,6 +78,7 @@ func New(state Status, options ...Option) *HealthCheck {
 			Unavailable: http.StatusServiceUnavailable,
 			Ready:       http.StatusNoContent,
 		},
+		mux: http.NewServeMux(),
 	}
 	for _, option := range options {
 		option(hc)
@@ -84,9 +86,21 @@ func New(state Status, options ...Option) *HealthCheck {
 	if hc.logger == nil {
 		hc.logger = zap.NewNop()
 	}
+	hc.mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(hc.mapping[hc.Get()])
+		// this is written only for response with an entity, so, it won't be used for a 204 - No content
+		w.Write([]byte("Server not available"))
+	})
+	version.RegisterHandler(hc.mux, hc.logger)
 	return hc
 }
 
+// Handle registers an additional handler for the given pattern on the health check HTTP server,
+// e.g. to expose the internal state of the service. It can be called before or after Serve.
+func (hc *HealthCheck) Handle(pattern string, handler http.Handler) {
+	hc.mux.Handle(pattern, handler)
+}
+
 // Serve starts HTTP server on the specified port.
 func (hc *HealthCheck) Serve(port int) (*HealthCheck, error) {
 	portStr := ":" + strconv.Itoa(port)
@@ -115,16 +129,9 @@ func (hc *HealthCheck) Close() error {
 	return hc.server.Shutdown(context.Background())
 }
 
-// httpHandler creates a new HTTP handler.
+// httpHandler returns the HTTP handler serving the health status and the additional handlers.
 func (hc *HealthCheck) httpHandler() http.Handler {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(hc.mapping[hc.Get()])
-		// this is written only for response with an entity, so, it won't be used for a 204 - No content
-		w.Write([]byte("Server not available"))
-	})
-	version.RegisterHandler(mux, hc.logger)
-	return mux
+	return hc.mux
 }
 
 // Set a new health check status
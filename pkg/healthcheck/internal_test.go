@@ -41,6 +41,24 @@ func TestHttpCall(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 }
 
+func TestHandle(t *testing.T) {
+	hc := New(Unavailable)
+	server := httptest.NewServer(hc.httpHandler())
+	defer server.Close()
+
+	hc.Handle("/extra", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	resp, err := http.Get(server.URL + "/extra")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
 func TestListenerClose(t *testing.T) {
 	logger, logBuf := testutils.NewLogger()
 	hc := New(Unavailable, Logger(logger))
@@ -0,0 +1,144 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"github.com/spf13/viper"
+
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
+)
+
+const (
+	// MechanismPlain authenticates with SASL/PLAIN
+	MechanismPlain = "PLAIN"
+	// MechanismSCRAMSHA256 authenticates with SASL/SCRAM using SHA-256
+	MechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	// MechanismSCRAMSHA512 authenticates with SASL/SCRAM using SHA-512
+	MechanismSCRAMSHA512 = "SCRAM-SHA-512"
+
+	suffixSASLMechanism = ".sasl.mechanism"
+	suffixSASLUsername  = ".sasl.username"
+	suffixSASLPassword  = ".sasl.password"
+	suffixTLS           = ".tls"
+	suffixCert          = ".tls.cert"
+	suffixKey           = ".tls.key"
+	suffixCA            = ".tls.ca"
+	suffixServerName    = ".tls.server-name"
+	suffixSkipVerify    = ".tls.skip-host-verify"
+)
+
+// AuthenticationConfig describes how to secure the connections to the kafka brokers
+type AuthenticationConfig struct {
+	// SASLMechanism is one of MechanismPlain, MechanismSCRAMSHA256 or MechanismSCRAMSHA512,
+	// SASL is disabled if it is empty
+	SASLMechanism string
+	Username      string
+	Password      string `json:"-"`
+	TLS           tlscfg.Options
+}
+
+// AddFlags adds the authentication flags using the given prefix, e.g. "kafka"
+func AddFlags(configPrefix string, flagSet *flag.FlagSet) {
+	flagSet.String(
+		configPrefix+suffixSASLMechanism,
+		"",
+		fmt.Sprintf(`The SASL mechanism ("%s", "%s" or "%s") used to authenticate with kafka (SASL is disabled if empty)`,
+			MechanismPlain, MechanismSCRAMSHA256, MechanismSCRAMSHA512))
+	flagSet.String(
+		configPrefix+suffixSASLUsername,
+		"",
+		"The username used to authenticate with kafka")
+	flagSet.String(
+		configPrefix+suffixSASLPassword,
+		"",
+		"The password used to authenticate with kafka")
+	flagSet.Bool(
+		configPrefix+suffixTLS,
+		false,
+		"Enable TLS to connect to kafka")
+	flagSet.String(
+		configPrefix+suffixCert,
+		"",
+		"Path to TLS certificate file")
+	flagSet.String(
+		configPrefix+suffixKey,
+		"",
+		"Path to TLS key file")
+	flagSet.String(
+		configPrefix+suffixCA,
+		"",
+		"Path to TLS CA file")
+	flagSet.String(
+		configPrefix+suffixServerName,
+		"",
+		"Override the TLS server name")
+	flagSet.Bool(
+		configPrefix+suffixSkipVerify,
+		false,
+		"Skip the verification of the kafka brokers certificates")
+}
+
+// InitFromViper initializes AuthenticationConfig with properties from viper using the given prefix
+func (c *AuthenticationConfig) InitFromViper(configPrefix string, v *viper.Viper) {
+	c.SASLMechanism = v.GetString(configPrefix + suffixSASLMechanism)
+	c.Username = v.GetString(configPrefix + suffixSASLUsername)
+	c.Password = v.GetString(configPrefix + suffixSASLPassword)
+	c.TLS.Enabled = v.GetBool(configPrefix + suffixTLS)
+	c.TLS.CertPath = v.GetString(configPrefix + suffixCert)
+	c.TLS.KeyPath = v.GetString(configPrefix + suffixKey)
+	c.TLS.CaPath = v.GetString(configPrefix + suffixCA)
+	c.TLS.ServerName = v.GetString(configPrefix + suffixServerName)
+	c.TLS.SkipHostVerify = v.GetBool(configPrefix + suffixSkipVerify)
+}
+
+// SetConfiguration applies the authentication to the sarama configuration
+func (c *AuthenticationConfig) SetConfiguration(saramaConfig *sarama.Config) error {
+	if c.TLS.Enabled {
+		tlsConfig, err := c.TLS.Config()
+		if err != nil {
+			return err
+		}
+		saramaConfig.Net.TLS.Enable = true
+		saramaConfig.Net.TLS.Config = tlsConfig
+	}
+	if c.SASLMechanism == "" {
+		return nil
+	}
+	saramaConfig.Net.SASL.Enable = true
+	saramaConfig.Net.SASL.User = c.Username
+	saramaConfig.Net.SASL.Password = c.Password
+	switch c.SASLMechanism {
+	case MechanismPlain:
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	case MechanismSCRAMSHA256:
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+		saramaConfig.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: sha256HashGenerator}
+		}
+	case MechanismSCRAMSHA512:
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+		saramaConfig.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: sha512HashGenerator}
+		}
+	default:
+		return fmt.Errorf(`SASL mechanism '%s' not recognised, use one of ("%s", "%s" or "%s")`,
+			c.SASLMechanism, MechanismPlain, MechanismSCRAMSHA256, MechanismSCRAMSHA512)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
+)
+
+func TestInitFromViper(t *testing.T) {
+	v, command := config.Viperize(func(flagSet *flag.FlagSet) {
+		AddFlags("kafka", flagSet)
+	})
+	command.ParseFlags([]string{
+		"--kafka.sasl.mechanism=SCRAM-SHA-512",
+		"--kafka.sasl.username=user",
+		"--kafka.sasl.password=secret",
+		"--kafka.tls=true",
+		"--kafka.tls.cert=/tmp/cert",
+		"--kafka.tls.key=/tmp/key",
+		"--kafka.tls.ca=/tmp/ca",
+		"--kafka.tls.server-name=kafka",
+		"--kafka.tls.skip-host-verify=true"})
+	c := &AuthenticationConfig{}
+	c.InitFromViper("kafka", v)
+
+	assert.Equal(t, AuthenticationConfig{
+		SASLMechanism: MechanismSCRAMSHA512,
+		Username:      "user",
+		Password:      "secret",
+		TLS: tlscfg.Options{
+			Enabled:        true,
+			CertPath:       "/tmp/cert",
+			KeyPath:        "/tmp/key",
+			CaPath:         "/tmp/ca",
+			ServerName:     "kafka",
+			SkipHostVerify: true,
+		},
+	}, *c)
+}
+
+func TestSetConfiguration(t *testing.T) {
+	testCases := []struct {
+		mechanism string
+		expected  sarama.SASLMechanism
+		scram     bool
+	}{
+		{mechanism: MechanismPlain, expected: sarama.SASLTypePlaintext},
+		{mechanism: MechanismSCRAMSHA256, expected: sarama.SASLTypeSCRAMSHA256, scram: true},
+		{mechanism: MechanismSCRAMSHA512, expected: sarama.SASLTypeSCRAMSHA512, scram: true},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.mechanism, func(t *testing.T) {
+			c := &AuthenticationConfig{
+				SASLMechanism: testCase.mechanism,
+				Username:      "user",
+				Password:      "secret",
+				TLS:           tlscfg.Options{Enabled: true, ServerName: "kafka"},
+			}
+			saramaConfig := sarama.NewConfig()
+			require.NoError(t, c.SetConfiguration(saramaConfig))
+
+			assert.True(t, saramaConfig.Net.SASL.Enable)
+			assert.Equal(t, testCase.expected, saramaConfig.Net.SASL.Mechanism)
+			assert.Equal(t, "user", saramaConfig.Net.SASL.User)
+			assert.Equal(t, "secret", saramaConfig.Net.SASL.Password)
+			if testCase.scram {
+				require.NotNil(t, saramaConfig.Net.SASL.SCRAMClientGeneratorFunc)
+				client := saramaConfig.Net.SASL.SCRAMClientGeneratorFunc()
+				require.NoError(t, client.Begin("user", "secret", ""))
+				assert.False(t, client.Done())
+			}
+			assert.True(t, saramaConfig.Net.TLS.Enable)
+			assert.Equal(t, "kafka", saramaConfig.Net.TLS.Config.ServerName)
+		})
+	}
+}
+
+func TestSetConfigurationDisabled(t *testing.T) {
+	saramaConfig := sarama.NewConfig()
+	c := &AuthenticationConfig{}
+	require.NoError(t, c.SetConfiguration(saramaConfig))
+	assert.False(t, saramaConfig.Net.SASL.Enable)
+	assert.False(t, saramaConfig.Net.TLS.Enable)
+}
+
+func TestSetConfigurationErrors(t *testing.T) {
+	testCases := []struct {
+		config AuthenticationConfig
+		err    string
+	}{
+		{
+			config: AuthenticationConfig{SASLMechanism: "GSSAPI"},
+			err:    "SASL mechanism 'GSSAPI' not recognised",
+		},
+		{
+			config: AuthenticationConfig{TLS: tlscfg.Options{Enabled: true, CaPath: "/does/not/exist"}},
+			err:    "failed to read TLS CA file",
+		},
+		{
+			config: AuthenticationConfig{TLS: tlscfg.Options{Enabled: true, CertPath: "/does/not/exist", KeyPath: "/does/not/exist"}},
+			err:    "failed to load TLS client certificate",
+		},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.err, func(t *testing.T) {
+			err := testCase.config.SetConfiguration(sarama.NewConfig())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.err)
+		})
+	}
+}
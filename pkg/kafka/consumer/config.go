@@ -17,7 +17,10 @@ package consumer
 import (
 	"io"
 
+	"github.com/Shopify/sarama"
 	"github.com/bsm/sarama-cluster"
+
+	"github.com/jaegertracing/jaeger/pkg/kafka/auth"
 )
 
 // Consumer is an interface to features of Sarama that are necessary for the consumer
@@ -37,12 +40,31 @@ type Configuration struct {
 	Brokers []string
 	Topic   string
 	GroupID string
+	// ProtocolVersion is the kafka version the consumer assumes, the sarama-cluster default is used if it is not set.
+	// Message headers are only fetched with 0.11.0.0 or later
+	ProtocolVersion sarama.KafkaVersion
+	// Authentication secures the connections to the brokers, it is disabled by default
+	Authentication auth.AuthenticationConfig
 	Consumer
 }
 
 // NewConsumer creates a new kafka consumer
 func (c *Configuration) NewConsumer() (Consumer, error) {
+	saramaConfig, err := c.saramaConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cluster.NewConsumer(c.Brokers, c.GroupID, []string{c.Topic}, saramaConfig)
+}
+
+func (c *Configuration) saramaConfig() (*cluster.Config, error) {
 	saramaConfig := cluster.NewConfig()
 	saramaConfig.Group.Mode = cluster.ConsumerModePartitions
-	return cluster.NewConsumer(c.Brokers, c.GroupID, []string{c.Topic}, saramaConfig)
+	if c.ProtocolVersion != (sarama.KafkaVersion{}) {
+		saramaConfig.Version = c.ProtocolVersion
+	}
+	if err := c.Authentication.SetConfiguration(&saramaConfig.Config); err != nil {
+		return nil, err
+	}
+	return saramaConfig, nil
 }
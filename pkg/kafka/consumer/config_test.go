@@ -0,0 +1,63 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/bsm/sarama-cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/pkg/kafka/auth"
+)
+
+func TestSaramaConfig(t *testing.T) {
+	c := Configuration{
+		ProtocolVersion: sarama.V1_0_0_0,
+		Authentication: auth.AuthenticationConfig{
+			SASLMechanism: auth.MechanismPlain,
+			Username:      "user",
+			Password:      "secret",
+		},
+	}
+	saramaConfig, err := c.saramaConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, sarama.V1_0_0_0, saramaConfig.Version)
+	assert.Equal(t, cluster.ConsumerModePartitions, saramaConfig.Group.Mode)
+	assert.True(t, saramaConfig.Net.SASL.Enable)
+	assert.Equal(t, "user", saramaConfig.Net.SASL.User)
+}
+
+func TestSaramaConfigDefaults(t *testing.T) {
+	c := Configuration{}
+	saramaConfig, err := c.saramaConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, cluster.NewConfig().Version, saramaConfig.Version)
+	assert.NoError(t, saramaConfig.Validate())
+	assert.False(t, saramaConfig.Net.SASL.Enable)
+	assert.False(t, saramaConfig.Net.TLS.Enable)
+}
+
+func TestSaramaConfigError(t *testing.T) {
+	c := Configuration{
+		Authentication: auth.AuthenticationConfig{SASLMechanism: "some"},
+	}
+	_, err := c.saramaConfig()
+	assert.Error(t, err)
+}
@@ -15,22 +15,125 @@
 package producer
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/Shopify/sarama"
+
+	"github.com/jaegertracing/jaeger/pkg/kafka/auth"
+)
+
+const (
+	// CompressionNone disables the compression of messages
+	CompressionNone = "none"
+	// CompressionGzip compresses messages with gzip
+	CompressionGzip = "gzip"
+	// CompressionSnappy compresses messages with snappy
+	CompressionSnappy = "snappy"
+	// CompressionLz4 compresses messages with lz4
+	CompressionLz4 = "lz4"
+
+	// RequiredAcksNone does not wait for any broker to acknowledge messages
+	RequiredAcksNone = "noack"
+	// RequiredAcksLocal waits for the partition leader to acknowledge messages
+	RequiredAcksLocal = "local"
+	// RequiredAcksAll waits for all in-sync replicas to acknowledge messages
+	RequiredAcksAll = "all"
 )
 
+var compressionCodecs = map[string]sarama.CompressionCodec{
+	CompressionNone:   sarama.CompressionNone,
+	CompressionGzip:   sarama.CompressionGZIP,
+	CompressionSnappy: sarama.CompressionSnappy,
+	CompressionLz4:    sarama.CompressionLZ4,
+}
+
+var requiredAcks = map[string]sarama.RequiredAcks{
+	RequiredAcksNone:  sarama.NoResponse,
+	RequiredAcksLocal: sarama.WaitForLocal,
+	RequiredAcksAll:   sarama.WaitForAll,
+}
+
 // Builder builds a new kafka producer
 type Builder interface {
 	NewProducer() (sarama.AsyncProducer, error)
 }
 
-// Configuration describes the configuration properties needed to create a Kafka producer
+// Configuration describes the configuration properties needed to create a Kafka producer.
+// The sarama defaults are used for the other properties that are not set.
 type Configuration struct {
 	Brokers []string
+	// ProtocolVersion is the kafka version the producer assumes, the sarama default is used if it is not set.
+	// Message headers require 0.11.0.0 or later
+	ProtocolVersion sarama.KafkaVersion
+	// Compression is one of CompressionNone, CompressionGzip, CompressionSnappy or CompressionLz4
+	Compression string
+	// RequiredAcks is one of RequiredAcksNone, RequiredAcksLocal or RequiredAcksAll
+	RequiredAcks string
+	// BatchSize is the number of bytes that triggers sending a batch of messages
+	BatchSize int
+	// BatchLinger is how long messages are buffered before sending a batch
+	BatchLinger time.Duration
+	// MaxMessageBytes is the maximum size of a message
+	MaxMessageBytes int
+	Authentication  auth.AuthenticationConfig
 }
 
 // NewProducer creates a new asynchronous kafka producer
 func (c *Configuration) NewProducer() (sarama.AsyncProducer, error) {
+	saramaConfig, err := c.saramaConfig()
+	if err != nil {
+		return nil, err
+	}
+	return sarama.NewAsyncProducer(c.Brokers, saramaConfig)
+}
+
+// NewSyncProducer creates a new synchronous kafka producer
+func (c *Configuration) NewSyncProducer() (sarama.SyncProducer, error) {
+	saramaConfig, err := c.saramaConfig()
+	if err != nil {
+		return nil, err
+	}
+	return sarama.NewSyncProducer(c.Brokers, saramaConfig)
+}
+
+func (c *Configuration) saramaConfig() (*sarama.Config, error) {
 	saramaConfig := sarama.NewConfig()
 	saramaConfig.Producer.Return.Successes = true
-	return sarama.NewAsyncProducer(c.Brokers, saramaConfig)
+	if c.ProtocolVersion != (sarama.KafkaVersion{}) {
+		saramaConfig.Version = c.ProtocolVersion
+	}
+	if c.Compression != "" {
+		codec, ok := compressionCodecs[c.Compression]
+		if !ok {
+			return nil, fmt.Errorf(`compression '%s' not recognised, use one of ("%s", "%s", "%s" or "%s")`,
+				c.Compression, CompressionNone, CompressionGzip, CompressionSnappy, CompressionLz4)
+		}
+		if codec == sarama.CompressionLZ4 && !saramaConfig.Version.IsAtLeast(sarama.V0_10_0_0) {
+			return nil, fmt.Errorf("compression '%s' requires a protocol version of %s or later, got %s",
+				c.Compression, sarama.V0_10_0_0, saramaConfig.Version)
+		}
+		saramaConfig.Producer.Compression = codec
+	}
+	if c.RequiredAcks != "" {
+		acks, ok := requiredAcks[c.RequiredAcks]
+		if !ok {
+			return nil, fmt.Errorf(`required acks '%s' not recognised, use one of ("%s", "%s" or "%s")`,
+				c.RequiredAcks, RequiredAcksNone, RequiredAcksLocal, RequiredAcksAll)
+		}
+		saramaConfig.Producer.RequiredAcks = acks
+	}
+	if c.BatchSize > 0 {
+		saramaConfig.Producer.Flush.Bytes = c.BatchSize
+	}
+	if c.BatchLinger > 0 {
+		saramaConfig.Producer.Flush.Frequency = c.BatchLinger
+	}
+	if c.MaxMessageBytes > 0 {
+		saramaConfig.Producer.MaxMessageBytes = c.MaxMessageBytes
+	}
+	if err := c.Authentication.SetConfiguration(saramaConfig); err != nil {
+		return nil, err
+	}
+	return saramaConfig, nil
 }
@@ -0,0 +1,108 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/pkg/kafka/auth"
+)
+
+func TestSaramaConfig(t *testing.T) {
+	c := Configuration{
+		ProtocolVersion: sarama.V0_11_0_0,
+		Compression:     CompressionSnappy,
+		RequiredAcks:    RequiredAcksAll,
+		BatchSize:       1024,
+		BatchLinger:     10 * time.Millisecond,
+		MaxMessageBytes: 2048,
+		Authentication: auth.AuthenticationConfig{
+			SASLMechanism: auth.MechanismPlain,
+			Username:      "user",
+			Password:      "secret",
+		},
+	}
+	saramaConfig, err := c.saramaConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, sarama.V0_11_0_0, saramaConfig.Version)
+	assert.Equal(t, sarama.CompressionSnappy, saramaConfig.Producer.Compression)
+	assert.Equal(t, sarama.WaitForAll, saramaConfig.Producer.RequiredAcks)
+	assert.Equal(t, 1024, saramaConfig.Producer.Flush.Bytes)
+	assert.Equal(t, 10*time.Millisecond, saramaConfig.Producer.Flush.Frequency)
+	assert.Equal(t, 2048, saramaConfig.Producer.MaxMessageBytes)
+	assert.True(t, saramaConfig.Net.SASL.Enable)
+	assert.Equal(t, "user", saramaConfig.Net.SASL.User)
+}
+
+func TestSaramaConfigLz4(t *testing.T) {
+	c := Configuration{ProtocolVersion: sarama.V0_10_0_0, Compression: CompressionLz4}
+	saramaConfig, err := c.saramaConfig()
+	require.NoError(t, err)
+	assert.Equal(t, sarama.CompressionLZ4, saramaConfig.Producer.Compression)
+}
+
+func TestSaramaConfigDefaults(t *testing.T) {
+	c := Configuration{}
+	saramaConfig, err := c.saramaConfig()
+	require.NoError(t, err)
+
+	defaults := sarama.NewConfig()
+	assert.Equal(t, defaults.Version, saramaConfig.Version)
+	assert.Equal(t, defaults.Producer.Compression, saramaConfig.Producer.Compression)
+	assert.Equal(t, defaults.Producer.RequiredAcks, saramaConfig.Producer.RequiredAcks)
+	assert.Equal(t, defaults.Producer.MaxMessageBytes, saramaConfig.Producer.MaxMessageBytes)
+	assert.False(t, saramaConfig.Net.SASL.Enable)
+	assert.False(t, saramaConfig.Net.TLS.Enable)
+}
+
+func TestSaramaConfigErrors(t *testing.T) {
+	testCases := []struct {
+		config Configuration
+		err    string
+	}{
+		{
+			config: Configuration{Compression: "zstd"},
+			err:    "compression 'zstd' not recognised",
+		},
+		{
+			config: Configuration{ProtocolVersion: sarama.V0_9_0_0, Compression: CompressionLz4},
+			err:    "compression 'lz4' requires a protocol version of 0.10.0.0 or later, got 0.9.0.0",
+		},
+		{
+			config: Configuration{RequiredAcks: "some"},
+			err:    "required acks 'some' not recognised",
+		},
+		{
+			config: Configuration{Authentication: auth.AuthenticationConfig{SASLMechanism: "GSSAPI"}},
+			err:    "SASL mechanism 'GSSAPI' not recognised",
+		},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.err, func(t *testing.T) {
+			_, err := testCase.config.saramaConfig()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.err)
+			_, err = testCase.config.NewProducer()
+			assert.Error(t, err)
+		})
+	}
+}
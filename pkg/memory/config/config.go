@@ -14,7 +14,13 @@
 
 package config
 
+import "time"
+
 // Configuration describes the options to customize the storage behavior
 type Configuration struct {
-	MaxTraces int `yaml:"max-traces"`
+	MaxTraces int           `yaml:"max-traces"`
+	MaxAge    time.Duration `yaml:"max-age"`
+	// SnapshotFile is where traces are persisted on shutdown and restored from on startup
+	SnapshotFile     string        `yaml:"snapshot-file"`
+	SnapshotInterval time.Duration `yaml:"snapshot-interval"`
 }
@@ -0,0 +1,108 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zipkin decodes the spans received by the Zipkin HTTP endpoints into Zipkin thrift spans.
+package zipkin
+
+import (
+	"errors"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/golang/protobuf/proto"
+	zipkinProto "github.com/openzipkin/zipkin-go/proto/v2"
+
+	"github.com/jaegertracing/jaeger/swagger-gen/models"
+	"github.com/jaegertracing/jaeger/swagger-gen/restapi"
+	"github.com/jaegertracing/jaeger/swagger-gen/restapi/operations"
+	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
+)
+
+// ErrUnsupportedContentType is returned for payloads that are not in one of the encodings of the Zipkin API
+var ErrUnsupportedContentType = errors.New("Unsupported Content-Type")
+
+var v2Formats = newV2Formats()
+
+func newV2Formats() strfmt.Registry {
+	swaggerSpec, _ := loads.Analyzed(restapi.SwaggerJSON, "")
+	return operations.NewZipkinAPI(swaggerSpec).Formats()
+}
+
+// DeserializeV1 decodes the spans of the Zipkin v1 API, encoded as thrift or JSON according to the content type
+func DeserializeV1(contentType string, body []byte) ([]*zipkincore.Span, error) {
+	switch contentType {
+	case "application/x-thrift":
+		return deserializeThrift(body)
+	case "application/json":
+		return DeserializeJSON(body)
+	default:
+		return nil, ErrUnsupportedContentType
+	}
+}
+
+// DeserializeV2 decodes the spans of the Zipkin v2 API, encoded as JSON or protobuf according to the content type,
+// and converts them to Zipkin thrift spans once they are validated against the API specification
+func DeserializeV2(contentType string, body []byte) ([]*zipkincore.Span, error) {
+	var spans models.ListOfSpans
+	var err error
+	switch contentType {
+	case "application/json":
+		err = swag.ReadJSON(body, &spans)
+	case "application/x-protobuf":
+		spans, err = deserializeProtoV2(body)
+	default:
+		return nil, ErrUnsupportedContentType
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := spans.Validate(v2Formats); err != nil {
+		return nil, err
+	}
+	return spansV2ToThrift(spans)
+}
+
+func deserializeProtoV2(b []byte) (models.ListOfSpans, error) {
+	var listOfSpans zipkinProto.ListOfSpans
+	if err := proto.Unmarshal(b, &listOfSpans); err != nil {
+		return nil, err
+	}
+	return protoSpansV2ToModels(&listOfSpans)
+}
+
+func deserializeThrift(b []byte) ([]*zipkincore.Span, error) {
+	buffer := thrift.NewTMemoryBuffer()
+	buffer.Write(b)
+
+	transport := thrift.NewTBinaryProtocolTransport(buffer)
+	_, size, err := transport.ReadListBegin() // Ignore the returned element type
+	if err != nil {
+		return nil, err
+	}
+
+	// We don't depend on the size returned by ReadListBegin to preallocate the array because it
+	// sometimes returns a nil error on bad input and provides an unreasonably large int for size
+	var spans []*zipkincore.Span
+	for i := 0; i < size; i++ {
+		zs := &zipkincore.Span{}
+		if err = zs.Read(transport); err != nil {
+			return nil, err
+		}
+		spans = append(spans, zs)
+	}
+
+	return spans, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin
+
+import (
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
+)
+
+func zipkinSerialize(spans []*zipkincore.Span) []byte {
+	t := thrift.NewTMemoryBuffer()
+	p := thrift.NewTBinaryProtocolTransport(t)
+	p.WriteListBegin(thrift.STRUCT, len(spans))
+	for _, s := range spans {
+		s.Write(p)
+	}
+	p.WriteListEnd()
+	return t.Buffer.Bytes()
+}
+
+func TestDeserializeV1(t *testing.T) {
+	spans, err := DeserializeV1("application/x-thrift", zipkinSerialize([]*zipkincore.Span{{Name: "thrift"}}))
+	require.NoError(t, err)
+	require.Len(t, spans, 1)
+	assert.Equal(t, "thrift", spans[0].Name)
+
+	spans, err = DeserializeV1("application/json", []byte(`[{"traceId":"1111111111111111","id":"1111111111111111","name":"json"}]`))
+	require.NoError(t, err)
+	require.Len(t, spans, 1)
+	assert.Equal(t, "json", spans[0].Name)
+
+	_, err = DeserializeV1("text/plain", []byte("[]"))
+	assert.Equal(t, ErrUnsupportedContentType, err)
+}
+
+func TestDeserializeV2(t *testing.T) {
+	spans, err := DeserializeV2("application/json", []byte(`[{"traceId":"1111111111111111","id":"2222222222222222","name":"json"}]`))
+	require.NoError(t, err)
+	require.Len(t, spans, 1)
+	assert.Equal(t, "json", spans[0].Name)
+
+	_, err = DeserializeV2("application/json", []byte(`[{"traceId":"zz","id":"2222222222222222"}]`))
+	assert.Error(t, err)
+
+	_, err = DeserializeV2("application/x-protobuf", []byte("not a protobuf"))
+	assert.Error(t, err)
+
+	_, err = DeserializeV2("application/x-thrift", []byte("[]"))
+	assert.Equal(t, ErrUnsupportedContentType, err)
+}
+
+func TestDeserializeWithBadListStart(t *testing.T) {
+	spanBytes := zipkinSerialize([]*zipkincore.Span{{}})
+	_, err := deserializeThrift(append([]byte{0, 255, 255}, spanBytes...))
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+
+	"github.com/go-openapi/strfmt"
+	zipkinProto "github.com/openzipkin/zipkin-go/proto/v2"
+
+	"github.com/jaegertracing/jaeger/swagger-gen/models"
+)
+
+var protoSpanKinds = map[zipkinProto.Span_Kind]string{
+	zipkinProto.Span_CLIENT:   models.SpanKindCLIENT,
+	zipkinProto.Span_SERVER:   models.SpanKindSERVER,
+	zipkinProto.Span_PRODUCER: models.SpanKindPRODUCER,
+	zipkinProto.Span_CONSUMER: models.SpanKindCONSUMER,
+}
+
+// protoSpansV2ToModels converts the zipkin2 proto3 spans to the spans of the JSON v2 API,
+// so that both formats are validated and converted the same way.
+func protoSpansV2ToModels(listOfSpans *zipkinProto.ListOfSpans) (models.ListOfSpans, error) {
+	spans := make(models.ListOfSpans, 0, len(listOfSpans.Spans))
+	for _, span := range listOfSpans.Spans {
+		s, err := protoSpanV2ToModel(span)
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, s)
+	}
+	return spans, nil
+}
+
+func protoSpanV2ToModel(s *zipkinProto.Span) (*models.Span, error) {
+	if len(s.TraceId) != 8 && len(s.TraceId) != 16 {
+		return nil, fmt.Errorf("invalid length for trace id: %d bytes", len(s.TraceId))
+	}
+	if len(s.Id) != 8 {
+		return nil, fmt.Errorf("invalid length for span id: %d bytes", len(s.Id))
+	}
+	traceID := hex.EncodeToString(s.TraceId)
+	id := hex.EncodeToString(s.Id)
+	span := &models.Span{
+		TraceID:   &traceID,
+		ID:        &id,
+		Name:      s.Name,
+		Kind:      protoSpanKinds[s.Kind],
+		Timestamp: int64(s.Timestamp),
+		Duration:  int64(s.Duration),
+		Debug:     s.Debug,
+		Shared:    s.Shared,
+	}
+	if len(s.ParentId) > 0 {
+		if len(s.ParentId) != 8 {
+			return nil, fmt.Errorf("invalid length for parent id: %d bytes", len(s.ParentId))
+		}
+		span.ParentID = hex.EncodeToString(s.ParentId)
+	}
+	if len(s.Tags) > 0 {
+		span.Tags = models.Tags(s.Tags)
+	}
+	for _, a := range s.Annotations {
+		span.Annotations = append(span.Annotations, &models.Annotation{
+			Timestamp: int64(a.Timestamp),
+			Value:     a.Value,
+		})
+	}
+	var err error
+	if span.LocalEndpoint, err = protoEndpointV2ToModel(s.LocalEndpoint); err != nil {
+		return nil, err
+	}
+	if span.RemoteEndpoint, err = protoEndpointV2ToModel(s.RemoteEndpoint); err != nil {
+		return nil, err
+	}
+	return span, nil
+}
+
+func protoEndpointV2ToModel(e *zipkinProto.Endpoint) (*models.Endpoint, error) {
+	if e == nil {
+		return nil, nil
+	}
+	endpoint := &models.Endpoint{
+		ServiceName: e.ServiceName,
+		Port:        int64(e.Port),
+	}
+	if len(e.Ipv4) > 0 {
+		if len(e.Ipv4) != net.IPv4len {
+			return nil, fmt.Errorf("invalid length for ipv4: %d bytes", len(e.Ipv4))
+		}
+		endpoint.IPV4 = strfmt.IPv4(net.IP(e.Ipv4).String())
+	}
+	if len(e.Ipv6) > 0 {
+		if len(e.Ipv6) != net.IPv6len {
+			return nil, fmt.Errorf("invalid length for ipv6: %d bytes", len(e.Ipv6))
+		}
+		endpoint.IPV6 = strfmt.IPv6(net.IP(e.Ipv6).String())
+	}
+	return endpoint, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin
+
+import (
+	"testing"
+
+	zipkinProto "github.com/openzipkin/zipkin-go/proto/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/swagger-gen/models"
+)
+
+func fixtureProtoSpan() *zipkinProto.Span {
+	return &zipkinProto.Span{
+		TraceId:   []byte{0xbd, 0x7a, 0x97, 0x45, 0x55, 0xf6, 0xb9, 0x82, 0xbd, 0x71, 0x97, 0x75, 0x55, 0xf6, 0xb9, 0x81},
+		Id:        []byte{0, 0, 0, 0, 0, 0, 0, 2},
+		ParentId:  []byte{0, 0, 0, 0, 0, 0, 0, 1},
+		Name:      "foo",
+		Kind:      zipkinProto.Span_CLIENT,
+		Debug:     true,
+		Shared:    true,
+		Timestamp: 1,
+		Duration:  10,
+		LocalEndpoint: &zipkinProto.Endpoint{
+			ServiceName: "foo",
+			Ipv4:        []byte{10, 43, 17, 42},
+		},
+		RemoteEndpoint: &zipkinProto.Endpoint{
+			ServiceName: "bar",
+			Ipv4:        []byte{10, 43, 17, 43},
+		},
+		Annotations: []*zipkinProto.Annotation{{Value: "foo", Timestamp: 1}},
+		Tags:        map[string]string{"foo": "bar"},
+	}
+}
+
+func TestProtoFixtures(t *testing.T) {
+	var jsonSpans models.ListOfSpans
+	loadJSON(t, "fixtures/zipkin_01.json", &jsonSpans)
+	expected, err := spansV2ToThrift(jsonSpans)
+	require.NoError(t, err)
+
+	spans, err := protoSpansV2ToModels(&zipkinProto.ListOfSpans{Spans: []*zipkinProto.Span{fixtureProtoSpan()}})
+	require.NoError(t, err)
+	tSpans, err := spansV2ToThrift(spans)
+	require.NoError(t, err)
+	assert.Equal(t, expected, tSpans)
+}
+
+func TestProtoIPV6(t *testing.T) {
+	e, err := protoEndpointV2ToModel(&zipkinProto.Endpoint{
+		Ipv6: []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		Port: 8080,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &models.Endpoint{IPV6: "2001:db8::1", Port: 8080}, e)
+}
+
+func TestProtoErrors(t *testing.T) {
+	tests := []struct {
+		span *zipkinProto.Span
+		err  string
+	}{
+		{
+			span: &zipkinProto.Span{TraceId: []byte{1}},
+			err:  "invalid length for trace id: 1 bytes",
+		},
+		{
+			span: &zipkinProto.Span{TraceId: make([]byte, 8), Id: []byte{1}},
+			err:  "invalid length for span id: 1 bytes",
+		},
+		{
+			span: &zipkinProto.Span{TraceId: make([]byte, 8), Id: make([]byte, 8), ParentId: []byte{1}},
+			err:  "invalid length for parent id: 1 bytes",
+		},
+		{
+			span: &zipkinProto.Span{TraceId: make([]byte, 8), Id: make([]byte, 8), LocalEndpoint: &zipkinProto.Endpoint{Ipv4: []byte{1}}},
+			err:  "invalid length for ipv4: 1 bytes",
+		},
+		{
+			span: &zipkinProto.Span{TraceId: make([]byte, 8), Id: make([]byte, 8), RemoteEndpoint: &zipkinProto.Endpoint{Ipv6: []byte{1}}},
+			err:  "invalid length for ipv6: 1 bytes",
+		},
+	}
+	for _, test := range tests {
+		_, err := protoSpansV2ToModels(&zipkinProto.ListOfSpans{Spans: []*zipkinProto.Span{test.span}})
+		assert.EqualError(t, err, test.err)
+	}
+}
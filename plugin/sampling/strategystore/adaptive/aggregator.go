@@ -0,0 +1,137 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+)
+
+const (
+	// samplerTypeProbabilistic is reported by clients that sampled a trace with the probability
+	// received in the sampling strategy.
+	samplerTypeProbabilistic = "probabilistic"
+
+	// samplerTypeLowerBound is reported by clients that sampled a trace to satisfy the lower bound
+	// of traces per second, while an operation's probability would not have sampled it.
+	samplerTypeLowerBound = "lowerbound"
+)
+
+// serviceOperationThroughput is a map of [service][operation] = throughput
+type serviceOperationThroughput map[string]map[string]*model.Throughput
+
+type aggregator struct {
+	sync.Mutex
+
+	currentThroughput serviceOperationThroughput
+	interval          time.Duration
+	storage           samplingstore.Store
+	logger            *zap.Logger
+	stop              chan struct{}
+	done              sync.WaitGroup
+}
+
+// NewAggregator creates an aggregator that counts the traces sampled by clients for each operation
+// and flushes the counts to storage every interval.
+func NewAggregator(interval time.Duration, storage samplingstore.Store, logger *zap.Logger) strategystore.Aggregator {
+	return &aggregator{
+		currentThroughput: make(serviceOperationThroughput),
+		interval:          interval,
+		storage:           storage,
+		logger:            logger,
+		stop:              make(chan struct{}),
+	}
+}
+
+// RecordThroughput implements strategystore.Aggregator#RecordThroughput
+func (a *aggregator) RecordThroughput(service, operation, samplerType string, probability float64) {
+	a.Lock()
+	defer a.Unlock()
+	if _, ok := a.currentThroughput[service]; !ok {
+		a.currentThroughput[service] = make(map[string]*model.Throughput)
+	}
+	throughput, ok := a.currentThroughput[service][operation]
+	if !ok {
+		throughput = &model.Throughput{
+			Service:       service,
+			Operation:     operation,
+			Probabilities: make(map[string]struct{}),
+		}
+		a.currentThroughput[service][operation] = throughput
+	}
+	throughput.Count++
+	if samplerType == samplerTypeProbabilistic || samplerType == samplerTypeLowerBound {
+		throughput.Probabilities[probabilityKey(probability)] = struct{}{}
+	}
+}
+
+// Start implements strategystore.Aggregator#Start
+func (a *aggregator) Start() {
+	a.done.Add(1)
+	go a.runAggregationLoop()
+}
+
+// Stop implements strategystore.Aggregator#Stop
+func (a *aggregator) Stop() {
+	close(a.stop)
+	a.done.Wait()
+}
+
+func (a *aggregator) runAggregationLoop() {
+	defer a.done.Done()
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.saveThroughput()
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+func (a *aggregator) saveThroughput() {
+	a.Lock()
+	currentThroughput := a.currentThroughput
+	a.currentThroughput = make(serviceOperationThroughput)
+	a.Unlock()
+
+	var throughput []*model.Throughput
+	for _, opThroughput := range currentThroughput {
+		for _, t := range opThroughput {
+			throughput = append(throughput, t)
+		}
+	}
+	if len(throughput) == 0 {
+		return
+	}
+	if err := a.storage.InsertThroughput(throughput); err != nil {
+		a.logger.Error("Failed to save throughput", zap.Error(err))
+	}
+}
+
+// probabilityKey formats a sampling probability the same way every time, so that the probabilities
+// reported by clients can be matched against the calculated ones.
+func probabilityKey(probability float64) string {
+	return strconv.FormatFloat(probability, 'f', -1, 64)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/jaegertracing/jaeger/pkg/testutils"
+	"github.com/jaegertracing/jaeger/storage/samplingstore/mocks"
+)
+
+func TestAggregator(t *testing.T) {
+	store := &mocks.Store{}
+	store.On("InsertThroughput", mock.AnythingOfType("[]*model.Throughput")).Return(nil)
+
+	a := NewAggregator(time.Hour, store, zap.NewNop()).(*aggregator)
+	a.RecordThroughput("A", "GET", samplerTypeProbabilistic, 0.001)
+	a.RecordThroughput("A", "GET", samplerTypeProbabilistic, 0.001)
+	a.RecordThroughput("A", "GET", samplerTypeLowerBound, 0.002)
+	a.RecordThroughput("A", "PUT", "const", 1)
+	a.RecordThroughput("B", "GET", samplerTypeProbabilistic, 0.5)
+
+	assert.Equal(t, serviceOperationThroughput{
+		"A": {
+			"GET": {Service: "A", Operation: "GET", Count: 3,
+				Probabilities: map[string]struct{}{"0.001": {}, "0.002": {}}},
+			"PUT": {Service: "A", Operation: "PUT", Count: 1,
+				Probabilities: map[string]struct{}{}},
+		},
+		"B": {
+			"GET": {Service: "B", Operation: "GET", Count: 1,
+				Probabilities: map[string]struct{}{"0.5": {}}},
+		},
+	}, a.currentThroughput)
+
+	a.saveThroughput()
+	assert.Empty(t, a.currentThroughput)
+	store.AssertNumberOfCalls(t, "InsertThroughput", 1)
+	saved := store.Calls[0].Arguments.Get(0).([]*model.Throughput)
+	assert.Len(t, saved, 3)
+
+	// nothing to save
+	a.saveThroughput()
+	store.AssertNumberOfCalls(t, "InsertThroughput", 1)
+}
+
+func TestAggregatorStorageError(t *testing.T) {
+	store := &mocks.Store{}
+	store.On("InsertThroughput", mock.AnythingOfType("[]*model.Throughput")).Return(errors.New("storage error"))
+	logger, buf := testutils.NewLogger()
+
+	a := NewAggregator(time.Hour, store, logger).(*aggregator)
+	a.RecordThroughput("A", "GET", samplerTypeProbabilistic, 0.001)
+	a.saveThroughput()
+	assert.Contains(t, buf.String(), "Failed to save throughput")
+}
+
+func TestAggregatorLoop(t *testing.T) {
+	store := &mocks.Store{}
+	saved := make(chan struct{}, 1)
+	store.On("InsertThroughput", mock.AnythingOfType("[]*model.Throughput")).Return(nil).Run(func(mock.Arguments) {
+		select {
+		case saved <- struct{}{}:
+		default:
+		}
+	})
+
+	a := NewAggregator(time.Millisecond, store, zap.NewNop())
+	a.Start()
+	a.RecordThroughput("A", "GET", samplerTypeProbabilistic, 0.001)
+	select {
+	case <-saved:
+	case <-time.After(5 * time.Second):
+		t.Fatal("throughput was not saved")
+	}
+	a.Stop()
+}
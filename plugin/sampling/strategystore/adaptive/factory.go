@@ -0,0 +1,93 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/spf13/viper"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/pkg/distributedlock"
+	"github.com/jaegertracing/jaeger/storage"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+)
+
+var errMissingSamplingStoreFactory = errors.New("adaptive sampling requires a storage backend that supports sampling data")
+
+// Factory implements strategystore.Factory for an adaptive strategy store.
+type Factory struct {
+	options        *Options
+	logger         *zap.Logger
+	metricsFactory metrics.Factory
+	lock           distributedlock.Lock
+	store          samplingstore.Store
+}
+
+// NewFactory creates a new Factory.
+func NewFactory() *Factory {
+	return &Factory{
+		options:        &Options{},
+		logger:         zap.NewNop(),
+		metricsFactory: metrics.NullFactory,
+	}
+}
+
+// AddFlags implements plugin.Configurable
+func (f *Factory) AddFlags(flagSet *flag.FlagSet) {
+	AddFlags(flagSet)
+}
+
+// InitFromViper implements plugin.Configurable
+func (f *Factory) InitFromViper(v *viper.Viper) {
+	f.options.InitFromViper(v)
+}
+
+// Initialize implements strategystore.Factory
+func (f *Factory) Initialize(metricsFactory metrics.Factory, ssFactory storage.SamplingStoreFactory, logger *zap.Logger) error {
+	if ssFactory == nil {
+		return errMissingSamplingStoreFactory
+	}
+	f.logger = logger
+	f.metricsFactory = metricsFactory
+	var err error
+	if f.lock, err = ssFactory.CreateLock(); err != nil {
+		return err
+	}
+	if f.store, err = ssFactory.CreateSamplingStore(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateStrategyStore implements strategystore.Factory
+func (f *Factory) CreateStrategyStore() (strategystore.StrategyStore, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	p := NewProcessor(*f.options, hostname, f.store, f.lock, f.metricsFactory, f.logger)
+	p.Start()
+	return p, nil
+}
+
+// CreateAggregator implements strategystore.AggregatorFactory
+func (f *Factory) CreateAggregator() (strategystore.Aggregator, error) {
+	return NewAggregator(f.options.CalculationInterval, f.store, f.logger), nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	ss "github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/pkg/config"
+	lmocks "github.com/jaegertracing/jaeger/pkg/distributedlock/mocks"
+	"github.com/jaegertracing/jaeger/plugin"
+	"github.com/jaegertracing/jaeger/storage/mocks"
+	smocks "github.com/jaegertracing/jaeger/storage/samplingstore/mocks"
+)
+
+var _ ss.Factory = new(Factory)
+var _ ss.AggregatorFactory = new(Factory)
+var _ plugin.Configurable = new(Factory)
+
+func TestFactory(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{
+		"--sampling.target-samples-per-second=5",
+		"--sampling.delta-tolerance=0.25",
+		"--sampling.calculation-interval=15m",
+		"--sampling.delay=3m",
+		"--sampling.initial-sampling-probability=0.02",
+		"--sampling.min-sampling-probability=0.01",
+		"--sampling.min-samples-per-second=1",
+		"--sampling.leader-lease-refresh-interval=1s",
+		"--sampling.follower-lease-refresh-interval=2s",
+	})
+	f.InitFromViper(v)
+
+	assert.Equal(t, 5.0, f.options.TargetSamplesPerSecond)
+	assert.Equal(t, 0.25, f.options.DeltaTolerance)
+	assert.Equal(t, 15*time.Minute, f.options.CalculationInterval)
+	assert.Equal(t, 3*time.Minute, f.options.Delay)
+	assert.Equal(t, 0.02, f.options.InitialSamplingProbability)
+	assert.Equal(t, 0.01, f.options.MinSamplingProbability)
+	assert.Equal(t, 1.0, f.options.MinSamplesPerSecond)
+	assert.Equal(t, time.Second, f.options.LeaderLeaseRefreshInterval)
+	assert.Equal(t, 2*time.Second, f.options.FollowerLeaseRefreshInterval)
+
+	lock := &lmocks.Lock{}
+	lock.On("Acquire", samplingLock, 2*time.Second).Return(false, nil)
+	store := &smocks.Store{}
+	store.On("GetLatestProbabilities").Return(model.ServiceOperationProbabilities{}, nil)
+	ssFactory := &mocks.SamplingStoreFactory{}
+	ssFactory.On("CreateLock").Return(lock, nil)
+	ssFactory.On("CreateSamplingStore").Return(store, nil)
+
+	require.NoError(t, f.Initialize(metrics.NullFactory, ssFactory, zap.NewNop()))
+	strategyStore, err := f.CreateStrategyStore()
+	require.NoError(t, err)
+	assert.NoError(t, strategyStore.(*Processor).Close())
+
+	aggregator, err := f.CreateAggregator()
+	require.NoError(t, err)
+	assert.NotNil(t, aggregator)
+}
+
+func TestFactoryInitializeErrors(t *testing.T) {
+	f := NewFactory()
+	assert.Equal(t, errMissingSamplingStoreFactory, f.Initialize(metrics.NullFactory, nil, zap.NewNop()))
+
+	ssFactory := &mocks.SamplingStoreFactory{}
+	ssFactory.On("CreateLock").Return(nil, errors.New("lock error")).Once()
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, ssFactory, zap.NewNop()), "lock error")
+
+	ssFactory.On("CreateLock").Return(&lmocks.Lock{}, nil)
+	ssFactory.On("CreateSamplingStore").Return(nil, errors.New("store error"))
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, ssFactory, zap.NewNop()), "store error")
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/distributedlock"
+)
+
+// leaderElection uses a distributed lock to elect a single collector
+// that calculates the sampling probabilities.
+type leaderElection struct {
+	lock             distributedlock.Lock
+	resource         string
+	leaderInterval   time.Duration
+	followerInterval time.Duration
+	logger           *zap.Logger
+
+	leader int32 // accessed atomically, 1 if this collector holds the lock
+	stop   chan struct{}
+	done   sync.WaitGroup
+}
+
+func newLeaderElection(
+	lock distributedlock.Lock,
+	resource string,
+	leaderInterval, followerInterval time.Duration,
+	logger *zap.Logger,
+) *leaderElection {
+	return &leaderElection{
+		lock:             lock,
+		resource:         resource,
+		leaderInterval:   leaderInterval,
+		followerInterval: followerInterval,
+		logger:           logger,
+		stop:             make(chan struct{}),
+	}
+}
+
+// Start attempts to acquire the lock and keeps renewing or re-acquiring it in the background.
+func (e *leaderElection) Start() {
+	interval := e.acquireLock()
+	e.done.Add(1)
+	go e.runAcquireLockLoop(interval)
+}
+
+// Close stops the election and releases the lock if this collector holds it.
+func (e *leaderElection) Close() error {
+	close(e.stop)
+	e.done.Wait()
+	if e.IsLeader() {
+		if _, err := e.lock.Forfeit(e.resource); err != nil {
+			return err
+		}
+		atomic.StoreInt32(&e.leader, 0)
+	}
+	return nil
+}
+
+// IsLeader returns true if this collector currently holds the lock.
+func (e *leaderElection) IsLeader() bool {
+	return atomic.LoadInt32(&e.leader) == 1
+}
+
+func (e *leaderElection) runAcquireLockLoop(interval time.Duration) {
+	defer e.done.Done()
+	timer := time.NewTimer(interval)
+	for {
+		select {
+		case <-timer.C:
+			timer.Reset(e.acquireLock())
+		case <-e.stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// acquireLock attempts to acquire or renew the lock and returns the interval before the next attempt.
+func (e *leaderElection) acquireLock() time.Duration {
+	// the lease lasts for a follower interval, so that a crashed leader is replaced in a timely manner
+	acquired, err := e.lock.Acquire(e.resource, e.followerInterval)
+	if err != nil {
+		e.logger.Error("Failed to acquire lock", zap.String("resource", e.resource), zap.Error(err))
+		acquired = false
+	}
+	if acquired != e.IsLeader() {
+		e.logger.Info("Sampling leadership changed", zap.Bool("leader", acquired))
+	}
+	if acquired {
+		atomic.StoreInt32(&e.leader, 1)
+		return e.leaderInterval
+	}
+	atomic.StoreInt32(&e.leader, 0)
+	return e.followerInterval
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/distributedlock/mocks"
+	"github.com/jaegertracing/jaeger/pkg/testutils"
+)
+
+func TestLeaderElection(t *testing.T) {
+	lock := &mocks.Lock{}
+	lock.On("Acquire", "resource", time.Minute).Return(true, nil).Once()
+	lock.On("Acquire", "resource", time.Minute).Return(false, nil).Once()
+	lock.On("Acquire", "resource", time.Minute).Return(false, errors.New("lock error"))
+	logger, buf := testutils.NewLogger()
+
+	e := newLeaderElection(lock, "resource", time.Second, time.Minute, logger)
+	assert.False(t, e.IsLeader())
+
+	assert.Equal(t, time.Second, e.acquireLock())
+	assert.True(t, e.IsLeader())
+
+	assert.Equal(t, time.Minute, e.acquireLock())
+	assert.False(t, e.IsLeader())
+
+	assert.Equal(t, time.Minute, e.acquireLock())
+	assert.False(t, e.IsLeader())
+	assert.Contains(t, buf.String(), "Failed to acquire lock")
+}
+
+func TestLeaderElectionStartAndClose(t *testing.T) {
+	lock := &mocks.Lock{}
+	lock.On("Acquire", "resource", time.Minute).Return(true, nil)
+	lock.On("Forfeit", "resource").Return(false, errors.New("forfeit error")).Once()
+	lock.On("Forfeit", "resource").Return(true, nil)
+
+	e := newLeaderElection(lock, "resource", time.Millisecond, time.Minute, zap.NewNop())
+	e.Start()
+	assert.True(t, e.IsLeader())
+	time.Sleep(5 * time.Millisecond)
+	assert.EqualError(t, e.Close(), "forfeit error")
+
+	e = newLeaderElection(lock, "resource", time.Millisecond, time.Minute, zap.NewNop())
+	e.Start()
+	assert.NoError(t, e.Close())
+	assert.False(t, e.IsLeader())
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"flag"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	targetSamplesPerSecond       = "sampling.target-samples-per-second"
+	deltaTolerance               = "sampling.delta-tolerance"
+	calculationInterval          = "sampling.calculation-interval"
+	delay                        = "sampling.delay"
+	initialSamplingProbability   = "sampling.initial-sampling-probability"
+	minSamplingProbability       = "sampling.min-sampling-probability"
+	minSamplesPerSecond          = "sampling.min-samples-per-second"
+	leaderLeaseRefreshInterval   = "sampling.leader-lease-refresh-interval"
+	followerLeaseRefreshInterval = "sampling.follower-lease-refresh-interval"
+
+	defaultTargetSamplesPerSecond       = 1
+	defaultDeltaTolerance               = 0.3
+	defaultCalculationInterval          = time.Minute
+	defaultDelay                        = time.Minute * 2
+	defaultInitialSamplingProbability   = 0.001
+	defaultMinSamplingProbability       = 1e-5                                   // one in 100k requests
+	defaultMinSamplesPerSecond          = 1.0 / float64(time.Minute/time.Second) // once every 1 minute
+	defaultLeaderLeaseRefreshInterval   = 5 * time.Second
+	defaultFollowerLeaseRefreshInterval = 60 * time.Second
+)
+
+// Options holds configuration for the adaptive sampling strategy store.
+type Options struct {
+	// TargetSamplesPerSecond is the number of traces per second that each operation
+	// should be sampled at, summed across all instances of the service.
+	TargetSamplesPerSecond float64
+
+	// DeltaTolerance is the acceptable relative deviation of the observed samples per second
+	// from TargetSamplesPerSecond before the sampling probability is recalculated.
+	DeltaTolerance float64
+
+	// CalculationInterval determines how often the throughput is aggregated and
+	// new sampling probabilities are calculated.
+	CalculationInterval time.Duration
+
+	// Delay is the amount of time the leader waits before using the throughput of an interval,
+	// giving all collectors the chance to flush their aggregated throughput to storage.
+	Delay time.Duration
+
+	// InitialSamplingProbability is the sampling probability given to operations
+	// for which no probability has been calculated yet.
+	InitialSamplingProbability float64
+
+	// MinSamplingProbability is the lower bound of any calculated sampling probability.
+	MinSamplingProbability float64
+
+	// MinSamplesPerSecond is the lower bound of traces per second sampled by each operation
+	// of a service instance, regardless of its sampling probability.
+	MinSamplesPerSecond float64
+
+	// LeaderLeaseRefreshInterval is how often the leader renews its lease on the sampling lock.
+	LeaderLeaseRefreshInterval time.Duration
+
+	// FollowerLeaseRefreshInterval is how often the other collectors try to acquire the sampling lock.
+	// It is also the duration of the lease, so that a crashed leader is replaced in a timely manner.
+	FollowerLeaseRefreshInterval time.Duration
+}
+
+// AddFlags adds flags for Options
+func AddFlags(flagSet *flag.FlagSet) {
+	flagSet.Float64(targetSamplesPerSecond, defaultTargetSamplesPerSecond,
+		"The global target rate of samples per operation.")
+	flagSet.Float64(deltaTolerance, defaultDeltaTolerance,
+		"The acceptable amount of deviation between the observed samples per second and the desired (target) samples per second, as a ratio.")
+	flagSet.Duration(calculationInterval, defaultCalculationInterval,
+		"How often new sampling probabilities are calculated.")
+	flagSet.Duration(delay, defaultDelay,
+		"How far back in time the throughput used to calculate sampling probabilities is fetched, so that all collectors had the time to flush it.")
+	flagSet.Float64(initialSamplingProbability, defaultInitialSamplingProbability,
+		"The initial sampling probability for all new operations.")
+	flagSet.Float64(minSamplingProbability, defaultMinSamplingProbability,
+		"The minimum sampling probability for all operations.")
+	flagSet.Float64(minSamplesPerSecond, defaultMinSamplesPerSecond,
+		"The minimum number of traces that are sampled per second.")
+	flagSet.Duration(leaderLeaseRefreshInterval, defaultLeaderLeaseRefreshInterval,
+		"The duration to sleep if this collector is elected leader before attempting to renew the lease on the leader lock. "+
+			"This should be less than follower-lease-refresh-interval to reduce lock thrashing.")
+	flagSet.Duration(followerLeaseRefreshInterval, defaultFollowerLeaseRefreshInterval,
+		"The duration to sleep if this collector is a follower before attempting to acquire the leader lock.")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (opts *Options) InitFromViper(v *viper.Viper) *Options {
+	opts.TargetSamplesPerSecond = v.GetFloat64(targetSamplesPerSecond)
+	opts.DeltaTolerance = v.GetFloat64(deltaTolerance)
+	opts.CalculationInterval = v.GetDuration(calculationInterval)
+	opts.Delay = v.GetDuration(delay)
+	opts.InitialSamplingProbability = v.GetFloat64(initialSamplingProbability)
+	opts.MinSamplingProbability = v.GetFloat64(minSamplingProbability)
+	opts.MinSamplesPerSecond = v.GetFloat64(minSamplesPerSecond)
+	opts.LeaderLeaseRefreshInterval = v.GetDuration(leaderLeaseRefreshInterval)
+	opts.FollowerLeaseRefreshInterval = v.GetDuration(followerLeaseRefreshInterval)
+	return opts
+}
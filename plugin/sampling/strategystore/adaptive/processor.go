@@ -0,0 +1,301 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/jaegertracing/jaeger/pkg/distributedlock"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+)
+
+const (
+	// samplingLock is the resource the collectors compete for to become the leader.
+	samplingLock = "sampling_lock"
+
+	// maxSamplingProbability is the upper bound of any calculated sampling probability.
+	maxSamplingProbability = 1.0
+
+	// maxProbabilityIncreaseFactor limits how much the probability of an operation can grow
+	// in a single calculation, so that a temporary lull in traffic is not followed by a burst of samples.
+	maxProbabilityIncreaseFactor = 2.0
+)
+
+type processorMetrics struct {
+	// Number of times the sampling probabilities were calculated by this collector
+	Calculations metrics.Counter `metric:"calculations"`
+
+	// Number of failed reads or writes of sampling data
+	StorageErrors metrics.Counter `metric:"storage-errors"`
+
+	// Whether this collector is the leader (1) or a follower (0)
+	Leader metrics.Gauge `metric:"leader"`
+}
+
+// Processor is a strategystore.StrategyStore that adapts the per-operation sampling probabilities
+// to the throughput of the traces sampled by the clients, so that every operation is sampled
+// at roughly Options.TargetSamplesPerSecond.
+//
+// The throughput is aggregated by every collector and written to storage. A single collector,
+// elected as leader through a distributed lock, reads the throughput of all collectors,
+// calculates new probabilities and writes them back. The other collectors periodically
+// load the latest probabilities from storage.
+type Processor struct {
+	sync.RWMutex
+	Options
+
+	hostname string
+	storage  samplingstore.Store
+	election *leaderElection
+	metrics  processorMetrics
+	logger   *zap.Logger
+	timeNow  func() time.Time
+
+	// probabilities are the latest calculated or loaded sampling probabilities
+	probabilities model.ServiceOperationProbabilities
+
+	// strategyResponses are the sampling strategies generated from probabilities
+	strategyResponses map[string]*sampling.SamplingStrategyResponse
+
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// NewProcessor creates a new adaptive sampling Processor. It does not calculate or serve
+// any probabilities until Start is called.
+func NewProcessor(
+	options Options,
+	hostname string,
+	storage samplingstore.Store,
+	lock distributedlock.Lock,
+	metricsFactory metrics.Factory,
+	logger *zap.Logger,
+) *Processor {
+	p := &Processor{
+		Options:           options,
+		hostname:          hostname,
+		storage:           storage,
+		logger:            logger,
+		timeNow:           time.Now,
+		probabilities:     make(model.ServiceOperationProbabilities),
+		strategyResponses: make(map[string]*sampling.SamplingStrategyResponse),
+		stop:              make(chan struct{}),
+	}
+	p.election = newLeaderElection(lock, samplingLock, options.LeaderLeaseRefreshInterval, options.FollowerLeaseRefreshInterval, logger)
+	metrics.Init(&p.metrics, metricsFactory.Namespace("adaptive-sampling", nil), nil)
+	return p
+}
+
+// Start begins the leader election and the periodic calculation or loading of probabilities.
+func (p *Processor) Start() {
+	p.election.Start()
+	p.loadProbabilities()
+	p.done.Add(1)
+	go p.runCalculationLoop()
+}
+
+// Close stops the Processor and gives up the leadership, if held.
+func (p *Processor) Close() error {
+	close(p.stop)
+	p.done.Wait()
+	return p.election.Close()
+}
+
+// GetSamplingStrategy implements strategystore.StrategyStore#GetSamplingStrategy.
+func (p *Processor) GetSamplingStrategy(service string) (*sampling.SamplingStrategyResponse, error) {
+	p.RLock()
+	defer p.RUnlock()
+	if strategy, ok := p.strategyResponses[service]; ok {
+		return strategy, nil
+	}
+	return p.generateDefaultStrategyResponse(), nil
+}
+
+func (p *Processor) runCalculationLoop() {
+	defer p.done.Done()
+	ticker := time.NewTicker(p.CalculationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.runCalculation()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+func (p *Processor) runCalculation() {
+	if !p.election.IsLeader() {
+		p.metrics.Leader.Update(0)
+		p.loadProbabilities()
+		return
+	}
+	p.metrics.Leader.Update(1)
+
+	endTime := p.timeNow().Add(-p.Delay)
+	startTime := endTime.Add(-p.CalculationInterval)
+	throughput, err := p.storage.GetThroughput(startTime, endTime)
+	if err != nil {
+		p.metrics.StorageErrors.Inc(1)
+		p.logger.Error("Failed to get throughput", zap.Error(err))
+		return
+	}
+	probabilities, qps := p.calculateProbabilities(throughput)
+	p.setProbabilities(probabilities)
+	p.metrics.Calculations.Inc(1)
+
+	if err := p.storage.InsertProbabilitiesAndQPS(p.hostname, probabilities, qps); err != nil {
+		p.metrics.StorageErrors.Inc(1)
+		p.logger.Error("Failed to save probabilities", zap.Error(err))
+	}
+}
+
+func (p *Processor) loadProbabilities() {
+	probabilities, err := p.storage.GetLatestProbabilities()
+	if err != nil {
+		p.metrics.StorageErrors.Inc(1)
+		p.logger.Warn("Failed to load sampling probabilities", zap.Error(err))
+		return
+	}
+	p.setProbabilities(probabilities)
+}
+
+func (p *Processor) setProbabilities(probabilities model.ServiceOperationProbabilities) {
+	strategyResponses := p.generateStrategyResponses(probabilities)
+	p.Lock()
+	defer p.Unlock()
+	p.probabilities = probabilities
+	p.strategyResponses = strategyResponses
+}
+
+// calculateProbabilities calculates new probabilities for all operations, starting from the current ones.
+func (p *Processor) calculateProbabilities(throughput []*model.Throughput) (model.ServiceOperationProbabilities, model.ServiceOperationQPS) {
+	p.RLock()
+	oldProbabilities := p.probabilities
+	p.RUnlock()
+
+	intervalSeconds := p.CalculationInterval.Seconds()
+	probabilities := make(model.ServiceOperationProbabilities)
+	qps := make(model.ServiceOperationQPS)
+	for svc, opThroughput := range aggregateThroughput(throughput) {
+		probabilities[svc] = make(map[string]float64)
+		qps[svc] = make(map[string]float64)
+		for op, t := range opThroughput {
+			opQPS := float64(t.Count) / intervalSeconds
+			qps[svc][op] = opQPS
+			oldProbability := p.InitialSamplingProbability
+			if prob, ok := oldProbabilities[svc][op]; ok {
+				oldProbability = prob
+			}
+			probabilities[svc][op] = p.calculateProbability(oldProbability, opQPS, t.Probabilities)
+		}
+	}
+	// operations without sampled traces in this interval keep their probabilities
+	for svc, opProbabilities := range oldProbabilities {
+		if _, ok := probabilities[svc]; !ok {
+			probabilities[svc] = make(map[string]float64)
+		}
+		for op, probability := range opProbabilities {
+			if _, ok := probabilities[svc][op]; !ok {
+				probabilities[svc][op] = probability
+			}
+		}
+	}
+	return probabilities, qps
+}
+
+// calculateProbability calculates the probability that would sample an operation at the target rate,
+// given the rate observed with oldProbability.
+func (p *Processor) calculateProbability(oldProbability, qps float64, usedProbabilities map[string]struct{}) float64 {
+	if _, ok := usedProbabilities[probabilityKey(oldProbability)]; !ok {
+		// The clients have not picked up the current probability yet, the observed
+		// throughput was generated with a different one and would skew the calculation.
+		return oldProbability
+	}
+	if qps == 0 || math.Abs(qps-p.TargetSamplesPerSecond) <= p.TargetSamplesPerSecond*p.DeltaTolerance {
+		return oldProbability
+	}
+	newProbability := oldProbability * p.TargetSamplesPerSecond / qps
+	newProbability = math.Min(newProbability, oldProbability*maxProbabilityIncreaseFactor)
+	return math.Min(maxSamplingProbability, math.Max(p.MinSamplingProbability, newProbability))
+}
+
+func (p *Processor) generateStrategyResponses(probabilities model.ServiceOperationProbabilities) map[string]*sampling.SamplingStrategyResponse {
+	strategyResponses := make(map[string]*sampling.SamplingStrategyResponse)
+	for svc, opProbabilities := range probabilities {
+		strategy := p.generateDefaultStrategyResponse()
+		for op, probability := range opProbabilities {
+			strategy.OperationSampling.PerOperationStrategies = append(strategy.OperationSampling.PerOperationStrategies,
+				&sampling.OperationSamplingStrategy{
+					Operation: op,
+					ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{
+						SamplingRate: probability,
+					},
+				})
+		}
+		opStrategies := strategy.OperationSampling.PerOperationStrategies
+		sort.Slice(opStrategies, func(i, j int) bool {
+			return opStrategies[i].Operation < opStrategies[j].Operation
+		})
+		strategyResponses[svc] = strategy
+	}
+	return strategyResponses
+}
+
+func (p *Processor) generateDefaultStrategyResponse() *sampling.SamplingStrategyResponse {
+	return &sampling.SamplingStrategyResponse{
+		StrategyType: sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{
+			SamplingRate: p.InitialSamplingProbability,
+		},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       p.InitialSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: p.MinSamplesPerSecond,
+		},
+	}
+}
+
+// aggregateThroughput merges the throughput reported by all collectors for the same operation.
+func aggregateThroughput(throughput []*model.Throughput) serviceOperationThroughput {
+	aggregated := make(serviceOperationThroughput)
+	for _, t := range throughput {
+		if _, ok := aggregated[t.Service]; !ok {
+			aggregated[t.Service] = make(map[string]*model.Throughput)
+		}
+		agg, ok := aggregated[t.Service][t.Operation]
+		if !ok {
+			agg = &model.Throughput{
+				Service:       t.Service,
+				Operation:     t.Operation,
+				Probabilities: make(map[string]struct{}),
+			}
+			aggregated[t.Service][t.Operation] = agg
+		}
+		agg.Count += t.Count
+		for probability := range t.Probabilities {
+			agg.Probabilities[probability] = struct{}{}
+		}
+	}
+	return aggregated
+}
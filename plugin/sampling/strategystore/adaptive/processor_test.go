@@ -0,0 +1,218 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	mTestutils "github.com/uber/jaeger-lib/metrics/testutils"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	lmocks "github.com/jaegertracing/jaeger/pkg/distributedlock/mocks"
+	smocks "github.com/jaegertracing/jaeger/storage/samplingstore/mocks"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+)
+
+var testOptions = Options{
+	TargetSamplesPerSecond:       1,
+	DeltaTolerance:               0.1,
+	CalculationInterval:          time.Minute,
+	Delay:                        2 * time.Minute,
+	InitialSamplingProbability:   0.001,
+	MinSamplingProbability:       0.00001,
+	MinSamplesPerSecond:          1.0 / 60,
+	LeaderLeaseRefreshInterval:   time.Millisecond,
+	FollowerLeaseRefreshInterval: time.Millisecond,
+}
+
+func newTestProcessor(store *smocks.Store, lock *lmocks.Lock, mFactory metrics.Factory) *Processor {
+	p := NewProcessor(testOptions, "host", store, lock, mFactory, zap.NewNop())
+	p.timeNow = func() time.Time { return time.Unix(1000, 0) }
+	return p
+}
+
+func makeThroughput(service, operation string, count int64, probabilities ...string) *model.Throughput {
+	t := &model.Throughput{Service: service, Operation: operation, Count: count, Probabilities: map[string]struct{}{}}
+	for _, p := range probabilities {
+		t.Probabilities[p] = struct{}{}
+	}
+	return t
+}
+
+func TestCalculateProbability(t *testing.T) {
+	p := newTestProcessor(&smocks.Store{}, &lmocks.Lock{}, metrics.NullFactory)
+	used := map[string]struct{}{"0.001": {}, "0.5": {}, "0.00001": {}}
+	tests := []struct {
+		caption        string
+		oldProbability float64
+		qps            float64
+		used           map[string]struct{}
+		expected       float64
+	}{
+		{caption: "probability not used by clients yet", oldProbability: 0.002, qps: 10, used: used, expected: 0.002},
+		{caption: "no traffic", oldProbability: 0.001, qps: 0, used: used, expected: 0.001},
+		{caption: "within tolerance", oldProbability: 0.001, qps: 1.05, used: used, expected: 0.001},
+		{caption: "too many samples", oldProbability: 0.5, qps: 5, used: used, expected: 0.1},
+		{caption: "too few samples, increase is capped", oldProbability: 0.001, qps: 0.1, used: used, expected: 0.002},
+		{caption: "too few samples", oldProbability: 0.5, qps: 0.8, used: used, expected: 0.625},
+		{caption: "capped at min probability", oldProbability: 0.00001, qps: 100, used: used, expected: 0.00001},
+		{caption: "capped at max probability", oldProbability: 0.5, qps: 0.1, used: used, expected: 1.0},
+	}
+	for _, tc := range tests {
+		testCase := tc // capture loop var
+		t.Run(testCase.caption, func(t *testing.T) {
+			actual := p.calculateProbability(testCase.oldProbability, testCase.qps, testCase.used)
+			assert.InDelta(t, testCase.expected, actual, 1e-9)
+		})
+	}
+}
+
+func TestCalculateProbabilities(t *testing.T) {
+	p := newTestProcessor(&smocks.Store{}, &lmocks.Lock{}, metrics.NullFactory)
+	p.probabilities = model.ServiceOperationProbabilities{
+		"svcA": {"GET": 0.5, "PUT": 0.2},
+		"svcB": {"GET": 0.3},
+	}
+	throughput := []*model.Throughput{
+		// reported by two collectors
+		makeThroughput("svcA", "GET", 150, "0.5"),
+		makeThroughput("svcA", "GET", 150, "0.5"),
+		makeThroughput("svcC", "GET", 6, "0.001"),
+	}
+	probabilities, qps := p.calculateProbabilities(throughput)
+	assert.Equal(t, model.ServiceOperationQPS{
+		"svcA": {"GET": 5},
+		"svcC": {"GET": 0.1},
+	}, qps)
+	assert.InDelta(t, 0.1, probabilities["svcA"]["GET"], 1e-9)
+	assert.InDelta(t, 0.002, probabilities["svcC"]["GET"], 1e-9)
+	// operations without throughput keep their probabilities
+	assert.Equal(t, 0.2, probabilities["svcA"]["PUT"])
+	assert.Equal(t, 0.3, probabilities["svcB"]["GET"])
+}
+
+func TestRunCalculationAsLeader(t *testing.T) {
+	store := &smocks.Store{}
+	mFactory := metrics.NewLocalFactory(0)
+	p := newTestProcessor(store, &lmocks.Lock{}, mFactory)
+	atomic.StoreInt32(&p.election.leader, 1)
+
+	start, end := time.Unix(820, 0), time.Unix(880, 0)
+	store.On("GetThroughput", start, end).Return([]*model.Throughput{makeThroughput("svc", "GET", 300, "0.001")}, nil).Once()
+	store.On("InsertProbabilitiesAndQPS", "host",
+		model.ServiceOperationProbabilities{"svc": {"GET": 0.0002}},
+		model.ServiceOperationQPS{"svc": {"GET": 5}},
+	).Return(errors.New("storage error"))
+
+	p.runCalculation()
+	store.AssertExpectations(t)
+
+	s, err := p.GetSamplingStrategy("svc")
+	require.NoError(t, err)
+	require.Len(t, s.OperationSampling.PerOperationStrategies, 1)
+	assert.Equal(t, "GET", s.OperationSampling.PerOperationStrategies[0].Operation)
+	assert.InDelta(t, 0.0002, s.OperationSampling.PerOperationStrategies[0].ProbabilisticSampling.SamplingRate, 1e-9)
+
+	mTestutils.AssertCounterMetrics(t, mFactory,
+		mTestutils.ExpectedMetric{Name: "adaptive-sampling.calculations", Value: 1},
+		mTestutils.ExpectedMetric{Name: "adaptive-sampling.storage-errors", Value: 1},
+	)
+	mTestutils.AssertGaugeMetrics(t, mFactory,
+		mTestutils.ExpectedMetric{Name: "adaptive-sampling.leader", Value: 1},
+	)
+
+	store.On("GetThroughput", start, end).Return(nil, errors.New("storage error"))
+	p.runCalculation()
+	mTestutils.AssertCounterMetrics(t, mFactory,
+		mTestutils.ExpectedMetric{Name: "adaptive-sampling.calculations", Value: 1},
+		mTestutils.ExpectedMetric{Name: "adaptive-sampling.storage-errors", Value: 2},
+	)
+}
+
+func TestRunCalculationAsFollower(t *testing.T) {
+	store := &smocks.Store{}
+	p := newTestProcessor(store, &lmocks.Lock{}, metrics.NullFactory)
+
+	store.On("GetLatestProbabilities").Return(model.ServiceOperationProbabilities{
+		"svc": {"PUT": 0.3, "GET": 0.2},
+	}, nil).Once()
+	p.runCalculation()
+
+	s, err := p.GetSamplingStrategy("svc")
+	require.NoError(t, err)
+	assert.Equal(t, &sampling.SamplingStrategyResponse{
+		StrategyType: sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{
+			SamplingRate: testOptions.InitialSamplingProbability,
+		},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       testOptions.InitialSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: testOptions.MinSamplesPerSecond,
+			PerOperationStrategies: []*sampling.OperationSamplingStrategy{
+				{Operation: "GET", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.2}},
+				{Operation: "PUT", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.3}},
+			},
+		},
+	}, s)
+
+	// the previous probabilities are kept if they cannot be loaded
+	store.On("GetLatestProbabilities").Return(model.ServiceOperationProbabilities(nil), errors.New("storage error"))
+	p.runCalculation()
+	s2, err := p.GetSamplingStrategy("svc")
+	require.NoError(t, err)
+	assert.Equal(t, s, s2)
+
+	s, err = p.GetSamplingStrategy("unknown")
+	require.NoError(t, err)
+	assert.Equal(t, p.generateDefaultStrategyResponse(), s)
+	assert.Empty(t, s.OperationSampling.PerOperationStrategies)
+}
+
+func TestProcessorStartAndClose(t *testing.T) {
+	store := &smocks.Store{}
+	store.On("GetLatestProbabilities").Return(model.ServiceOperationProbabilities{}, nil)
+	store.On("GetThroughput", mock.Anything, mock.Anything).Return(nil, nil)
+	calculated := make(chan struct{}, 1)
+	store.On("InsertProbabilitiesAndQPS", "host", mock.Anything, mock.Anything).Return(nil).Run(func(mock.Arguments) {
+		select {
+		case calculated <- struct{}{}:
+		default:
+		}
+	})
+	lock := &lmocks.Lock{}
+	lock.On("Acquire", samplingLock, testOptions.FollowerLeaseRefreshInterval).Return(true, nil)
+	lock.On("Forfeit", samplingLock).Return(true, nil)
+
+	options := testOptions
+	options.CalculationInterval = time.Millisecond
+	p := NewProcessor(options, "host", store, lock, metrics.NullFactory, zap.NewNop())
+	p.Start()
+	assert.True(t, p.election.IsLeader())
+	select {
+	case <-calculated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("probabilities were not calculated")
+	}
+	require.NoError(t, p.Close())
+	lock.AssertCalled(t, "Forfeit", samplingLock)
+}
@@ -27,6 +27,7 @@ import (
 
 	ss "github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	"github.com/jaegertracing/jaeger/plugin"
+	"github.com/jaegertracing/jaeger/storage"
 )
 
 var _ ss.Factory = new(Factory)
@@ -42,13 +43,13 @@ func TestNewFactory(t *testing.T) {
 	mock := new(mockFactory)
 	f.factories[staticStrategyStoreType] = mock
 
-	assert.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	assert.NoError(t, f.Initialize(metrics.NullFactory, nil, zap.NewNop()))
 	_, err = f.CreateStrategyStore()
 	assert.NoError(t, err)
 
 	// force the mock to return errors
 	mock.retError = true
-	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()), "error initializing store")
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, nil, zap.NewNop()), "error initializing store")
 	_, err = f.CreateStrategyStore()
 	assert.EqualError(t, err, "error creating store")
 
@@ -61,6 +62,25 @@ func TestNewFactory(t *testing.T) {
 	assert.Contains(t, err.Error(), "Unknown sampling strategy store type")
 }
 
+func TestCreateAggregator(t *testing.T) {
+	f, err := NewFactory(FactoryConfig{StrategyStoreType: staticStrategyStoreType})
+	require.NoError(t, err)
+	agg, err := f.CreateAggregator()
+	require.NoError(t, err)
+	assert.Nil(t, agg)
+
+	f, err = NewFactory(FactoryConfig{StrategyStoreType: adaptiveStrategyStoreType})
+	require.NoError(t, err)
+	assert.NotEmpty(t, f.factories[adaptiveStrategyStoreType])
+	agg, err = f.CreateAggregator()
+	require.NoError(t, err)
+	assert.NotNil(t, agg)
+
+	f.StrategyStoreType = "nonsense"
+	_, err = f.CreateAggregator()
+	assert.EqualError(t, err, "No nonsense strategy store registered")
+}
+
 func TestConfigurable(t *testing.T) {
 	clearEnv()
 	defer clearEnv()
@@ -104,7 +124,7 @@ func (f *mockFactory) CreateStrategyStore() (ss.StrategyStore, error) {
 	return nil, nil
 }
 
-func (f *mockFactory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger) error {
+func (f *mockFactory) Initialize(metricsFactory metrics.Factory, ssFactory storage.SamplingStoreFactory, logger *zap.Logger) error {
 	if f.retError {
 		return errors.New("error initializing store")
 	}
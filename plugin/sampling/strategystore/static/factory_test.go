@@ -16,6 +16,7 @@ package static
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/uber/jaeger-lib/metrics"
@@ -32,10 +33,15 @@ var _ plugin.Configurable = new(Factory)
 func TestFactory(t *testing.T) {
 	f := NewFactory()
 	v, command := config.Viperize(f.AddFlags)
-	command.ParseFlags([]string{"--sampling.strategies-file=fixtures/strategies.json"})
+	command.ParseFlags([]string{
+		"--sampling.strategies-file=fixtures/strategies.json",
+		"--sampling.strategies-poll-interval=1m",
+	})
 	f.InitFromViper(v)
+	assert.Equal(t, time.Minute, f.options.PollInterval)
 
-	assert.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
-	_, err := f.CreateStrategyStore()
+	assert.NoError(t, f.Initialize(metrics.NullFactory, nil, zap.NewNop()))
+	store, err := f.CreateStrategyStore()
 	assert.NoError(t, err)
+	assert.NoError(t, store.(*strategyStore).Close())
 }
@@ -16,27 +16,35 @@ package static
 
 import (
 	"flag"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 const (
-	samplingStrategiesFile = "sampling.strategies-file"
+	samplingStrategiesFile         = "sampling.strategies-file"
+	samplingStrategiesPollInterval = "sampling.strategies-poll-interval"
 )
 
 // Options holds configuration for the static sampling strategy store.
 type Options struct {
-	// StrategiesFile is the path for the sampling strategies file in JSON format
+	// StrategiesFile is the path for the sampling strategies file in JSON format,
+	// or an http(s) URL from which the file is downloaded
 	StrategiesFile string
+	// PollInterval is how often the strategies file is polled and reloaded if it changed.
+	// If zero, a local file is watched for changes instead, and an URL is not reloaded.
+	PollInterval time.Duration
 }
 
 // AddFlags adds flags for Options
 func AddFlags(flagSet *flag.FlagSet) {
-	flagSet.String(samplingStrategiesFile, "", "The path for the sampling strategies file in JSON format. See sampling documentation to see format of the file")
+	flagSet.String(samplingStrategiesFile, "", "The path for the sampling strategies file in JSON format, or an http(s) URL to download it from. See sampling documentation to see format of the file")
+	flagSet.Duration(samplingStrategiesPollInterval, 0, "Interval at which the sampling strategies file is polled and reloaded if it changed. Zero value means a local file is watched for changes and an URL is not reloaded")
 }
 
 // InitFromViper initializes Options with properties from viper
 func (opts *Options) InitFromViper(v *viper.Viper) *Options {
 	opts.StrategiesFile = v.GetString(samplingStrategiesFile)
+	opts.PollInterval = v.GetDuration(samplingStrategiesPollInterval)
 	return opts
 }
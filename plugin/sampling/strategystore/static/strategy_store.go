@@ -18,51 +18,226 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/pkg/errors"
+	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
 	ss "github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
 )
 
+// downloadTimeout is the timeout for downloading the strategies from an URL
+const downloadTimeout = 10 * time.Second
+
+type storeMetrics struct {
+	// Number of times the strategies were reloaded after they changed
+	ReloadSuccess metrics.Counter `metric:"sampling.strategies-reload" tags:"result=ok"`
+
+	// Number of times the strategies could not be reloaded, the previous strategies remain in use
+	ReloadFailure metrics.Counter `metric:"sampling.strategies-reload" tags:"result=err"`
+}
+
 type strategyStore struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	metrics storeMetrics
+
+	// storedStrategies holds *storedStrategies, which are swapped as a whole when the strategies are reloaded
+	storedStrategies atomic.Value
 
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     sync.WaitGroup
+}
+
+type storedStrategies struct {
 	defaultStrategy   *sampling.SamplingStrategyResponse
 	serviceStrategies map[string]*sampling.SamplingStrategyResponse
 }
 
+// strategyLoader returns the contents of the strategies file, or nil if there is no file
+type strategyLoader func() ([]byte, error)
+
 // NewStrategyStore creates a strategy store that holds static sampling strategies.
-func NewStrategyStore(options Options, logger *zap.Logger) (ss.StrategyStore, error) {
+// The strategies are reloaded until Close is called: a local strategies file is watched for changes,
+// unless options.PollInterval is set, in which case the file or URL is polled instead.
+func NewStrategyStore(options Options, metricsFactory metrics.Factory, logger *zap.Logger) (ss.StrategyStore, error) {
 	h := &strategyStore{
-		logger:            logger,
-		serviceStrategies: make(map[string]*sampling.SamplingStrategyResponse),
+		logger: logger,
+		stop:   make(chan struct{}),
+	}
+	metrics.Init(&h.metrics, metricsFactory, nil)
+
+	loadFn := samplingStrategyLoader(options.StrategiesFile)
+	bytes, err := loadFn()
+	if err != nil {
+		return nil, err
 	}
-	strategies, err := loadStrategies(options.StrategiesFile)
+	strategies, err := loadStrategies(bytes)
 	if err != nil {
 		return nil, err
 	}
+	if err := validateStrategies(strategies); err != nil {
+		// such strategies were always accepted at startup, only reloads reject them
+		logger.Warn("Invalid sampling strategies, they will not be accepted on reload", zap.Error(err))
+	}
 	h.parseStrategies(strategies)
+
+	switch {
+	case options.StrategiesFile == "":
+	case options.PollInterval > 0:
+		h.done.Add(1)
+		go h.autoUpdateStrategies(options.PollInterval, loadFn, string(bytes))
+	case !isURL(options.StrategiesFile):
+		if err := h.watchStrategies(options.StrategiesFile, loadFn, string(bytes)); err != nil {
+			return nil, err
+		}
+	}
 	return h, nil
 }
 
 // GetSamplingStrategy implements StrategyStore#GetSamplingStrategy.
 func (h *strategyStore) GetSamplingStrategy(serviceName string) (*sampling.SamplingStrategyResponse, error) {
-	if strategy, ok := h.serviceStrategies[serviceName]; ok {
+	stored := h.storedStrategies.Load().(*storedStrategies)
+	if strategy, ok := stored.serviceStrategies[serviceName]; ok {
 		return strategy, nil
 	}
-	return h.defaultStrategy, nil
+	return stored.defaultStrategy, nil
+}
+
+// Close stops reloading the strategies.
+func (h *strategyStore) Close() error {
+	h.stopOnce.Do(func() {
+		close(h.stop)
+	})
+	h.done.Wait()
+	return nil
+}
+
+func (h *strategyStore) autoUpdateStrategies(interval time.Duration, loadFn strategyLoader, lastValue string) {
+	defer h.done.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			lastValue = h.reloadSamplingStrategy(loadFn, lastValue)
+		case <-h.stop:
+			return
+		}
+	}
+}
+
+// watchStrategies reloads the strategies when the file changes. The directory of the file is watched
+// rather than the file, so that the file can be replaced by renaming another one over it,
+// like editors and Kubernetes ConfigMap volumes do.
+func (h *strategyStore) watchStrategies(strategiesFile string, loadFn strategyLoader, lastValue string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "Failed to create strategies file watcher")
+	}
+	if err := watcher.Add(filepath.Dir(strategiesFile)); err != nil {
+		watcher.Close()
+		return errors.Wrap(err, "Failed to watch strategies file")
+	}
+	h.done.Add(1)
+	go func() {
+		defer h.done.Done()
+		defer watcher.Close()
+		for {
+			select {
+			case <-watcher.Events:
+				lastValue = h.reloadSamplingStrategy(loadFn, lastValue)
+			case err := <-watcher.Errors:
+				h.logger.Error("Failed to watch sampling strategies file", zap.Error(err))
+			case <-h.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// reloadSamplingStrategy replaces the strategies if the file changed since lastValue and
+// returns the contents of the file the current strategies were loaded from.
+func (h *strategyStore) reloadSamplingStrategy(loadFn strategyLoader, lastValue string) string {
+	bytes, err := loadFn()
+	if err != nil {
+		h.metrics.ReloadFailure.Inc(1)
+		h.logger.Error("Failed to reload sampling strategies", zap.Error(err))
+		return lastValue
+	}
+	if string(bytes) == lastValue {
+		return lastValue
+	}
+	strategies, err := loadStrategies(bytes)
+	if err == nil {
+		err = validateStrategies(strategies)
+	}
+	if err != nil {
+		h.metrics.ReloadFailure.Inc(1)
+		h.logger.Error("Failed to reload sampling strategies, keeping the previous ones", zap.Error(err))
+		return lastValue
+	}
+	h.parseStrategies(strategies)
+	h.metrics.ReloadSuccess.Inc(1)
+	h.logger.Info("Reloaded sampling strategies")
+	return string(bytes)
 }
 
-// TODO good candidate for a global util function
-func loadStrategies(strategiesFile string) (*strategies, error) {
+func samplingStrategyLoader(strategiesFile string) strategyLoader {
 	if strategiesFile == "" {
-		return nil, nil
+		return func() ([]byte, error) {
+			return nil, nil
+		}
 	}
-	bytes, err := ioutil.ReadFile(strategiesFile) /* nolint #nosec , this comes from an admin, not user */
+	if isURL(strategiesFile) {
+		return func() ([]byte, error) {
+			return downloadStrategies(strategiesFile)
+		}
+	}
+	return func() ([]byte, error) {
+		bytes, err := ioutil.ReadFile(strategiesFile) /* nolint #nosec , this comes from an admin, not user */
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to open strategies file")
+		}
+		return bytes, nil
+	}
+}
+
+func isURL(strategiesFile string) bool {
+	return strings.HasPrefix(strategiesFile, "http://") || strings.HasPrefix(strategiesFile, "https://")
+}
+
+func downloadStrategies(url string) ([]byte, error) {
+	client := &http.Client{Timeout: downloadTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to download strategies file")
+	}
+	defer resp.Body.Close()
+	bytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.Wrap(err, "Failed to open strategies file")
+		return nil, errors.Wrap(err, "Failed to read strategies file")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to download strategies file: status code %d, body: %s", resp.StatusCode, string(bytes))
+	}
+	return bytes, nil
+}
+
+// loadStrategies parses the strategies file, returning nil if there is no file.
+func loadStrategies(bytes []byte) (*strategies, error) {
+	if bytes == nil {
+		return nil, nil
 	}
 	var strategies strategies
 	if err := json.Unmarshal(bytes, &strategies); err != nil {
@@ -71,18 +246,61 @@ func loadStrategies(strategiesFile string) (*strategies, error) {
 	return &strategies, nil
 }
 
-func (h *strategyStore) parseStrategies(strategies *strategies) {
-	h.defaultStrategy = &defaultStrategy
+// validateStrategies checks the strategies, so that a bad edit does not replace the strategies in use.
+func validateStrategies(strategies *strategies) error {
 	if strategies == nil {
-		h.logger.Info("No sampling strategies provided, using defaults")
-		return
+		return nil
 	}
 	if strategies.DefaultStrategy != nil {
-		h.defaultStrategy = h.parseStrategy(strategies.DefaultStrategy)
+		if err := validateStrategy(strategies.DefaultStrategy); err != nil {
+			return errors.Wrap(err, "Invalid default strategy")
+		}
 	}
 	for _, s := range strategies.ServiceStrategies {
-		h.serviceStrategies[s.Service] = h.parseServiceStrategies(s)
+		if err := validateStrategy(&s.strategy); err != nil {
+			return errors.Wrapf(err, "Invalid strategy of service %s", s.Service)
+		}
+		for _, o := range s.OperationStrategies {
+			if err := validateStrategy(&o.strategy); err != nil {
+				return errors.Wrapf(err, "Invalid strategy of service %s, operation %s", s.Service, o.Operation)
+			}
+		}
+	}
+	return nil
+}
+
+func validateStrategy(strategy *strategy) error {
+	switch strategy.Type {
+	case samplerTypeProbabilistic:
+		if strategy.Param < 0 || strategy.Param > 1 {
+			return fmt.Errorf("sampling probability %v is not between 0 and 1", strategy.Param)
+		}
+	case samplerTypeRateLimiting:
+		if strategy.Param < 0 || strategy.Param > math.MaxInt16 {
+			return fmt.Errorf("max traces per second %v is not between 0 and %d", strategy.Param, math.MaxInt16)
+		}
+	default:
+		return fmt.Errorf("unknown strategy type %q", strategy.Type)
+	}
+	return nil
+}
+
+func (h *strategyStore) parseStrategies(strategies *strategies) {
+	stored := &storedStrategies{
+		defaultStrategy:   &defaultStrategy,
+		serviceStrategies: make(map[string]*sampling.SamplingStrategyResponse),
+	}
+	if strategies == nil {
+		h.logger.Info("No sampling strategies provided, using defaults")
+	} else {
+		if strategies.DefaultStrategy != nil {
+			stored.defaultStrategy = h.parseStrategy(strategies.DefaultStrategy)
+		}
+		for _, s := range strategies.ServiceStrategies {
+			stored.serviceStrategies[s.Service] = h.parseServiceStrategies(s)
+		}
 	}
+	h.storedStrategies.Store(stored)
 }
 
 func (h *strategyStore) parseServiceStrategies(strategy *serviceStrategy) *sampling.SamplingStrategyResponse {
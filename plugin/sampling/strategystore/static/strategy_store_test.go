@@ -15,27 +15,39 @@
 package static
 
 import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	mTestutils "github.com/uber/jaeger-lib/metrics/testutils"
 	"go.uber.org/zap"
 
+	ss "github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	"github.com/jaegertracing/jaeger/pkg/testutils"
 	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
 )
 
 func TestStrategyStore(t *testing.T) {
-	_, err := NewStrategyStore(Options{StrategiesFile: "fileNotFound.json"}, zap.NewNop())
+	_, err := NewStrategyStore(Options{StrategiesFile: "fileNotFound.json"}, metrics.NullFactory, zap.NewNop())
 	assert.EqualError(t, err, "Failed to open strategies file: open fileNotFound.json: no such file or directory")
 
-	_, err = NewStrategyStore(Options{StrategiesFile: "fixtures/bad_strategies.json"}, zap.NewNop())
+	_, err = NewStrategyStore(Options{StrategiesFile: "fixtures/bad_strategies.json"}, metrics.NullFactory, zap.NewNop())
 	assert.EqualError(t, err,
 		"Failed to unmarshal strategies: json: cannot unmarshal string into Go value of type static.strategies")
 
 	// Test default strategy
 	logger, buf := testutils.NewLogger()
-	store, err := NewStrategyStore(Options{}, logger)
+	store, err := NewStrategyStore(Options{}, metrics.NullFactory, logger)
 	require.NoError(t, err)
 	assert.Contains(t, buf.String(), "No sampling strategies provided, using defaults")
 	s, err := store.GetSamplingStrategy("foo")
@@ -43,7 +55,7 @@ func TestStrategyStore(t *testing.T) {
 	assert.EqualValues(t, makeResponse(sampling.SamplingStrategyType_PROBABILISTIC, 0.001), *s)
 
 	// Test reading strategies from a file
-	store, err = NewStrategyStore(Options{StrategiesFile: "fixtures/strategies.json"}, logger)
+	store, err = NewStrategyStore(Options{StrategiesFile: "fixtures/strategies.json"}, metrics.NullFactory, logger)
 	require.NoError(t, err)
 	s, err = store.GetSamplingStrategy("foo")
 	require.NoError(t, err)
@@ -60,7 +72,7 @@ func TestStrategyStore(t *testing.T) {
 
 func TestPerOperationSamplingStrategies(t *testing.T) {
 	logger, buf := testutils.NewLogger()
-	store, err := NewStrategyStore(Options{StrategiesFile: "fixtures/operation_strategies.json"}, logger)
+	store, err := NewStrategyStore(Options{StrategiesFile: "fixtures/operation_strategies.json"}, metrics.NullFactory, logger)
 	assert.Contains(t, buf.String(), "Operation strategies only supports probabilistic sampling at the moment,"+
 		"'op2' defaulting to probabilistic sampling with probability 0.8")
 	assert.Contains(t, buf.String(), "Operation strategies only supports probabilistic sampling at the moment,"+
@@ -137,6 +149,187 @@ func TestParseStrategy(t *testing.T) {
 	assert.Contains(t, buf.String(), "Failed to parse sampling strategy")
 }
 
+func TestInvalidStrategies(t *testing.T) {
+	tests := []struct {
+		strategies string
+		err        string
+	}{
+		{
+			strategies: `{"default_strategy": {"type": "probabilistic", "param": 1.5}}`,
+			err:        "Invalid default strategy: sampling probability 1.5 is not between 0 and 1",
+		},
+		{
+			strategies: `{"service_strategies": [{"service": "foo", "type": "blah", "param": 1}]}`,
+			err:        `Invalid strategy of service foo: unknown strategy type "blah"`,
+		},
+		{
+			strategies: `{"service_strategies": [{"service": "foo", "type": "probabilistic", "param": 1,
+				"operation_strategies": [{"operation": "op1", "type": "ratelimiting", "param": -1}]}]}`,
+			err: "Invalid strategy of service foo, operation op1: max traces per second -1 is not between 0 and 32767",
+		},
+	}
+	for _, test := range tests {
+		strategies, err := loadStrategies([]byte(test.strategies))
+		require.NoError(t, err)
+		assert.EqualError(t, validateStrategies(strategies), test.err)
+	}
+	assert.NoError(t, validateStrategies(nil))
+}
+
+func TestInvalidStrategiesAtStartup(t *testing.T) {
+	tempFile, err := ioutil.TempFile("", "strategies")
+	require.NoError(t, err)
+	defer os.Remove(tempFile.Name())
+	require.NoError(t, ioutil.WriteFile(tempFile.Name(), []byte(`{"default_strategy": {"type": "blah"}}`), 0644))
+
+	logger, buf := testutils.NewLogger()
+	store, err := NewStrategyStore(Options{StrategiesFile: tempFile.Name()}, metrics.NullFactory, logger)
+	require.NoError(t, err)
+	defer store.(*strategyStore).Close()
+	assert.Contains(t, buf.String(), "Invalid sampling strategies, they will not be accepted on reload")
+	assertDefaultProbability(t, store, defaultSamplingProbability)
+}
+
+func TestAutoUpdateStrategies(t *testing.T) {
+	tempFile, err := ioutil.TempFile("", "strategies")
+	require.NoError(t, err)
+	defer os.Remove(tempFile.Name())
+	writeStrategies(t, tempFile.Name(), 0.5)
+
+	metricsFactory := metrics.NewLocalFactory(0)
+	store, err := NewStrategyStore(Options{
+		StrategiesFile: tempFile.Name(),
+		PollInterval:   time.Millisecond,
+	}, metricsFactory, zap.NewNop())
+	require.NoError(t, err)
+	defer store.(*strategyStore).Close()
+	assertDefaultProbability(t, store, 0.5)
+
+	writeStrategies(t, tempFile.Name(), 0.8)
+	waitForDefaultProbability(t, store, 0.8)
+	mTestutils.AssertCounterMetrics(t, metricsFactory, mTestutils.ExpectedMetric{
+		Name: "sampling.strategies-reload", Tags: map[string]string{"result": "ok"}, Value: 1,
+	})
+}
+
+func TestWatchStrategies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strategies")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "strategies.json")
+	writeStrategies(t, path, 0.5)
+
+	metricsFactory := metrics.NewLocalFactory(0)
+	store, err := NewStrategyStore(Options{StrategiesFile: path}, metricsFactory, zap.NewNop())
+	require.NoError(t, err)
+	assertDefaultProbability(t, store, 0.5)
+
+	writeStrategies(t, path, 0.8)
+	waitForDefaultProbability(t, store, 0.8)
+
+	// replacing the file by renaming another one over it
+	writeStrategies(t, filepath.Join(dir, "new.json"), 0.3)
+	require.NoError(t, os.Rename(filepath.Join(dir, "new.json"), path))
+	waitForDefaultProbability(t, store, 0.3)
+
+	closer := store.(io.Closer)
+	require.NoError(t, closer.Close())
+	require.NoError(t, closer.Close(), "Close must be idempotent")
+	mTestutils.AssertCounterMetrics(t, metricsFactory, mTestutils.ExpectedMetric{
+		Name: "sampling.strategies-reload", Tags: map[string]string{"result": "ok"}, Value: 2,
+	})
+}
+
+func TestReloadSamplingStrategy(t *testing.T) {
+	tempFile, err := ioutil.TempFile("", "strategies")
+	require.NoError(t, err)
+	defer os.Remove(tempFile.Name())
+	writeStrategies(t, tempFile.Name(), 0.5)
+
+	metricsFactory := metrics.NewLocalFactory(0)
+	logger, buf := testutils.NewLogger()
+	// a long poll interval keeps the store from reloading the file by itself
+	s, err := NewStrategyStore(Options{StrategiesFile: tempFile.Name(), PollInterval: time.Hour}, metricsFactory, logger)
+	require.NoError(t, err)
+	store := s.(*strategyStore)
+	defer store.Close()
+	loadFn := samplingStrategyLoader(tempFile.Name())
+	lastValue, err := loadFn()
+	require.NoError(t, err)
+
+	// unchanged file
+	assert.Equal(t, string(lastValue), store.reloadSamplingStrategy(loadFn, string(lastValue)))
+
+	// invalid file keeps the previous strategies
+	require.NoError(t, ioutil.WriteFile(tempFile.Name(), []byte(`{"default_strategy": {"type": "blah"}}`), 0644))
+	assert.Equal(t, string(lastValue), store.reloadSamplingStrategy(loadFn, string(lastValue)))
+	assert.Contains(t, buf.String(), "Failed to reload sampling strategies, keeping the previous ones")
+	assertDefaultProbability(t, store, 0.5)
+
+	// missing file keeps the previous strategies
+	os.Remove(tempFile.Name())
+	assert.Equal(t, string(lastValue), store.reloadSamplingStrategy(loadFn, string(lastValue)))
+	assertDefaultProbability(t, store, 0.5)
+
+	mTestutils.AssertCounterMetrics(t, metricsFactory, mTestutils.ExpectedMetric{
+		Name: "sampling.strategies-reload", Tags: map[string]string{"result": "err"}, Value: 2,
+	})
+}
+
+func TestDownloadStrategies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probability := strings.TrimPrefix(r.URL.Path, "/")
+		if probability == "missing" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"default_strategy": {"type": "probabilistic", "param": ` + probability + `}}`))
+	}))
+	defer server.Close()
+
+	s, err := NewStrategyStore(Options{StrategiesFile: server.URL + "/0.5"}, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	store := s.(*strategyStore)
+	assertDefaultProbability(t, store, 0.5)
+
+	lastValue := store.reloadSamplingStrategy(samplingStrategyLoader(server.URL+"/0.8"), "")
+	assert.Contains(t, lastValue, "0.8")
+	assertDefaultProbability(t, store, 0.8)
+
+	_, err = samplingStrategyLoader(server.URL + "/missing")()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to download strategies file: status code 404")
+
+	_, err = NewStrategyStore(Options{StrategiesFile: "http://localhost:0/strategies.json"}, metrics.NullFactory, zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to download strategies file")
+}
+
+func writeStrategies(t *testing.T, path string, probability float64) {
+	s := strategies{DefaultStrategy: &strategy{Type: samplerTypeProbabilistic, Param: probability}}
+	bytes, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, bytes, 0644))
+}
+
+func assertDefaultProbability(t *testing.T, store ss.StrategyStore, probability float64) {
+	s, err := store.GetSamplingStrategy("foo")
+	require.NoError(t, err)
+	assert.EqualValues(t, makeResponse(sampling.SamplingStrategyType_PROBABILISTIC, probability), *s)
+}
+
+func waitForDefaultProbability(t *testing.T, store ss.StrategyStore, probability float64) {
+	for i := 0; i < 1000; i++ {
+		s, err := store.GetSamplingStrategy("foo")
+		require.NoError(t, err)
+		if s.ProbabilisticSampling.SamplingRate == probability {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("strategies were not reloaded with probability %v", probability)
+}
+
 func makeResponse(samplerType sampling.SamplingStrategyType, param float64) (resp sampling.SamplingStrategyResponse) {
 	resp.StrategyType = samplerType
 	if samplerType == sampling.SamplingStrategyType_PROBABILISTIC {
@@ -0,0 +1,77 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencystore
+
+import (
+	"math"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/adjuster"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// DependencyStore computes the service dependencies from the traces stored in badger
+type DependencyStore struct {
+	reader  spanstore.Reader
+	deduper adjuster.Adjuster
+}
+
+// NewDependencyStore returns a DependencyStore that reads the traces with the given reader
+func NewDependencyStore(reader spanstore.Reader) *DependencyStore {
+	return &DependencyStore{
+		reader:  reader,
+		deduper: adjuster.SpanIDDeduper(),
+	}
+}
+
+// GetDependencies returns all interservice dependencies of the traces that started in the lookback period.
+// The traces are loaded one service at a time, so only the traces of a single service are held in memory.
+// A trace spanning several services is only counted for the first one, and its span IDs are deduplicated
+// before the calls are counted.
+func (s *DependencyStore) GetDependencies(endTs time.Time, lookback time.Duration) ([]model.DependencyLink, error) {
+	services, err := s.reader.GetServices()
+	if err != nil {
+		return nil, err
+	}
+	aggregator := dependencystore.NewAggregator()
+	seen := make(map[model.TraceID]struct{})
+	for _, service := range services {
+		traces, err := s.reader.FindTraces(&spanstore.TraceQueryParameters{
+			ServiceName:  service,
+			StartTimeMin: endTs.Add(-lookback),
+			StartTimeMax: endTs,
+			NumTraces:    math.MaxInt32,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, trace := range traces {
+			if len(trace.Spans) == 0 {
+				continue
+			}
+			traceID := trace.Spans[0].TraceID
+			if _, ok := seen[traceID]; ok {
+				continue
+			}
+			seen[traceID] = struct{}{}
+			// SpanIDDeduper never returns an err
+			trace, _ = s.deduper.Adjust(trace)
+			aggregator.AddTrace(trace)
+		}
+	}
+	return aggregator.Links(), nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencystore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+	"github.com/jaegertracing/jaeger/storage/spanstore/mocks"
+)
+
+func TestGetDependencies(t *testing.T) {
+	endTs := time.Now()
+	traceID := model.NewTraceID(0, 1)
+	span := func(spanID, parentID uint64, service string) *model.Span {
+		s := &model.Span{
+			TraceID: traceID,
+			SpanID:  model.SpanID(spanID),
+			Process: &model.Process{ServiceName: service},
+		}
+		if parentID != 0 {
+			s.References = []model.SpanRef{model.NewChildOfRef(traceID, model.SpanID(parentID))}
+		}
+		return s
+	}
+	trace := &model.Trace{
+		Spans: []*model.Span{
+			span(1, 0, "frontend"),
+			span(2, 1, "frontend"),
+			span(3, 2, "backend"),
+			span(4, 2, "backend"),
+			span(5, 3, "db"),
+		},
+	}
+
+	reader := &mocks.Reader{}
+	reader.On("GetServices").Return([]string{"frontend", "backend", "db"}, nil)
+	for _, service := range []string{"frontend", "backend", "db"} {
+		service := service
+		reader.On("FindTraces", mock.MatchedBy(func(query *spanstore.TraceQueryParameters) bool {
+			return query.ServiceName == service &&
+				query.StartTimeMin.Equal(endTs.Add(-time.Hour)) && query.StartTimeMax.Equal(endTs)
+		})).Return([]*model.Trace{trace}, nil).Once()
+	}
+
+	dependencies, err := NewDependencyStore(reader).GetDependencies(endTs, time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, dependencies, 2)
+	assert.Contains(t, dependencies, model.DependencyLink{Parent: "frontend", Child: "backend", CallCount: 2})
+	assert.Contains(t, dependencies, model.DependencyLink{Parent: "backend", Child: "db", CallCount: 1})
+	reader.AssertExpectations(t)
+
+	reader.On("FindTraces", mock.Anything).Return(nil, errors.New("find error")).Once()
+	_, err = NewDependencyStore(reader).GetDependencies(endTs, time.Hour)
+	assert.EqualError(t, err, "find error")
+
+	reader = &mocks.Reader{}
+	reader.On("GetServices").Return(nil, errors.New("services error"))
+	_, err = NewDependencyStore(reader).GetDependencies(endTs, time.Hour)
+	assert.EqualError(t, err, "services error")
+}
+
+func TestGetDependenciesSharedSpanIDs(t *testing.T) {
+	traceID := model.NewTraceID(0, 1)
+	// a zipkin-style RPC: the client and server spans share the same span ID
+	trace := &model.Trace{
+		Spans: []*model.Span{
+			{
+				TraceID: traceID,
+				SpanID:  1,
+				Process: &model.Process{ServiceName: "frontend"},
+				Tags:    model.KeyValues{model.String("span.kind", "client")},
+			},
+			{
+				TraceID: traceID,
+				SpanID:  1,
+				Process: &model.Process{ServiceName: "backend"},
+				Tags:    model.KeyValues{model.String("span.kind", "server")},
+			},
+		},
+	}
+	reader := &mocks.Reader{}
+	reader.On("GetServices").Return([]string{"frontend"}, nil)
+	reader.On("FindTraces", mock.Anything).Return([]*model.Trace{trace}, nil)
+
+	dependencies, err := NewDependencyStore(reader).GetDependencies(time.Now(), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []model.DependencyLink{{Parent: "frontend", Child: "backend", CallCount: 1}}, dependencies)
+}
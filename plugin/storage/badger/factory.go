@@ -0,0 +1,163 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/spf13/viper"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	depStore "github.com/jaegertracing/jaeger/plugin/storage/badger/dependencystore"
+	badgerStore "github.com/jaegertracing/jaeger/plugin/storage/badger/spanstore"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+const valueLogGCDiscardRatio = 0.5
+
+type factoryMetrics struct {
+	// ValueLogGCRuns counts the value log garbage collections that rewrote a file
+	ValueLogGCRuns metrics.Counter `metric:"badger.value-log.gc-runs"`
+	// LSMSize is the size of the key storage in bytes
+	LSMSize metrics.Gauge `metric:"badger.lsm.size-bytes"`
+	// ValueLogSize is the size of the value storage in bytes
+	ValueLogSize metrics.Gauge `metric:"badger.value-log.size-bytes"`
+}
+
+// Factory implements storage.Factory for badger, an embedded key-value store
+type Factory struct {
+	Options *Options
+	store   *badger.DB
+	cache   *badgerStore.CacheStore
+	logger  *zap.Logger
+	tmpDir  string
+
+	metrics factoryMetrics
+
+	maintenanceDone chan struct{}
+	maintenanceWG   sync.WaitGroup
+}
+
+// NewFactory creates a new Factory.
+func NewFactory() *Factory {
+	return &Factory{
+		Options:         NewOptions(defaultNamespace),
+		maintenanceDone: make(chan struct{}),
+	}
+}
+
+// AddFlags implements plugin.Configurable
+func (f *Factory) AddFlags(flagSet *flag.FlagSet) {
+	f.Options.AddFlags(flagSet)
+}
+
+// InitFromViper implements plugin.Configurable
+func (f *Factory) InitFromViper(v *viper.Viper) {
+	f.Options.InitFromViper(v)
+}
+
+// Initialize implements storage.Factory
+func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger) error {
+	f.logger = logger
+
+	opts := badger.DefaultOptions
+	if f.Options.Ephemeral {
+		dir, err := ioutil.TempDir("", "badger")
+		if err != nil {
+			return err
+		}
+		f.tmpDir = dir
+		opts.Dir, opts.ValueDir = dir, dir
+		opts.SyncWrites = false
+	} else {
+		opts.Dir = f.Options.KeyDirectory
+		opts.ValueDir = f.Options.ValueDirectory
+		opts.SyncWrites = f.Options.SyncWrites
+	}
+
+	store, err := badger.Open(opts)
+	if err != nil {
+		return err
+	}
+	f.store = store
+	f.cache = badgerStore.NewCacheStore(f.store, f.Options.SpanStoreTTL, true)
+
+	metrics.Init(&f.metrics, metricsFactory, nil)
+	f.maintenanceWG.Add(1)
+	go f.maintenance()
+
+	logger.Info("Badger storage configuration", zap.Any("configuration", opts))
+	return nil
+}
+
+// CreateSpanReader implements storage.Factory
+func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
+	return badgerStore.NewTraceReader(f.store, f.cache), nil
+}
+
+// CreateSpanWriter implements storage.Factory
+func (f *Factory) CreateSpanWriter() (spanstore.Writer, error) {
+	return badgerStore.NewSpanWriter(f.store, f.cache, f.Options.SpanStoreTTL), nil
+}
+
+// CreateDependencyReader implements storage.Factory
+func (f *Factory) CreateDependencyReader() (dependencystore.Reader, error) {
+	return depStore.NewDependencyStore(badgerStore.NewTraceReader(f.store, f.cache)), nil
+}
+
+// Close stops the maintenance and closes the database. The data of an ephemeral storage is removed.
+func (f *Factory) Close() error {
+	close(f.maintenanceDone)
+	f.maintenanceWG.Wait()
+	err := f.store.Close()
+	if f.tmpDir != "" {
+		if rmErr := os.RemoveAll(f.tmpDir); err == nil {
+			err = rmErr
+		}
+	}
+	return err
+}
+
+// maintenance periodically garbage collects the value log, which reclaims the space of expired spans
+func (f *Factory) maintenance() {
+	defer f.maintenanceWG.Done()
+	ticker := time.NewTicker(f.Options.MaintenanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.runValueLogGC()
+		case <-f.maintenanceDone:
+			return
+		}
+	}
+}
+
+func (f *Factory) runValueLogGC() {
+	// each successful run rewrites at most one file, so keep going until there is nothing to rewrite
+	for f.store.RunValueLogGC(valueLogGCDiscardRatio) == nil {
+		f.metrics.ValueLogGCRuns.Inc(1)
+	}
+	lsmSize, valueLogSize := f.store.Size()
+	f.metrics.LSMSize.Update(lsmSize)
+	f.metrics.ValueLogSize.Update(valueLogSize)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/storage"
+)
+
+var _ storage.Factory = new(Factory)
+
+func TestEphemeralStorage(t *testing.T) {
+	f := NewFactory()
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	tmpDir := f.tmpDir
+	assert.NotEmpty(t, tmpDir)
+
+	writer, err := f.CreateSpanWriter()
+	require.NoError(t, err)
+	reader, err := f.CreateSpanReader()
+	require.NoError(t, err)
+	_, err = f.CreateDependencyReader()
+	require.NoError(t, err)
+
+	span := &model.Span{
+		TraceID:       model.NewTraceID(0, 1),
+		SpanID:        model.SpanID(1),
+		OperationName: "op",
+		StartTime:     time.Now(),
+		Process:       &model.Process{ServiceName: "svc"},
+	}
+	require.NoError(t, writer.WriteSpan(span))
+	trace, err := reader.GetTrace(span.TraceID)
+	require.NoError(t, err)
+	assert.Len(t, trace.Spans, 1)
+
+	assert.NoError(t, f.Close())
+	_, err = os.Stat(tmpDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPersistentStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badger-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{
+		"--badger.ephemeral=false",
+		"--badger.consistency=true",
+		"--badger.directory-key=" + dir + "/keys",
+		"--badger.directory-value=" + dir + "/values",
+		"--badger.maintenance-interval=10ms",
+	})
+	f.InitFromViper(v)
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+
+	writer, err := f.CreateSpanWriter()
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteSpan(&model.Span{
+		TraceID:       model.NewTraceID(0, 1),
+		SpanID:        model.SpanID(1),
+		OperationName: "op",
+		StartTime:     time.Now(),
+		Process:       &model.Process{ServiceName: "svc"},
+	}))
+	// let the maintenance run at least once
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, f.Close())
+	assert.Empty(t, f.tmpDir)
+
+	// the services are loaded from the indexes when the storage is reopened
+	f = NewFactory()
+	f.InitFromViper(v)
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	defer f.Close()
+	reader, err := f.CreateSpanReader()
+	require.NoError(t, err)
+	services, err := reader.GetServices()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc"}, services)
+	operations, err := reader.GetOperations("svc")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op"}, operations)
+}
+
+func TestMaintenanceMetrics(t *testing.T) {
+	f := NewFactory()
+	f.Options.MaintenanceInterval = time.Hour
+	metricsFactory := metrics.NewLocalFactory(0)
+	require.NoError(t, f.Initialize(metricsFactory, zap.NewNop()))
+	defer f.Close()
+
+	f.runValueLogGC()
+	_, gauges := metricsFactory.Snapshot()
+	assert.Contains(t, gauges, "badger.lsm.size-bytes")
+	assert.Contains(t, gauges, "badger.value-log.size-bytes")
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger
+
+import (
+	"flag"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	suffixEphemeral           = ".ephemeral"
+	suffixKeyDirectory        = ".directory-key"
+	suffixValueDirectory      = ".directory-value"
+	suffixSpanStoreTTL        = ".span-store-ttl"
+	suffixMaintenanceInterval = ".maintenance-interval"
+	suffixSyncWrites          = ".consistency"
+
+	defaultNamespace           = "badger"
+	defaultKeyDirectory        = "./data/keys"
+	defaultValueDirectory      = "./data/values"
+	defaultSpanStoreTTL        = 72 * time.Hour
+	defaultMaintenanceInterval = 5 * time.Minute
+)
+
+// Options stores the configuration entries for this storage
+type Options struct {
+	namespace string
+	// Ephemeral keeps the data in a temporary directory that is removed on shutdown
+	Ephemeral bool
+	// KeyDirectory is where badger stores the keys
+	KeyDirectory string
+	// ValueDirectory is where badger stores the values
+	ValueDirectory string
+	// SpanStoreTTL is how long the spans are kept
+	SpanStoreTTL time.Duration
+	// MaintenanceInterval is how often the value log is garbage collected
+	MaintenanceInterval time.Duration
+	// SyncWrites makes every write wait until it is synced to disk
+	SyncWrites bool
+}
+
+// NewOptions creates a new Options struct with the default configuration.
+func NewOptions(namespace string) *Options {
+	return &Options{
+		namespace:           namespace,
+		Ephemeral:           true,
+		KeyDirectory:        defaultKeyDirectory,
+		ValueDirectory:      defaultValueDirectory,
+		SpanStoreTTL:        defaultSpanStoreTTL,
+		MaintenanceInterval: defaultMaintenanceInterval,
+	}
+}
+
+// AddFlags adds flags for Options
+func (opt *Options) AddFlags(flagSet *flag.FlagSet) {
+	flagSet.Bool(
+		opt.namespace+suffixEphemeral,
+		true,
+		"Mark this storage ephemeral, data is stored in a temporary directory and removed on shutdown")
+	flagSet.String(
+		opt.namespace+suffixKeyDirectory,
+		defaultKeyDirectory,
+		"Path to store the keys (indexes), this directory should reside in SSD disk. Set ephemeral to false if you want to define this setting")
+	flagSet.String(
+		opt.namespace+suffixValueDirectory,
+		defaultValueDirectory,
+		"Path to store the values (spans). Set ephemeral to false if you want to define this setting")
+	flagSet.Duration(
+		opt.namespace+suffixSpanStoreTTL,
+		defaultSpanStoreTTL,
+		"How long to store the data. Format is time.Duration (https://golang.org/pkg/time/#Duration)")
+	flagSet.Duration(
+		opt.namespace+suffixMaintenanceInterval,
+		defaultMaintenanceInterval,
+		"How often the maintenance thread for values is ran. Format is time.Duration (https://golang.org/pkg/time/#Duration)")
+	flagSet.Bool(
+		opt.namespace+suffixSyncWrites,
+		false,
+		"If all writes should be synced immediately to physical disk. This will impact write performance")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (opt *Options) InitFromViper(v *viper.Viper) {
+	opt.Ephemeral = v.GetBool(opt.namespace + suffixEphemeral)
+	opt.KeyDirectory = v.GetString(opt.namespace + suffixKeyDirectory)
+	opt.ValueDirectory = v.GetString(opt.namespace + suffixValueDirectory)
+	opt.SpanStoreTTL = v.GetDuration(opt.namespace + suffixSpanStoreTTL)
+	opt.MaintenanceInterval = v.GetDuration(opt.namespace + suffixMaintenanceInterval)
+	opt.SyncWrites = v.GetBool(opt.namespace + suffixSyncWrites)
+}
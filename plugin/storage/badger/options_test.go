@@ -0,0 +1,59 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func TestDefaultOptions(t *testing.T) {
+	opts := NewOptions("badger")
+	v, command := config.Viperize(opts.AddFlags)
+	command.ParseFlags([]string{})
+	opts.InitFromViper(v)
+
+	assert.True(t, opts.Ephemeral)
+	assert.False(t, opts.SyncWrites)
+	assert.Equal(t, defaultKeyDirectory, opts.KeyDirectory)
+	assert.Equal(t, defaultValueDirectory, opts.ValueDirectory)
+	assert.Equal(t, 72*time.Hour, opts.SpanStoreTTL)
+	assert.Equal(t, 5*time.Minute, opts.MaintenanceInterval)
+}
+
+func TestOptionsWithFlags(t *testing.T) {
+	opts := NewOptions("badger")
+	v, command := config.Viperize(opts.AddFlags)
+	command.ParseFlags([]string{
+		"--badger.ephemeral=false",
+		"--badger.consistency=true",
+		"--badger.directory-key=/var/lib/badger/keys",
+		"--badger.directory-value=/mnt/slow/badger/values",
+		"--badger.span-store-ttl=168h",
+		"--badger.maintenance-interval=1m",
+	})
+	opts.InitFromViper(v)
+
+	assert.False(t, opts.Ephemeral)
+	assert.True(t, opts.SyncWrites)
+	assert.Equal(t, "/var/lib/badger/keys", opts.KeyDirectory)
+	assert.Equal(t, "/mnt/slow/badger/values", opts.ValueDirectory)
+	assert.Equal(t, 168*time.Hour, opts.SpanStoreTTL)
+	assert.Equal(t, time.Minute, opts.MaintenanceInterval)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+)
+
+// CacheStore keeps the service and operation names in memory, together with the time when
+// the last span that referenced them expires from the storage.
+type CacheStore struct {
+	lock       sync.Mutex
+	services   map[string]int64            // service name -> expiry (unix seconds)
+	operations map[string]map[string]int64 // service name -> operation name -> expiry (unix seconds)
+	store      *badger.DB
+	ttl        time.Duration
+	timeNow    func() time.Time
+}
+
+// NewCacheStore returns a CacheStore. If prefill is true, the names are loaded from the indexes in the storage.
+func NewCacheStore(db *badger.DB, ttl time.Duration, prefill bool) *CacheStore {
+	c := &CacheStore{
+		services:   make(map[string]int64),
+		operations: make(map[string]map[string]int64),
+		store:      db,
+		ttl:        ttl,
+		timeNow:    time.Now,
+	}
+	if prefill {
+		c.prefill()
+	}
+	return c
+}
+
+func (c *CacheStore) prefill() {
+	c.store.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for _, prefix := range []byte{serviceNameIndexKey, operationNameIndexKey} {
+			for it.Seek([]byte{prefix}); it.ValidForPrefix([]byte{prefix}); it.Next() {
+				item := it.Item()
+				value := string(indexValue(item.Key()))
+				expiry := int64(item.ExpiresAt())
+				if prefix == serviceNameIndexKey {
+					c.updateLocked(value, "", expiry)
+				} else if parts := strings.SplitN(value, separator, 2); len(parts) == 2 {
+					c.updateLocked(parts[0], parts[1], expiry)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Update records that the service and operation are referenced by a span that expires at expireTime
+func (c *CacheStore) Update(service, operation string, expireTime int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.updateLocked(service, operation, expireTime)
+}
+
+func (c *CacheStore) updateLocked(service, operation string, expireTime int64) {
+	if c.services[service] < expireTime {
+		c.services[service] = expireTime
+	}
+	if operation == "" {
+		return
+	}
+	operations, ok := c.operations[service]
+	if !ok {
+		operations = make(map[string]int64)
+		c.operations[service] = operations
+	}
+	if operations[operation] < expireTime {
+		operations[operation] = expireTime
+	}
+}
+
+// GetServices returns the names of the services that have not expired, sorted
+func (c *CacheStore) GetServices() ([]string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.timeNow().Unix()
+	services := make([]string, 0, len(c.services))
+	for service, expiry := range c.services {
+		if expiry < now {
+			delete(c.services, service)
+			delete(c.operations, service)
+			continue
+		}
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// GetOperations returns the names of the operations of the service that have not expired, sorted
+func (c *CacheStore) GetOperations(service string) ([]string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.timeNow().Unix()
+	operations := make([]string, 0, len(c.operations[service]))
+	for operation, expiry := range c.operations[service] {
+		if expiry < now {
+			delete(c.operations[service], operation)
+			continue
+		}
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	return operations, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheExpiration(t *testing.T) {
+	withStore(t, func(db *badger.DB) {
+		cache := NewCacheStore(db, time.Hour, false)
+		now := time.Now()
+		cache.timeNow = func() time.Time { return now }
+
+		cache.Update("svc", "op1", now.Add(time.Minute).Unix())
+		cache.Update("svc", "op2", now.Add(-time.Minute).Unix())
+		cache.Update("old", "", now.Add(-time.Minute).Unix())
+
+		services, err := cache.GetServices()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"svc"}, services)
+		operations, err := cache.GetOperations("svc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"op1"}, operations)
+
+		// a later update extends the expiration
+		cache.Update("svc", "op1", now.Add(time.Hour).Unix())
+		now = now.Add(30 * time.Minute)
+		operations, err = cache.GetOperations("svc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"op1"}, operations)
+		operations, err = cache.GetOperations("unknown")
+		require.NoError(t, err)
+		assert.Empty(t, operations)
+	})
+}
+
+func TestCachePrefill(t *testing.T) {
+	withStore(t, func(db *badger.DB) {
+		cache := NewCacheStore(db, time.Hour, false)
+		writeSpans(t, NewSpanWriter(db, cache, time.Hour),
+			makeSpan(1, 1, "svc1", "op1", 0, time.Second),
+			makeSpan(2, 2, "svc2", "op2", 0, time.Second),
+		)
+
+		prefilled := NewCacheStore(db, time.Hour, true)
+		services, err := prefilled.GetServices()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"svc1", "svc2"}, services)
+		operations, err := prefilled.GetOperations("svc2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"op2"}, operations)
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+// All keys start with a one byte prefix that identifies the type of the key.
+//
+// Span keys hold the protobuf encoded span:
+//   spanKeyPrefix | traceID.High | traceID.Low | startTime | spanID
+//
+// Index keys have empty values and end with the start time of the span and the trace ID,
+// so that the index can be scanned for a time range:
+//   indexKeyPrefix | index value | startTime | traceID.High | traceID.Low
+//
+// Composite index values (service and operation, service and tag) are separated with a zero byte.
+// Durations and times are stored as big endian microseconds, which keeps them sorted.
+
+const (
+	spanKeyPrefix         byte = 0x80
+	serviceNameIndexKey   byte = 0x81
+	operationNameIndexKey byte = 0x82
+	tagIndexKey           byte = 0x83
+	durationIndexKey      byte = 0x84
+
+	sizeOfTraceID = 16
+	sizeOfTime    = 8
+	// indexKeySuffixLength is the length of the startTime | traceID suffix of the index keys
+	indexKeySuffixLength = sizeOfTime + sizeOfTraceID
+
+	separator = "\x00"
+)
+
+func createSpanKey(span *model.Span) []byte {
+	key := make([]byte, 1+sizeOfTraceID+sizeOfTime+8)
+	key[0] = spanKeyPrefix
+	putTraceID(key[1:], span.TraceID)
+	binary.BigEndian.PutUint64(key[1+sizeOfTraceID:], model.TimeAsEpochMicroseconds(span.StartTime))
+	binary.BigEndian.PutUint64(key[1+sizeOfTraceID+sizeOfTime:], uint64(span.SpanID))
+	return key
+}
+
+func createTraceKeyPrefix(traceID model.TraceID) []byte {
+	key := make([]byte, 1+sizeOfTraceID)
+	key[0] = spanKeyPrefix
+	putTraceID(key[1:], traceID)
+	return key
+}
+
+func createIndexKey(indexPrefix byte, value []byte, startTime time.Time, traceID model.TraceID) []byte {
+	key := make([]byte, 1+len(value)+indexKeySuffixLength)
+	key[0] = indexPrefix
+	n := 1 + copy(key[1:], value)
+	binary.BigEndian.PutUint64(key[n:], model.TimeAsEpochMicroseconds(startTime))
+	putTraceID(key[n+sizeOfTime:], traceID)
+	return key
+}
+
+// createIndexSeekKey returns the first possible index key for the value and start time
+func createIndexSeekKey(indexPrefix byte, value []byte, startTime time.Time) []byte {
+	key := make([]byte, 1+len(value)+sizeOfTime)
+	key[0] = indexPrefix
+	n := 1 + copy(key[1:], value)
+	binary.BigEndian.PutUint64(key[n:], model.TimeAsEpochMicroseconds(startTime))
+	return key
+}
+
+func indexValue(key []byte) []byte {
+	return key[1 : len(key)-indexKeySuffixLength]
+}
+
+func parseIndexKeySuffix(key []byte) (uint64, model.TraceID) {
+	suffix := key[len(key)-indexKeySuffixLength:]
+	return binary.BigEndian.Uint64(suffix), parseTraceID(suffix[sizeOfTime:])
+}
+
+func durationIndexValue(duration time.Duration) []byte {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, model.DurationAsMicroseconds(duration))
+	return value
+}
+
+func operationIndexValue(service, operation string) []byte {
+	return []byte(service + separator + operation)
+}
+
+func tagIndexValue(service, key, value string) []byte {
+	return []byte(service + separator + key + separator + value)
+}
+
+func putTraceID(dst []byte, traceID model.TraceID) {
+	binary.BigEndian.PutUint64(dst, traceID.High)
+	binary.BigEndian.PutUint64(dst[8:], traceID.Low)
+}
+
+func parseTraceID(src []byte) model.TraceID {
+	return model.NewTraceID(binary.BigEndian.Uint64(src), binary.BigEndian.Uint64(src[8:]))
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+func withStore(t *testing.T, test func(db *badger.DB)) {
+	dir, err := ioutil.TempDir("", "badger-spanstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := badger.DefaultOptions
+	opts.Dir, opts.ValueDir = dir, dir
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+	test(db)
+}
+
+func withReaderWriter(t *testing.T, test func(r *TraceReader, w *SpanWriter)) {
+	withStore(t, func(db *badger.DB) {
+		cache := NewCacheStore(db, time.Hour, false)
+		test(NewTraceReader(db, cache), NewSpanWriter(db, cache, time.Hour))
+	})
+}
+
+var baseTime = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func makeSpan(traceID uint64, spanID uint64, service, operation string, offset, duration time.Duration, tags ...model.KeyValue) *model.Span {
+	return &model.Span{
+		TraceID:       model.NewTraceID(0, traceID),
+		SpanID:        model.SpanID(spanID),
+		OperationName: operation,
+		StartTime:     baseTime.Add(offset),
+		Duration:      duration,
+		Tags:          tags,
+		Process:       &model.Process{ServiceName: service},
+	}
+}
+
+func writeSpans(t *testing.T, w *SpanWriter, spans ...*model.Span) {
+	for _, span := range spans {
+		require.NoError(t, w.WriteSpan(span))
+	}
+}
+
+func traceIDs(traces []*model.Trace) []uint64 {
+	ids := make([]uint64, len(traces))
+	for i, trace := range traces {
+		ids[i] = trace.Spans[0].TraceID.Low
+	}
+	return ids
+}
+
+func TestWriteReadTrace(t *testing.T) {
+	withReaderWriter(t, func(r *TraceReader, w *SpanWriter) {
+		writeSpans(t, w,
+			makeSpan(1, 1, "svc", "op", 0, time.Second, model.String("k", "v")),
+			makeSpan(1, 2, "svc", "child", time.Millisecond, time.Millisecond),
+			makeSpan(2, 3, "svc", "op", 0, time.Second),
+		)
+
+		trace, err := r.GetTrace(model.NewTraceID(0, 1))
+		require.NoError(t, err)
+		require.Len(t, trace.Spans, 2)
+		assert.Equal(t, "op", trace.Spans[0].OperationName)
+		assert.Equal(t, []model.KeyValue{model.String("k", "v")}, trace.Spans[0].Tags)
+		assert.Equal(t, "child", trace.Spans[1].OperationName)
+
+		_, err = r.GetTrace(model.NewTraceID(0, 42))
+		assert.Equal(t, spanstore.ErrTraceNotFound, err)
+
+		services, err := r.GetServices()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"svc"}, services)
+		operations, err := r.GetOperations("svc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"child", "op"}, operations)
+	})
+}
+
+func TestFindTraces(t *testing.T) {
+	withReaderWriter(t, func(r *TraceReader, w *SpanWriter) {
+		writeSpans(t, w,
+			makeSpan(1, 1, "foo", "op1", 0, time.Second, model.String("http.status_code", "200")),
+			makeSpan(2, 2, "foo", "op2", time.Minute, 10*time.Second, model.Int64("http.status_code", 500)),
+			makeSpan(3, 3, "foobar", "op1", 2*time.Minute, time.Millisecond),
+			makeSpan(4, 4, "foo", "op1", 3*time.Minute, 5*time.Second, model.String("error", "true")),
+			makeSpan(4, 5, "bar", "op3", 3*time.Minute, time.Second),
+		)
+		tests := []struct {
+			name     string
+			query    spanstore.TraceQueryParameters
+			expected []uint64
+		}{
+			{
+				name:     "service",
+				query:    spanstore.TraceQueryParameters{ServiceName: "foo"},
+				expected: []uint64{4, 2, 1},
+			},
+			{
+				name:     "all traces",
+				query:    spanstore.TraceQueryParameters{},
+				expected: []uint64{4, 3, 2, 1},
+			},
+			{
+				name:     "operation",
+				query:    spanstore.TraceQueryParameters{ServiceName: "foo", OperationName: "op1"},
+				expected: []uint64{4, 1},
+			},
+			{
+				name:     "tag of any type",
+				query:    spanstore.TraceQueryParameters{ServiceName: "foo", Tags: map[string]string{"http.status_code": "500"}},
+				expected: []uint64{2},
+			},
+			{
+				name: "tag and operation",
+				query: spanstore.TraceQueryParameters{
+					ServiceName:   "foo",
+					OperationName: "op1",
+					Tags:          map[string]string{"error": "true"},
+				},
+				expected: []uint64{4},
+			},
+			{
+				name: "time range",
+				query: spanstore.TraceQueryParameters{
+					ServiceName:  "foo",
+					StartTimeMin: baseTime.Add(30 * time.Second),
+					StartTimeMax: baseTime.Add(2 * time.Minute),
+				},
+				expected: []uint64{2},
+			},
+			{
+				name: "duration",
+				query: spanstore.TraceQueryParameters{
+					ServiceName: "foo",
+					DurationMin: 2 * time.Second,
+					DurationMax: 10 * time.Second,
+				},
+				expected: []uint64{4, 2},
+			},
+			{
+				name:     "duration and service",
+				query:    spanstore.TraceQueryParameters{ServiceName: "bar", DurationMin: 500 * time.Millisecond},
+				expected: []uint64{4},
+			},
+			{
+				name:     "min duration only",
+				query:    spanstore.TraceQueryParameters{DurationMin: 6 * time.Second},
+				expected: []uint64{2},
+			},
+			{
+				name:     "limit",
+				query:    spanstore.TraceQueryParameters{ServiceName: "foo", NumTraces: 2},
+				expected: []uint64{4, 2},
+			},
+			{
+				name:     "no match",
+				query:    spanstore.TraceQueryParameters{ServiceName: "fo"},
+				expected: []uint64{},
+			},
+		}
+		for _, test := range tests {
+			traces, err := r.FindTraces(&test.query)
+			require.NoError(t, err, test.name)
+			assert.Equal(t, test.expected, traceIDs(traces), test.name)
+		}
+	})
+}
+
+func TestFindTracesInvalidQuery(t *testing.T) {
+	withReaderWriter(t, func(r *TraceReader, w *SpanWriter) {
+		tests := []struct {
+			query *spanstore.TraceQueryParameters
+			err   error
+		}{
+			{query: nil, err: ErrMalformedRequestObject},
+			{query: &spanstore.TraceQueryParameters{OperationName: "op"}, err: ErrServiceNameNotSet},
+			{query: &spanstore.TraceQueryParameters{Tags: map[string]string{"k": "v"}}, err: ErrServiceNameNotSet},
+			{
+				query: &spanstore.TraceQueryParameters{StartTimeMin: baseTime, StartTimeMax: baseTime.Add(-time.Second)},
+				err:   ErrStartTimeMinGreaterThanMax,
+			},
+			{
+				query: &spanstore.TraceQueryParameters{DurationMin: time.Second, DurationMax: time.Millisecond},
+				err:   ErrDurationMinGreaterThanMax,
+			},
+		}
+		for _, test := range tests {
+			_, err := r.FindTraces(test.query)
+			assert.Equal(t, test.err, err)
+		}
+	})
+}
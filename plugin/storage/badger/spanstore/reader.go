@@ -0,0 +1,274 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/dgraph-io/badger"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+const defaultNumTraces = 100
+
+var (
+	// ErrServiceNameNotSet occurs when attempting to query for operation or tags without a service name
+	ErrServiceNameNotSet = errors.New("Service Name must be set")
+
+	// ErrStartTimeMinGreaterThanMax occurs when start time min is above start time max
+	ErrStartTimeMinGreaterThanMax = errors.New("Start Time Minimum is above Maximum")
+
+	// ErrDurationMinGreaterThanMax occurs when duration min is above duration max
+	ErrDurationMinGreaterThanMax = errors.New("Duration Minimum is above Maximum")
+
+	// ErrMalformedRequestObject occurs when a request object is nil
+	ErrMalformedRequestObject = errors.New("Malformed request object")
+)
+
+// TraceReader reads traces from badger, using the indexes written by SpanWriter to find them
+type TraceReader struct {
+	store *badger.DB
+	cache *CacheStore
+}
+
+// NewTraceReader returns a TraceReader
+func NewTraceReader(db *badger.DB, cache *CacheStore) *TraceReader {
+	return &TraceReader{
+		store: db,
+		cache: cache,
+	}
+}
+
+// GetTrace implements spanstore.Reader#GetTrace
+func (r *TraceReader) GetTrace(traceID model.TraceID) (*model.Trace, error) {
+	var trace *model.Trace
+	err := r.store.View(func(txn *badger.Txn) error {
+		var err error
+		trace, err = readTrace(txn, traceID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if trace == nil {
+		return nil, spanstore.ErrTraceNotFound
+	}
+	return trace, nil
+}
+
+// GetServices implements spanstore.Reader#GetServices
+func (r *TraceReader) GetServices() ([]string, error) {
+	return r.cache.GetServices()
+}
+
+// GetOperations implements spanstore.Reader#GetOperations
+func (r *TraceReader) GetOperations(service string) ([]string, error) {
+	return r.cache.GetOperations(service)
+}
+
+// FindTraces implements spanstore.Reader#FindTraces. The traces are returned from the newest to the oldest.
+func (r *TraceReader) FindTraces(query *spanstore.TraceQueryParameters) ([]*model.Trace, error) {
+	if err := validateQuery(query); err != nil {
+		return nil, err
+	}
+	numTraces := query.NumTraces
+	if numTraces == 0 {
+		numTraces = defaultNumTraces
+	}
+	var traces []*model.Trace
+	err := r.store.View(func(txn *badger.Txn) error {
+		traceIDs := findTraceIDs(txn, query)
+		if len(traceIDs) > numTraces {
+			traceIDs = traceIDs[:numTraces]
+		}
+		for _, traceID := range traceIDs {
+			trace, err := readTrace(txn, traceID)
+			if err != nil {
+				return err
+			}
+			if trace != nil {
+				traces = append(traces, trace)
+			}
+		}
+		return nil
+	})
+	return traces, err
+}
+
+func validateQuery(query *spanstore.TraceQueryParameters) error {
+	if query == nil {
+		return ErrMalformedRequestObject
+	}
+	if query.ServiceName == "" && (query.OperationName != "" || len(query.Tags) > 0) {
+		return ErrServiceNameNotSet
+	}
+	if !query.StartTimeMin.IsZero() && !query.StartTimeMax.IsZero() && query.StartTimeMax.Before(query.StartTimeMin) {
+		return ErrStartTimeMinGreaterThanMax
+	}
+	if query.DurationMin != 0 && query.DurationMax != 0 && query.DurationMin > query.DurationMax {
+		return ErrDurationMinGreaterThanMax
+	}
+	return nil
+}
+
+func readTrace(txn *badger.Txn, traceID model.TraceID) (*model.Trace, error) {
+	it := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+
+	prefix := createTraceKeyPrefix(traceID)
+	var spans []*model.Span
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		value, err := it.Item().Value()
+		if err != nil {
+			return nil, err
+		}
+		span := &model.Span{}
+		if err := span.Unmarshal(value); err != nil {
+			return nil, err
+		}
+		spans = append(spans, span)
+	}
+	if len(spans) == 0 {
+		return nil, nil
+	}
+	return &model.Trace{Spans: spans}, nil
+}
+
+// indexScan describes the range of an index to scan for matching traces
+type indexScan struct {
+	prefix byte
+	// value of the index, the scan matches only the keys with exactly this value unless anyValue is set
+	value    []byte
+	anyValue bool
+	// maxValue limits the scan of the duration index
+	maxValue []byte
+}
+
+// findTraceIDs returns the IDs of the traces that match all conditions of the query, from the newest to the oldest
+func findTraceIDs(txn *badger.Txn, query *spanstore.TraceQueryParameters) []model.TraceID {
+	minTime, maxTime := uint64(0), uint64(math.MaxUint64)
+	if !query.StartTimeMin.IsZero() {
+		minTime = model.TimeAsEpochMicroseconds(query.StartTimeMin)
+	}
+	if !query.StartTimeMax.IsZero() {
+		maxTime = model.TimeAsEpochMicroseconds(query.StartTimeMax)
+	}
+
+	var scans []indexScan
+	for key, value := range query.Tags {
+		scans = append(scans, indexScan{prefix: tagIndexKey, value: tagIndexValue(query.ServiceName, key, value)})
+	}
+	if query.OperationName != "" {
+		scans = append(scans, indexScan{
+			prefix: operationNameIndexKey,
+			value:  operationIndexValue(query.ServiceName, query.OperationName),
+		})
+	}
+	if query.DurationMin != 0 || query.DurationMax != 0 {
+		scan := indexScan{prefix: durationIndexKey, value: durationIndexValue(query.DurationMin), anyValue: true}
+		if query.DurationMax != 0 {
+			scan.maxValue = durationIndexValue(query.DurationMax)
+		}
+		scans = append(scans, scan)
+	}
+	if query.OperationName == "" && len(query.Tags) == 0 && (query.ServiceName != "" || len(scans) == 0) {
+		// the operation and tag indexes already include the service name, otherwise the service index
+		// is scanned, for all services if the query has no conditions at all
+		scans = append(scans, indexScan{
+			prefix:   serviceNameIndexKey,
+			value:    []byte(query.ServiceName),
+			anyValue: query.ServiceName == "",
+		})
+	}
+
+	var matches map[model.TraceID]uint64
+	for _, scan := range scans {
+		found := scanIndex(txn, scan, minTime, maxTime)
+		if matches == nil {
+			matches = found
+			continue
+		}
+		for traceID, startTime := range matches {
+			if otherStartTime, ok := found[traceID]; !ok {
+				delete(matches, traceID)
+			} else if otherStartTime > startTime {
+				matches[traceID] = otherStartTime
+			}
+		}
+	}
+
+	traceIDs := make([]model.TraceID, 0, len(matches))
+	for traceID := range matches {
+		traceIDs = append(traceIDs, traceID)
+	}
+	sort.Slice(traceIDs, func(i, j int) bool {
+		return matches[traceIDs[i]] > matches[traceIDs[j]]
+	})
+	return traceIDs
+}
+
+// scanIndex returns the IDs of the traces with spans in the index range that started between minTime and maxTime,
+// with the latest start time of such span
+func scanIndex(txn *badger.Txn, scan indexScan, minTime, maxTime uint64) map[model.TraceID]uint64 {
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	var prefix, seekKey []byte
+	if scan.anyValue {
+		prefix = []byte{scan.prefix}
+		seekKey = append([]byte{scan.prefix}, scan.value...)
+	} else {
+		prefix = append([]byte{scan.prefix}, scan.value...)
+		seekKey = createIndexSeekKey(scan.prefix, scan.value, model.EpochMicrosecondsAsTime(minTime))
+	}
+
+	found := make(map[model.TraceID]uint64)
+	for it.Seek(seekKey); it.ValidForPrefix(prefix); it.Next() {
+		key := it.Item().Key()
+		if len(key) < 1+indexKeySuffixLength {
+			continue
+		}
+		value := indexValue(key)
+		if !scan.anyValue && !bytes.Equal(value, scan.value) {
+			// a longer value that shares the prefix, e.g. service "foobar" when looking for "foo"
+			continue
+		}
+		if scan.maxValue != nil && bytes.Compare(value, scan.maxValue) > 0 {
+			break
+		}
+		startTime, traceID := parseIndexKeySuffix(key)
+		if startTime < minTime {
+			continue
+		}
+		if startTime > maxTime {
+			if !scan.anyValue {
+				// the keys with exactly the same value are sorted by start time
+				break
+			}
+			continue
+		}
+		if startTime > found[traceID] {
+			found[traceID] = startTime
+		}
+	}
+	return found
+}
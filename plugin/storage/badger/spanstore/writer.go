@@ -0,0 +1,87 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"time"
+
+	"github.com/dgraph-io/badger"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+// SpanWriter writes the spans and their indexes to badger
+type SpanWriter struct {
+	store   *badger.DB
+	ttl     time.Duration
+	cache   *CacheStore
+	timeNow func() time.Time
+}
+
+// NewSpanWriter returns a SpanWriter. The spans and their indexes expire after ttl.
+func NewSpanWriter(db *badger.DB, cache *CacheStore, ttl time.Duration) *SpanWriter {
+	return &SpanWriter{
+		store:   db,
+		ttl:     ttl,
+		cache:   cache,
+		timeNow: time.Now,
+	}
+}
+
+// WriteSpan writes the span together with its service, operation, tag and duration indexes
+func (w *SpanWriter) WriteSpan(span *model.Span) error {
+	value, err := span.Marshal()
+	if err != nil {
+		return err
+	}
+	keys := w.indexKeys(span)
+	err = w.store.Update(func(txn *badger.Txn) error {
+		if err := txn.SetWithTTL(createSpanKey(span), value, w.ttl); err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := txn.SetWithTTL(key, nil, w.ttl); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.cache.Update(span.Process.ServiceName, span.OperationName, w.timeNow().Add(w.ttl).Unix())
+	return nil
+}
+
+func (w *SpanWriter) indexKeys(span *model.Span) [][]byte {
+	service := span.Process.ServiceName
+	keys := [][]byte{
+		createIndexKey(serviceNameIndexKey, []byte(service), span.StartTime, span.TraceID),
+		createIndexKey(operationNameIndexKey, operationIndexValue(service, span.OperationName), span.StartTime, span.TraceID),
+		createIndexKey(durationIndexKey, durationIndexValue(span.Duration), span.StartTime, span.TraceID),
+	}
+	addTags := func(tags model.KeyValues) {
+		for i := range tags {
+			value := tagIndexValue(service, tags[i].Key, tags[i].AsString())
+			keys = append(keys, createIndexKey(tagIndexKey, value, span.StartTime, span.TraceID))
+		}
+	}
+	addTags(span.Tags)
+	addTags(span.Process.Tags)
+	for _, log := range span.Logs {
+		addTags(log.Fields)
+	}
+	return keys
+}
@@ -16,6 +16,7 @@ package cassandra
 
 import (
 	"flag"
+	"os"
 
 	"github.com/spf13/viper"
 	"github.com/uber/jaeger-lib/metrics"
@@ -23,10 +24,15 @@ import (
 
 	"github.com/jaegertracing/jaeger/pkg/cassandra"
 	"github.com/jaegertracing/jaeger/pkg/cassandra/config"
+	"github.com/jaegertracing/jaeger/pkg/distributedlock"
+	cLock "github.com/jaegertracing/jaeger/plugin/pkg/distributedlock/cassandra"
 	cDepStore "github.com/jaegertracing/jaeger/plugin/storage/cassandra/dependencystore"
+	cSamplingStore "github.com/jaegertracing/jaeger/plugin/storage/cassandra/samplingstore"
+	"github.com/jaegertracing/jaeger/plugin/storage/cassandra/schema"
 	cSpanStore "github.com/jaegertracing/jaeger/plugin/storage/cassandra/spanstore"
 	"github.com/jaegertracing/jaeger/storage"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
@@ -47,6 +53,9 @@ type Factory struct {
 	primarySession cassandra.Session
 	archiveConfig  config.SessionBuilder
 	archiveSession cassandra.Session
+
+	// the schema version of the primary keyspace, or 0 if unknown
+	primarySchemaVersion int
 }
 
 // NewFactory creates a new Factory.
@@ -81,6 +90,9 @@ func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger)
 		return err
 	}
 	f.primarySession = primarySession
+	if f.primarySchemaVersion, err = f.checkSchemaVersion(primarySession, primaryStorageConfig); err != nil {
+		return err
+	}
 
 	if f.archiveConfig != nil {
 		if archiveSession, err := f.archiveConfig.NewSession(); err == nil {
@@ -88,12 +100,41 @@ func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger)
 		} else {
 			return err
 		}
+		if _, err := f.checkSchemaVersion(f.archiveSession, archiveStorageConfig); err != nil {
+			return err
+		}
 	} else {
 		logger.Info("Cassandra archive storage configuration is empty, skipping")
 	}
 	return nil
 }
 
+// checkSchemaVersion verifies that the schema of the session's keyspace can be used by the span and dependency stores.
+// Keyspaces created without the schema migration tool have no version table, their version is reported as 0 (unknown).
+func (f *Factory) checkSchemaVersion(session cassandra.Session, namespace string) (int, error) {
+	version, err := schema.ReadVersion(session)
+	if err == schema.ErrNoVersionTable {
+		f.logger.Warn("Cannot verify Cassandra schema version, assuming it is compatible",
+			zap.String("namespace", namespace), zap.Error(err))
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := schema.CheckVersion(version, schema.MinSpanStoreVersion); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// checkSamplingSchemaVersion verifies, if the schema version is known, that it contains the adaptive sampling tables.
+func (f *Factory) checkSamplingSchemaVersion() error {
+	if f.primarySchemaVersion == 0 {
+		return nil
+	}
+	return schema.CheckVersion(f.primarySchemaVersion, schema.MinSamplingStoreVersion)
+}
+
 // CreateSpanReader implements storage.Factory
 func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
 	return cSpanStore.NewSpanReader(f.primarySession, f.primaryMetricsFactory, f.logger), nil
@@ -109,6 +150,17 @@ func (f *Factory) CreateDependencyReader() (dependencystore.Reader, error) {
 	return cDepStore.NewDependencyStore(f.primarySession, f.primaryMetricsFactory, f.logger), nil
 }
 
+// CreateDependencyWriter implements storage.DependencyWriterFactory
+func (f *Factory) CreateDependencyWriter() (dependencystore.Writer, error) {
+	return cDepStore.NewDependencyStore(f.primarySession, f.primaryMetricsFactory, f.logger), nil
+}
+
+// CreatePurger implements storage.PurgerFactory. Cassandra removes old spans and their index entries
+// on its own once the default_time_to_live of the tables expires, so purging is not supported.
+func (f *Factory) CreatePurger() (spanstore.Purger, error) {
+	return nil, storage.ErrPurgerNotSupported
+}
+
 // CreateArchiveSpanReader implements storage.ArchiveFactory
 func (f *Factory) CreateArchiveSpanReader() (spanstore.Reader, error) {
 	if f.archiveSession == nil {
@@ -124,3 +176,23 @@ func (f *Factory) CreateArchiveSpanWriter() (spanstore.Writer, error) {
 	}
 	return cSpanStore.NewSpanWriter(f.archiveSession, f.Options.SpanStoreWriteCacheTTL, f.archiveMetricsFactory, f.logger), nil
 }
+
+// CreateLock implements storage.SamplingStoreFactory
+func (f *Factory) CreateLock() (distributedlock.Lock, error) {
+	if err := f.checkSamplingSchemaVersion(); err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return cLock.NewLock(f.primarySession, hostname), nil
+}
+
+// CreateSamplingStore implements storage.SamplingStoreFactory
+func (f *Factory) CreateSamplingStore() (samplingstore.Store, error) {
+	if err := f.checkSamplingSchemaVersion(); err != nil {
+		return nil, err
+	}
+	return cSamplingStore.New(f.primarySession, f.primaryMetricsFactory, f.logger), nil
+}
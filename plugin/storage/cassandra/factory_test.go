@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
@@ -31,16 +32,43 @@ import (
 
 var _ storage.Factory = new(Factory)
 var _ storage.ArchiveFactory = new(Factory)
+var _ storage.DependencyWriterFactory = new(Factory)
+var _ storage.SamplingStoreFactory = new(Factory)
 
 type mockSessionBuilder struct {
 	err error
+	// schemaVersions are returned from the schema version table, which is missing if nil
+	schemaVersions []int
+	// schemaVersionErr is returned when reading the schema version table
+	schemaVersionErr error
 }
 
 func (m *mockSessionBuilder) NewSession() (cassandra.Session, error) {
-	if m.err == nil {
-		return &mocks.Session{}, nil
+	if m.err != nil {
+		return nil, m.err
 	}
-	return nil, m.err
+	var next int
+	iter := &mocks.Iterator{}
+	iter.On("Scan", mock.Anything).Return(func(dest ...interface{}) bool {
+		if next >= len(m.schemaVersions) {
+			return false
+		}
+		*(dest[0].(*int)) = m.schemaVersions[next]
+		next++
+		return true
+	})
+	if m.schemaVersionErr != nil {
+		iter.On("Close").Return(m.schemaVersionErr)
+	} else if m.schemaVersions == nil {
+		iter.On("Close").Return(errors.New("unconfigured table schema_migrations"))
+	} else {
+		iter.On("Close").Return(nil)
+	}
+	query := &mocks.Query{}
+	query.On("Iter").Return(iter)
+	session := &mocks.Session{}
+	session.On("Query", mock.AnythingOfType("string"), mock.Anything).Return(query)
+	return session, nil
 }
 
 func TestCassandraFactory(t *testing.T) {
@@ -72,12 +100,24 @@ func TestCassandraFactory(t *testing.T) {
 	_, err = f.CreateDependencyReader()
 	assert.NoError(t, err)
 
+	_, err = f.CreateDependencyWriter()
+	assert.NoError(t, err)
+
+	_, err = f.CreatePurger()
+	assert.Equal(t, storage.ErrPurgerNotSupported, err)
+
 	_, err = f.CreateArchiveSpanReader()
 	assert.EqualError(t, err, "Archive storage not configured")
 
 	_, err = f.CreateArchiveSpanWriter()
 	assert.EqualError(t, err, "Archive storage not configured")
 
+	_, err = f.CreateLock()
+	assert.NoError(t, err)
+
+	_, err = f.CreateSamplingStore()
+	assert.NoError(t, err)
+
 	f.archiveConfig = &mockSessionBuilder{}
 	assert.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
 
@@ -87,3 +127,40 @@ func TestCassandraFactory(t *testing.T) {
 	_, err = f.CreateArchiveSpanWriter()
 	assert.NoError(t, err)
 }
+
+func TestCassandraFactorySchemaVersion(t *testing.T) {
+	logger, logBuf := testutils.NewLogger()
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{})
+	f.InitFromViper(v)
+
+	f.primaryConfig = &mockSessionBuilder{}
+	assert.NoError(t, f.Initialize(metrics.NullFactory, logger))
+	assert.Contains(t, logBuf.String(), "Cannot verify Cassandra schema version, assuming it is compatible")
+
+	f.primaryConfig = &mockSessionBuilder{schemaVersions: []int{1, 2}}
+	assert.NoError(t, f.Initialize(metrics.NullFactory, logger))
+	_, err := f.CreateSamplingStore()
+	assert.NoError(t, err)
+
+	f.primaryConfig = &mockSessionBuilder{schemaVersions: []int{1}}
+	assert.NoError(t, f.Initialize(metrics.NullFactory, logger))
+	_, err = f.CreateSpanWriter()
+	assert.NoError(t, err)
+	_, err = f.CreateSamplingStore()
+	assert.EqualError(t, err, "Cassandra schema version 1 is older than the required version 2, please upgrade the schema")
+	_, err = f.CreateLock()
+	assert.Error(t, err)
+
+	f.primaryConfig = &mockSessionBuilder{schemaVersions: []int{}}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, logger),
+		"Cassandra schema version 0 is older than the required version 1, please upgrade the schema")
+
+	f.primaryConfig = &mockSessionBuilder{schemaVersions: []int{3}}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, logger),
+		"Cassandra schema version 3 is newer than the latest version 2 supported by this binary")
+
+	f.primaryConfig = &mockSessionBuilder{schemaVersionErr: errors.New("timeout")}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, logger), "cannot read schema version: timeout")
+}
@@ -1,4 +1,7 @@
 #!/bin/bash
+#
+# Keyspaces created by this script do not record their schema version. Use the jaeger-cassandra-schema
+# command (cmd/cassandra-schema) to create the keyspace and to upgrade it to newer schema versions.
 
 function usage {
     >&2 echo "Error: $1"
@@ -14,7 +17,8 @@ function usage {
     >&2 echo "  REPLICATION_FACTOR - replication factor for prod (default: 2 for prod, 1 for test)"
     >&2 echo ""
     >&2 echo "The template-file argument must be fully qualified path to a v00#.cql.tmpl template file."
-    >&2 echo "If omitted, the template file with the highest available version will be used."
+    >&2 echo "If omitted, all template files will be used in the order of their versions. Each template only"
+    >&2 echo "contains the changes since the previous version, so a single template can only upgrade an existing keyspace."
     exit 1
 }
 
@@ -23,7 +27,7 @@ dependencies_ttl=${DEPENDENCIES_TTL:-0}
 
 template=$1
 if [[ "$template" == "" ]]; then
-    template=$(ls $(dirname $0)/*cql.tmpl | sort | tail -1)
+    template=$(ls $(dirname $0)/*cql.tmpl | sort)
 fi
 
 if [[ "$MODE" == "" ]]; then
@@ -48,7 +52,7 @@ if [[ $keyspace =~ [^a-zA-Z0-9_] ]]; then
 fi
 
 >&2 cat <<EOF
-Using template file $template with parameters:
+Using template files $(echo $template) with parameters:
     mode = $MODE
     datacenter = $datacenter
     keyspace = $keyspace
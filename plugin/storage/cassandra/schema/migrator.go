@@ -0,0 +1,219 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/cassandra"
+)
+
+const (
+	// LatestVersion is the most recent schema version known to this version of Jaeger.
+	LatestVersion = 2
+	// MinSpanStoreVersion is the schema version required by the span and dependency stores.
+	MinSpanStoreVersion = 1
+	// MinSamplingStoreVersion is the schema version required by the sampling store and the distributed lock.
+	MinSamplingStoreVersion = 2
+
+	versionTable = "schema_migrations"
+)
+
+var (
+	// ErrNoVersionTable is returned by ReadVersion if the keyspace has no schema version table,
+	// which is the case for keyspaces created with create.sh.
+	ErrNoVersionTable = errors.New("Cassandra schema version table does not exist")
+
+	templateFileName = regexp.MustCompile(`^v(\d{3})\.cql\.tmpl$`)
+	commentRegexp    = regexp.MustCompile(`--.*`)
+	keyspaceRegexp   = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+)
+
+// Params are substituted into the schema templates, cf. create.sh.
+type Params struct {
+	Keyspace string
+	// Replication is the replication strategy of the keyspace, e.g. {'class': 'SimpleStrategy', 'replication_factor': '1'}
+	Replication string
+	// TraceTTL is the default time to live for trace data
+	TraceTTL time.Duration
+	// DependenciesTTL is the default time to live for dependencies data, 0 means no TTL
+	DependenciesTTL time.Duration
+}
+
+// Template is a schema template of a specific version.
+type Template struct {
+	Version int
+	Content string
+}
+
+// LoadTemplates reads the v###.cql.tmpl schema templates from the given directory, ordered by version.
+func LoadTemplates(dir string) ([]Template, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read schema directory")
+	}
+	var templates []Template
+	for _, file := range files {
+		match := templateFileName.FindStringSubmatch(file.Name())
+		if match == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read schema template %s", file.Name())
+		}
+		templates = append(templates, Template{Version: version, Content: string(content)})
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no schema templates found in %s", dir)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Version < templates[j].Version })
+	return templates, nil
+}
+
+// Render substitutes the parameters into the template and splits it into individual CQL statements.
+func Render(template string, params Params) []string {
+	replacer := strings.NewReplacer(
+		"${keyspace}", params.Keyspace,
+		"${replication}", params.Replication,
+		"${trace_ttl}", strconv.FormatInt(int64(params.TraceTTL/time.Second), 10),
+		"${dependencies_ttl}", strconv.FormatInt(int64(params.DependenciesTTL/time.Second), 10),
+	)
+	cql := replacer.Replace(commentRegexp.ReplaceAllString(template, ""))
+	var statements []string
+	for _, stmt := range strings.Split(cql, ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
+
+// Migrator creates the keyspace and brings its schema up to date by applying
+// the versioned templates that have not been applied yet.
+type Migrator struct {
+	session   cassandra.Session
+	templates []Template
+	params    Params
+	logger    *zap.Logger
+}
+
+// NewMigrator creates a new Migrator. The session must not be bound to the keyspace, as it may not exist yet.
+func NewMigrator(session cassandra.Session, templates []Template, params Params, logger *zap.Logger) (*Migrator, error) {
+	if !keyspaceRegexp.MatchString(params.Keyspace) {
+		return nil, fmt.Errorf("invalid characters in keyspace %q, please use letters, digits or underscores", params.Keyspace)
+	}
+	return &Migrator{
+		session:   session,
+		templates: templates,
+		params:    params,
+		logger:    logger,
+	}, nil
+}
+
+// Migrate applies all templates newer than the current schema version and records every applied version.
+// Since all statements in the templates are idempotent, a migration interrupted midway can simply be re-run.
+// It returns the resulting schema version.
+func (m *Migrator) Migrate() (int, error) {
+	setup := []string{
+		fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %s WITH replication = %s", m.params.Keyspace, m.params.Replication),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (version int, applied_at timestamp, PRIMARY KEY (version))",
+			m.params.Keyspace, versionTable),
+	}
+	for _, stmt := range setup {
+		if err := m.session.Query(stmt).Exec(); err != nil {
+			return 0, errors.Wrap(err, "cannot create schema version table")
+		}
+	}
+
+	current, err := readVersion(m.session, m.params.Keyspace+"."+versionTable)
+	if err != nil {
+		return 0, err
+	}
+	if current > LatestVersion {
+		return current, fmt.Errorf("schema version %d is newer than the latest version %d known to this tool", current, LatestVersion)
+	}
+	m.logger.Info("Current schema version", zap.String("keyspace", m.params.Keyspace), zap.Int("version", current))
+
+	for _, template := range m.templates {
+		if template.Version <= current {
+			continue
+		}
+		for _, stmt := range Render(template.Content, m.params) {
+			if err := m.session.Query(stmt).Exec(); err != nil {
+				return current, errors.Wrapf(err, "failed to apply schema version %d", template.Version)
+			}
+		}
+		insert := fmt.Sprintf("INSERT INTO %s.%s (version, applied_at) VALUES (?, ?)", m.params.Keyspace, versionTable)
+		if err := m.session.Query(insert, template.Version, time.Now()).Exec(); err != nil {
+			return current, errors.Wrapf(err, "failed to record schema version %d", template.Version)
+		}
+		current = template.Version
+		m.logger.Info("Applied schema version", zap.String("keyspace", m.params.Keyspace), zap.Int("version", current))
+	}
+	return current, nil
+}
+
+// ReadVersion returns the schema version of the keyspace the session is bound to.
+// It returns ErrNoVersionTable if the keyspace has no version table, or another error if it cannot be read.
+func ReadVersion(session cassandra.Session) (int, error) {
+	return readVersion(session, versionTable)
+}
+
+func readVersion(session cassandra.Session, table string) (int, error) {
+	iter := session.Query(fmt.Sprintf("SELECT version FROM %s", table)).Iter()
+	var version, current int
+	for iter.Scan(&version) {
+		if version > current {
+			current = version
+		}
+	}
+	if err := iter.Close(); err != nil {
+		if isMissingTable(err) {
+			return 0, ErrNoVersionTable
+		}
+		return 0, errors.Wrap(err, "cannot read schema version")
+	}
+	return current, nil
+}
+
+// isMissingTable returns true if the error is returned by Cassandra for a query to a table that does not exist,
+// "unconfigured table" in Cassandra 3.x and "unconfigured columnfamily" in older versions.
+func isMissingTable(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "unconfigured table") || strings.Contains(msg, "unconfigured columnfamily")
+}
+
+// CheckVersion returns an error if the schema version is older than minVersion or newer than LatestVersion.
+func CheckVersion(version int, minVersion int) error {
+	if version > LatestVersion {
+		return fmt.Errorf("Cassandra schema version %d is newer than the latest version %d supported by this binary", version, LatestVersion)
+	}
+	if version < minVersion {
+		return fmt.Errorf("Cassandra schema version %d is older than the required version %d, please upgrade the schema", version, minVersion)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/cassandra"
+	"github.com/jaegertracing/jaeger/pkg/cassandra/mocks"
+)
+
+var testParams = Params{
+	Keyspace:        "jaeger_v1_test",
+	Replication:     "{'class': 'SimpleStrategy', 'replication_factor': '1'}",
+	TraceTTL:        48 * time.Hour,
+	DependenciesTTL: 0,
+}
+
+type fakeCassandra struct {
+	versions []int
+	execErr  error
+	executed []string
+}
+
+// session returns a mock session that reports the schema versions of the fake
+// and records all executed statements.
+func (f *fakeCassandra) session() *mocks.Session {
+	session := &mocks.Session{}
+	session.On("Query", mock.AnythingOfType("string"), mock.Anything).Return(func(stmt string, values ...interface{}) cassandra.Query {
+		query := &mocks.Query{}
+		if strings.HasPrefix(stmt, "SELECT version") {
+			var next int
+			iter := &mocks.Iterator{}
+			iter.On("Scan", mock.Anything).Return(func(dest ...interface{}) bool {
+				if next >= len(f.versions) {
+					return false
+				}
+				*(dest[0].(*int)) = f.versions[next]
+				next++
+				return true
+			})
+			iter.On("Close").Return(nil)
+			query.On("Iter").Return(iter)
+		}
+		query.On("Exec").Return(func() error {
+			f.executed = append(f.executed, stmt)
+			return f.execErr
+		})
+		return query
+	})
+	return session
+}
+
+func TestLoadTemplates(t *testing.T) {
+	templates, err := LoadTemplates(".")
+	require.NoError(t, err)
+	require.Len(t, templates, LatestVersion)
+	for i, template := range templates {
+		assert.Equal(t, i+1, template.Version)
+	}
+	assert.Contains(t, templates[0].Content, "CREATE KEYSPACE IF NOT EXISTS ${keyspace}")
+	assert.Contains(t, templates[1].Content, "CREATE TABLE IF NOT EXISTS ${keyspace}.operation_throughput")
+	assert.NotContains(t, templates[1].Content, "${keyspace}.traces")
+
+	_, err = LoadTemplates("/does/not/exist")
+	assert.Error(t, err)
+
+	dir, err := ioutil.TempDir("", "cassandra-schema")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	_, err = LoadTemplates(dir)
+	assert.EqualError(t, err, "no schema templates found in "+dir)
+}
+
+func TestRender(t *testing.T) {
+	template := `-- a comment
+CREATE KEYSPACE IF NOT EXISTS ${keyspace} WITH replication = ${replication};
+
+CREATE TABLE IF NOT EXISTS ${keyspace}.traces (
+    trace_id blob, -- the trace ID
+    PRIMARY KEY (trace_id)
+) WITH default_time_to_live = ${trace_ttl};
+CREATE TABLE IF NOT EXISTS ${keyspace}.dependencies (ts timestamp) WITH default_time_to_live = ${dependencies_ttl};
+`
+	statements := Render(template, testParams)
+	require.Len(t, statements, 3)
+	assert.Equal(t, "CREATE KEYSPACE IF NOT EXISTS jaeger_v1_test WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '1'}", statements[0])
+	assert.Contains(t, statements[1], "default_time_to_live = 172800")
+	assert.NotContains(t, statements[1], "--")
+	assert.Contains(t, statements[2], "default_time_to_live = 0")
+}
+
+func TestNewMigratorInvalidKeyspace(t *testing.T) {
+	_, err := NewMigrator(&mocks.Session{}, nil, Params{Keyspace: "jaeger-v1"}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestMigrate(t *testing.T) {
+	templates := []Template{
+		{Version: 1, Content: "CREATE TABLE IF NOT EXISTS ${keyspace}.traces (id int PRIMARY KEY);"},
+		{Version: 2, Content: "CREATE TABLE IF NOT EXISTS ${keyspace}.leases (name text PRIMARY KEY);"},
+	}
+	testCases := []struct {
+		caption         string
+		versions        []int
+		expectedVersion int
+		expectedApplied []string
+	}{
+		{
+			caption:         "empty keyspace",
+			expectedVersion: 2,
+			expectedApplied: []string{
+				"CREATE TABLE IF NOT EXISTS jaeger_v1_test.traces (id int PRIMARY KEY)",
+				"CREATE TABLE IF NOT EXISTS jaeger_v1_test.leases (name text PRIMARY KEY)",
+			},
+		},
+		{
+			caption:         "partially migrated",
+			versions:        []int{1},
+			expectedVersion: 2,
+			expectedApplied: []string{"CREATE TABLE IF NOT EXISTS jaeger_v1_test.leases (name text PRIMARY KEY)"},
+		},
+		{
+			caption:         "up to date",
+			versions:        []int{2, 1},
+			expectedVersion: 2,
+		},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.caption, func(t *testing.T) {
+			fake := &fakeCassandra{versions: testCase.versions}
+			migrator, err := NewMigrator(fake.session(), templates, testParams, zap.NewNop())
+			require.NoError(t, err)
+			version, err := migrator.Migrate()
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersion, version)
+
+			require.True(t, len(fake.executed) >= 2)
+			assert.Contains(t, fake.executed[0], "CREATE KEYSPACE IF NOT EXISTS jaeger_v1_test")
+			assert.Contains(t, fake.executed[1], "CREATE TABLE IF NOT EXISTS jaeger_v1_test.schema_migrations")
+			var applied []string
+			var inserts int
+			for _, stmt := range fake.executed[2:] {
+				if strings.HasPrefix(stmt, "INSERT INTO jaeger_v1_test.schema_migrations") {
+					inserts++
+				} else {
+					applied = append(applied, stmt)
+				}
+			}
+			assert.Equal(t, testCase.expectedApplied, applied)
+			assert.Equal(t, len(testCase.expectedApplied), inserts)
+		})
+	}
+}
+
+func TestMigrateErrors(t *testing.T) {
+	templates := []Template{{Version: 1, Content: "CREATE TABLE IF NOT EXISTS ${keyspace}.traces (id int PRIMARY KEY);"}}
+
+	fake := &fakeCassandra{versions: []int{LatestVersion + 1}}
+	migrator, err := NewMigrator(fake.session(), templates, testParams, zap.NewNop())
+	require.NoError(t, err)
+	_, err = migrator.Migrate()
+	assert.EqualError(t, err, "schema version 3 is newer than the latest version 2 known to this tool")
+
+	fake = &fakeCassandra{execErr: errors.New("timeout")}
+	migrator, err = NewMigrator(fake.session(), templates, testParams, zap.NewNop())
+	require.NoError(t, err)
+	_, err = migrator.Migrate()
+	assert.EqualError(t, err, "cannot create schema version table: timeout")
+}
+
+func TestReadVersion(t *testing.T) {
+	fake := &fakeCassandra{versions: []int{1, 2}}
+	version, err := ReadVersion(fake.session())
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	query := &mocks.Query{}
+	iter := &mocks.Iterator{}
+	iter.On("Scan", mock.Anything).Return(false)
+	iter.On("Close").Return(errors.New("unconfigured table schema_migrations"))
+	query.On("Iter").Return(iter)
+	session := &mocks.Session{}
+	session.On("Query", mock.AnythingOfType("string"), mock.Anything).Return(query)
+	_, err = ReadVersion(session)
+	assert.Equal(t, ErrNoVersionTable, err)
+
+	query = &mocks.Query{}
+	iter = &mocks.Iterator{}
+	iter.On("Scan", mock.Anything).Return(false)
+	iter.On("Close").Return(errors.New("timeout"))
+	query.On("Iter").Return(iter)
+	session = &mocks.Session{}
+	session.On("Query", mock.AnythingOfType("string"), mock.Anything).Return(query)
+	_, err = ReadVersion(session)
+	assert.EqualError(t, err, "cannot read schema version: timeout")
+}
+
+func TestCheckVersion(t *testing.T) {
+	assert.NoError(t, CheckVersion(1, MinSpanStoreVersion))
+	assert.NoError(t, CheckVersion(LatestVersion, MinSamplingStoreVersion))
+	assert.Error(t, CheckVersion(1, MinSamplingStoreVersion))
+	assert.Error(t, CheckVersion(0, MinSpanStoreVersion))
+	assert.Error(t, CheckVersion(LatestVersion+1, MinSpanStoreVersion))
+}
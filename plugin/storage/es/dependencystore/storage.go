@@ -28,8 +28,10 @@ import (
 )
 
 const (
-	dependencyType        = "dependencies"
-	dependencyIndexPrefix = "jaeger-dependencies-"
+	// DependencyIndexPrefix is the name of the dependency indices without the date
+	DependencyIndexPrefix = "jaeger-dependencies-"
+
+	dependencyType = "dependencies"
 )
 
 type timeToDependencies struct {
@@ -39,23 +41,29 @@ type timeToDependencies struct {
 
 // DependencyStore handles all queries and insertions to ElasticSearch dependencies
 type DependencyStore struct {
-	ctx    context.Context
-	client es.Client
-	logger *zap.Logger
+	ctx         context.Context
+	client      es.Client
+	logger      *zap.Logger
+	indexPrefix string
 }
 
-// NewDependencyStore returns a DependencyStore
-func NewDependencyStore(client es.Client, logger *zap.Logger) *DependencyStore {
+// NewDependencyStore returns a DependencyStore. A non-empty indexPrefix is prepended,
+// followed by a dash, to the names of the dependency indices.
+func NewDependencyStore(client es.Client, logger *zap.Logger, indexPrefix string) *DependencyStore {
+	if indexPrefix != "" {
+		indexPrefix += "-"
+	}
 	return &DependencyStore{
-		ctx:    context.Background(),
-		client: client,
-		logger: logger,
+		ctx:         context.Background(),
+		client:      client,
+		logger:      logger,
+		indexPrefix: indexPrefix + DependencyIndexPrefix,
 	}
 }
 
 // WriteDependencies implements dependencystore.Writer#WriteDependencies.
 func (s *DependencyStore) WriteDependencies(ts time.Time, dependencies []model.DependencyLink) error {
-	indexName := indexName(ts)
+	indexName := indexWithDate(s.indexPrefix, ts)
 	if err := s.createIndex(indexName); err != nil {
 		return err
 	}
@@ -65,12 +73,23 @@ func (s *DependencyStore) WriteDependencies(ts time.Time, dependencies []model.D
 
 func (s *DependencyStore) createIndex(indexName string) error {
 	_, err := s.client.CreateIndex(indexName).Body(dependenciesMapping).Do(s.ctx)
-	if err != nil {
+	if err != nil && !indexAlreadyExists(err) {
 		return errors.Wrap(err, "Failed to create index")
 	}
 	return nil
 }
 
+// indexAlreadyExists returns true if the error was caused by creating an index that exists,
+// e.g. when dependencies are written several times on the same day
+func indexAlreadyExists(err error) bool {
+	eErr, ok := err.(*elastic.Error)
+	return ok && eErr.Details != nil &&
+		// ES 5.x
+		(eErr.Details.Type == "index_already_exists_exception" ||
+			// ES 6.x
+			eErr.Details.Type == "resource_already_exists_exception")
+}
+
 func (s *DependencyStore) writeDependencies(indexName string, ts time.Time, dependencies []model.DependencyLink) {
 	s.client.Index().Index(indexName).Type(dependencyType).
 		BodyJson(&timeToDependencies{Timestamp: ts,
@@ -78,9 +97,14 @@ func (s *DependencyStore) writeDependencies(indexName string, ts time.Time, depe
 		}).Add()
 }
 
+// Close flushes the pending writes and closes the client
+func (s *DependencyStore) Close() error {
+	return s.client.Close()
+}
+
 // GetDependencies returns all interservice dependencies
 func (s *DependencyStore) GetDependencies(endTs time.Time, lookback time.Duration) ([]model.DependencyLink, error) {
-	searchResult, err := s.client.Search(getIndices(endTs, lookback)...).
+	searchResult, err := s.client.Search(getIndices(s.indexPrefix, endTs, lookback)...).
 		Type(dependencyType).
 		Size(10000). // the default elasticsearch allowed limit
 		Query(buildTSQuery(endTs, lookback)).
@@ -107,18 +131,18 @@ func buildTSQuery(endTs time.Time, lookback time.Duration) elastic.Query {
 	return elastic.NewRangeQuery("timestamp").Gte(endTs.Add(-lookback)).Lte(endTs)
 }
 
-func getIndices(ts time.Time, lookback time.Duration) []string {
+func getIndices(prefix string, ts time.Time, lookback time.Duration) []string {
 	var indices []string
-	firstIndex := indexName(ts.Add(-lookback))
-	currentIndex := indexName(ts)
+	firstIndex := indexWithDate(prefix, ts.Add(-lookback))
+	currentIndex := indexWithDate(prefix, ts)
 	for currentIndex != firstIndex {
 		indices = append(indices, currentIndex)
 		ts = ts.Add(-24 * time.Hour)
-		currentIndex = indexName(ts)
+		currentIndex = indexWithDate(prefix, ts)
 	}
 	return append(indices, firstIndex)
 }
 
-func indexName(date time.Time) string {
-	return dependencyIndexPrefix + date.UTC().Format("2006-01-02")
+func indexWithDate(prefix string, date time.Time) string {
+	return prefix + date.UTC().Format("2006-01-02")
 }
@@ -46,7 +46,7 @@ func withDepStorage(fn func(r *depStorageTest)) {
 		client:    client,
 		logger:    logger,
 		logBuffer: logBuffer,
-		storage:   NewDependencyStore(client, logger),
+		storage:   NewDependencyStore(client, logger, ""),
 	}
 	fn(r)
 }
@@ -64,12 +64,15 @@ func TestWriteDependencies(t *testing.T) {
 			createIndexError: errors.New("index not created"),
 			expectedError:    "Failed to create index: index not created",
 		},
+		{
+			createIndexError: &elastic.Error{Details: &elastic.ErrorDetails{Type: "index_already_exists_exception"}},
+		},
 		{},
 	}
 	for _, testCase := range testCases {
 		withDepStorage(func(r *depStorageTest) {
 			fixedTime := time.Date(1995, time.April, 21, 4, 21, 19, 95, time.UTC)
-			indexName := indexName(fixedTime)
+			indexName := indexWithDate(DependencyIndexPrefix, fixedTime)
 
 			indexService := &mocks.IndicesCreateService{}
 			writeService := &mocks.IndexService{}
@@ -95,6 +98,13 @@ func TestWriteDependencies(t *testing.T) {
 	}
 }
 
+func TestClose(t *testing.T) {
+	withDepStorage(func(r *depStorageTest) {
+		r.client.On("Close").Return(errors.New("close-error"))
+		assert.EqualError(t, r.storage.Close(), "close-error")
+	})
+}
+
 func TestGetDependencies(t *testing.T) {
 	goodDependencies :=
 		`{
@@ -176,27 +186,36 @@ func TestGetIndices(t *testing.T) {
 		lookback time.Duration
 	}{
 		{
-			expected: []string{indexName(fixedTime), indexName(fixedTime.Add(-24 * time.Hour))},
+			expected: []string{indexWithDate(DependencyIndexPrefix, fixedTime), indexWithDate(DependencyIndexPrefix, fixedTime.Add(-24*time.Hour))},
 			lookback: 23 * time.Hour,
 		},
 		{
-			expected: []string{indexName(fixedTime), indexName(fixedTime.Add(-24 * time.Hour))},
+			expected: []string{indexWithDate(DependencyIndexPrefix, fixedTime), indexWithDate(DependencyIndexPrefix, fixedTime.Add(-24*time.Hour))},
 			lookback: 13 * time.Hour,
 		},
 		{
-			expected: []string{indexName(fixedTime)},
+			expected: []string{indexWithDate(DependencyIndexPrefix, fixedTime)},
 			lookback: 1 * time.Hour,
 		},
 		{
-			expected: []string{indexName(fixedTime)},
+			expected: []string{indexWithDate(DependencyIndexPrefix, fixedTime)},
 			lookback: 0,
 		},
 	}
 	for _, testCase := range testCases {
-		assert.EqualValues(t, testCase.expected, getIndices(fixedTime, testCase.lookback))
+		assert.EqualValues(t, testCase.expected, getIndices(DependencyIndexPrefix, fixedTime, testCase.lookback))
 	}
 }
 
+func TestIndexPrefix(t *testing.T) {
+	fixedTime := time.Date(1995, time.April, 21, 4, 12, 19, 95, time.UTC)
+	store := NewDependencyStore(&mocks.Client{}, zap.NewNop(), "staging")
+	assert.Equal(t, []string{"staging-jaeger-dependencies-1995-04-21"}, getIndices(store.indexPrefix, fixedTime, 0))
+
+	store = NewDependencyStore(&mocks.Client{}, zap.NewNop(), "")
+	assert.Equal(t, []string{"jaeger-dependencies-1995-04-21"}, getIndices(store.indexPrefix, fixedTime, 0))
+}
+
 // stringMatcher can match a string argument when it contains a specific substring q
 func stringMatcher(q string) interface{} {
 	matchFunc := func(s string) bool {
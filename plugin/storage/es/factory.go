@@ -25,10 +25,16 @@ import (
 	"github.com/jaegertracing/jaeger/pkg/es/config"
 	esDepStore "github.com/jaegertracing/jaeger/plugin/storage/es/dependencystore"
 	esSpanStore "github.com/jaegertracing/jaeger/plugin/storage/es/spanstore"
+	"github.com/jaegertracing/jaeger/storage"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
+const (
+	primaryNamespace = "es"
+	archiveNamespace = "es-archive"
+)
+
 // Factory implements storage.Factory for Elasticsearch backend.
 type Factory struct {
 	Options *Options
@@ -38,12 +44,14 @@ type Factory struct {
 
 	primaryConfig config.ClientBuilder
 	primaryClient es.Client
+	archiveConfig config.ClientBuilder
+	archiveClient es.Client
 }
 
 // NewFactory creates a new Factory.
 func NewFactory() *Factory {
 	return &Factory{
-		Options: NewOptions("es"), // TODO add "es-archive" once supported
+		Options: NewOptions(primaryNamespace, archiveNamespace),
 	}
 }
 
@@ -56,6 +64,9 @@ func (f *Factory) AddFlags(flagSet *flag.FlagSet) {
 func (f *Factory) InitFromViper(v *viper.Viper) {
 	f.Options.InitFromViper(v)
 	f.primaryConfig = f.Options.GetPrimary()
+	if cfg := f.Options.Get(archiveNamespace); cfg != nil {
+		f.archiveConfig = cfg // cf. https://golang.org/doc/faq#nil_error
+	}
 }
 
 // Initialize implements storage.Factory
@@ -67,23 +78,93 @@ func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger)
 		return err
 	}
 	f.primaryClient = primaryClient
-	// TODO init archive (cf. https://github.com/jaegertracing/jaeger/pull/604)
+
+	if f.archiveConfig != nil {
+		archiveClient, err := f.archiveConfig.NewClient(logger, metricsFactory)
+		if err != nil {
+			return err
+		}
+		f.archiveClient = archiveClient
+	} else {
+		logger.Info("Elasticsearch archive storage configuration is empty, skipping")
+	}
 	return nil
 }
 
 // CreateSpanReader implements storage.Factory
 func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
-	cfg := f.primaryConfig
-	return esSpanStore.NewSpanReader(f.primaryClient, f.logger, cfg.GetMaxSpanAge(), f.metricsFactory), nil
+	return createSpanReader(f.metricsFactory, f.logger, f.primaryClient, f.primaryConfig, false), nil
 }
 
 // CreateSpanWriter implements storage.Factory
 func (f *Factory) CreateSpanWriter() (spanstore.Writer, error) {
-	cfg := f.primaryConfig
-	return esSpanStore.NewSpanWriter(f.primaryClient, f.logger, f.metricsFactory, cfg.GetNumShards(), cfg.GetNumReplicas()), nil
+	return createSpanWriter(f.metricsFactory, f.logger, f.primaryClient, f.primaryConfig, false), nil
 }
 
 // CreateDependencyReader implements storage.Factory
 func (f *Factory) CreateDependencyReader() (dependencystore.Reader, error) {
-	return esDepStore.NewDependencyStore(f.primaryClient, f.logger), nil
+	return esDepStore.NewDependencyStore(f.primaryClient, f.logger, f.primaryConfig.GetIndexPrefix()), nil
+}
+
+// CreateDependencyWriter implements storage.DependencyWriterFactory
+func (f *Factory) CreateDependencyWriter() (dependencystore.Writer, error) {
+	return esDepStore.NewDependencyStore(f.primaryClient, f.logger, f.primaryConfig.GetIndexPrefix()), nil
+}
+
+// CreatePurger implements storage.PurgerFactory
+func (f *Factory) CreatePurger() (spanstore.Purger, error) {
+	return esSpanStore.NewSpanPurger(f.primaryClient, f.logger, f.primaryConfig.GetIndexPrefix()), nil
+}
+
+// CreateArchiveSpanReader implements storage.ArchiveFactory
+func (f *Factory) CreateArchiveSpanReader() (spanstore.Reader, error) {
+	if f.archiveClient == nil {
+		return nil, storage.ErrArchiveStorageNotConfigured
+	}
+	return createSpanReader(f.metricsFactory, f.logger, f.archiveClient, f.archiveConfig, true), nil
+}
+
+// CreateArchiveSpanWriter implements storage.ArchiveFactory
+func (f *Factory) CreateArchiveSpanWriter() (spanstore.Writer, error) {
+	if f.archiveClient == nil {
+		return nil, storage.ErrArchiveStorageNotConfigured
+	}
+	return createSpanWriter(f.metricsFactory, f.logger, f.archiveClient, f.archiveConfig, true), nil
+}
+
+func createSpanReader(
+	mFactory metrics.Factory,
+	logger *zap.Logger,
+	client es.Client,
+	cfg config.ClientBuilder,
+	archive bool,
+) spanstore.Reader {
+	return esSpanStore.NewSpanReader(esSpanStore.SpanReaderParams{
+		Client:              client,
+		Logger:              logger,
+		MetricsFactory:      mFactory,
+		MaxSpanAge:          cfg.GetMaxSpanAge(),
+		IndexPrefix:         cfg.GetIndexPrefix(),
+		UseReadWriteAliases: cfg.GetUseReadWriteAliases(),
+		Archive:             archive,
+	})
+}
+
+func createSpanWriter(
+	mFactory metrics.Factory,
+	logger *zap.Logger,
+	client es.Client,
+	cfg config.ClientBuilder,
+	archive bool,
+) spanstore.Writer {
+	return esSpanStore.NewSpanWriter(esSpanStore.SpanWriterParams{
+		Client:              client,
+		Logger:              logger,
+		MetricsFactory:      mFactory,
+		NumShards:           cfg.GetNumShards(),
+		NumReplicas:         cfg.GetNumReplicas(),
+		IndexPrefix:         cfg.GetIndexPrefix(),
+		UseReadWriteAliases: cfg.GetUseReadWriteAliases(),
+		Archive:             archive,
+	})
 }
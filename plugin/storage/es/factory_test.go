@@ -30,6 +30,8 @@ import (
 )
 
 var _ storage.Factory = new(Factory)
+var _ storage.ArchiveFactory = new(Factory)
+var _ storage.DependencyWriterFactory = new(Factory)
 
 type mockClientBuilder struct {
 	escfg.Configuration
@@ -65,4 +67,38 @@ func TestElasticsearchFactory(t *testing.T) {
 
 	_, err = f.CreateDependencyReader()
 	assert.NoError(t, err)
+
+	_, err = f.CreateDependencyWriter()
+	assert.NoError(t, err)
+
+	_, err = f.CreatePurger()
+	assert.NoError(t, err)
+
+	_, err = f.CreateArchiveSpanReader()
+	assert.EqualError(t, err, storage.ErrArchiveStorageNotConfigured.Error())
+
+	_, err = f.CreateArchiveSpanWriter()
+	assert.EqualError(t, err, storage.ErrArchiveStorageNotConfigured.Error())
+}
+
+func TestElasticsearchFactoryArchive(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{"--es-archive.enabled=true", "--es-archive.index-prefix=archived"})
+	f.InitFromViper(v)
+	assert.NotNil(t, f.archiveConfig)
+	assert.Equal(t, "archived", f.archiveConfig.GetIndexPrefix())
+
+	f.primaryConfig = &mockClientBuilder{}
+	f.archiveConfig = &mockClientBuilder{err: errors.New("made-up archive error")}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()), "made-up archive error")
+
+	f.archiveConfig = &mockClientBuilder{}
+	assert.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+
+	_, err := f.CreateArchiveSpanReader()
+	assert.NoError(t, err)
+
+	_, err = f.CreateArchiveSpanWriter()
+	assert.NoError(t, err)
 }
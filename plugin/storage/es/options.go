@@ -36,6 +36,14 @@ const (
 	suffixBulkWorkers       = ".bulk.workers"
 	suffixBulkActions       = ".bulk.actions"
 	suffixBulkFlushInterval = ".bulk.flush-interval"
+	suffixUseAliases        = ".use-aliases"
+	suffixIndexPrefix       = ".index-prefix"
+	suffixEnabled           = ".enabled"
+	suffixTLS               = ".tls"
+	suffixCert              = ".tls.cert"
+	suffixKey               = ".tls.key"
+	suffixCA                = ".tls.ca"
+	suffixSkipHostVerify    = ".tls.skip-host-verify"
 )
 
 // TODO this should be moved next to config.Configuration struct (maybe ./flags package)
@@ -56,6 +64,8 @@ type namespaceConfig struct {
 	config.Configuration
 	servers   string
 	namespace string
+	primary   bool
+	Enabled   bool
 }
 
 // NewOptions creates a new Options struct.
@@ -77,6 +87,8 @@ func NewOptions(primaryNamespace string, otherNamespaces ...string) *Options {
 			},
 			servers:   "http://127.0.0.1:9200",
 			namespace: primaryNamespace,
+			primary:   true,
+			Enabled:   true,
 		},
 		others: make(map[string]*namespaceConfig, len(otherNamespaces)),
 	}
@@ -97,6 +109,13 @@ func (opt *Options) AddFlags(flagSet *flag.FlagSet) {
 }
 
 func addFlags(flagSet *flag.FlagSet, nsConfig *namespaceConfig) {
+	if !nsConfig.primary {
+		flagSet.Bool(
+			nsConfig.namespace+suffixEnabled,
+			false,
+			"Enable the archive storage, i.e. the jaeger-span-archive and jaeger-service-archive indices "+
+				"where the query service saves the traces archived from the UI")
+	}
 	flagSet.String(
 		nsConfig.namespace+suffixUsername,
 		nsConfig.Username,
@@ -141,6 +160,35 @@ func addFlags(flagSet *flag.FlagSet, nsConfig *namespaceConfig) {
 		nsConfig.namespace+suffixBulkFlushInterval,
 		nsConfig.BulkFlushInterval,
 		"A time.Duration after which bulk requests are committed, regardless of other tresholds. Set to zero to disable. By default, this is disabled.")
+	flagSet.Bool(
+		nsConfig.namespace+suffixUseAliases,
+		nsConfig.UseReadWriteAliases,
+		"Use read and write aliases (jaeger-span-read, jaeger-span-write etc.) instead of daily indices. "+
+			"The aliases and their indices must be created and rolled over with jaeger-es-rollover")
+	flagSet.String(
+		nsConfig.namespace+suffixIndexPrefix,
+		nsConfig.IndexPrefix,
+		"Optional prefix of Jaeger indices. For example \"production\" creates \"production-jaeger-span-*\"")
+	flagSet.Bool(
+		nsConfig.namespace+suffixTLS,
+		nsConfig.TLS.Enabled,
+		"Enable TLS")
+	flagSet.String(
+		nsConfig.namespace+suffixCert,
+		nsConfig.TLS.CertPath,
+		"Path to TLS certificate file")
+	flagSet.String(
+		nsConfig.namespace+suffixKey,
+		nsConfig.TLS.KeyPath,
+		"Path to TLS key file")
+	flagSet.String(
+		nsConfig.namespace+suffixCA,
+		nsConfig.TLS.CaPath,
+		"Path to TLS CA file")
+	flagSet.Bool(
+		nsConfig.namespace+suffixSkipHostVerify,
+		nsConfig.TLS.SkipHostVerify,
+		"(insecure) Skip server's certificate chain and host name verification")
 }
 
 // InitFromViper initializes Options with properties from viper
@@ -152,6 +200,9 @@ func (opt *Options) InitFromViper(v *viper.Viper) {
 }
 
 func initFromViper(cfg *namespaceConfig, v *viper.Viper) {
+	if !cfg.primary {
+		cfg.Enabled = v.GetBool(cfg.namespace + suffixEnabled)
+	}
 	cfg.Username = v.GetString(cfg.namespace + suffixUsername)
 	cfg.Password = v.GetString(cfg.namespace + suffixPassword)
 	cfg.Sniffer = v.GetBool(cfg.namespace + suffixSniffer)
@@ -163,6 +214,13 @@ func initFromViper(cfg *namespaceConfig, v *viper.Viper) {
 	cfg.BulkWorkers = v.GetInt(cfg.namespace + suffixBulkWorkers)
 	cfg.BulkActions = v.GetInt(cfg.namespace + suffixBulkActions)
 	cfg.BulkFlushInterval = v.GetDuration(cfg.namespace + suffixBulkFlushInterval)
+	cfg.UseReadWriteAliases = v.GetBool(cfg.namespace + suffixUseAliases)
+	cfg.IndexPrefix = v.GetString(cfg.namespace + suffixIndexPrefix)
+	cfg.TLS.Enabled = v.GetBool(cfg.namespace + suffixTLS)
+	cfg.TLS.CertPath = v.GetString(cfg.namespace + suffixCert)
+	cfg.TLS.KeyPath = v.GetString(cfg.namespace + suffixKey)
+	cfg.TLS.CaPath = v.GetString(cfg.namespace + suffixCA)
+	cfg.TLS.SkipHostVerify = v.GetBool(cfg.namespace + suffixSkipHostVerify)
 }
 
 // GetPrimary returns primary configuration.
@@ -171,13 +229,16 @@ func (opt *Options) GetPrimary() *config.Configuration {
 	return &opt.primary.Configuration
 }
 
-// Get returns auxiliary named configuration.
+// Get returns auxiliary named configuration, or nil if it is not enabled.
 func (opt *Options) Get(namespace string) *config.Configuration {
 	nsCfg, ok := opt.others[namespace]
 	if !ok {
 		nsCfg = &namespaceConfig{}
 		opt.others[namespace] = nsCfg
 	}
+	if !nsCfg.Enabled {
+		return nil
+	}
 	nsCfg.Configuration.ApplyDefaults(&opt.primary.Configuration)
 	if nsCfg.servers == "" {
 		nsCfg.servers = opt.primary.servers
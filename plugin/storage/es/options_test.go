@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
 )
 
 func TestOptions(t *testing.T) {
@@ -33,11 +34,9 @@ func TestOptions(t *testing.T) {
 	assert.Equal(t, int64(1), primary.NumReplicas)
 	assert.Equal(t, 72*time.Hour, primary.MaxSpanAge)
 	assert.False(t, primary.Sniffer)
+	assert.Empty(t, primary.IndexPrefix)
 
-	aux := opts.Get("archive")
-	assert.Equal(t, primary.Username, aux.Username)
-	assert.Equal(t, primary.Password, aux.Password)
-	assert.Equal(t, primary.Servers, aux.Servers)
+	assert.Nil(t, opts.Get("archive"), "auxiliary configuration is disabled by default")
 }
 
 func TestOptionsWithFlags(t *testing.T) {
@@ -51,7 +50,15 @@ func TestOptionsWithFlags(t *testing.T) {
 		"--es.max-span-age=48h",
 		"--es.num-shards=20",
 		"--es.num-replicas=10",
+		"--es.use-aliases=true",
+		"--es.index-prefix=staging",
+		"--es.tls=true",
+		"--es.tls.ca=/etc/es/ca.pem",
+		"--es.tls.cert=/etc/es/cert.pem",
+		"--es.tls.key=/etc/es/key.pem",
+		"--es.tls.skip-host-verify=true",
 		// a couple overrides
+		"--es.aux.enabled=true",
 		"--es.aux.server-urls=3.3.3.3,4.4.4.4",
 		"--es.aux.max-span-age=24h",
 		"--es.aux.num-replicas=10",
@@ -63,6 +70,15 @@ func TestOptionsWithFlags(t *testing.T) {
 	assert.Equal(t, []string{"1.1.1.1", "2.2.2.2"}, primary.Servers)
 	assert.Equal(t, 48*time.Hour, primary.MaxSpanAge)
 	assert.True(t, primary.Sniffer)
+	assert.True(t, primary.UseReadWriteAliases)
+	assert.Equal(t, "staging", primary.IndexPrefix)
+	assert.Equal(t, tlscfg.Options{
+		Enabled:        true,
+		SkipHostVerify: true,
+		CaPath:         "/etc/es/ca.pem",
+		CertPath:       "/etc/es/cert.pem",
+		KeyPath:        "/etc/es/key.pem",
+	}, primary.TLS)
 
 	aux := opts.Get("es.aux")
 	assert.Equal(t, []string{"3.3.3.3", "4.4.4.4"}, aux.Servers)
@@ -72,5 +88,8 @@ func TestOptionsWithFlags(t *testing.T) {
 	assert.Equal(t, int64(10), aux.NumReplicas)
 	assert.Equal(t, 24*time.Hour, aux.MaxSpanAge)
 	assert.True(t, aux.Sniffer)
+	assert.True(t, aux.UseReadWriteAliases)
+	assert.Equal(t, "staging", aux.IndexPrefix)
+	assert.Equal(t, primary.TLS, aux.TLS)
 
 }
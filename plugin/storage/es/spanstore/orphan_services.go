@@ -0,0 +1,144 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gopkg.in/olivere/elastic.v5"
+
+	"github.com/jaegertracing/jaeger/pkg/es"
+)
+
+// servicesPageSize is the number of services aggregated at once when looking for services without spans
+const servicesPageSize = 1000
+
+// ForEachServiceWithoutSpans calls fn with the services of the service indices that have no span matching
+// spanQuery in the span indices, all spans are considered if spanQuery is nil. The indices are refreshed
+// first, so that spans deleted just before are not counted anymore.
+//
+// The services are read page by page with partitioned terms aggregations (ElasticSearch 5.2 or newer), so
+// their number is not limited by the maximum number of aggregation buckets, and fn is called once per page
+// with at most servicesPageSize services. The services are only ever deleted by name, services written
+// after they were read are never affected.
+func ForEachServiceWithoutSpans(
+	ctx context.Context,
+	client es.Client,
+	spanIndices []string,
+	serviceIndices []string,
+	spanQuery elastic.Query,
+	fn func(services []interface{}) error,
+) error {
+	indices := append(append([]string{}, spanIndices...), serviceIndices...)
+	if _, err := client.Refresh(indices...).Do(ctx); err != nil {
+		return errors.Wrap(err, "Failed to refresh indices")
+	}
+	// the number of partitions is doubled until every partition fits into a page
+	for numPartitions := 1; ; numPartitions *= 2 {
+		complete, err := forEachServicePage(ctx, client, spanIndices, serviceIndices, spanQuery, numPartitions, fn)
+		if err != nil || complete {
+			return err
+		}
+	}
+}
+
+// forEachServicePage calls fn with the services without spans of every partition. It returns false
+// if a partition holds more than servicesPageSize services.
+func forEachServicePage(
+	ctx context.Context,
+	client es.Client,
+	spanIndices []string,
+	serviceIndices []string,
+	spanQuery elastic.Query,
+	numPartitions int,
+	fn func(services []interface{}) error,
+) (bool, error) {
+	for partition := 0; partition < numPartitions; partition++ {
+		aggregation := elastic.NewTermsAggregation().
+			Field(serviceName).
+			Size(servicesPageSize).
+			Partition(partition).
+			NumPartitions(numPartitions)
+		services, complete, err := aggregateServices(ctx, client.Search(serviceIndices...), nil, aggregation)
+		if err != nil {
+			return false, err
+		}
+		if !complete {
+			return false, nil
+		}
+		if len(services) == 0 {
+			continue
+		}
+		query := elastic.NewBoolQuery().Filter(elastic.NewTermsQuery(serviceNameField, services...))
+		if spanQuery != nil {
+			query = query.Filter(spanQuery)
+		}
+		aggregation = elastic.NewTermsAggregation().Field(serviceNameField).Size(len(services))
+		withSpans, _, err := aggregateServices(ctx, client.Search(spanIndices...), query, aggregation)
+		if err != nil {
+			return false, err
+		}
+		found := make(map[interface{}]struct{}, len(withSpans))
+		for _, service := range withSpans {
+			found[service] = struct{}{}
+		}
+		var withoutSpans []interface{}
+		for _, service := range services {
+			if _, ok := found[service]; !ok {
+				withoutSpans = append(withoutSpans, service)
+			}
+		}
+		if len(withoutSpans) == 0 {
+			continue
+		}
+		if err := fn(withoutSpans); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// aggregateServices returns the services of the aggregation buckets, and whether all buckets were returned
+func aggregateServices(
+	ctx context.Context,
+	search es.SearchService,
+	query elastic.Query,
+	aggregation *elastic.TermsAggregation,
+) ([]interface{}, bool, error) {
+	if query != nil {
+		search = search.Query(query)
+	}
+	searchResult, err := search.
+		Size(0). // set to 0 because we don't want actual documents.
+		IgnoreUnavailable(true).
+		Aggregation(servicesAggregation, aggregation).
+		Do(ctx)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "Search service failed")
+	}
+	if searchResult.Aggregations == nil {
+		return nil, true, nil
+	}
+	bucket, found := searchResult.Aggregations.Terms(servicesAggregation)
+	if !found {
+		return nil, true, nil
+	}
+	services := make([]interface{}, len(bucket.Buckets))
+	for i, keyItem := range bucket.Buckets {
+		services[i] = keyItem.Key
+	}
+	return services, bucket.SumOfOtherDocCount == 0, nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/olivere/elastic.v5"
+
+	"github.com/jaegertracing/jaeger/pkg/es/mocks"
+)
+
+// servicesResult returns a search result with a services aggregation of the given services
+func servicesResult(t *testing.T, services []interface{}, sumOfOtherDocCount int) *elastic.SearchResult {
+	buckets := make([]map[string]interface{}, len(services))
+	for i, service := range services {
+		buckets[i] = map[string]interface{}{"key": service, "doc_count": 1}
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"aggregations": map[string]interface{}{
+			servicesAggregation: map[string]interface{}{"buckets": buckets, "sum_other_doc_count": sumOfOtherDocCount},
+		},
+	})
+	require.NoError(t, err)
+	result := &elastic.SearchResult{}
+	require.NoError(t, json.Unmarshal(data, result))
+	return result
+}
+
+func TestForEachServiceWithoutSpans(t *testing.T) {
+	// more services than fit into a single page, only every third one has spans
+	var services []interface{}
+	for i := 0; i < 3*servicesPageSize/2; i++ {
+		services = append(services, fmt.Sprintf("svc%d", i))
+	}
+	hasSpans := func(service interface{}) bool {
+		var i int
+		fmt.Sscanf(service.(string), "svc%d", &i)
+		return i%3 == 0
+	}
+
+	var serviceAggregation map[string]interface{}
+	serviceSearch := &mocks.SearchService{}
+	serviceSearch.On("Size", 0).Return(serviceSearch)
+	serviceSearch.On("IgnoreUnavailable", true).Return(serviceSearch)
+	serviceSearch.On("Aggregation", servicesAggregation, mock.AnythingOfType("*elastic.TermsAggregation")).Run(func(args mock.Arguments) {
+		source, err := args.Get(1).(*elastic.TermsAggregation).Source()
+		require.NoError(t, err)
+		serviceAggregation = source.(map[string]interface{})["terms"].(map[string]interface{})
+	}).Return(serviceSearch)
+	serviceSearch.On("Do", mock.Anything).Return(func(context.Context) *elastic.SearchResult {
+		include := serviceAggregation["include"].(map[string]interface{})
+		partition, numPartitions := include["partition"].(int), include["num_partitions"].(int)
+		var page []interface{}
+		for i, service := range services {
+			if i%numPartitions == partition {
+				page = append(page, service)
+			}
+		}
+		if len(page) > servicesPageSize {
+			return servicesResult(t, page[:servicesPageSize], len(page)-servicesPageSize)
+		}
+		return servicesResult(t, page, 0)
+	}, nil)
+
+	var spanQuery map[string]interface{}
+	spanSearch := &mocks.SearchService{}
+	spanSearch.On("Query", mock.AnythingOfType("*elastic.BoolQuery")).Run(func(args mock.Arguments) {
+		source, err := args.Get(0).(elastic.Query).Source()
+		require.NoError(t, err)
+		spanQuery = source.(map[string]interface{})["bool"].(map[string]interface{})
+	}).Return(spanSearch)
+	spanSearch.On("Size", 0).Return(spanSearch)
+	spanSearch.On("IgnoreUnavailable", true).Return(spanSearch)
+	spanSearch.On("Aggregation", servicesAggregation, mock.AnythingOfType("*elastic.TermsAggregation")).Return(spanSearch)
+	spanSearch.On("Do", mock.Anything).Return(func(context.Context) *elastic.SearchResult {
+		filter := spanQuery["filter"].([]interface{})
+		candidates := filter[0].(map[string]interface{})["terms"].(map[string]interface{})[serviceNameField].([]interface{})
+		var withSpans []interface{}
+		for _, service := range candidates {
+			if hasSpans(service) {
+				withSpans = append(withSpans, service)
+			}
+		}
+		return servicesResult(t, withSpans, 0)
+	}, nil)
+
+	refresh := &mocks.RefreshService{}
+	refresh.On("Do", mock.Anything).Return(&elastic.RefreshResult{}, nil)
+	client := &mocks.Client{}
+	client.On("Refresh", "jaeger-span-*", "jaeger-service-*").Return(refresh)
+	client.On("Search", "jaeger-service-*").Return(serviceSearch)
+	client.On("Search", "jaeger-span-*").Return(spanSearch)
+
+	var pages int
+	withoutSpans := make(map[interface{}]struct{})
+	err := ForEachServiceWithoutSpans(
+		context.Background(),
+		client,
+		[]string{"jaeger-span-*"},
+		[]string{"jaeger-service-*"},
+		elastic.NewRangeQuery(startTimeField).Gte(1),
+		func(services []interface{}) error {
+			pages++
+			assert.True(t, len(services) <= servicesPageSize)
+			for _, service := range services {
+				assert.False(t, hasSpans(service), service)
+				withoutSpans[service] = struct{}{}
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, 2, pages, "the services do not fit into one page, so two partitions are used")
+	assert.Len(t, withoutSpans, len(services)-len(services)/3)
+	refresh.AssertNumberOfCalls(t, "Do", 1)
+}
+
+func TestForEachServiceWithoutSpansErrors(t *testing.T) {
+	noop := func(services []interface{}) error { return nil }
+
+	refresh := &mocks.RefreshService{}
+	refresh.On("Do", mock.Anything).Return(nil, errors.New("refresh error"))
+	client := &mocks.Client{}
+	client.On("Refresh", "span", "service").Return(refresh)
+	err := ForEachServiceWithoutSpans(context.Background(), client, []string{"span"}, []string{"service"}, nil, noop)
+	assert.EqualError(t, err, "Failed to refresh indices: refresh error")
+
+	refresh = &mocks.RefreshService{}
+	refresh.On("Do", mock.Anything).Return(&elastic.RefreshResult{}, nil)
+	serviceSearch := &mocks.SearchService{}
+	serviceSearch.On("Size", 0).Return(serviceSearch)
+	serviceSearch.On("IgnoreUnavailable", true).Return(serviceSearch)
+	serviceSearch.On("Aggregation", servicesAggregation, mock.Anything).Return(serviceSearch)
+	serviceSearch.On("Do", mock.Anything).Return(nil, errors.New("search error")).Once()
+	serviceSearch.On("Do", mock.Anything).Return(servicesResult(t, []interface{}{"svc"}, 0), nil)
+	spanSearch := &mocks.SearchService{}
+	spanSearch.On("Query", mock.Anything).Return(spanSearch)
+	spanSearch.On("Size", 0).Return(spanSearch)
+	spanSearch.On("IgnoreUnavailable", true).Return(spanSearch)
+	spanSearch.On("Aggregation", servicesAggregation, mock.Anything).Return(spanSearch)
+	spanSearch.On("Do", mock.Anything).Return(nil, errors.New("span search error")).Once()
+	spanSearch.On("Do", mock.Anything).Return(&elastic.SearchResult{}, nil)
+	client = &mocks.Client{}
+	client.On("Refresh", "span", "service").Return(refresh)
+	client.On("Search", "service").Return(serviceSearch)
+	client.On("Search", "span").Return(spanSearch)
+
+	err = ForEachServiceWithoutSpans(context.Background(), client, []string{"span"}, []string{"service"}, nil, noop)
+	assert.EqualError(t, err, "Search service failed: search error")
+	err = ForEachServiceWithoutSpans(context.Background(), client, []string{"span"}, []string{"service"}, nil, noop)
+	assert.EqualError(t, err, "Search service failed: span search error")
+	err = ForEachServiceWithoutSpans(context.Background(), client, []string{"span"}, []string{"service"}, nil,
+		func(services []interface{}) error {
+			assert.Equal(t, []interface{}{"svc"}, services)
+			return errors.New("delete error")
+		})
+	assert.EqualError(t, err, "delete error")
+}
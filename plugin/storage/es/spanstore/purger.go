@@ -0,0 +1,102 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gopkg.in/olivere/elastic.v5"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/es"
+)
+
+var errCutoffNotAfterEpoch = errors.New("The purge cutoff must be after the Unix epoch")
+
+// SpanPurger deletes spans from the span indices of ElasticSearch, both daily and rolled over ones.
+// The services and operations that are left without spans are deleted from the service indices.
+// The archive indices are not affected. Purging only deletes documents, dropping the empty
+// indices is left to jaeger-es-index-cleaner.
+type SpanPurger struct {
+	ctx            context.Context
+	client         es.Client
+	logger         *zap.Logger
+	spanIndices    []string
+	serviceIndices []string
+}
+
+// NewSpanPurger returns a SpanPurger
+func NewSpanPurger(client es.Client, logger *zap.Logger, prefix string) *SpanPurger {
+	spanPrefix := indexPrefix(prefix) + SpanIndexPrefix
+	servicePrefix := indexPrefix(prefix) + ServiceIndexPrefix
+	return &SpanPurger{
+		ctx:            context.Background(),
+		client:         client,
+		logger:         logger,
+		spanIndices:    []string{spanPrefix + "*", "-" + spanPrefix + ArchiveIndexSuffix + "*"},
+		serviceIndices: []string{servicePrefix + "*", "-" + servicePrefix + ArchiveIndexSuffix + "*"},
+	}
+}
+
+// DeleteTrace deletes all spans of the trace
+func (p *SpanPurger) DeleteTrace(traceID model.TraceID) error {
+	return p.deleteByQuery(elastic.NewTermQuery(traceIDField, traceID.String()))
+}
+
+// Purge deletes all spans that started before the cutoff
+func (p *SpanPurger) Purge(cutoff time.Time) error {
+	if !cutoff.After(time.Unix(0, 0)) {
+		return errCutoffNotAfterEpoch
+	}
+	return p.deleteByQuery(elastic.NewRangeQuery(startTimeField).Lt(model.TimeAsEpochMicroseconds(cutoff)))
+}
+
+func (p *SpanPurger) deleteByQuery(query elastic.Query) error {
+	response, err := p.client.DeleteByQuery(p.spanIndices...).Query(query).IgnoreUnavailable(true).Do(p.ctx)
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete spans")
+	}
+	if len(response.Failures) > 0 {
+		return fmt.Errorf("Failed to delete %d spans", len(response.Failures))
+	}
+	p.logger.Info("Deleted spans", zap.Int64("deleted", response.Deleted))
+	return p.deleteServices()
+}
+
+// deleteServices deletes the service and operation documents of the services that have no spans left
+func (p *SpanPurger) deleteServices() error {
+	var deleted int64
+	err := ForEachServiceWithoutSpans(p.ctx, p.client, p.spanIndices, p.serviceIndices, nil, func(services []interface{}) error {
+		query := elastic.NewTermsQuery(serviceName, services...)
+		response, err := p.client.DeleteByQuery(p.serviceIndices...).Query(query).IgnoreUnavailable(true).Do(p.ctx)
+		if err != nil {
+			return errors.Wrap(err, "Failed to delete services")
+		}
+		if len(response.Failures) > 0 {
+			return fmt.Errorf("Failed to delete %d services", len(response.Failures))
+		}
+		deleted += response.Deleted
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	p.logger.Info("Deleted services", zap.Int64("deleted", deleted))
+	return nil
+}
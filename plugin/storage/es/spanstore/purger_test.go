@@ -0,0 +1,218 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/olivere/elastic.v5"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/es/mocks"
+	"github.com/jaegertracing/jaeger/pkg/testutils"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+var _ spanstore.Purger = &SpanPurger{} // check API conformance
+
+func TestSpanPurger(t *testing.T) {
+	withFailures := &elastic.BulkIndexByScrollResponse{}
+	require.NoError(t, json.Unmarshal([]byte(`{"deleted": 1, "failures": [{"index": "jaeger-span-2018-01-01"}]}`), withFailures))
+	deleted := &elastic.BulkIndexByScrollResponse{Deleted: 3}
+	spanIndices := []interface{}{"jaeger-span-*", "-jaeger-span-archive*"}
+	serviceIndices := []interface{}{"jaeger-service-*", "-jaeger-service-archive*"}
+
+	testCases := []struct {
+		caption         string
+		prefix          string
+		spanIndices     []interface{}
+		serviceIndices  []interface{}
+		spanResponse    *elastic.BulkIndexByScrollResponse
+		spanErr         error
+		searchErr       error
+		serviceResponse *elastic.BulkIndexByScrollResponse
+		serviceErr      error
+		expectedErr     string
+	}{
+		{
+			caption:         "deleted",
+			spanIndices:     spanIndices,
+			serviceIndices:  serviceIndices,
+			spanResponse:    deleted,
+			serviceResponse: deleted,
+		},
+		{
+			caption:         "index prefix",
+			prefix:          "foo",
+			spanIndices:     []interface{}{"foo-jaeger-span-*", "-foo-jaeger-span-archive*"},
+			serviceIndices:  []interface{}{"foo-jaeger-service-*", "-foo-jaeger-service-archive*"},
+			spanResponse:    deleted,
+			serviceResponse: deleted,
+		},
+		{
+			caption:        "request error",
+			spanIndices:    spanIndices,
+			serviceIndices: serviceIndices,
+			spanErr:        errors.New("request error"),
+			expectedErr:    "Failed to delete spans: request error",
+		},
+		{
+			caption:        "failures",
+			spanIndices:    spanIndices,
+			serviceIndices: serviceIndices,
+			spanResponse:   withFailures,
+			expectedErr:    "Failed to delete 1 spans",
+		},
+		{
+			caption:        "search error",
+			spanIndices:    spanIndices,
+			serviceIndices: serviceIndices,
+			spanResponse:   deleted,
+			searchErr:      errors.New("search error"),
+			expectedErr:    "Search service failed: search error",
+		},
+		{
+			caption:        "service request error",
+			spanIndices:    spanIndices,
+			serviceIndices: serviceIndices,
+			spanResponse:   deleted,
+			serviceErr:     errors.New("request error"),
+			expectedErr:    "Failed to delete services: request error",
+		},
+		{
+			caption:         "service failures",
+			spanIndices:     spanIndices,
+			serviceIndices:  serviceIndices,
+			spanResponse:    deleted,
+			serviceResponse: withFailures,
+			expectedErr:     "Failed to delete 1 services",
+		},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.caption, func(t *testing.T) {
+			deleteSpans := &mocks.DeleteByQueryService{}
+			deleteSpans.On("Query", mock.AnythingOfType("*elastic.TermQuery")).Return(deleteSpans)
+			deleteSpans.On("Query", mock.AnythingOfType("*elastic.RangeQuery")).Return(deleteSpans)
+			deleteSpans.On("IgnoreUnavailable", true).Return(deleteSpans)
+			deleteSpans.On("Do", mock.Anything).Return(testCase.spanResponse, testCase.spanErr)
+			serviceSearch := &mocks.SearchService{}
+			serviceSearch.On("Size", 0).Return(serviceSearch)
+			serviceSearch.On("IgnoreUnavailable", true).Return(serviceSearch)
+			serviceSearch.On("Aggregation", servicesAggregation, mock.AnythingOfType("*elastic.TermsAggregation")).Return(serviceSearch)
+			serviceSearch.On("Do", mock.Anything).Return(servicesResult(t, []interface{}{"svc1", "svc2"}, 0), testCase.searchErr)
+			spanSearch := &mocks.SearchService{}
+			spanSearch.On("Query", mock.AnythingOfType("*elastic.BoolQuery")).Return(spanSearch)
+			spanSearch.On("Size", 0).Return(spanSearch)
+			spanSearch.On("IgnoreUnavailable", true).Return(spanSearch)
+			spanSearch.On("Aggregation", servicesAggregation, mock.AnythingOfType("*elastic.TermsAggregation")).Return(spanSearch)
+			spanSearch.On("Do", mock.Anything).Return(servicesResult(t, []interface{}{"svc1"}, 0), nil)
+			refresh := &mocks.RefreshService{}
+			refresh.On("Do", mock.Anything).Return(&elastic.RefreshResult{}, nil)
+			deleteServices := &mocks.DeleteByQueryService{}
+			deleteServices.On("Query", mock.AnythingOfType("*elastic.TermsQuery")).Return(deleteServices)
+			deleteServices.On("IgnoreUnavailable", true).Return(deleteServices)
+			deleteServices.On("Do", mock.Anything).Return(testCase.serviceResponse, testCase.serviceErr)
+			client := &mocks.Client{}
+			client.On("DeleteByQuery", testCase.spanIndices...).Return(deleteSpans)
+			client.On("Refresh", append(append([]interface{}{}, testCase.spanIndices...), testCase.serviceIndices...)...).Return(refresh)
+			client.On("Search", testCase.serviceIndices...).Return(serviceSearch)
+			client.On("Search", testCase.spanIndices...).Return(spanSearch)
+			client.On("DeleteByQuery", testCase.serviceIndices...).Return(deleteServices)
+			logger, logBuffer := testutils.NewLogger()
+			purger := NewSpanPurger(client, logger, testCase.prefix)
+
+			for _, err := range []error{
+				purger.DeleteTrace(model.TraceID{Low: 1}),
+				purger.Purge(time.Unix(100, 0)),
+			} {
+				if testCase.expectedErr == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, testCase.expectedErr)
+				}
+			}
+			if testCase.expectedErr == "" {
+				assert.Contains(t, logBuffer.String(), `"msg":"Deleted spans","deleted":3`)
+				assert.Contains(t, logBuffer.String(), `"msg":"Deleted services","deleted":3`)
+				client.AssertNumberOfCalls(t, "DeleteByQuery", 4)
+			}
+		})
+	}
+}
+
+func TestSpanPurgerQueries(t *testing.T) {
+	var queries []elastic.Query
+	deleteService := &mocks.DeleteByQueryService{}
+	deleteService.On("Query", mock.Anything).Run(func(args mock.Arguments) {
+		queries = append(queries, args.Get(0).(elastic.Query))
+	}).Return(deleteService)
+	deleteService.On("IgnoreUnavailable", true).Return(deleteService)
+	deleteService.On("Do", mock.Anything).Return(&elastic.BulkIndexByScrollResponse{}, nil)
+	searchService := &mocks.SearchService{}
+	searchService.On("Query", mock.Anything).Return(searchService)
+	searchService.On("Size", 0).Return(searchService)
+	searchService.On("IgnoreUnavailable", true).Return(searchService)
+	searchService.On("Aggregation", servicesAggregation, mock.Anything).Return(searchService)
+	// the service index has svc, which has no spans left
+	searchService.On("Do", mock.Anything).Return(servicesResult(t, []interface{}{"svc"}, 0), nil).Once()
+	searchService.On("Do", mock.Anything).Return(servicesResult(t, nil, 0), nil).Once()
+	searchService.On("Do", mock.Anything).Return(servicesResult(t, []interface{}{"svc"}, 0), nil).Once()
+	searchService.On("Do", mock.Anything).Return(servicesResult(t, nil, 0), nil).Once()
+	refresh := &mocks.RefreshService{}
+	refresh.On("Do", mock.Anything).Return(&elastic.RefreshResult{}, nil)
+	client := &mocks.Client{}
+	client.On("DeleteByQuery", mock.Anything, mock.Anything).Return(deleteService)
+	client.On("Search", mock.Anything, mock.Anything).Return(searchService)
+	client.On("Refresh", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(refresh)
+	logger, _ := testutils.NewLogger()
+	purger := NewSpanPurger(client, logger, "")
+
+	require.NoError(t, purger.DeleteTrace(model.TraceID{Low: 0xab}))
+	require.NoError(t, purger.Purge(time.Unix(100, 0)))
+	require.Len(t, queries, 4)
+
+	source, err := queries[0].Source()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"term": map[string]interface{}{"traceID": "ab"}}, source)
+
+	source, err = queries[1].Source()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"terms": map[string]interface{}{"serviceName": []interface{}{"svc"}}}, source)
+
+	source, err = queries[2].Source()
+	require.NoError(t, err)
+	rangeQuery := source.(map[string]interface{})["range"].(map[string]interface{})[startTimeField].(map[string]interface{})
+	assert.Equal(t, uint64(100000000), rangeQuery["to"])
+	assert.Equal(t, false, rangeQuery["include_upper"])
+	refresh.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func TestSpanPurgerCutoffNotAfterEpoch(t *testing.T) {
+	client := &mocks.Client{}
+	logger, _ := testutils.NewLogger()
+	purger := NewSpanPurger(client, logger, "")
+
+	for _, cutoff := range []time.Time{time.Unix(0, 0), time.Unix(-100, 0)} {
+		assert.Equal(t, errCutoffNotAfterEpoch, purger.Purge(cutoff))
+	}
+	client.AssertNotCalled(t, "DeleteByQuery", mock.Anything, mock.Anything)
+}
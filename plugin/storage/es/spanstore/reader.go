@@ -34,8 +34,15 @@ import (
 )
 
 const (
-	spanIndexPrefix    = "jaeger-span-"
-	serviceIndexPrefix = "jaeger-service-"
+	// SpanIndexPrefix is the name of the span indices without the date, or the read and write alias suffix
+	SpanIndexPrefix = "jaeger-span-"
+	// ServiceIndexPrefix is the name of the service indices without the date, or the read and write alias suffix
+	ServiceIndexPrefix = "jaeger-service-"
+	// ArchiveIndexSuffix is appended to SpanIndexPrefix and ServiceIndexPrefix to form the names of the archive indices
+	ArchiveIndexSuffix = "archive"
+
+	readAliasSuffix    = "read"
+	writeAliasSuffix   = "write"
 	traceIDAggregation = "traceIDs"
 
 	traceIDField       = "traceID"
@@ -72,10 +79,11 @@ var (
 	// ErrUnableToFindTraceIDAggregation occurs when an aggregation query for TraceIDs fail.
 	ErrUnableToFindTraceIDAggregation = errors.New("Could not find aggregation of traceIDs")
 
-	errNoTraces = errors.New("No trace with that ID found")
-
 	defaultMaxDuration = model.DurationAsMicroseconds(time.Hour * 24)
 
+	// archived traces are kept in a single index and are never too old to be read
+	archiveMaxLookback = time.Hour * 24 * 365 * 50
+
 	tagFieldList = []string{tagsField, processTagsField, logFieldsField}
 )
 
@@ -88,21 +96,61 @@ type SpanReader struct {
 	// this will be rounded down to UTC 00:00 of that day.
 	maxLookback             time.Duration
 	serviceOperationStorage *ServiceOperationStorage
+	spanIndices             indicesForTimeRangeFn
+	serviceIndices          indicesForTimeRangeFn
+}
+
+// SpanReaderParams holds constructor params for NewSpanReader
+type SpanReaderParams struct {
+	Client              es.Client
+	Logger              *zap.Logger
+	MaxSpanAge          time.Duration
+	MetricsFactory      metrics.Factory
+	IndexPrefix         string
+	UseReadWriteAliases bool
+	Archive             bool
 }
 
+type indicesForTimeRangeFn func(startTime time.Time, endTime time.Time) []string
+
 // NewSpanReader returns a new SpanReader with a metrics.
-func NewSpanReader(client es.Client, logger *zap.Logger, maxLookback time.Duration, metricsFactory metrics.Factory) spanstore.Reader {
-	return storageMetrics.NewReadMetricsDecorator(newSpanReader(client, logger, maxLookback), metricsFactory)
+func NewSpanReader(p SpanReaderParams) spanstore.Reader {
+	return storageMetrics.NewReadMetricsDecorator(newSpanReader(p), p.MetricsFactory)
 }
 
-func newSpanReader(client es.Client, logger *zap.Logger, maxLookback time.Duration) *SpanReader {
+func newSpanReader(p SpanReaderParams) *SpanReader {
 	ctx := context.Background()
+	maxLookback := p.MaxSpanAge
+	if p.Archive {
+		maxLookback = archiveMaxLookback
+	}
+	prefix := indexPrefix(p.IndexPrefix)
 	return &SpanReader{
 		ctx:                     ctx,
-		client:                  client,
-		logger:                  logger,
+		client:                  p.Client,
+		logger:                  p.Logger,
 		maxLookback:             maxLookback,
-		serviceOperationStorage: NewServiceOperationStorage(ctx, client, metrics.NullFactory, logger, 0), // the decorator takes care of metrics
+		serviceOperationStorage: NewServiceOperationStorage(ctx, p.Client, metrics.NullFactory, p.Logger, 0), // the decorator takes care of metrics
+		spanIndices:             getIndicesFn(prefix+SpanIndexPrefix, p.UseReadWriteAliases, p.Archive),
+		serviceIndices:          getIndicesFn(prefix+ServiceIndexPrefix, p.UseReadWriteAliases, p.Archive),
+	}
+}
+
+// getIndicesFn returns a function that returns the archive index or the read alias when those are used,
+// and otherwise the daily indices covering the time range.
+func getIndicesFn(prefix string, useReadWriteAliases bool, archive bool) indicesForTimeRangeFn {
+	if archive {
+		return func(startTime time.Time, endTime time.Time) []string {
+			return []string{prefix + ArchiveIndexSuffix}
+		}
+	}
+	if useReadWriteAliases {
+		return func(startTime time.Time, endTime time.Time) []string {
+			return []string{prefix + readAliasSuffix}
+		}
+	}
+	return func(startTime time.Time, endTime time.Time) []string {
+		return findIndices(prefix, startTime, endTime)
 	}
 }
 
@@ -114,7 +162,7 @@ func (s *SpanReader) GetTrace(traceID model.TraceID) (*model.Trace, error) {
 		return nil, err
 	}
 	if len(traces) == 0 {
-		return nil, errNoTraces
+		return nil, spanstore.ErrTraceNotFound
 	}
 	return traces[0], nil
 }
@@ -166,14 +214,14 @@ func indexWithDate(prefix string, date time.Time) string {
 // GetServices returns all services traced by Jaeger, ordered by frequency
 func (s *SpanReader) GetServices() ([]string, error) {
 	currentTime := time.Now()
-	jaegerIndices := findIndices(serviceIndexPrefix, currentTime.Add(-s.maxLookback), currentTime)
+	jaegerIndices := s.serviceIndices(currentTime.Add(-s.maxLookback), currentTime)
 	return s.serviceOperationStorage.getServices(jaegerIndices)
 }
 
 // GetOperations returns all operations for a specific service traced by Jaeger
 func (s *SpanReader) GetOperations(service string) ([]string, error) {
 	currentTime := time.Now()
-	jaegerIndices := findIndices(serviceIndexPrefix, currentTime.Add(-s.maxLookback), currentTime)
+	jaegerIndices := s.serviceIndices(currentTime.Add(-s.maxLookback), currentTime)
 	return s.serviceOperationStorage.getOperations(jaegerIndices, service)
 }
 
@@ -214,7 +262,7 @@ func (s *SpanReader) multiRead(traceIDs []string, startTime, endTime time.Time)
 	var traces []*model.Trace
 	// Add an hour in both directions so that traces that straddle two indexes are retrieved.
 	// i.e starts in one and ends in another.
-	indices := findIndices(spanIndexPrefix, startTime.Add(-time.Hour), endTime.Add(time.Hour))
+	indices := s.spanIndices(startTime.Add(-time.Hour), endTime.Add(time.Hour))
 
 	nextTime := model.TimeAsEpochMicroseconds(startTime.Add(-time.Hour))
 
@@ -347,7 +395,7 @@ func (s *SpanReader) findTraceIDs(traceQuery *spanstore.TraceQueryParameters) ([
 	aggregation := s.buildTraceIDAggregation(traceQuery.NumTraces)
 	boolQuery := s.buildFindTraceIDsQuery(traceQuery)
 
-	jaegerIndices := findIndices(spanIndexPrefix, traceQuery.StartTimeMin, traceQuery.StartTimeMax)
+	jaegerIndices := s.spanIndices(traceQuery.StartTimeMin, traceQuery.StartTimeMax)
 
 	searchService := s.client.Search(jaegerIndices...).
 		Type(spanType).
@@ -89,7 +89,11 @@ func withSpanReader(fn func(r *spanReaderTest)) {
 		client:    client,
 		logger:    logger,
 		logBuffer: logBuffer,
-		reader:    newSpanReader(client, logger, 72*time.Hour),
+		reader: newSpanReader(SpanReaderParams{
+			Client:     client,
+			Logger:     logger,
+			MaxSpanAge: 72 * time.Hour,
+		}),
 	}
 	fn(r)
 }
@@ -98,7 +102,7 @@ var _ spanstore.Reader = &SpanReader{} // check API conformance
 
 func TestNewSpanReader(t *testing.T) {
 	client := &mocks.Client{}
-	reader := NewSpanReader(client, zap.NewNop(), 0, metrics.NullFactory)
+	reader := NewSpanReader(SpanReaderParams{Client: client, Logger: zap.NewNop(), MetricsFactory: metrics.NullFactory})
 	assert.NotNil(t, reader)
 }
 
@@ -169,7 +173,7 @@ func TestSpanReader_GetTraceQueryError(t *testing.T) {
 				Responses: []*elastic.SearchResult{},
 			}, nil)
 		trace, err := r.reader.GetTrace(model.NewTraceID(0, 1))
-		require.EqualError(t, err, "No trace with that ID found")
+		require.EqualError(t, err, spanstore.ErrTraceNotFound.Error())
 		require.Nil(t, trace)
 	})
 }
@@ -188,7 +192,7 @@ func TestSpanReader_GetTraceNilHits(t *testing.T) {
 			}, nil)
 
 		trace, err := r.reader.GetTrace(model.NewTraceID(0, 1))
-		require.EqualError(t, err, "No trace with that ID found")
+		require.EqualError(t, err, spanstore.ErrTraceNotFound.Error())
 		require.Nil(t, trace)
 	})
 }
@@ -286,36 +290,83 @@ func TestSpanReaderFindIndices(t *testing.T) {
 			startTime: today.Add(-time.Millisecond),
 			endTime:   today,
 			expected: []string{
-				indexWithDate(spanIndexPrefix, today),
+				indexWithDate(SpanIndexPrefix, today),
 			},
 		},
 		{
 			startTime: today.Add(-13 * time.Hour),
 			endTime:   today,
 			expected: []string{
-				indexWithDate(spanIndexPrefix, today),
-				indexWithDate(spanIndexPrefix, yesterday),
+				indexWithDate(SpanIndexPrefix, today),
+				indexWithDate(SpanIndexPrefix, yesterday),
 			},
 		},
 		{
 			startTime: today.Add(-48 * time.Hour),
 			endTime:   today,
 			expected: []string{
-				indexWithDate(spanIndexPrefix, today),
-				indexWithDate(spanIndexPrefix, yesterday),
-				indexWithDate(spanIndexPrefix, twoDaysAgo),
+				indexWithDate(SpanIndexPrefix, today),
+				indexWithDate(SpanIndexPrefix, yesterday),
+				indexWithDate(SpanIndexPrefix, twoDaysAgo),
 			},
 		},
 	}
 	for _, testCase := range testCases {
-		actual := findIndices(spanIndexPrefix, testCase.startTime, testCase.endTime)
+		actual := findIndices(SpanIndexPrefix, testCase.startTime, testCase.endTime)
 		assert.EqualValues(t, testCase.expected, actual)
 	}
 }
 
+func TestSpanReader_indices(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		params          SpanReaderParams
+		expectedSpan    []string
+		expectedService []string
+	}{
+		{
+			params:          SpanReaderParams{},
+			expectedSpan:    findIndices(SpanIndexPrefix, now.Add(-48*time.Hour), now),
+			expectedService: findIndices(ServiceIndexPrefix, now.Add(-48*time.Hour), now),
+		},
+		{
+			params:          SpanReaderParams{IndexPrefix: "foo"},
+			expectedSpan:    findIndices("foo-"+SpanIndexPrefix, now.Add(-48*time.Hour), now),
+			expectedService: findIndices("foo-"+ServiceIndexPrefix, now.Add(-48*time.Hour), now),
+		},
+		{
+			params:          SpanReaderParams{UseReadWriteAliases: true},
+			expectedSpan:    []string{"jaeger-span-read"},
+			expectedService: []string{"jaeger-service-read"},
+		},
+		{
+			params:          SpanReaderParams{IndexPrefix: "foo", UseReadWriteAliases: true},
+			expectedSpan:    []string{"foo-jaeger-span-read"},
+			expectedService: []string{"foo-jaeger-service-read"},
+		},
+		{
+			params:          SpanReaderParams{IndexPrefix: "foo", Archive: true},
+			expectedSpan:    []string{"foo-jaeger-span-archive"},
+			expectedService: []string{"foo-jaeger-service-archive"},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase.params.Client = &mocks.Client{}
+		testCase.params.Logger = zap.NewNop()
+		reader := newSpanReader(testCase.params)
+		assert.Equal(t, testCase.expectedSpan, reader.spanIndices(now.Add(-48*time.Hour), now))
+		assert.Equal(t, testCase.expectedService, reader.serviceIndices(now.Add(-48*time.Hour), now))
+	}
+}
+
+func TestSpanReader_archiveLookback(t *testing.T) {
+	reader := newSpanReader(SpanReaderParams{Client: &mocks.Client{}, Logger: zap.NewNop(), MaxSpanAge: time.Hour, Archive: true})
+	assert.Equal(t, archiveMaxLookback, reader.maxLookback)
+}
+
 func TestSpanReader_indexWithDate(t *testing.T) {
 	withSpanReader(func(r *spanReaderTest) {
-		actual := indexWithDate(spanIndexPrefix, time.Date(1995, time.April, 21, 4, 21, 19, 95, time.UTC))
+		actual := indexWithDate(SpanIndexPrefix, time.Date(1995, time.April, 21, 4, 21, 19, 95, time.UTC))
 		assert.Equal(t, "jaeger-span-1995-04-21", actual)
 	})
 }
@@ -14,7 +14,11 @@
 
 package spanstore
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // TODO: resolve traceID concerns (may not require any changes here)
 const mapping = `{
@@ -176,3 +180,19 @@ var (
 	}
 }`)
 )
+
+// GetSpanMapping returns the span index mapping with the given number of shards and replicas
+func GetSpanMapping(numShards, numReplicas int64) string {
+	return fixMapping(spanMapping, numShards, numReplicas)
+}
+
+// GetServiceMapping returns the service index mapping with the given number of shards and replicas
+func GetServiceMapping(numShards, numReplicas int64) string {
+	return fixMapping(serviceMapping, numShards, numReplicas)
+}
+
+func fixMapping(mapping string, numShards, numReplicas int64) string {
+	mapping = strings.Replace(mapping, "${__NUMBER_OF_SHARDS__}", strconv.FormatInt(numShards, 10), 1)
+	mapping = strings.Replace(mapping, "${__NUMBER_OF_REPLICAS__}", strconv.FormatInt(numReplicas, 10), 1)
+	return mapping
+}
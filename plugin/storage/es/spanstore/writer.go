@@ -18,8 +18,6 @@ import (
 	"context"
 	"fmt"
 	"hash/fnv"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -58,8 +56,14 @@ type SpanWriter struct {
 	serviceWriter serviceWriter
 	numShards     int64
 	numReplicas   int64
+	indexNames    indexNamesFn
+	// when set, spans and services are written to the write aliases maintained by jaeger-es-rollover
+	// instead of daily indices, and no indices are created on the fly
+	useReadWriteAliases bool
 }
 
+type indexNamesFn func(span *model.Span) (spanIndexName string, serviceIndexName string)
+
 // Service is the JSON struct for service:operation documents in ElasticSearch
 type Service struct {
 	ServiceName   string `json:"serviceName"`
@@ -82,27 +86,36 @@ func (s Service) hashCode() string {
 	return fmt.Sprintf("%x", h.Sum64())
 }
 
+// SpanWriterParams holds constructor parameters for NewSpanWriter
+type SpanWriterParams struct {
+	Client              es.Client
+	Logger              *zap.Logger
+	MetricsFactory      metrics.Factory
+	NumShards           int64
+	NumReplicas         int64
+	IndexPrefix         string
+	UseReadWriteAliases bool
+	Archive             bool
+}
+
 // NewSpanWriter creates a new SpanWriter for use
-func NewSpanWriter(
-	client es.Client,
-	logger *zap.Logger,
-	metricsFactory metrics.Factory,
-	numShards int64,
-	numReplicas int64,
-) *SpanWriter {
+func NewSpanWriter(p SpanWriterParams) *SpanWriter {
 	ctx := context.Background()
-	if numShards == 0 {
-		numShards = defaultNumShards
+	if p.NumShards == 0 {
+		p.NumShards = defaultNumShards
+	}
+	if p.Archive {
+		p.UseReadWriteAliases = false
 	}
 
 	// TODO: Configurable TTL
-	serviceOperationStorage := NewServiceOperationStorage(ctx, client, metricsFactory, logger, time.Hour*12)
+	serviceOperationStorage := NewServiceOperationStorage(ctx, p.Client, p.MetricsFactory, p.Logger, time.Hour*12)
 	return &SpanWriter{
 		ctx:    ctx,
-		client: client,
-		logger: logger,
+		client: p.Client,
+		logger: p.Logger,
 		writerMetrics: spanWriterMetrics{
-			indexCreate: storageMetrics.NewWriteMetrics(metricsFactory, "index_create"),
+			indexCreate: storageMetrics.NewWriteMetrics(p.MetricsFactory, "index_create"),
 		},
 		serviceWriter: serviceOperationStorage.Write,
 		indexCache: cache.NewLRUWithOptions(
@@ -111,17 +124,25 @@ func NewSpanWriter(
 				TTL: 48 * time.Hour,
 			},
 		),
-		numShards:   numShards,
-		numReplicas: numReplicas,
+		numShards:           p.NumShards,
+		numReplicas:         p.NumReplicas,
+		indexNames:          getIndexNamesFn(indexPrefix(p.IndexPrefix), p.UseReadWriteAliases, p.Archive),
+		useReadWriteAliases: p.UseReadWriteAliases,
 	}
 }
 
 // WriteSpan writes a span and its corresponding service:operation in ElasticSearch
 func (s *SpanWriter) WriteSpan(span *model.Span) error {
-	spanIndexName, serviceIndexName := indexNames(span)
+	spanIndexName, serviceIndexName := s.indexNames(span)
 	// Convert model.Span into json.Span
 	jsonSpan := json.FromDomainEmbedProcess(span)
 
+	if s.useReadWriteAliases {
+		s.writeService(serviceIndexName, jsonSpan)
+		s.writeSpan(spanIndexName, jsonSpan)
+		return nil
+	}
+
 	if err := s.createIndex(serviceIndexName, serviceMapping, jsonSpan); err != nil {
 		return err
 	}
@@ -138,9 +159,31 @@ func (s *SpanWriter) Close() error {
 	return s.client.Close()
 }
 
-func indexNames(span *model.Span) (string, string) {
-	spanDate := span.StartTime.UTC().Format("2006-01-02")
-	return spanIndexPrefix + spanDate, serviceIndexPrefix + spanDate
+// getIndexNamesFn returns a function that maps a span to the names of the span and service
+// indices (or write aliases) it must be written to.
+func getIndexNamesFn(prefix string, useReadWriteAliases bool, archive bool) indexNamesFn {
+	spanPrefix, servicePrefix := prefix+SpanIndexPrefix, prefix+ServiceIndexPrefix
+	if archive {
+		return func(span *model.Span) (string, string) {
+			return spanPrefix + ArchiveIndexSuffix, servicePrefix + ArchiveIndexSuffix
+		}
+	}
+	if useReadWriteAliases {
+		return func(span *model.Span) (string, string) {
+			return spanPrefix + writeAliasSuffix, servicePrefix + writeAliasSuffix
+		}
+	}
+	return func(span *model.Span) (string, string) {
+		return indexWithDate(spanPrefix, span.StartTime), indexWithDate(servicePrefix, span.StartTime)
+	}
+}
+
+// indexPrefix turns the user supplied index prefix into the string prepended to all index names.
+func indexPrefix(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "-"
 }
 
 func (s *SpanWriter) createIndex(indexName string, mapping string, jsonSpan *jModel.Span) error {
@@ -177,9 +220,7 @@ func writeCache(key string, c cache.Cache) {
 }
 
 func (s *SpanWriter) fixMapping(mapping string) string {
-	mapping = strings.Replace(mapping, "${__NUMBER_OF_SHARDS__}", strconv.FormatInt(s.numShards, 10), 1)
-	mapping = strings.Replace(mapping, "${__NUMBER_OF_REPLICAS__}", strconv.FormatInt(s.numReplicas, 10), 1)
-	return mapping
+	return fixMapping(mapping, s.numShards, s.numReplicas)
 }
 
 func (s *SpanWriter) writeService(indexName string, jsonSpan *jModel.Span) {
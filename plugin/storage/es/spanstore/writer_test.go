@@ -48,7 +48,7 @@ func withSpanWriter(fn func(w *spanWriterTest)) {
 		client:    client,
 		logger:    logger,
 		logBuffer: logBuffer,
-		writer:    NewSpanWriter(client, logger, metricsFactory, 0, 0),
+		writer:    NewSpanWriter(SpanWriterParams{Client: client, Logger: logger, MetricsFactory: metricsFactory}),
 	}
 	fn(w)
 }
@@ -195,15 +195,85 @@ func TestSpanWriter_WriteSpan(t *testing.T) {
 	}
 }
 
+func TestSpanWriter_WriteSpanWithAliases(t *testing.T) {
+	client := &mocks.Client{}
+	logger, logBuffer := testutils.NewLogger()
+	writer := NewSpanWriter(SpanWriterParams{
+		Client:              client,
+		Logger:              logger,
+		MetricsFactory:      metrics.NullFactory,
+		UseReadWriteAliases: true,
+	})
+
+	indexService := &mocks.IndexService{}
+	indexServicePut := &mocks.IndexService{}
+	indexSpanPut := &mocks.IndexService{}
+
+	indexService.On("Index", stringMatcher("jaeger-span-write")).Return(indexService)
+	indexService.On("Index", stringMatcher("jaeger-service-write")).Return(indexService)
+	indexService.On("Type", stringMatcher(serviceType)).Return(indexServicePut)
+	indexService.On("Type", stringMatcher(spanType)).Return(indexSpanPut)
+	indexServicePut.On("Id", mock.AnythingOfType("string")).Return(indexServicePut)
+	indexServicePut.On("BodyJson", mock.AnythingOfType("spanstore.Service")).Return(indexServicePut)
+	indexServicePut.On("Add")
+	indexSpanPut.On("BodyJson", mock.AnythingOfType("*spanstore.Span")).Return(indexSpanPut)
+	indexSpanPut.On("Add")
+	client.On("Index").Return(indexService)
+
+	span := &model.Span{
+		TraceID:       model.NewTraceID(0, 1),
+		SpanID:        model.NewSpanID(0),
+		OperationName: "operation",
+		Process:       &model.Process{ServiceName: "service"},
+		StartTime:     time.Now(),
+	}
+	require.NoError(t, writer.WriteSpan(span))
+
+	indexServicePut.AssertNumberOfCalls(t, "Add", 1)
+	indexSpanPut.AssertNumberOfCalls(t, "Add", 1)
+	client.AssertNotCalled(t, "IndexExists", mock.Anything)
+	client.AssertNotCalled(t, "CreateIndex", mock.Anything)
+	assert.Equal(t, "", logBuffer.String())
+}
+
 func TestSpanIndexName(t *testing.T) {
 	date, err := time.Parse(time.RFC3339, "1995-04-21T22:08:41+00:00")
 	require.NoError(t, err)
 	span := &model.Span{
 		StartTime: date,
 	}
-	spanIndexName, serviceIndexName := indexNames(span)
-	assert.Equal(t, "jaeger-span-1995-04-21", spanIndexName)
-	assert.Equal(t, "jaeger-service-1995-04-21", serviceIndexName)
+	testCases := []struct {
+		prefix          string
+		useAliases      bool
+		archive         bool
+		expectedSpan    string
+		expectedService string
+	}{
+		{expectedSpan: "jaeger-span-1995-04-21", expectedService: "jaeger-service-1995-04-21"},
+		{prefix: "foo-", expectedSpan: "foo-jaeger-span-1995-04-21", expectedService: "foo-jaeger-service-1995-04-21"},
+		{useAliases: true, expectedSpan: "jaeger-span-write", expectedService: "jaeger-service-write"},
+		{prefix: "foo-", useAliases: true, expectedSpan: "foo-jaeger-span-write", expectedService: "foo-jaeger-service-write"},
+		{archive: true, expectedSpan: "jaeger-span-archive", expectedService: "jaeger-service-archive"},
+		{prefix: "foo-", archive: true, expectedSpan: "foo-jaeger-span-archive", expectedService: "foo-jaeger-service-archive"},
+	}
+	for _, testCase := range testCases {
+		spanIndexName, serviceIndexName := getIndexNamesFn(testCase.prefix, testCase.useAliases, testCase.archive)(span)
+		assert.Equal(t, testCase.expectedSpan, spanIndexName)
+		assert.Equal(t, testCase.expectedService, serviceIndexName)
+	}
+}
+
+func TestSpanWriterIndexPrefix(t *testing.T) {
+	writer := NewSpanWriter(SpanWriterParams{
+		Client:         &mocks.Client{},
+		Logger:         zap.NewNop(),
+		MetricsFactory: metrics.NullFactory,
+		IndexPrefix:    "staging",
+		Archive:        true,
+	})
+	spanIndexName, serviceIndexName := writer.indexNames(&model.Span{})
+	assert.Equal(t, "staging-jaeger-span-archive", spanIndexName)
+	assert.Equal(t, "staging-jaeger-service-archive", serviceIndexName)
 }
 
 func TestFixMapping(t *testing.T) {
@@ -17,18 +17,24 @@ package storage
 import (
 	"flag"
 	"fmt"
+	"io"
 
 	"github.com/spf13/viper"
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
+	"github.com/jaegertracing/jaeger/pkg/distributedlock"
+	"github.com/jaegertracing/jaeger/pkg/multierror"
 	"github.com/jaegertracing/jaeger/plugin"
+	"github.com/jaegertracing/jaeger/plugin/storage/badger"
 	"github.com/jaegertracing/jaeger/plugin/storage/cassandra"
 	"github.com/jaegertracing/jaeger/plugin/storage/es"
+	"github.com/jaegertracing/jaeger/plugin/storage/grpc"
 	"github.com/jaegertracing/jaeger/plugin/storage/kafka"
 	"github.com/jaegertracing/jaeger/plugin/storage/memory"
 	"github.com/jaegertracing/jaeger/storage"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
@@ -37,9 +43,18 @@ const (
 	elasticsearchStorageType = "elasticsearch"
 	memoryStorageType        = "memory"
 	kafkaStorageType         = "kafka"
+	grpcPluginStorageType    = "plugin"
+	badgerStorageType        = "badger"
 )
 
-var allStorageTypes = []string{cassandraStorageType, elasticsearchStorageType, memoryStorageType, kafkaStorageType}
+var allStorageTypes = []string{
+	cassandraStorageType,
+	elasticsearchStorageType,
+	memoryStorageType,
+	kafkaStorageType,
+	grpcPluginStorageType,
+	badgerStorageType,
+}
 
 // Factory implements storage.Factory interface as a meta-factory for storage components.
 type Factory struct {
@@ -79,6 +94,10 @@ func (f *Factory) getFactoryOfType(factoryType string) (storage.Factory, error)
 		return memory.NewFactory(), nil
 	case kafkaStorageType:
 		return kafka.NewFactory(), nil
+	case grpcPluginStorageType:
+		return grpc.NewFactory(), nil
+	case badgerStorageType:
+		return badger.NewFactory(), nil
 	default:
 		return nil, fmt.Errorf("Unknown storage type %s. Valid types are %v", factoryType, allStorageTypes)
 	}
@@ -94,6 +113,19 @@ func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger)
 	return nil
 }
 
+// Close closes the resources held by the underlying factories, such as storage plugin processes or local databases.
+func (f *Factory) Close() error {
+	var errors []error
+	for _, factory := range f.factories {
+		if closer, ok := factory.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return multierror.Wrap(errors)
+}
+
 // CreateSpanReader implements storage.Factory
 func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
 	factory, ok := f.factories[f.SpanReaderType]
@@ -132,6 +164,19 @@ func (f *Factory) CreateDependencyReader() (dependencystore.Reader, error) {
 	return factory.CreateDependencyReader()
 }
 
+// CreateDependencyWriter implements storage.DependencyWriterFactory
+func (f *Factory) CreateDependencyWriter() (dependencystore.Writer, error) {
+	factory, ok := f.factories[f.DependenciesStorageType]
+	if !ok {
+		return nil, fmt.Errorf("No %s backend registered for span store", f.DependenciesStorageType)
+	}
+	writerFactory, ok := factory.(storage.DependencyWriterFactory)
+	if !ok {
+		return nil, storage.ErrDependencyWriterNotSupported
+	}
+	return writerFactory.CreateDependencyWriter()
+}
+
 // AddFlags implements plugin.Configurable
 func (f *Factory) AddFlags(flagSet *flag.FlagSet) {
 	for _, factory := range f.factories {
@@ -175,3 +220,66 @@ func (f *Factory) CreateArchiveSpanWriter() (spanstore.Writer, error) {
 	}
 	return archive.CreateArchiveSpanWriter()
 }
+
+// CreatePurger implements storage.PurgerFactory. The purger deletes traces from all span writer backends that support it,
+// backends that rely on a time to live instead are skipped.
+func (f *Factory) CreatePurger() (spanstore.Purger, error) {
+	var purgers []spanstore.Purger
+	for _, storageType := range f.SpanWriterTypes {
+		factory, ok := f.factories[storageType]
+		if !ok {
+			return nil, fmt.Errorf("No %s backend registered for span store", storageType)
+		}
+		purgerFactory, ok := factory.(storage.PurgerFactory)
+		if !ok {
+			continue
+		}
+		purger, err := purgerFactory.CreatePurger()
+		if err == storage.ErrPurgerNotSupported {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		purgers = append(purgers, purger)
+	}
+	switch len(purgers) {
+	case 0:
+		return nil, storage.ErrPurgerNotSupported
+	case 1:
+		return purgers[0], nil
+	default:
+		return spanstore.NewCompositePurger(purgers...), nil
+	}
+}
+
+// CreateLock implements storage.SamplingStoreFactory
+func (f *Factory) CreateLock() (distributedlock.Lock, error) {
+	factory, err := f.samplingStoreFactory()
+	if err != nil {
+		return nil, err
+	}
+	return factory.CreateLock()
+}
+
+// CreateSamplingStore implements storage.SamplingStoreFactory
+func (f *Factory) CreateSamplingStore() (samplingstore.Store, error) {
+	factory, err := f.samplingStoreFactory()
+	if err != nil {
+		return nil, err
+	}
+	return factory.CreateSamplingStore()
+}
+
+// samplingStoreFactory returns the factory of the primary span writer, which also stores sampling data.
+func (f *Factory) samplingStoreFactory() (storage.SamplingStoreFactory, error) {
+	factory, ok := f.factories[f.SpanWriterTypes[0]]
+	if !ok {
+		return nil, fmt.Errorf("No %s backend registered for span store", f.SpanWriterTypes[0])
+	}
+	ssFactory, ok := factory.(storage.SamplingStoreFactory)
+	if !ok {
+		return nil, storage.ErrSamplingStoreNotSupported
+	}
+	return ssFactory, nil
+}
@@ -44,7 +44,8 @@ type FactoryConfig struct {
 //   * `elasticsearch` - built-in
 //   * `memory` - built-in
 //   * `kafka` - built-in
-//   * `plugin` - loads a dynamic plugin that implements storage.Factory interface (not supported at the moment)
+//   * `badger` - built-in
+//   * `plugin` - launches an external storage plugin and talks to it over gRPC, see plugin/storage/grpc
 //
 // For backwards compatibility it also parses the args looking for deprecated --span-storage.type flag.
 // If found, it writes a deprecation warning to the log.
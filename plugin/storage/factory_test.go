@@ -25,15 +25,20 @@ import (
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
+	lockMocks "github.com/jaegertracing/jaeger/pkg/distributedlock/mocks"
 	"github.com/jaegertracing/jaeger/storage"
 	depStoreMocks "github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
 	"github.com/jaegertracing/jaeger/storage/mocks"
+	samplingStoreMocks "github.com/jaegertracing/jaeger/storage/samplingstore/mocks"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 	spanStoreMocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
 )
 
 var _ storage.Factory = new(Factory)
 var _ storage.ArchiveFactory = new(Factory)
+var _ storage.SamplingStoreFactory = new(Factory)
+var _ storage.PurgerFactory = new(Factory)
+var _ storage.DependencyWriterFactory = new(Factory)
 
 func defaultCfg() FactoryConfig {
 	return FactoryConfig{
@@ -53,7 +58,7 @@ func TestNewFactory(t *testing.T) {
 	assert.Equal(t, cassandraStorageType, f.DependenciesStorageType)
 
 	f, err = NewFactory(FactoryConfig{
-		SpanWriterTypes:         []string{cassandraStorageType, kafkaStorageType},
+		SpanWriterTypes:         []string{cassandraStorageType, kafkaStorageType, grpcPluginStorageType, badgerStorageType},
 		SpanReaderType:          elasticsearchStorageType,
 		DependenciesStorageType: memoryStorageType,
 	})
@@ -61,9 +66,11 @@ func TestNewFactory(t *testing.T) {
 	assert.NotEmpty(t, f.factories)
 	assert.NotEmpty(t, f.factories[cassandraStorageType])
 	assert.NotNil(t, f.factories[kafkaStorageType])
+	assert.NotNil(t, f.factories[grpcPluginStorageType])
 	assert.NotEmpty(t, f.factories[elasticsearchStorageType])
 	assert.NotNil(t, f.factories[memoryStorageType])
-	assert.Equal(t, []string{cassandraStorageType, kafkaStorageType}, f.SpanWriterTypes)
+	assert.NotNil(t, f.factories[badgerStorageType])
+	assert.Equal(t, []string{cassandraStorageType, kafkaStorageType, grpcPluginStorageType, badgerStorageType}, f.SpanWriterTypes)
 	assert.Equal(t, elasticsearchStorageType, f.SpanReaderType)
 	assert.Equal(t, memoryStorageType, f.DependenciesStorageType)
 
@@ -128,6 +135,12 @@ func TestCreate(t *testing.T) {
 	_, err = f.CreateArchiveSpanWriter()
 	assert.EqualError(t, err, "Archive storage not supported")
 
+	_, err = f.CreateLock()
+	assert.EqualError(t, err, "Sampling store not supported")
+
+	_, err = f.CreateSamplingStore()
+	assert.EqualError(t, err, "Sampling store not supported")
+
 	mock.On("CreateSpanWriter").Return(spanWriter, nil)
 	w, err = f.CreateSpanWriter()
 	assert.NoError(t, err)
@@ -187,6 +200,113 @@ func TestCreateArchive(t *testing.T) {
 	assert.EqualError(t, err, "archive-span-writer-error")
 }
 
+func TestCreateSamplingStore(t *testing.T) {
+	f, err := NewFactory(defaultCfg())
+	require.NoError(t, err)
+	assert.NotEmpty(t, f.factories[cassandraStorageType])
+
+	mock := &struct {
+		mocks.Factory
+		mocks.SamplingStoreFactory
+	}{}
+	f.factories[cassandraStorageType] = mock
+
+	lock := new(lockMocks.Lock)
+	samplingStore := new(samplingStoreMocks.Store)
+
+	mock.SamplingStoreFactory.On("CreateLock").Return(lock, errors.New("lock-error"))
+	mock.SamplingStoreFactory.On("CreateSamplingStore").Return(samplingStore, errors.New("sampling-store-error"))
+
+	l, err := f.CreateLock()
+	assert.Equal(t, lock, l)
+	assert.EqualError(t, err, "lock-error")
+
+	ss, err := f.CreateSamplingStore()
+	assert.Equal(t, samplingStore, ss)
+	assert.EqualError(t, err, "sampling-store-error")
+}
+
+func TestCreateDependencyWriter(t *testing.T) {
+	f, err := NewFactory(defaultCfg())
+	require.NoError(t, err)
+	assert.NotEmpty(t, f.factories[cassandraStorageType])
+
+	mock := &struct {
+		mocks.Factory
+		mocks.DependencyWriterFactory
+	}{}
+	f.factories[cassandraStorageType] = mock
+
+	depWriter := new(depStoreMocks.Writer)
+	mock.DependencyWriterFactory.On("CreateDependencyWriter").Return(depWriter, errors.New("dep-writer-error"))
+
+	w, err := f.CreateDependencyWriter()
+	assert.Equal(t, depWriter, w)
+	assert.EqualError(t, err, "dep-writer-error")
+
+	f.factories[cassandraStorageType] = new(mocks.Factory)
+	_, err = f.CreateDependencyWriter()
+	assert.Equal(t, storage.ErrDependencyWriterNotSupported, err)
+}
+
+func TestCreatePurger(t *testing.T) {
+	cfg := defaultCfg()
+	cfg.SpanWriterTypes = append(cfg.SpanWriterTypes, elasticsearchStorageType, kafkaStorageType)
+	f, err := NewFactory(cfg)
+	require.NoError(t, err)
+
+	kafkaMock := new(mocks.Factory)
+	f.factories[kafkaStorageType] = kafkaMock
+	cassandraMock := &struct {
+		mocks.Factory
+		mocks.PurgerFactory
+	}{}
+	f.factories[cassandraStorageType] = cassandraMock
+	esMock := &struct {
+		mocks.Factory
+		mocks.PurgerFactory
+	}{}
+	f.factories[elasticsearchStorageType] = esMock
+
+	purger := new(spanStoreMocks.Purger)
+	purger2 := new(spanStoreMocks.Purger)
+	cassandraMock.PurgerFactory.On("CreatePurger").Return(purger, nil)
+	esMock.PurgerFactory.On("CreatePurger").Return(purger2, nil).Once()
+	esMock.PurgerFactory.On("CreatePurger").Return(nil, errors.New("purger-error"))
+
+	p, err := f.CreatePurger()
+	assert.NoError(t, err)
+	assert.Equal(t, spanstore.NewCompositePurger(purger, purger2), p)
+
+	_, err = f.CreatePurger()
+	assert.EqualError(t, err, "purger-error")
+
+	f.SpanWriterTypes = []string{cassandraStorageType, kafkaStorageType}
+	p, err = f.CreatePurger()
+	assert.NoError(t, err)
+	assert.Equal(t, purger, p)
+
+	f.SpanWriterTypes = []string{kafkaStorageType}
+	_, err = f.CreatePurger()
+	assert.Equal(t, storage.ErrPurgerNotSupported, err)
+
+	// backends relying on a time to live are skipped
+	f.SpanWriterTypes = []string{cassandraStorageType, elasticsearchStorageType}
+	ttlMock := &struct {
+		mocks.Factory
+		mocks.PurgerFactory
+	}{}
+	ttlMock.PurgerFactory.On("CreatePurger").Return(nil, storage.ErrPurgerNotSupported)
+	f.factories[elasticsearchStorageType] = ttlMock
+	p, err = f.CreatePurger()
+	assert.NoError(t, err)
+	assert.Equal(t, purger, p)
+
+	f.SpanWriterTypes = []string{elasticsearchStorageType}
+	_, err = f.CreatePurger()
+	assert.Equal(t, storage.ErrPurgerNotSupported, err)
+}
+
 func TestCreateError(t *testing.T) {
 	f, err := NewFactory(defaultCfg())
 	require.NoError(t, err)
@@ -225,6 +345,30 @@ func TestCreateError(t *testing.T) {
 		assert.Nil(t, w)
 		assert.EqualError(t, err, expectedErr)
 	}
+
+	{
+		l, err := f.CreateLock()
+		assert.Nil(t, l)
+		assert.EqualError(t, err, expectedErr)
+	}
+
+	{
+		s, err := f.CreateSamplingStore()
+		assert.Nil(t, s)
+		assert.EqualError(t, err, expectedErr)
+	}
+
+	{
+		w, err := f.CreateDependencyWriter()
+		assert.Nil(t, w)
+		assert.EqualError(t, err, expectedErr)
+	}
+
+	{
+		p, err := f.CreatePurger()
+		assert.Nil(t, p)
+		assert.EqualError(t, err, expectedErr)
+	}
 }
 
 type configurable struct {
@@ -264,3 +408,32 @@ func TestConfigurable(t *testing.T) {
 	assert.Equal(t, fs, mock.flagSet)
 	assert.Equal(t, v, mock.viper)
 }
+
+type closeable struct {
+	mocks.Factory
+	closed bool
+	err    error
+}
+
+func (f *closeable) Close() error {
+	f.closed = true
+	return f.err
+}
+
+func TestClose(t *testing.T) {
+	f, err := NewFactory(FactoryConfig{
+		SpanWriterTypes:         []string{cassandraStorageType, grpcPluginStorageType},
+		SpanReaderType:          cassandraStorageType,
+		DependenciesStorageType: cassandraStorageType,
+	})
+	require.NoError(t, err)
+
+	f.factories[cassandraStorageType] = new(mocks.Factory)
+	plugin := &closeable{}
+	f.factories[grpcPluginStorageType] = plugin
+	assert.NoError(t, f.Close())
+	assert.True(t, plugin.closed)
+
+	plugin.err = errors.New("close-error")
+	assert.EqualError(t, f.Close(), "close-error")
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/spf13/viper"
+
+	"github.com/jaegertracing/jaeger/plugin/storage/grpc/shared"
+	"github.com/jaegertracing/jaeger/plugin/storage/memory"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// main is an example storage plugin that keeps the spans in memory. The optional configuration
+// file accepts the same keys as the flags of the memory storage, e.g. memory.max-traces.
+func main() {
+	var configPath string
+	flag.StringVar(&configPath, "config", "", "A path to the plugin's configuration file")
+	flag.Parse()
+
+	opts := memory.Options{}
+	if configPath != "" {
+		v := viper.New()
+		v.SetConfigFile(configPath)
+		if err := v.ReadInConfig(); err != nil {
+			log.Fatalf("Failed to read the configuration file %s: %v", configPath, err)
+		}
+		opts.InitFromViper(v)
+	}
+
+	plugin := &memoryStorePlugin{store: memory.WithConfiguration(opts.Configuration)}
+	if err := shared.Serve(plugin); err != nil {
+		log.Fatalf("Storage plugin failed: %v", err)
+	}
+}
+
+type memoryStorePlugin struct {
+	store *memory.Store
+}
+
+func (p *memoryStorePlugin) SpanReader() spanstore.Reader {
+	return p.store
+}
+
+func (p *memoryStorePlugin) SpanWriter() spanstore.Writer {
+	return p.store
+}
+
+func (p *memoryStorePlugin) DependencyReader() dependencystore.Reader {
+	return p.store
+}
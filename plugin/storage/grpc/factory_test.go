@@ -0,0 +1,71 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/plugin/storage/grpc/shared"
+	"github.com/jaegertracing/jaeger/storage"
+)
+
+var _ storage.Factory = new(Factory)
+
+func TestFactoryInitializeErrors(t *testing.T) {
+	f := NewFactory()
+	assert.Equal(t, errMissingPluginBinary, f.Initialize(nil, zap.NewNop()))
+	assert.NoError(t, f.Close())
+
+	f.options.PluginBinary = "/does/not/exist"
+	f.options.PluginStartTimeout = time.Second
+	err := f.Initialize(nil, zap.NewNop())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Failed to start the storage plugin")
+	}
+}
+
+func TestOptionsWithFlags(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{})
+	f.InitFromViper(v)
+	assert.Equal(t, defaultPluginStartTimeout, f.options.PluginStartTimeout)
+	assert.Equal(t, shared.ClientOptions{
+		Timeout:           shared.DefaultTimeout,
+		BulkSize:          shared.DefaultBulkSize,
+		BulkFlushInterval: shared.DefaultBulkFlushInterval,
+	}, f.options.ClientOptions)
+	assert.Nil(t, f.options.pluginArgs())
+
+	command.ParseFlags([]string{
+		"--grpc-storage-plugin.binary=noop-grpc-plugin",
+		"--grpc-storage-plugin.configuration-file=config.json",
+		"--grpc-storage-plugin.start-timeout=1m",
+		"--grpc-storage-plugin.timeout=2s",
+		"--grpc-storage-plugin.bulk.size=10",
+		"--grpc-storage-plugin.bulk.flush-interval=1s",
+	})
+	f.InitFromViper(v)
+	assert.Equal(t, "noop-grpc-plugin", f.options.PluginBinary)
+	assert.Equal(t, "config.json", f.options.PluginConfigurationFile)
+	assert.Equal(t, time.Minute, f.options.PluginStartTimeout)
+	assert.Equal(t, []string{"--config", "config.json"}, f.options.pluginArgs())
+	assert.Equal(t, shared.ClientOptions{Timeout: 2 * time.Second, BulkSize: 10, BulkFlushInterval: time.Second}, f.options.ClientOptions)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"flag"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/jaegertracing/jaeger/plugin/storage/grpc/shared"
+)
+
+const (
+	pluginBinary            = "grpc-storage-plugin.binary"
+	pluginConfigurationFile = "grpc-storage-plugin.configuration-file"
+	pluginStartTimeout      = "grpc-storage-plugin.start-timeout"
+	pluginTimeout           = "grpc-storage-plugin.timeout"
+	pluginBulkSize          = "grpc-storage-plugin.bulk.size"
+	pluginBulkFlushInterval = "grpc-storage-plugin.bulk.flush-interval"
+
+	defaultPluginStartTimeout = 10 * time.Second
+)
+
+// Options contains the configuration of the storage plugin
+type Options struct {
+	// PluginBinary is the path to the plugin executable
+	PluginBinary string
+	// PluginConfigurationFile is passed to the plugin with the --config argument
+	PluginConfigurationFile string
+	// PluginStartTimeout is how long to wait for the plugin to start serving
+	PluginStartTimeout time.Duration
+	// ClientOptions configures the calls to the plugin
+	ClientOptions shared.ClientOptions
+}
+
+// AddFlags adds flags for Options
+func (opt *Options) AddFlags(flagSet *flag.FlagSet) {
+	flagSet.String(pluginBinary, "", "The location of the storage plugin binary")
+	flagSet.String(pluginConfigurationFile, "", "A path pointing to the plugin's configuration file, passed to the plugin with --config")
+	flagSet.Duration(pluginStartTimeout, defaultPluginStartTimeout, "How long to wait for the storage plugin to start")
+	flagSet.Duration(pluginTimeout, shared.DefaultTimeout, "The timeout of each call to the storage plugin")
+	flagSet.Int(pluginBulkSize, shared.DefaultBulkSize, "The number of spans written to the storage plugin in one call")
+	flagSet.Duration(pluginBulkFlushInterval, shared.DefaultBulkFlushInterval, "How long spans are buffered before being written to the storage plugin, even if there are fewer than the bulk size")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (opt *Options) InitFromViper(v *viper.Viper) {
+	opt.PluginBinary = v.GetString(pluginBinary)
+	opt.PluginConfigurationFile = v.GetString(pluginConfigurationFile)
+	opt.PluginStartTimeout = v.GetDuration(pluginStartTimeout)
+	opt.ClientOptions.Timeout = v.GetDuration(pluginTimeout)
+	opt.ClientOptions.BulkSize = v.GetInt(pluginBulkSize)
+	opt.ClientOptions.BulkFlushInterval = v.GetDuration(pluginBulkFlushInterval)
+}
+
+func (opt *Options) pluginArgs() []string {
+	if opt.PluginConfigurationFile == "" {
+		return nil
+	}
+	return []string{"--config", opt.PluginConfigurationFile}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/plugin/storage/grpc/proto/storage_v1"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+const (
+	// DefaultTimeout is the default timeout of the calls to the plugin
+	DefaultTimeout = 10 * time.Second
+	// DefaultBulkSize is the default number of spans written to the plugin in one call
+	DefaultBulkSize = 100
+	// DefaultBulkFlushInterval is the default time after which buffered spans are written to the plugin
+	DefaultBulkFlushInterval = 200 * time.Millisecond
+)
+
+// ClientOptions contains the options of the GRPCClient. Zero values are replaced by the defaults.
+type ClientOptions struct {
+	// Timeout is the timeout of each call to the plugin
+	Timeout time.Duration
+	// BulkSize is the number of spans written to the plugin in one call
+	BulkSize int
+	// BulkFlushInterval is how long spans are buffered before being written, even if there are fewer than BulkSize
+	BulkFlushInterval time.Duration
+}
+
+// GRPCClient implements spanstore.Reader, spanstore.Writer and dependencystore.Reader
+// by forwarding the calls to a storage plugin over gRPC. Like the bulk processor of the
+// Elasticsearch span writer, it buffers the spans and writes them to the plugin in bulks,
+// logging the errors of the writes.
+type GRPCClient struct {
+	readerClient       storage_v1.SpanReaderPluginClient
+	writerClient       storage_v1.SpanWriterPluginClient
+	depsReaderClient   storage_v1.DependenciesReaderPluginClient
+	options            ClientOptions
+	logger             *zap.Logger
+	spans              chan *model.Span
+	closeOnce          sync.Once
+	flushingSpansGroup sync.WaitGroup
+}
+
+// NewGRPCClient creates a GRPCClient that talks to the plugin over the given connection
+func NewGRPCClient(conn *grpc.ClientConn, options ClientOptions, logger *zap.Logger) *GRPCClient {
+	if options.Timeout == 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.BulkSize <= 0 {
+		options.BulkSize = DefaultBulkSize
+	}
+	if options.BulkFlushInterval == 0 {
+		options.BulkFlushInterval = DefaultBulkFlushInterval
+	}
+	c := &GRPCClient{
+		readerClient:     storage_v1.NewSpanReaderPluginClient(conn),
+		writerClient:     storage_v1.NewSpanWriterPluginClient(conn),
+		depsReaderClient: storage_v1.NewDependenciesReaderPluginClient(conn),
+		options:          options,
+		logger:           logger,
+		spans:            make(chan *model.Span, options.BulkSize),
+	}
+	c.flushingSpansGroup.Add(1)
+	go c.flushSpans()
+	return c
+}
+
+// WriteSpan implements spanstore.Writer. The span is written to the plugin asynchronously.
+func (c *GRPCClient) WriteSpan(span *model.Span) error {
+	c.spans <- span
+	return nil
+}
+
+// Close writes the buffered spans to the plugin. WriteSpan must not be called after Close.
+func (c *GRPCClient) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.spans)
+	})
+	c.flushingSpansGroup.Wait()
+	return nil
+}
+
+func (c *GRPCClient) flushSpans() {
+	defer c.flushingSpansGroup.Done()
+	ticker := time.NewTicker(c.options.BulkFlushInterval)
+	defer ticker.Stop()
+	bulk := make([]*model.Span, 0, c.options.BulkSize)
+	for {
+		select {
+		case span, ok := <-c.spans:
+			if !ok {
+				c.writeSpans(bulk)
+				return
+			}
+			bulk = append(bulk, span)
+			if len(bulk) >= c.options.BulkSize {
+				c.writeSpans(bulk)
+				bulk = bulk[:0]
+			}
+		case <-ticker.C:
+			c.writeSpans(bulk)
+			bulk = bulk[:0]
+		}
+	}
+}
+
+func (c *GRPCClient) writeSpans(spans []*model.Span) {
+	if len(spans) == 0 {
+		return
+	}
+	ctx, cancel := c.callContext()
+	defer cancel()
+	if _, err := c.writerClient.WriteSpans(ctx, &storage_v1.WriteSpansRequest{Spans: spans}); err != nil {
+		c.logger.Error("Failed to write spans to the storage plugin", zap.Int("spans", len(spans)), zap.Error(err))
+	}
+}
+
+// callContext returns the context of a call to the plugin. The storage interfaces do not take a context,
+// so each call is only bounded by the configured timeout.
+func (c *GRPCClient) callContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.options.Timeout)
+}
+
+// GetTrace implements spanstore.Reader
+func (c *GRPCClient) GetTrace(traceID model.TraceID) (*model.Trace, error) {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	stream, err := c.readerClient.GetTrace(ctx, &storage_v1.GetTraceRequest{TraceID: traceID})
+	if err != nil {
+		return nil, err
+	}
+	trace := &model.Trace{}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if status.Code(err) == codes.NotFound {
+			return nil, spanstore.ErrTraceNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		trace.Spans = append(trace.Spans, chunk.Spans...)
+	}
+	if len(trace.Spans) == 0 {
+		return nil, spanstore.ErrTraceNotFound
+	}
+	return trace, nil
+}
+
+// GetServices implements spanstore.Reader
+func (c *GRPCClient) GetServices() ([]string, error) {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	res, err := c.readerClient.GetServices(ctx, &storage_v1.GetServicesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Services, nil
+}
+
+// GetOperations implements spanstore.Reader
+func (c *GRPCClient) GetOperations(service string) ([]string, error) {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	res, err := c.readerClient.GetOperations(ctx, &storage_v1.GetOperationsRequest{Service: service})
+	if err != nil {
+		return nil, err
+	}
+	return res.Operations, nil
+}
+
+// FindTraces implements spanstore.Reader
+func (c *GRPCClient) FindTraces(query *spanstore.TraceQueryParameters) ([]*model.Trace, error) {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	stream, err := c.readerClient.FindTraces(ctx, &storage_v1.FindTracesRequest{
+		Query: &storage_v1.TraceQueryParameters{
+			ServiceName:   query.ServiceName,
+			OperationName: query.OperationName,
+			Tags:          query.Tags,
+			StartTimeMin:  query.StartTimeMin,
+			StartTimeMax:  query.StartTimeMax,
+			DurationMin:   query.DurationMin,
+			DurationMax:   query.DurationMax,
+			NumTraces:     int32(query.NumTraces),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var traces []*model.Trace
+	var trace *model.Trace
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return traces, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(chunk.Spans) == 0 {
+			continue
+		}
+		// the chunks of a trace are sent one after another
+		if trace == nil || trace.Spans[0].TraceID != chunk.Spans[0].TraceID {
+			trace = &model.Trace{}
+			traces = append(traces, trace)
+		}
+		trace.Spans = append(trace.Spans, chunk.Spans...)
+	}
+}
+
+// GetDependencies implements dependencystore.Reader
+func (c *GRPCClient) GetDependencies(endTs time.Time, lookback time.Duration) ([]model.DependencyLink, error) {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	res, err := c.depsReaderClient.GetDependencies(ctx, &storage_v1.GetDependenciesRequest{
+		EndTime:  endTs,
+		Lookback: lookback,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Dependencies, nil
+}
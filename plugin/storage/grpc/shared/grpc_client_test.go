@@ -0,0 +1,222 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/testutils"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	depsmocks "github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+	spanstoremocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
+)
+
+var errStorage = errors.New("storage error")
+
+type mockPlugin struct {
+	spanReader *spanstoremocks.Reader
+	spanWriter *spanstoremocks.Writer
+	depsReader *depsmocks.Reader
+}
+
+func (p *mockPlugin) SpanReader() spanstore.Reader {
+	return p.spanReader
+}
+
+func (p *mockPlugin) SpanWriter() spanstore.Writer {
+	return p.spanWriter
+}
+
+func (p *mockPlugin) DependencyReader() dependencystore.Reader {
+	return p.depsReader
+}
+
+func withMockPlugin(t *testing.T, options ClientOptions, logger *zap.Logger, test func(p *mockPlugin, client *GRPCClient)) {
+	p := &mockPlugin{
+		spanReader: &spanstoremocks.Reader{},
+		spanWriter: &spanstoremocks.Writer{},
+		depsReader: &depsmocks.Reader{},
+	}
+	withServedPlugin(t, p, options, logger, func(client *GRPCClient) {
+		test(p, client)
+	})
+}
+
+func makeTrace(traceID model.TraceID, numSpans int) *model.Trace {
+	trace := &model.Trace{}
+	for i := 0; i < numSpans; i++ {
+		trace.Spans = append(trace.Spans, &model.Span{
+			TraceID:       traceID,
+			SpanID:        model.SpanID(i + 1),
+			OperationName: "op",
+			Process:       &model.Process{ServiceName: "svc"},
+		})
+	}
+	return trace
+}
+
+var mockTrace = makeTrace(model.NewTraceID(0, 1), 1)
+
+func TestGRPCClientWriteSpan(t *testing.T) {
+	logger, buf := testutils.NewLogger()
+	withMockPlugin(t, ClientOptions{BulkSize: 2, BulkFlushInterval: time.Hour}, logger, func(p *mockPlugin, client *GRPCClient) {
+		written := make(chan struct{}, 3)
+		p.spanWriter.On("WriteSpan", mock.AnythingOfType("*model.Span")).Return(nil).Twice().
+			Run(func(mock.Arguments) { written <- struct{}{} })
+		p.spanWriter.On("WriteSpan", mock.AnythingOfType("*model.Span")).Return(errStorage).Once()
+
+		// a full bulk is written without waiting for the flush interval
+		assert.NoError(t, client.WriteSpan(mockTrace.Spans[0]))
+		assert.NoError(t, client.WriteSpan(mockTrace.Spans[0]))
+		for i := 0; i < 2; i++ {
+			select {
+			case <-written:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the bulk was not written")
+			}
+		}
+
+		// Close writes the remaining spans, the errors are logged
+		assert.NoError(t, client.WriteSpan(mockTrace.Spans[0]))
+		require.NoError(t, client.Close())
+		p.spanWriter.AssertExpectations(t)
+		assert.Contains(t, buf.String(), "Failed to write spans to the storage plugin")
+		assert.Contains(t, buf.String(), errStorage.Error())
+	})
+}
+
+func TestGRPCClientFlushInterval(t *testing.T) {
+	withMockPlugin(t, ClientOptions{BulkFlushInterval: time.Millisecond}, zap.NewNop(), func(p *mockPlugin, client *GRPCClient) {
+		written := make(chan struct{}, 1)
+		p.spanWriter.On("WriteSpan", mock.AnythingOfType("*model.Span")).Return(nil).Once().
+			Run(func(mock.Arguments) { written <- struct{}{} })
+		assert.NoError(t, client.WriteSpan(mockTrace.Spans[0]))
+		select {
+		case <-written:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the span was not flushed")
+		}
+	})
+}
+
+func TestGRPCClientTimeout(t *testing.T) {
+	withMockPlugin(t, ClientOptions{Timeout: time.Millisecond}, zap.NewNop(), func(p *mockPlugin, client *GRPCClient) {
+		p.spanReader.On("GetServices").Return([]string{"svc"}, nil).Once().
+			Run(func(mock.Arguments) { time.Sleep(100 * time.Millisecond) })
+		_, err := client.GetServices()
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+}
+
+func TestGRPCClientGetTrace(t *testing.T) {
+	traceID := mockTrace.Spans[0].TraceID
+	largeTrace := makeTrace(traceID, 2*spansPerChunk+1)
+	withMockPlugin(t, ClientOptions{}, zap.NewNop(), func(p *mockPlugin, client *GRPCClient) {
+		p.spanReader.On("GetTrace", traceID).Return(mockTrace, nil).Once()
+		trace, err := client.GetTrace(traceID)
+		require.NoError(t, err)
+		assert.Equal(t, mockTrace, trace)
+
+		p.spanReader.On("GetTrace", traceID).Return(largeTrace, nil).Once()
+		trace, err = client.GetTrace(traceID)
+		require.NoError(t, err)
+		assert.Equal(t, largeTrace, trace)
+
+		p.spanReader.On("GetTrace", traceID).Return(nil, spanstore.ErrTraceNotFound).Once()
+		_, err = client.GetTrace(traceID)
+		assert.Equal(t, spanstore.ErrTraceNotFound, err)
+
+		p.spanReader.On("GetTrace", traceID).Return(nil, errStorage).Once()
+		_, err = client.GetTrace(traceID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errStorage.Error())
+	})
+}
+
+func TestGRPCClientGetServicesAndOperations(t *testing.T) {
+	withMockPlugin(t, ClientOptions{}, zap.NewNop(), func(p *mockPlugin, client *GRPCClient) {
+		p.spanReader.On("GetServices").Return([]string{"svc"}, nil).Once()
+		services, err := client.GetServices()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"svc"}, services)
+
+		p.spanReader.On("GetServices").Return(nil, errStorage).Once()
+		_, err = client.GetServices()
+		assert.Error(t, err)
+
+		p.spanReader.On("GetOperations", "svc").Return([]string{"op"}, nil).Once()
+		operations, err := client.GetOperations("svc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"op"}, operations)
+
+		p.spanReader.On("GetOperations", "svc").Return(nil, errStorage).Once()
+		_, err = client.GetOperations("svc")
+		assert.Error(t, err)
+	})
+}
+
+func TestGRPCClientFindTraces(t *testing.T) {
+	query := &spanstore.TraceQueryParameters{
+		ServiceName:   "svc",
+		OperationName: "op",
+		Tags:          map[string]string{"k": "v"},
+		StartTimeMin:  time.Unix(100, 0).UTC(),
+		StartTimeMax:  time.Unix(200, 0).UTC(),
+		DurationMin:   time.Second,
+		DurationMax:   time.Minute,
+		NumTraces:     10,
+	}
+	traces := []*model.Trace{
+		makeTrace(model.NewTraceID(0, 1), 1),
+		makeTrace(model.NewTraceID(0, 2), spansPerChunk+1),
+		makeTrace(model.NewTraceID(0, 3), 2),
+	}
+	withMockPlugin(t, ClientOptions{}, zap.NewNop(), func(p *mockPlugin, client *GRPCClient) {
+		p.spanReader.On("FindTraces", query).Return(traces, nil).Once()
+		found, err := client.FindTraces(query)
+		require.NoError(t, err)
+		assert.Equal(t, traces, found)
+
+		p.spanReader.On("FindTraces", mock.AnythingOfType("*spanstore.TraceQueryParameters")).Return(nil, errStorage).Once()
+		_, err = client.FindTraces(&spanstore.TraceQueryParameters{})
+		assert.Error(t, err)
+	})
+}
+
+func TestGRPCClientGetDependencies(t *testing.T) {
+	endTs := time.Unix(1000, 0).UTC()
+	links := []model.DependencyLink{{Parent: "a", Child: "b", CallCount: 3}}
+	withMockPlugin(t, ClientOptions{}, zap.NewNop(), func(p *mockPlugin, client *GRPCClient) {
+		p.depsReader.On("GetDependencies", endTs, time.Hour).Return(links, nil).Once()
+		dependencies, err := client.GetDependencies(endTs, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, links, dependencies)
+
+		p.depsReader.On("GetDependencies", endTs, time.Hour).Return(nil, errStorage).Once()
+		_, err = client.GetDependencies(endTs, time.Hour)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/plugin/storage/grpc/proto/storage_v1"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// spansPerChunk is the maximum number of spans sent in one SpansResponseChunk,
+// so that large traces do not exceed the maximum gRPC message size
+const spansPerChunk = 1000
+
+// grpcServer exposes a StoragePlugin as the gRPC services of the storage plugin protocol
+type grpcServer struct {
+	impl StoragePlugin
+}
+
+// WriteSpans implements storage_v1.SpanWriterPluginServer
+func (s *grpcServer) WriteSpans(ctx context.Context, r *storage_v1.WriteSpansRequest) (*storage_v1.WriteSpansResponse, error) {
+	writer := s.impl.SpanWriter()
+	for _, span := range r.Spans {
+		if err := writer.WriteSpan(span); err != nil {
+			return nil, err
+		}
+	}
+	return &storage_v1.WriteSpansResponse{}, nil
+}
+
+// GetTrace implements storage_v1.SpanReaderPluginServer
+func (s *grpcServer) GetTrace(r *storage_v1.GetTraceRequest, stream storage_v1.SpanReaderPlugin_GetTraceServer) error {
+	trace, err := s.impl.SpanReader().GetTrace(r.TraceID)
+	if err == spanstore.ErrTraceNotFound {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return sendSpans(trace.Spans, stream.Send)
+}
+
+// FindTraces implements storage_v1.SpanReaderPluginServer
+func (s *grpcServer) FindTraces(r *storage_v1.FindTracesRequest, stream storage_v1.SpanReaderPlugin_FindTracesServer) error {
+	query := &spanstore.TraceQueryParameters{}
+	if q := r.Query; q != nil {
+		query = &spanstore.TraceQueryParameters{
+			ServiceName:   q.ServiceName,
+			OperationName: q.OperationName,
+			Tags:          q.Tags,
+			StartTimeMin:  q.StartTimeMin,
+			StartTimeMax:  q.StartTimeMax,
+			DurationMin:   q.DurationMin,
+			DurationMax:   q.DurationMax,
+			NumTraces:     int(q.NumTraces),
+		}
+	}
+	traces, err := s.impl.SpanReader().FindTraces(query)
+	if err != nil {
+		return err
+	}
+	for _, trace := range traces {
+		if err := sendSpans(trace.Spans, stream.Send); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendSpans sends the spans of a trace in chunks of at most spansPerChunk spans
+func sendSpans(spans []*model.Span, send func(*storage_v1.SpansResponseChunk) error) error {
+	for len(spans) > 0 {
+		n := len(spans)
+		if n > spansPerChunk {
+			n = spansPerChunk
+		}
+		if err := send(&storage_v1.SpansResponseChunk{Spans: spans[:n]}); err != nil {
+			return err
+		}
+		spans = spans[n:]
+	}
+	return nil
+}
+
+// GetServices implements storage_v1.SpanReaderPluginServer
+func (s *grpcServer) GetServices(ctx context.Context, r *storage_v1.GetServicesRequest) (*storage_v1.GetServicesResponse, error) {
+	services, err := s.impl.SpanReader().GetServices()
+	if err != nil {
+		return nil, err
+	}
+	return &storage_v1.GetServicesResponse{Services: services}, nil
+}
+
+// GetOperations implements storage_v1.SpanReaderPluginServer
+func (s *grpcServer) GetOperations(ctx context.Context, r *storage_v1.GetOperationsRequest) (*storage_v1.GetOperationsResponse, error) {
+	operations, err := s.impl.SpanReader().GetOperations(r.Service)
+	if err != nil {
+		return nil, err
+	}
+	return &storage_v1.GetOperationsResponse{Operations: operations}, nil
+}
+
+// GetDependencies implements storage_v1.DependenciesReaderPluginServer
+func (s *grpcServer) GetDependencies(ctx context.Context, r *storage_v1.GetDependenciesRequest) (*storage_v1.GetDependenciesResponse, error) {
+	dependencies, err := s.impl.DependencyReader().GetDependencies(r.EndTime, r.Lookback)
+	if err != nil {
+		return nil, err
+	}
+	return &storage_v1.GetDependenciesResponse{Dependencies: dependencies}, nil
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// StoragePlugin is the interface implemented by the storage plugins. The span and dependency
+// operations are exposed to jaeger over gRPC with the services of plugin/storage/grpc/proto/storage.proto.
+type StoragePlugin interface {
+	SpanReader() spanstore.Reader
+	SpanWriter() spanstore.Writer
+	DependencyReader() dependencystore.Reader
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/jaegertracing/jaeger/plugin/storage/grpc/proto/storage_v1"
+)
+
+const (
+	// ProtocolVersion is the version of the plugin protocol, incremented on incompatible changes
+	ProtocolVersion = 1
+
+	handshakePrefix = "jaeger-storage-plugin"
+	socketName      = "plugin.sock"
+	closeTimeout    = 5 * time.Second
+)
+
+// Serve is called by the storage plugins from their main function. It starts a gRPC server on a unix socket
+// that only the user running the plugin can access, and announces the path of the socket to jaeger by writing
+// the handshake line to stdout. Serve returns when jaeger closes the stdin of the plugin, which happens when
+// jaeger exits.
+func Serve(impl StoragePlugin) error {
+	return serve(impl, os.Stdout, os.Stdin)
+}
+
+func serve(impl StoragePlugin, stdout io.Writer, stdin io.Reader) error {
+	// TempDir creates the directory with 0700 permissions, so no other user can reach the socket
+	// before its own permissions are restricted
+	dir, err := ioutil.TempDir("", handshakePrefix)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create the socket directory")
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, socketName)
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		return errors.Wrap(err, "Failed to listen")
+	}
+	if err := os.Chmod(socket, 0600); err != nil {
+		lis.Close()
+		return errors.Wrap(err, "Failed to restrict the socket permissions")
+	}
+	server := grpc.NewServer()
+	adapter := &grpcServer{impl: impl}
+	storage_v1.RegisterSpanWriterPluginServer(server, adapter)
+	storage_v1.RegisterSpanReaderPluginServer(server, adapter)
+	storage_v1.RegisterDependenciesReaderPluginServer(server, adapter)
+
+	if _, err := fmt.Fprintf(stdout, "%s|%d|%s\n", handshakePrefix, ProtocolVersion, socket); err != nil {
+		lis.Close()
+		return errors.Wrap(err, "Failed to write the handshake")
+	}
+	go func() {
+		io.Copy(ioutil.Discard, stdin)
+		server.Stop()
+	}()
+	return server.Serve(lis)
+}
+
+// Plugin is a running storage plugin process
+type Plugin struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	conn   *grpc.ClientConn
+	client *GRPCClient
+}
+
+// Launch starts the plugin binary and connects to it. The plugin must announce its address
+// within startTimeout.
+func Launch(binary string, args []string, startTimeout time.Duration, clientOptions ClientOptions, logger *zap.Logger) (*Plugin, error) {
+	cmd := exec.Command(binary, args...)
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "Failed to start the storage plugin")
+	}
+	p := &Plugin{cmd: cmd, stdin: stdin}
+
+	addr, err := readHandshake(stdout, startTimeout)
+	if err != nil {
+		p.kill()
+		return nil, err
+	}
+	logger.Info("Storage plugin started", zap.String("binary", binary), zap.String("socket", addr))
+
+	conn, err := dial(addr)
+	if err != nil {
+		p.kill()
+		return nil, errors.Wrap(err, "Failed to connect to the storage plugin")
+	}
+	p.conn = conn
+	p.client = NewGRPCClient(conn, clientOptions, logger)
+	return p, nil
+}
+
+// dial connects to the gRPC server of the plugin listening on the unix socket
+func dial(socket string) (*grpc.ClientConn, error) {
+	return grpc.Dial(socket, grpc.WithInsecure(), grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("unix", addr, timeout)
+	}))
+}
+
+// Client returns the client for the storage operations of the plugin
+func (p *Plugin) Client() *GRPCClient {
+	return p.client
+}
+
+// Close writes the buffered spans, disconnects from the plugin and waits for the plugin process to exit
+func (p *Plugin) Close() error {
+	p.client.Close()
+	p.conn.Close()
+	p.stdin.Close()
+	done := make(chan error, 1)
+	go func() { done <- p.cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(closeTimeout):
+		p.cmd.Process.Kill()
+		return <-done
+	}
+}
+
+func (p *Plugin) kill() {
+	p.cmd.Process.Kill()
+	p.cmd.Wait()
+}
+
+// readHandshake reads the first line written by the plugin and returns the socket of its gRPC server.
+// The rest of the plugin output is discarded.
+func readHandshake(stdout io.Reader, timeout time.Duration) (string, error) {
+	lines := make(chan string, 1)
+	reader := bufio.NewReader(stdout)
+	go func() {
+		line, _ := reader.ReadString('\n')
+		lines <- line
+		io.Copy(ioutil.Discard, reader)
+	}()
+	select {
+	case line := <-lines:
+		return parseHandshake(strings.TrimSpace(line))
+	case <-time.After(timeout):
+		return "", errors.New("Timed out waiting for the storage plugin handshake")
+	}
+}
+
+func parseHandshake(line string) (string, error) {
+	parts := strings.Split(line, "|")
+	if len(parts) != 3 || parts[0] != handshakePrefix {
+		return "", fmt.Errorf("Invalid storage plugin handshake %q", line)
+	}
+	if parts[1] != fmt.Sprint(ProtocolVersion) {
+		return "", fmt.Errorf("Unsupported storage plugin protocol version %s, expecting %d", parts[1], ProtocolVersion)
+	}
+	return parts[2], nil
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/plugin/storage/memory"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// helperPluginEnvVar makes the test binary act as a storage plugin, see TestMain
+const helperPluginEnvVar = "JAEGER_TEST_STORAGE_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(helperPluginEnvVar) != "" {
+		if err := Serve(newMemoryPlugin()); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+type memoryPlugin struct {
+	store *memory.Store
+}
+
+func newMemoryPlugin() *memoryPlugin {
+	return &memoryPlugin{store: memory.NewStore()}
+}
+
+func (p *memoryPlugin) SpanReader() spanstore.Reader {
+	return p.store
+}
+
+func (p *memoryPlugin) SpanWriter() spanstore.Writer {
+	return p.store
+}
+
+func (p *memoryPlugin) DependencyReader() dependencystore.Reader {
+	return p.store
+}
+
+// withServedPlugin serves the plugin in-process and passes a client connected to it to the test
+func withServedPlugin(t *testing.T, plugin StoragePlugin, options ClientOptions, logger *zap.Logger, test func(client *GRPCClient)) {
+	stdoutReader, stdoutWriter := io.Pipe()
+	stdinReader, stdinWriter := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(plugin, stdoutWriter, stdinReader)
+	}()
+
+	socket, err := readHandshake(stdoutReader, time.Second)
+	require.NoError(t, err)
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	conn, err := dial(socket)
+	require.NoError(t, err)
+
+	client := NewGRPCClient(conn, options, logger)
+	test(client)
+
+	require.NoError(t, client.Close())
+	conn.Close()
+	stdinWriter.Close()
+	assert.NoError(t, <-served)
+	_, err = os.Stat(socket)
+	assert.True(t, os.IsNotExist(err), "the socket is removed when the plugin exits")
+}
+
+func TestServeStopsOnStdinClose(t *testing.T) {
+	withServedPlugin(t, newMemoryPlugin(), ClientOptions{}, zap.NewNop(), func(client *GRPCClient) {
+		services, err := client.GetServices()
+		require.NoError(t, err)
+		assert.Empty(t, services)
+	})
+}
+
+func TestServeHandshakeError(t *testing.T) {
+	stdout := &failingWriter{}
+	err := serve(newMemoryPlugin(), stdout, strings.NewReader(""))
+	assert.EqualError(t, err, "Failed to write the handshake: io: read/write on closed pipe")
+}
+
+type failingWriter struct{}
+
+func (*failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestReadHandshake(t *testing.T) {
+	socket, err := readHandshake(strings.NewReader("jaeger-storage-plugin|1|/tmp/plugin.sock\nmore output\n"), time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/plugin.sock", socket)
+
+	_, err = readHandshake(strings.NewReader("hello\n"), time.Second)
+	assert.EqualError(t, err, `Invalid storage plugin handshake "hello"`)
+
+	_, err = readHandshake(strings.NewReader("jaeger-storage-plugin|2|/tmp/plugin.sock\n"), time.Second)
+	assert.EqualError(t, err, "Unsupported storage plugin protocol version 2, expecting 1")
+
+	blocked, _ := io.Pipe()
+	_, err = readHandshake(blocked, time.Millisecond)
+	assert.EqualError(t, err, "Timed out waiting for the storage plugin handshake")
+}
+
+func TestLaunch(t *testing.T) {
+	os.Setenv(helperPluginEnvVar, "1")
+	defer os.Unsetenv(helperPluginEnvVar)
+
+	plugin, err := Launch(os.Args[0], nil, 10*time.Second, ClientOptions{BulkFlushInterval: time.Millisecond}, zap.NewNop())
+	require.NoError(t, err)
+
+	span := &model.Span{
+		TraceID:       model.NewTraceID(0, 1),
+		SpanID:        model.SpanID(2),
+		OperationName: "op",
+		Process:       &model.Process{ServiceName: "svc"},
+	}
+	require.NoError(t, plugin.Client().WriteSpan(span))
+	for i := 0; i < 100; i++ {
+		if _, err = plugin.Client().GetTrace(span.TraceID); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	services, err := plugin.Client().GetServices()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc"}, services)
+
+	_, err = plugin.Client().GetTrace(model.NewTraceID(0, 42))
+	assert.Equal(t, spanstore.ErrTraceNotFound, err, "the memory store must report missing traces as such")
+
+	assert.NoError(t, plugin.Close())
+}
+
+func TestLaunchErrors(t *testing.T) {
+	_, err := Launch("/does/not/exist", nil, time.Second, ClientOptions{}, zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to start the storage plugin")
+
+	_, err = Launch("/bin/echo", []string{"not a handshake"}, time.Second, ClientOptions{}, zap.NewNop())
+	assert.EqualError(t, err, `Invalid storage plugin handshake "not a handshake"`)
+}
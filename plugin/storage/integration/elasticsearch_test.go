@@ -64,7 +64,7 @@ func (s *ESStorageIntegration) initializeES() error {
 
 	s.bulkProcessor, _ = s.client.BulkProcessor().Do(context.Background())
 	client := es.WrapESClient(s.client, s.bulkProcessor)
-	dependencyStore := dependencystore.NewDependencyStore(client, s.logger)
+	dependencyStore := dependencystore.NewDependencyStore(client, s.logger, "")
 	s.DependencyReader = dependencyStore
 	s.DependencyWriter = dependencyStore
 	s.initSpanstore()
@@ -83,8 +83,17 @@ func (s *ESStorageIntegration) esCleanUp() error {
 func (s *ESStorageIntegration) initSpanstore() {
 	bp, _ := s.client.BulkProcessor().BulkActions(1).FlushInterval(time.Nanosecond).Do(context.Background())
 	client := es.WrapESClient(s.client, bp)
-	s.SpanWriter = spanstore.NewSpanWriter(client, s.logger, metrics.NullFactory, 0, 0)
-	s.SpanReader = spanstore.NewSpanReader(client, s.logger, 72*time.Hour, metrics.NullFactory)
+	s.SpanWriter = spanstore.NewSpanWriter(spanstore.SpanWriterParams{
+		Client:         client,
+		Logger:         s.logger,
+		MetricsFactory: metrics.NullFactory,
+	})
+	s.SpanReader = spanstore.NewSpanReader(spanstore.SpanReaderParams{
+		Client:         client,
+		Logger:         s.logger,
+		MetricsFactory: metrics.NullFactory,
+		MaxSpanAge:     72 * time.Hour,
+	})
 }
 
 func (s *ESStorageIntegration) esRefresh() error {
@@ -62,18 +62,25 @@ func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger)
 	logger.Info("Kafka factory",
 		zap.Any("producer builder", f.Builder),
 		zap.Any("topic", f.options.topic))
+	if f.options.protocolVersion != "" {
+		version, err := sarama.ParseKafkaVersion(f.options.protocolVersion)
+		if err != nil {
+			return err
+		}
+		f.options.config.ProtocolVersion = version
+	}
 	p, err := f.NewProducer()
 	if err != nil {
 		return err
 	}
 	f.producer = p
 	switch f.options.encoding {
-	case encodingProto:
+	case EncodingProto:
 		f.marshaller = newProtobufMarshaller()
-	case encodingJSON:
+	case EncodingJSON:
 		f.marshaller = newJSONMarshaller()
 	default:
-		return errors.New("kafka encoding is not one of '" + encodingJSON + "' or '" + encodingProto + "'")
+		return errors.New("kafka encoding is not one of '" + EncodingJSON + "' or '" + EncodingProto + "'")
 	}
 	return nil
 }
@@ -85,7 +92,7 @@ func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
 
 // CreateSpanWriter implements storage.Factory
 func (f *Factory) CreateSpanWriter() (spanstore.Writer, error) {
-	return NewSpanWriter(f.producer, f.marshaller, f.options.topic, f.metricsFactory), nil
+	return NewSpanWriter(f.producer, f.marshaller, f.options.encoding, f.options.topic, f.options.config.ProtocolVersion, f.metricsFactory), nil
 }
 
 // CreateDependencyReader implements storage.Factory
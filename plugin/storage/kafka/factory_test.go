@@ -93,6 +93,39 @@ func TestKafkaFactoryEncoding(t *testing.T) {
 	}
 }
 
+func TestKafkaFactoryProtocolVersion(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{"--kafka.protocol-version=1.0.0"})
+	f.InitFromViper(v)
+
+	f.Builder = &mockProducerBuilder{t: t}
+	assert.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	assert.Equal(t, sarama.V1_0_0_0, f.options.config.ProtocolVersion)
+}
+
+func TestKafkaFactoryDefaultProtocolVersion(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{})
+	f.InitFromViper(v)
+
+	f.Builder = &mockProducerBuilder{t: t}
+	assert.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	// the sarama default is used, and the spans are sent without headers
+	assert.Equal(t, sarama.KafkaVersion{}, f.options.config.ProtocolVersion)
+}
+
+func TestKafkaFactoryProtocolVersionErr(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{"--kafka.protocol-version=bad-input"})
+	f.InitFromViper(v)
+
+	f.Builder = &mockProducerBuilder{t: t}
+	assert.Error(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+}
+
 func TestKafkaFactoryMarshallerErr(t *testing.T) {
 	f := NewFactory()
 	v, command := config.Viperize(f.AddFlags)
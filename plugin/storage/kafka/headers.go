@@ -0,0 +1,73 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+// Headers describing the spans sent to kafka, only available with kafka 0.11.0.0 or later
+const (
+	HeaderEncoding         = "jaeger-encoding"
+	HeaderSchemaVersion    = "jaeger-schema-version"
+	HeaderServiceName      = "jaeger-service-name"
+	HeaderSpanFormat       = "jaeger-span-format"
+	HeaderProduceTimestamp = "jaeger-produce-timestamp"
+
+	// SchemaVersion is the version of the message schema sent by this version of the writer
+	SchemaVersion = "1"
+	// SpanFormatJaeger indicates the messages contain a Jaeger model.Span
+	SpanFormatJaeger = "jaeger"
+)
+
+// NewUnmarshaller creates the Unmarshaller of encoding
+func NewUnmarshaller(encoding string) (Unmarshaller, error) {
+	switch encoding {
+	case EncodingProto:
+		return NewProtobufUnmarshaller(), nil
+	case EncodingJSON:
+		return NewJSONUnmarshaller(), nil
+	default:
+		return nil, fmt.Errorf(`encoding '%s' not recognised, use one of ("%s" or "%s")`,
+			encoding, EncodingProto, EncodingJSON)
+	}
+}
+
+// DetectUnmarshaller returns the Unmarshaller of the encoding declared in the headers of a message.
+// Messages without an encoding header, e.g. sent by earlier versions of the writer, are decoded with defaultUnmarshaller.
+func DetectUnmarshaller(headers []*sarama.RecordHeader, defaultUnmarshaller Unmarshaller) (Unmarshaller, error) {
+	if version, ok := headerValue(headers, HeaderSchemaVersion); ok && version != SchemaVersion {
+		return nil, fmt.Errorf("unsupported schema version '%s'", version)
+	}
+	if format, ok := headerValue(headers, HeaderSpanFormat); ok && format != SpanFormatJaeger {
+		return nil, fmt.Errorf("unsupported span format '%s'", format)
+	}
+	encoding, ok := headerValue(headers, HeaderEncoding)
+	if !ok {
+		return defaultUnmarshaller, nil
+	}
+	return NewUnmarshaller(encoding)
+}
+
+func headerValue(headers []*sarama.RecordHeader, key string) (string, bool) {
+	for _, header := range headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func header(key, value string) *sarama.RecordHeader {
+	return &sarama.RecordHeader{Key: []byte(key), Value: []byte(value)}
+}
+
+func TestNewUnmarshaller(t *testing.T) {
+	u, err := NewUnmarshaller(EncodingProto)
+	require.NoError(t, err)
+	assert.IsType(t, &ProtobufUnmarshaller{}, u)
+
+	u, err = NewUnmarshaller(EncodingJSON)
+	require.NoError(t, err)
+	assert.IsType(t, &JSONUnmarshaller{}, u)
+
+	_, err = NewUnmarshaller("thrift")
+	assert.EqualError(t, err, `encoding 'thrift' not recognised, use one of ("protobuf" or "json")`)
+}
+
+func TestDetectUnmarshaller(t *testing.T) {
+	defaultUnmarshaller := NewProtobufUnmarshaller()
+	testCases := []struct {
+		name     string
+		headers  []*sarama.RecordHeader
+		expected Unmarshaller
+		err      string
+	}{
+		{
+			name:     "no headers",
+			expected: defaultUnmarshaller,
+		},
+		{
+			name:     "no encoding header",
+			headers:  []*sarama.RecordHeader{header(HeaderServiceName, "service"), nil},
+			expected: defaultUnmarshaller,
+		},
+		{
+			name: "json",
+			headers: []*sarama.RecordHeader{
+				header(HeaderEncoding, EncodingJSON),
+				header(HeaderSchemaVersion, SchemaVersion),
+				header(HeaderSpanFormat, SpanFormatJaeger),
+			},
+			expected: NewJSONUnmarshaller(),
+		},
+		{
+			name:    "unknown encoding",
+			headers: []*sarama.RecordHeader{header(HeaderEncoding, "thrift")},
+			err:     `encoding 'thrift' not recognised, use one of ("protobuf" or "json")`,
+		},
+		{
+			name:    "unknown schema version",
+			headers: []*sarama.RecordHeader{header(HeaderEncoding, EncodingJSON), header(HeaderSchemaVersion, "2")},
+			err:     "unsupported schema version '2'",
+		},
+		{
+			name:    "unknown span format",
+			headers: []*sarama.RecordHeader{header(HeaderEncoding, EncodingJSON), header(HeaderSpanFormat, "zipkin")},
+			err:     "unsupported span format 'zipkin'",
+		},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.name, func(t *testing.T) {
+			u, err := DetectUnmarshaller(testCase.headers, defaultUnmarshaller)
+			if testCase.err != "" {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, u)
+		})
+	}
+}
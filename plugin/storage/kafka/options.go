@@ -21,28 +21,40 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/jaegertracing/jaeger/pkg/kafka/auth"
 	"github.com/jaegertracing/jaeger/pkg/kafka/producer"
 )
 
 const (
-	configPrefix   = "kafka"
-	suffixBrokers  = ".brokers"
-	suffixTopic    = ".topic"
-	suffixEncoding = ".encoding"
+	configPrefix          = "kafka"
+	suffixBrokers         = ".brokers"
+	suffixTopic           = ".topic"
+	suffixEncoding        = ".encoding"
+	suffixCompression     = ".compression"
+	suffixRequiredAcks    = ".required-acks"
+	suffixBatchSize       = ".batch-size"
+	suffixBatchLinger     = ".batch-linger"
+	suffixMaxMessageBytes = ".max-message-bytes"
+	suffixProtocolVersion = ".protocol-version"
 
-	encodingJSON  = "json"
-	encodingProto = "protobuf"
+	// EncodingJSON indicates spans are encoded as a json byte array
+	EncodingJSON = "json"
+	// EncodingProto indicates spans are encoded as a protobuf byte array
+	EncodingProto = "protobuf"
 
-	defaultBroker   = "127.0.0.1:9092"
-	defaultTopic    = "jaeger-spans"
-	defaultEncoding = encodingProto
+	defaultBroker       = "127.0.0.1:9092"
+	defaultTopic        = "jaeger-spans"
+	defaultEncoding     = EncodingProto
+	defaultCompression  = producer.CompressionNone
+	defaultRequiredAcks = producer.RequiredAcksLocal
 )
 
 // Options stores the configuration options for Kafka
 type Options struct {
-	config   producer.Configuration
-	topic    string
-	encoding string
+	config          producer.Configuration
+	topic           string
+	encoding        string
+	protocolVersion string
 }
 
 // AddFlags adds flags for Options
@@ -58,15 +70,50 @@ func (opt *Options) AddFlags(flagSet *flag.FlagSet) {
 	flagSet.String(
 		configPrefix+suffixEncoding,
 		defaultEncoding,
-		fmt.Sprintf(`Encoding of spans ("%s" or "%s") sent to kafka.`, encodingProto, encodingJSON),
+		fmt.Sprintf(`Encoding of spans ("%s" or "%s") sent to kafka.`, EncodingProto, EncodingJSON),
 	)
+	flagSet.String(
+		configPrefix+suffixCompression,
+		defaultCompression,
+		fmt.Sprintf(`Compression of the messages ("%s", "%s", "%s" or "%s") sent to kafka, "%s" requires a protocol version of 0.10.0.0 or later`,
+			producer.CompressionNone, producer.CompressionGzip, producer.CompressionSnappy, producer.CompressionLz4, producer.CompressionLz4))
+	flagSet.String(
+		configPrefix+suffixRequiredAcks,
+		defaultRequiredAcks,
+		fmt.Sprintf(`The acknowledgements required from the brokers ("%s", "%s" or "%s") before a message is considered sent`,
+			producer.RequiredAcksNone, producer.RequiredAcksLocal, producer.RequiredAcksAll))
+	flagSet.Int(
+		configPrefix+suffixBatchSize,
+		0,
+		"The number of bytes that triggers sending a batch of messages (0 uses the kafka client default)")
+	flagSet.Duration(
+		configPrefix+suffixBatchLinger,
+		0,
+		"How long messages are buffered before sending a batch (0 uses the kafka client default)")
+	flagSet.Int(
+		configPrefix+suffixMaxMessageBytes,
+		0,
+		"The maximum size of a message sent to kafka (0 uses the kafka client default)")
+	flagSet.String(
+		configPrefix+suffixProtocolVersion,
+		"",
+		"The kafka version of the brokers, e.g. '1.0.0' (the kafka client default is used if empty). "+
+			"Spans are sent with headers describing their encoding if it is 0.11.0.0 or later")
+	auth.AddFlags(configPrefix, flagSet)
 }
 
 // InitFromViper initializes Options with properties from viper
 func (opt *Options) InitFromViper(v *viper.Viper) {
 	opt.config = producer.Configuration{
-		Brokers: strings.Split(v.GetString(configPrefix+suffixBrokers), ","),
+		Brokers:         strings.Split(v.GetString(configPrefix+suffixBrokers), ","),
+		Compression:     v.GetString(configPrefix + suffixCompression),
+		RequiredAcks:    v.GetString(configPrefix + suffixRequiredAcks),
+		BatchSize:       v.GetInt(configPrefix + suffixBatchSize),
+		BatchLinger:     v.GetDuration(configPrefix + suffixBatchLinger),
+		MaxMessageBytes: v.GetInt(configPrefix + suffixMaxMessageBytes),
 	}
+	opt.config.Authentication.InitFromViper(configPrefix, v)
 	opt.topic = v.GetString(configPrefix + suffixTopic)
 	opt.encoding = v.GetString(configPrefix + suffixEncoding)
+	opt.protocolVersion = v.GetString(configPrefix + suffixProtocolVersion)
 }
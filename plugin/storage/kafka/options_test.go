@@ -16,6 +16,7 @@ package kafka
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -28,12 +29,32 @@ func TestOptionsWithFlags(t *testing.T) {
 	command.ParseFlags([]string{
 		"--kafka.topic=topic1",
 		"--kafka.brokers=127.0.0.1:9092,0.0.0:1234",
-		"--kafka.encoding=protobuf"})
+		"--kafka.encoding=protobuf",
+		"--kafka.compression=snappy",
+		"--kafka.required-acks=all",
+		"--kafka.batch-size=16384",
+		"--kafka.batch-linger=5ms",
+		"--kafka.max-message-bytes=2000000",
+		"--kafka.sasl.mechanism=PLAIN",
+		"--kafka.sasl.username=user",
+		"--kafka.sasl.password=secret",
+		"--kafka.tls=true",
+		"--kafka.protocol-version=1.0.0"})
 	opts.InitFromViper(v)
 
 	assert.Equal(t, "topic1", opts.topic)
 	assert.Equal(t, []string{"127.0.0.1:9092", "0.0.0:1234"}, opts.config.Brokers)
 	assert.Equal(t, "protobuf", opts.encoding)
+	assert.Equal(t, "snappy", opts.config.Compression)
+	assert.Equal(t, "all", opts.config.RequiredAcks)
+	assert.Equal(t, 16384, opts.config.BatchSize)
+	assert.Equal(t, 5*time.Millisecond, opts.config.BatchLinger)
+	assert.Equal(t, 2000000, opts.config.MaxMessageBytes)
+	assert.Equal(t, "PLAIN", opts.config.Authentication.SASLMechanism)
+	assert.Equal(t, "user", opts.config.Authentication.Username)
+	assert.Equal(t, "secret", opts.config.Authentication.Password)
+	assert.True(t, opts.config.Authentication.TLS.Enabled)
+	assert.Equal(t, "1.0.0", opts.protocolVersion)
 }
 
 func TestFlagDefaults(t *testing.T) {
@@ -45,4 +66,12 @@ func TestFlagDefaults(t *testing.T) {
 	assert.Equal(t, defaultTopic, opts.topic)
 	assert.Equal(t, []string{defaultBroker}, opts.config.Brokers)
 	assert.Equal(t, defaultEncoding, opts.encoding)
+	assert.Equal(t, defaultCompression, opts.config.Compression)
+	assert.Equal(t, defaultRequiredAcks, opts.config.RequiredAcks)
+	assert.Zero(t, opts.config.BatchSize)
+	assert.Zero(t, opts.config.BatchLinger)
+	assert.Zero(t, opts.config.MaxMessageBytes)
+	assert.Empty(t, opts.config.Authentication.SASLMechanism)
+	assert.False(t, opts.config.Authentication.TLS.Enabled)
+	assert.Empty(t, opts.protocolVersion)
 }
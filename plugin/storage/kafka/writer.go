@@ -15,6 +15,8 @@
 package kafka
 
 import (
+	"time"
+
 	"github.com/Shopify/sarama"
 	"github.com/uber/jaeger-lib/metrics"
 
@@ -28,14 +30,26 @@ type spanWriterMetrics struct {
 
 // SpanWriter writes spans to kafka. Implements spanstore.Writer
 type SpanWriter struct {
-	metrics    spanWriterMetrics
-	producer   sarama.AsyncProducer
-	marshaller Marshaller
-	topic      string
+	metrics         spanWriterMetrics
+	producer        sarama.AsyncProducer
+	marshaller      Marshaller
+	encoding        string
+	topic           string
+	protocolVersion sarama.KafkaVersion
+	timeNow         func() time.Time
 }
 
-// NewSpanWriter initiates and returns a new kafka spanwriter
-func NewSpanWriter(producer sarama.AsyncProducer, marshaller Marshaller, topic string, factory metrics.Factory) *SpanWriter {
+// NewSpanWriter initiates and returns a new kafka spanwriter, encoding is the name of the marshaller encoding
+// sent in the message headers. The headers are only sent if the protocolVersion of the producer is 0.11.0.0
+// or later, the brokers reject them otherwise.
+func NewSpanWriter(
+	producer sarama.AsyncProducer,
+	marshaller Marshaller,
+	encoding string,
+	topic string,
+	protocolVersion sarama.KafkaVersion,
+	factory metrics.Factory,
+) *SpanWriter {
 	writeMetrics := spanWriterMetrics{
 		SpansWrittenSuccess: factory.Counter("kafka_spans_written", map[string]string{"status": "success"}),
 		SpansWrittenFailure: factory.Counter("kafka_spans_written", map[string]string{"status": "failure"}),
@@ -53,10 +67,13 @@ func NewSpanWriter(producer sarama.AsyncProducer, marshaller Marshaller, topic s
 	}()
 
 	return &SpanWriter{
-		producer:   producer,
-		marshaller: marshaller,
-		topic:      topic,
-		metrics:    writeMetrics,
+		producer:        producer,
+		marshaller:      marshaller,
+		encoding:        encoding,
+		topic:           topic,
+		protocolVersion: protocolVersion,
+		timeNow:         time.Now,
+		metrics:         writeMetrics,
 	}
 }
 
@@ -70,14 +87,38 @@ func (w *SpanWriter) WriteSpan(span *model.Span) error {
 
 	// The AsyncProducer accepts messages on a channel and produces them asynchronously
 	// in the background as efficiently as possible
-	w.producer.Input() <- &sarama.ProducerMessage{
-		Topic: w.topic,
-		Key:   sarama.StringEncoder(span.TraceID.String()),
-		Value: sarama.ByteEncoder(spanBytes),
-	}
+	w.producer.Input() <- w.message(span, spanBytes, w.timeNow())
 	return nil
 }
 
+func (w *SpanWriter) message(span *model.Span, spanBytes []byte, now time.Time) *sarama.ProducerMessage {
+	message := &sarama.ProducerMessage{
+		Topic:     w.topic,
+		Key:       sarama.StringEncoder(span.TraceID.String()),
+		Value:     sarama.ByteEncoder(spanBytes),
+		Timestamp: now,
+	}
+	// the producer fails the messages with headers if the protocol version does not support them
+	if w.protocolVersion.IsAtLeast(sarama.V0_11_0_0) {
+		message.Headers = w.headers(span, now)
+	}
+	return message
+}
+
+func (w *SpanWriter) headers(span *model.Span, now time.Time) []sarama.RecordHeader {
+	serviceName := ""
+	if span.Process != nil {
+		serviceName = span.Process.ServiceName
+	}
+	return []sarama.RecordHeader{
+		{Key: []byte(HeaderEncoding), Value: []byte(w.encoding)},
+		{Key: []byte(HeaderSchemaVersion), Value: []byte(SchemaVersion)},
+		{Key: []byte(HeaderServiceName), Value: []byte(serviceName)},
+		{Key: []byte(HeaderSpanFormat), Value: []byte(SpanFormatJaeger)},
+		{Key: []byte(HeaderProduceTimestamp), Value: []byte(now.UTC().Format(time.RFC3339Nano))},
+	}
+}
+
 // Close closes SpanWriter by closing producer
 func (w *SpanWriter) Close() error {
 	return w.producer.Close()
@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/uber/jaeger-lib/metrics"
 	"github.com/uber/jaeger-lib/metrics/testutils"
 
@@ -78,6 +79,7 @@ func withSpanWriter(t *testing.T, fn func(span *model.Span, w *spanWriterTest))
 	serviceMetrics := metrics.NewLocalFactory(100 * time.Millisecond)
 	saramaConfig := sarama.NewConfig()
 	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Version = sarama.V0_11_0_0
 	producer := saramaMocks.NewAsyncProducer(t, saramaConfig)
 	marshaller := &mocks.Marshaller{}
 	marshaller.On("Marshal", mock.AnythingOfType("*model.Span")).Return([]byte{}, nil)
@@ -86,7 +88,7 @@ func withSpanWriter(t *testing.T, fn func(span *model.Span, w *spanWriterTest))
 		producer:       producer,
 		marshaller:     marshaller,
 		metricsFactory: serviceMetrics,
-		writer:         NewSpanWriter(producer, marshaller, "someTopic", serviceMetrics),
+		writer:         NewSpanWriter(producer, marshaller, EncodingProto, "someTopic", saramaConfig.Version, serviceMetrics),
 	}
 
 	fn(sampleSpan, writerTest)
@@ -184,3 +186,54 @@ func TestMarshallerErr(t *testing.T) {
 			})
 	})
 }
+
+func TestKafkaWriterHeaders(t *testing.T) {
+	withSpanWriter(t, func(span *model.Span, w *spanWriterTest) {
+		now := time.Date(2018, 10, 1, 12, 30, 0, 0, time.UTC)
+
+		headers := w.writer.headers(span, now)
+		assert.Equal(t, []sarama.RecordHeader{
+			{Key: []byte(HeaderEncoding), Value: []byte(EncodingProto)},
+			{Key: []byte(HeaderSchemaVersion), Value: []byte(SchemaVersion)},
+			{Key: []byte(HeaderServiceName), Value: []byte("someServiceName")},
+			{Key: []byte(HeaderSpanFormat), Value: []byte(SpanFormatJaeger)},
+			{Key: []byte(HeaderProduceTimestamp), Value: []byte("2018-10-01T12:30:00Z")},
+		}, headers)
+
+		headers = w.writer.headers(&model.Span{}, now)
+		assert.Equal(t, []byte{}, headers[2].Value)
+
+		w.writer.Close()
+	})
+}
+
+func TestKafkaWriterMessageHeaders(t *testing.T) {
+	testCases := []struct {
+		version sarama.KafkaVersion
+		headers bool
+	}{
+		{version: sarama.V0_10_2_0, headers: false},
+		{version: sarama.V0_11_0_0, headers: true},
+		{version: sarama.V1_0_0_0, headers: true},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.version.String(), func(t *testing.T) {
+			saramaConfig := sarama.NewConfig()
+			saramaConfig.Producer.Return.Successes = true
+			saramaConfig.Version = testCase.version
+			require.NoError(t, saramaConfig.Validate())
+			producer := saramaMocks.NewAsyncProducer(t, saramaConfig)
+			writer := NewSpanWriter(producer, &mocks.Marshaller{}, EncodingProto, "someTopic", saramaConfig.Version, metrics.NullFactory)
+
+			message := writer.message(sampleSpan, []byte{}, time.Now())
+			assert.Equal(t, "someTopic", message.Topic)
+			if testCase.headers {
+				assert.Len(t, message.Headers, 5)
+			} else {
+				assert.Nil(t, message.Headers)
+			}
+			writer.Close()
+		})
+	}
+}
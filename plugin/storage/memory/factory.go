@@ -51,7 +51,10 @@ func (f *Factory) InitFromViper(v *viper.Viper) {
 // Initialize implements storage.Factory
 func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger) error {
 	f.metricsFactory, f.logger = metricsFactory, logger
-	f.store = WithConfiguration(f.options.Configuration)
+	if err := f.options.Validate(); err != nil {
+		return err
+	}
+	f.store = newStore(f.options.Configuration, logger)
 	logger.Info("Memory storage configuration", zap.Any("configuration", f.store.config))
 	return nil
 }
@@ -70,3 +73,19 @@ func (f *Factory) CreateSpanWriter() (spanstore.Writer, error) {
 func (f *Factory) CreateDependencyReader() (dependencystore.Reader, error) {
 	return f.store, nil
 }
+
+// CreatePurger implements storage.PurgerFactory
+func (f *Factory) CreatePurger() (spanstore.Purger, error) {
+	return f.store, nil
+}
+
+// Close stops the background goroutines of the store and writes the final snapshot if configured
+func (f *Factory) Close() error {
+	if err := f.store.Close(); err != nil {
+		return err
+	}
+	if f.options.Configuration.SnapshotFile == "" {
+		return nil
+	}
+	return f.store.Snapshot(f.options.Configuration.SnapshotFile)
+}
@@ -25,6 +25,7 @@ import (
 )
 
 var _ storage.Factory = new(Factory)
+var _ storage.PurgerFactory = new(Factory)
 
 func TestMemoryStorageFactory(t *testing.T) {
 	f := NewFactory()
@@ -39,6 +40,9 @@ func TestMemoryStorageFactory(t *testing.T) {
 	depReader, err := f.CreateDependencyReader()
 	assert.NoError(t, err)
 	assert.Equal(t, f.store, depReader)
+	purger, err := f.CreatePurger()
+	assert.NoError(t, err)
+	assert.Equal(t, f.store, purger)
 }
 
 func TestWithConfiguration(t *testing.T) {
@@ -48,3 +52,11 @@ func TestWithConfiguration(t *testing.T) {
 	f.InitFromViper(v)
 	assert.Equal(t, f.options.Configuration.MaxTraces, 100)
 }
+
+func TestInitializeInvalidMaxAge(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{"--memory.max-age=-1h"})
+	f.InitFromViper(v)
+	assert.EqualError(t, f.Initialize(nil, zap.NewNop()), "memory.max-age must not be negative, got -1h0m0s")
+}
@@ -15,28 +15,37 @@
 package memory
 
 import (
-	"errors"
 	"sync"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/model/adjuster"
 	"github.com/jaegertracing/jaeger/pkg/memory/config"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
-var errTraceNotFound = errors.New("Trace was not found")
-
 // Store is an in-memory store of traces
 type Store struct {
 	sync.RWMutex
-	ids        []*model.TraceID
-	traces     map[model.TraceID]*model.Trace
-	services   map[string]struct{}
-	operations map[string]map[string]struct{}
+	ids []*model.TraceID
+	// positions maps the traces to their position in ids, if MaxTraces is set
+	positions map[model.TraceID]int
+	traces    map[model.TraceID]*model.Trace
+	// services and operations count the stored spans of every service and operation
+	services   map[string]int
+	operations map[string]map[string]int
 	deduper    adjuster.Adjuster
 	config     config.Configuration
 	index      int
+	logger     *zap.Logger
+	timeNow    func() time.Time
+	// stop terminates the background goroutines evicting old traces and taking snapshots, if any
+	stop chan struct{}
+	// snapshotLock prevents concurrent snapshots from writing to the same temporary file
+	snapshotLock sync.Mutex
 }
 
 // NewStore creates an unbounded in-memory store
@@ -44,16 +53,54 @@ func NewStore() *Store {
 	return WithConfiguration(config.Configuration{MaxTraces: 0})
 }
 
-// WithConfiguration creates a new in memory storage based on the given configuration
+// WithConfiguration creates a new in memory storage based on the given configuration.
+// If MaxAge or SnapshotFile is set, the store must be closed to stop its background goroutines.
 func WithConfiguration(configuration config.Configuration) *Store {
-	return &Store{
+	return newStore(configuration, zap.NewNop())
+}
+
+func newStore(configuration config.Configuration, logger *zap.Logger) *Store {
+	store := &Store{
 		ids:        make([]*model.TraceID, configuration.MaxTraces),
+		positions:  map[model.TraceID]int{},
 		traces:     map[model.TraceID]*model.Trace{},
-		services:   map[string]struct{}{},
-		operations: map[string]map[string]struct{}{},
+		services:   map[string]int{},
+		operations: map[string]map[string]int{},
 		deduper:    adjuster.SpanIDDeduper(),
 		config:     configuration,
+		logger:     logger,
+		timeNow:    time.Now,
+	}
+	if configuration.SnapshotFile != "" {
+		store.restoreSnapshot()
 	}
+	if configuration.MaxAge > 0 || (configuration.SnapshotFile != "" && configuration.SnapshotInterval > 0) {
+		store.stop = make(chan struct{})
+	}
+	if configuration.MaxAge > 0 {
+		go store.sweep(sweepInterval(configuration.MaxAge), store.stop)
+	}
+	if configuration.SnapshotFile != "" && configuration.SnapshotInterval > 0 {
+		go store.snapshotPeriodically(configuration.SnapshotInterval, store.stop)
+	}
+	return store
+}
+
+// minSweepInterval prevents very small max ages from making the store sweep continuously
+const minSweepInterval = time.Second
+
+// sweepInterval returns how often traces older than maxAge are evicted,
+// so that they are kept for at most 10% longer than maxAge, but at least once a minute
+// and at most once a second.
+func sweepInterval(maxAge time.Duration) time.Duration {
+	interval := maxAge / 10
+	if interval < minSweepInterval {
+		return minSweepInterval
+	}
+	if interval > time.Minute {
+		return time.Minute
+	}
+	return interval
 }
 
 // GetDependencies returns dependencies between services
@@ -61,46 +108,16 @@ func (m *Store) GetDependencies(endTs time.Time, lookback time.Duration) ([]mode
 	// deduper used below can modify the spans, so we take an exclusive lock
 	m.Lock()
 	defer m.Unlock()
-	deps := map[string]*model.DependencyLink{}
+	aggregator := dependencystore.NewAggregator()
 	startTs := endTs.Add(-1 * lookback)
 	for _, orig := range m.traces {
 		// SpanIDDeduper never returns an err
 		trace, _ := m.deduper.Adjust(orig)
 		if m.traceIsBetweenStartAndEnd(startTs, endTs, trace) {
-			for _, s := range trace.Spans {
-				parentSpan := m.findSpan(trace, s.ParentSpanID())
-				if parentSpan != nil {
-					if parentSpan.Process.ServiceName == s.Process.ServiceName {
-						continue
-					}
-					depKey := parentSpan.Process.ServiceName + "&&&" + s.Process.ServiceName
-					if _, ok := deps[depKey]; !ok {
-						deps[depKey] = &model.DependencyLink{
-							Parent:    parentSpan.Process.ServiceName,
-							Child:     s.Process.ServiceName,
-							CallCount: 1,
-						}
-					} else {
-						deps[depKey].CallCount++
-					}
-				}
-			}
-		}
-	}
-	retMe := make([]model.DependencyLink, 0, len(deps))
-	for _, dep := range deps {
-		retMe = append(retMe, *dep)
-	}
-	return retMe, nil
-}
-
-func (m *Store) findSpan(trace *model.Trace, spanID model.SpanID) *model.Span {
-	for _, s := range trace.Spans {
-		if s.SpanID == spanID {
-			return s
+			aggregator.AddTrace(trace)
 		}
 	}
-	return nil
+	return aggregator.Links(), nil
 }
 
 func (m *Store) traceIsBetweenStartAndEnd(startTs, endTs time.Time, trace *model.Trace) bool {
@@ -117,10 +134,10 @@ func (m *Store) WriteSpan(span *model.Span) error {
 	m.Lock()
 	defer m.Unlock()
 	if _, ok := m.operations[span.Process.ServiceName]; !ok {
-		m.operations[span.Process.ServiceName] = map[string]struct{}{}
+		m.operations[span.Process.ServiceName] = map[string]int{}
 	}
-	m.operations[span.Process.ServiceName][span.OperationName] = struct{}{}
-	m.services[span.Process.ServiceName] = struct{}{}
+	m.operations[span.Process.ServiceName][span.OperationName]++
+	m.services[span.Process.ServiceName]++
 	if _, ok := m.traces[span.TraceID]; !ok {
 		m.traces[span.TraceID] = &model.Trace{}
 
@@ -132,11 +149,12 @@ func (m *Store) WriteSpan(span *model.Span) error {
 			// do we have an item already on this position? if so, we are overriding it,
 			// and we need to remove from the map
 			if m.ids[m.index] != nil {
-				delete(m.traces, *m.ids[m.index])
+				m.deleteTrace(*m.ids[m.index])
 			}
 
 			// update the ring with the trace id
 			m.ids[m.index] = &span.TraceID
+			m.positions[span.TraceID] = m.index
 		}
 
 	}
@@ -145,13 +163,129 @@ func (m *Store) WriteSpan(span *model.Span) error {
 	return nil
 }
 
+// DeleteTrace removes the trace from the store
+func (m *Store) DeleteTrace(traceID model.TraceID) error {
+	m.Lock()
+	defer m.Unlock()
+	m.deleteTrace(traceID)
+	return nil
+}
+
+// Purge removes all spans that started before the cutoff, and the traces left without spans
+func (m *Store) Purge(cutoff time.Time) error {
+	m.Lock()
+	defer m.Unlock()
+	for traceID, trace := range m.traces {
+		// the trace may be held by readers that got it from GetTrace, so its spans are copied rather than filtered in place
+		var spans, purgedSpans []*model.Span
+		for _, span := range trace.Spans {
+			if span.StartTime.Before(cutoff) {
+				purgedSpans = append(purgedSpans, span)
+			} else {
+				spans = append(spans, span)
+			}
+		}
+		if len(spans) == 0 {
+			m.deleteTrace(traceID)
+		} else if len(purgedSpans) > 0 {
+			purged := *trace
+			purged.Spans = spans
+			m.traces[traceID] = &purged
+			m.removeSpans(purgedSpans)
+		}
+	}
+	return nil
+}
+
+// Close stops the background goroutines evicting old traces and taking snapshots, if any
+func (m *Store) Close() error {
+	m.Lock()
+	stop := m.stop
+	m.stop = nil
+	m.Unlock()
+	if stop != nil {
+		close(stop)
+	}
+	return nil
+}
+
+// sweep periodically evicts the traces older than the configured max age until the store is closed
+func (m *Store) sweep(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.evictExpired()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// evictExpired removes the traces whose newest span started more than MaxAge ago
+func (m *Store) evictExpired() {
+	m.Lock()
+	defer m.Unlock()
+	cutoff := m.timeNow().Add(-m.config.MaxAge)
+	for traceID, trace := range m.traces {
+		if newestStartTime(trace).Before(cutoff) {
+			m.deleteTrace(traceID)
+		}
+	}
+}
+
+func newestStartTime(trace *model.Trace) time.Time {
+	var newest time.Time
+	for _, span := range trace.Spans {
+		if span.StartTime.After(newest) {
+			newest = span.StartTime
+		}
+	}
+	return newest
+}
+
+// deleteTrace removes the trace, if it exists, together with its position in the ring, so that this
+// position cannot later evict a new trace with the same ID, and with the spans counted by the indexes.
+func (m *Store) deleteTrace(traceID model.TraceID) {
+	trace, ok := m.traces[traceID]
+	if !ok {
+		return
+	}
+	delete(m.traces, traceID)
+	if position, ok := m.positions[traceID]; ok {
+		m.ids[position] = nil
+		delete(m.positions, traceID)
+	}
+	m.removeSpans(trace.Spans)
+}
+
+// removeSpans uncounts the spans from the services and operations, and removes the services
+// and operations that no longer appear in any span.
+func (m *Store) removeSpans(spans []*model.Span) {
+	for _, span := range spans {
+		service := span.Process.ServiceName
+		if operations := m.operations[service]; operations != nil {
+			if operations[span.OperationName]--; operations[span.OperationName] <= 0 {
+				delete(operations, span.OperationName)
+			}
+			if len(operations) == 0 {
+				delete(m.operations, service)
+			}
+		}
+		if m.services[service]--; m.services[service] <= 0 {
+			delete(m.services, service)
+		}
+	}
+}
+
 // GetTrace gets a trace
 func (m *Store) GetTrace(traceID model.TraceID) (*model.Trace, error) {
 	m.RLock()
 	defer m.RUnlock()
 	retMe := m.traces[traceID]
 	if retMe == nil {
-		return nil, errTraceNotFound
+		return nil, spanstore.ErrTraceNotFound
 	}
 	return retMe, nil
 }
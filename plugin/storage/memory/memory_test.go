@@ -194,6 +194,128 @@ func TestStoreWithLimit(t *testing.T) {
 
 	assert.Equal(t, maxTraces, len(store.traces))
 	assert.Equal(t, maxTraces, len(store.ids))
+	assert.Equal(t, map[string]int{"TestStoreWithLimit": maxTraces * 2}, store.services)
+	assert.Equal(t, map[string]int{"": maxTraces, "childOperationName": maxTraces}, store.operations["TestStoreWithLimit"])
+}
+
+func TestStoreDeleteTrace(t *testing.T) {
+	store := WithConfiguration(config.Configuration{MaxTraces: 2})
+	assert.NoError(t, store.WriteSpan(testingSpan))
+	assert.NoError(t, store.DeleteTrace(traceID))
+	assert.NoError(t, store.DeleteTrace(traceID))
+	_, err := store.GetTrace(traceID)
+	assert.EqualError(t, err, spanstore.ErrTraceNotFound.Error())
+	assert.Equal(t, []*model.TraceID{nil, nil}, store.ids)
+	assert.Empty(t, store.services)
+	assert.Empty(t, store.operations)
+
+	// the ring position of the deleted trace must not evict the trace written again
+	assert.NoError(t, store.WriteSpan(testingSpan))
+	assert.NoError(t, store.WriteSpan(&model.Span{
+		TraceID: model.NewTraceID(1, 3),
+		Process: &model.Process{ServiceName: "serviceName"},
+	}))
+	trace, err := store.GetTrace(traceID)
+	assert.NoError(t, err)
+	assert.Len(t, trace.Spans, 1)
+}
+
+func TestStorePurge(t *testing.T) {
+	withMemoryStore(func(store *Store) {
+		assert.NoError(t, store.WriteSpan(testingSpan))
+		assert.NoError(t, store.WriteSpan(childSpan1))
+		otherTraceID := model.NewTraceID(1, 3)
+		assert.NoError(t, store.WriteSpan(&model.Span{
+			TraceID:   otherTraceID,
+			Process:   &model.Process{ServiceName: "serviceName"},
+			StartTime: time.Unix(100, 0),
+		}))
+		childSpan := *childSpan1
+		childSpan.SpanID = model.NewSpanID(3)
+		childSpan.StartTime = time.Unix(500, 0)
+		assert.NoError(t, store.WriteSpan(&childSpan))
+		before, err := store.GetTrace(traceID)
+		assert.NoError(t, err)
+		spansBefore := append([]*model.Span(nil), before.Spans...)
+
+		assert.NoError(t, store.Purge(time.Unix(400, 0)))
+		trace, err := store.GetTrace(traceID)
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.Span{&childSpan}, trace.Spans)
+		// the trace returned before the purge is not modified
+		assert.Equal(t, spansBefore, before.Spans)
+		_, err = store.GetTrace(otherTraceID)
+		assert.EqualError(t, err, spanstore.ErrTraceNotFound.Error())
+		assert.Equal(t, map[string]int{"childService": 1}, store.services)
+		assert.Equal(t, map[string]map[string]int{"childService": {"childOperationName": 1}}, store.operations)
+	})
+}
+
+func TestStoreEvictExpired(t *testing.T) {
+	store := WithConfiguration(config.Configuration{MaxTraces: 3, MaxAge: time.Minute})
+	defer store.Close()
+	store.timeNow = func() time.Time { return time.Unix(400, 0) }
+
+	// testingSpan is too old, but childSpan keeps its trace alive
+	assert.NoError(t, store.WriteSpan(testingSpan))
+	childSpan := *childSpan1
+	childSpan.StartTime = time.Unix(350, 0)
+	assert.NoError(t, store.WriteSpan(&childSpan))
+	otherTraceID := model.NewTraceID(1, 3)
+	assert.NoError(t, store.WriteSpan(&model.Span{
+		TraceID:       otherTraceID,
+		Process:       &model.Process{ServiceName: "otherService"},
+		OperationName: "otherOperation",
+		StartTime:     time.Unix(300, 0),
+	}))
+
+	store.evictExpired()
+	trace, err := store.GetTrace(traceID)
+	assert.NoError(t, err)
+	assert.Len(t, trace.Spans, 2)
+	_, err = store.GetTrace(otherTraceID)
+	assert.EqualError(t, err, spanstore.ErrTraceNotFound.Error())
+	assert.Equal(t, []*model.TraceID{nil, &traceID, nil}, store.ids)
+	services, err := store.GetServices()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"serviceName", "childService"}, services)
+	operations, err := store.GetOperations("otherService")
+	assert.NoError(t, err)
+	assert.Empty(t, operations)
+
+	store.timeNow = func() time.Time { return time.Unix(500, 0) }
+	store.evictExpired()
+	assert.Empty(t, store.traces)
+	services, err = store.GetServices()
+	assert.NoError(t, err)
+	assert.Empty(t, services)
+}
+
+func TestStoreSweeper(t *testing.T) {
+	store := WithConfiguration(config.Configuration{MaxAge: 10 * time.Millisecond})
+	assert.NoError(t, store.WriteSpan(testingSpan))
+	for i := 0; i < 100; i++ {
+		store.RLock()
+		count := len(store.traces)
+		store.RUnlock()
+		if count == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	_, err := store.GetTrace(traceID)
+	assert.EqualError(t, err, spanstore.ErrTraceNotFound.Error())
+	assert.NoError(t, store.Close())
+	assert.NoError(t, store.Close())
+}
+
+func TestSweepInterval(t *testing.T) {
+	assert.Equal(t, time.Second, sweepInterval(0))
+	assert.Equal(t, time.Second, sweepInterval(time.Millisecond))
+	assert.Equal(t, 30*time.Second, sweepInterval(5*time.Minute))
+	assert.Equal(t, time.Second, sweepInterval(10*time.Second))
+	assert.Equal(t, time.Minute, sweepInterval(10*time.Minute))
+	assert.Equal(t, time.Minute, sweepInterval(72*time.Hour))
 }
 
 func TestStoreGetTraceSuccess(t *testing.T) {
@@ -208,7 +330,7 @@ func TestStoreGetTraceSuccess(t *testing.T) {
 func TestStoreGetTraceFailure(t *testing.T) {
 	withPopulatedMemoryStore(func(store *Store) {
 		trace, err := store.GetTrace(model.TraceID{})
-		assert.EqualError(t, err, errTraceNotFound.Error())
+		assert.EqualError(t, err, spanstore.ErrTraceNotFound.Error())
 		assert.Nil(t, trace)
 	})
 }
@@ -16,13 +16,20 @@ package memory
 
 import (
 	"flag"
+	"fmt"
 
 	"github.com/spf13/viper"
 
 	"github.com/jaegertracing/jaeger/pkg/memory/config"
 )
 
-const limit = "memory.max-traces"
+const (
+	limit  = "memory.max-traces"
+	maxAge = "memory.max-age"
+
+	snapshotFile     = "memory.snapshot-file"
+	snapshotInterval = "memory.snapshot-interval"
+)
 
 // Options stores the configuration entries for this storage
 type Options struct {
@@ -32,9 +39,23 @@ type Options struct {
 // AddFlags from this storage to the CLI
 func (opt *Options) AddFlags(flagSet *flag.FlagSet) {
 	flagSet.Int(limit, opt.Configuration.MaxTraces, "The maximum amount of traces to store in memory")
+	flagSet.Duration(maxAge, opt.Configuration.MaxAge, "The maximum age of traces kept in memory, based on their newest span (0 keeps traces forever)")
+	flagSet.String(snapshotFile, opt.Configuration.SnapshotFile, "The file where traces are saved on shutdown and restored from on startup (disabled if empty)")
+	flagSet.Duration(snapshotInterval, opt.Configuration.SnapshotInterval, "How often traces are also saved to the snapshot file while running (0 saves them only on shutdown)")
 }
 
 // InitFromViper initializes the options struct with values from Viper
 func (opt *Options) InitFromViper(v *viper.Viper) {
 	opt.Configuration.MaxTraces = v.GetInt(limit)
+	opt.Configuration.MaxAge = v.GetDuration(maxAge)
+	opt.Configuration.SnapshotFile = v.GetString(snapshotFile)
+	opt.Configuration.SnapshotInterval = v.GetDuration(snapshotInterval)
+}
+
+// Validate returns an error if the options are invalid
+func (opt *Options) Validate() error {
+	if opt.Configuration.MaxAge < 0 {
+		return fmt.Errorf("%s must not be negative, got %v", maxAge, opt.Configuration.MaxAge)
+	}
+	return nil
 }
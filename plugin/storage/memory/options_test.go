@@ -16,6 +16,7 @@ package memory
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -25,8 +26,16 @@ import (
 func TestOptionsWithFlags(t *testing.T) {
 	opts := &Options{}
 	v, command := config.Viperize(opts.AddFlags)
-	command.ParseFlags([]string{"--memory.max-traces=100"})
+	command.ParseFlags([]string{
+		"--memory.max-traces=100",
+		"--memory.max-age=24h",
+		"--memory.snapshot-file=/tmp/jaeger.snapshot",
+		"--memory.snapshot-interval=5m",
+	})
 	opts.InitFromViper(v)
 
 	assert.Equal(t, 100, opts.Configuration.MaxTraces)
+	assert.Equal(t, 24*time.Hour, opts.Configuration.MaxAge)
+	assert.Equal(t, "/tmp/jaeger.snapshot", opts.Configuration.SnapshotFile)
+	assert.Equal(t, 5*time.Minute, opts.Configuration.SnapshotInterval)
 }
@@ -0,0 +1,180 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+// A snapshot file starts with snapshotMagic, followed by one record per trace. Each record is
+// the uvarint length of the payload, the big-endian CRC-32 (IEEE) of the payload and the payload
+// itself, which is a model.Trace encoded as protobuf.
+const (
+	snapshotMagic = "JAEGER-MEMORY-SNAPSHOT-1\n"
+
+	// maxSnapshotRecordSize protects against allocating huge buffers when the length of a record is corrupted
+	maxSnapshotRecordSize = 64 * 1024 * 1024
+)
+
+var errInvalidSnapshot = errors.New("File is not a memory storage snapshot")
+
+// Snapshot writes all traces in the store to the given file. The file is replaced atomically,
+// so that a crash while writing never leaves a partial snapshot behind.
+func (m *Store) Snapshot(path string) error {
+	m.snapshotLock.Lock()
+	defer m.snapshotLock.Unlock()
+	records, err := m.marshalTraces()
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create snapshot file")
+	}
+	if err := writeSnapshot(file, records); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return errors.Wrap(err, "Failed to write snapshot")
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return errors.Wrap(err, "Failed to write snapshot")
+	}
+	return errors.Wrap(os.Rename(tmpPath, path), "Failed to replace snapshot file")
+}
+
+func (m *Store) marshalTraces() ([][]byte, error) {
+	m.RLock()
+	defer m.RUnlock()
+	records := make([][]byte, 0, len(m.traces))
+	for _, trace := range m.traces {
+		record, err := trace.Marshal()
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to marshal trace")
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func writeSnapshot(file *os.File, records [][]byte) error {
+	w := bufio.NewWriter(file)
+	if _, err := w.WriteString(snapshotMagic); err != nil {
+		return err
+	}
+	header := make([]byte, binary.MaxVarintLen64+4)
+	for _, record := range records {
+		n := binary.PutUvarint(header, uint64(len(record)))
+		binary.BigEndian.PutUint32(header[n:], crc32.ChecksumIEEE(record))
+		if _, err := w.Write(header[:n+4]); err != nil {
+			return err
+		}
+		if _, err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// Restore loads the traces from the given snapshot file into the store. Records that are
+// corrupted are skipped, and a truncated file is read up to its last complete record.
+// It returns the number of traces restored and the number of records skipped.
+func (m *Store) Restore(path string) (restored int, skipped int, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	r := bufio.NewReader(file)
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, []byte(snapshotMagic)) {
+		return 0, 0, errInvalidSnapshot
+	}
+	for {
+		length, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			return restored, skipped, nil
+		}
+		if err != nil || length > maxSnapshotRecordSize {
+			// the records cannot be delimited anymore, keep what was read so far
+			return restored, skipped + 1, nil
+		}
+		record := make([]byte, 4+length)
+		if _, err := io.ReadFull(r, record); err != nil {
+			return restored, skipped + 1, nil
+		}
+		payload := record[4:]
+		var trace model.Trace
+		if binary.BigEndian.Uint32(record) != crc32.ChecksumIEEE(payload) || trace.Unmarshal(payload) != nil {
+			skipped++
+			continue
+		}
+		for _, span := range trace.Spans {
+			if span.Process == nil {
+				span.Process = &model.Process{}
+			}
+			m.WriteSpan(span)
+		}
+		restored++
+	}
+}
+
+func (m *Store) restoreSnapshot() {
+	path := m.config.SnapshotFile
+	restored, skipped, err := m.Restore(path)
+	if os.IsNotExist(err) {
+		m.logger.Info("No memory storage snapshot to restore", zap.String("file", path))
+		return
+	}
+	if err != nil {
+		m.logger.Error("Failed to restore memory storage snapshot", zap.String("file", path), zap.Error(err))
+		return
+	}
+	if skipped > 0 {
+		m.logger.Warn("Skipped corrupted records in memory storage snapshot", zap.String("file", path), zap.Int("skipped", skipped))
+	}
+	m.logger.Info("Restored memory storage snapshot", zap.String("file", path), zap.Int("traces", restored))
+}
+
+// snapshotPeriodically writes a snapshot of the store at every interval until the store is closed
+func (m *Store) snapshotPeriodically(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.Snapshot(m.config.SnapshotFile); err != nil {
+				m.logger.Error("Failed to snapshot memory storage", zap.Error(err))
+			}
+		case <-stop:
+			return
+		}
+	}
+}
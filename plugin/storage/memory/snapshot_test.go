@@ -0,0 +1,178 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/memory/config"
+)
+
+func withSnapshotFile(t *testing.T, f func(path string)) {
+	dir, err := ioutil.TempDir("", "jaeger-memory-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	f(filepath.Join(dir, "snapshot"))
+}
+
+func snapshotSpan(traceID model.TraceID, serviceName string) *model.Span {
+	return &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.NewSpanID(1),
+		OperationName: "operationName",
+		Process:       model.NewProcess(serviceName, nil),
+		Tags:          model.KeyValues{model.String("tagKey", "tagValue")},
+		StartTime:     time.Unix(300, 0).UTC(),
+		Duration:      time.Second,
+	}
+}
+
+func TestStoreSnapshotRestore(t *testing.T) {
+	withSnapshotFile(t, func(path string) {
+		store := NewStore()
+		span1 := snapshotSpan(model.NewTraceID(1, 2), "service1")
+		span2 := snapshotSpan(model.NewTraceID(1, 3), "service2")
+		require.NoError(t, store.WriteSpan(span1))
+		require.NoError(t, store.WriteSpan(span2))
+		require.NoError(t, store.Snapshot(path))
+		_, err := os.Stat(path + ".tmp")
+		assert.True(t, os.IsNotExist(err))
+
+		restoredStore := NewStore()
+		restored, skipped, err := restoredStore.Restore(path)
+		require.NoError(t, err)
+		assert.Equal(t, 2, restored)
+		assert.Equal(t, 0, skipped)
+		trace, err := restoredStore.GetTrace(span1.TraceID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.Span{span1}, trace.Spans)
+		services, err := restoredStore.GetServices()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"service1", "service2"}, services)
+	})
+}
+
+func TestStoreRestoreCorrupted(t *testing.T) {
+	withSnapshotFile(t, func(path string) {
+		store := NewStore()
+		require.NoError(t, store.WriteSpan(snapshotSpan(model.NewTraceID(1, 2), "service1")))
+		require.NoError(t, store.Snapshot(path))
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		good := data[len(snapshotMagic):]
+
+		// flip a byte of the payload, so that the checksum does not match
+		bad := append([]byte{}, good...)
+		bad[len(bad)-1] ^= 0xff
+
+		testCases := []struct {
+			caption  string
+			content  []byte
+			restored int
+			skipped  int
+		}{
+			{caption: "bad checksum", content: concat(snapshotMagic, bad, good), restored: 1, skipped: 1},
+			{caption: "truncated record", content: concat(snapshotMagic, good, good[:len(good)-1]), restored: 1, skipped: 1},
+			{caption: "invalid length", content: concat(snapshotMagic, good, []byte{0xff, 0xff, 0xff, 0xff, 0x7f}), restored: 1, skipped: 1},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.caption, func(t *testing.T) {
+				require.NoError(t, ioutil.WriteFile(path, testCase.content, 0644))
+				restored, skipped, err := NewStore().Restore(path)
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.restored, restored)
+				assert.Equal(t, testCase.skipped, skipped)
+			})
+		}
+	})
+}
+
+func concat(magic string, records ...[]byte) []byte {
+	data := []byte(magic)
+	for _, record := range records {
+		data = append(data, record...)
+	}
+	return data
+}
+
+func TestStoreRestoreErrors(t *testing.T) {
+	withSnapshotFile(t, func(path string) {
+		_, _, err := NewStore().Restore(path)
+		assert.True(t, os.IsNotExist(err))
+
+		require.NoError(t, ioutil.WriteFile(path, []byte("not a snapshot"), 0644))
+		_, _, err = NewStore().Restore(path)
+		assert.Equal(t, errInvalidSnapshot, err)
+	})
+}
+
+func TestStoreSnapshotError(t *testing.T) {
+	withSnapshotFile(t, func(path string) {
+		err := NewStore().Snapshot(filepath.Join(path, "missing-dir", "snapshot"))
+		assert.Contains(t, err.Error(), "Failed to create snapshot file")
+	})
+}
+
+func TestFactorySnapshotOnClose(t *testing.T) {
+	withSnapshotFile(t, func(path string) {
+		f := NewFactory()
+		f.options.Configuration.SnapshotFile = path
+		require.NoError(t, f.Initialize(nil, zap.NewNop()))
+		span := snapshotSpan(model.NewTraceID(1, 2), "service1")
+		require.NoError(t, f.store.WriteSpan(span))
+		// closing the span writer does not prevent the factory from writing the final snapshot
+		require.NoError(t, f.store.Close())
+		require.NoError(t, f.Close())
+
+		restoredStore := WithConfiguration(f.options.Configuration)
+		defer restoredStore.Close()
+		trace, err := restoredStore.GetTrace(span.TraceID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.Span{span}, trace.Spans)
+	})
+}
+
+func TestFactoryCloseSnapshotError(t *testing.T) {
+	withSnapshotFile(t, func(path string) {
+		f := NewFactory()
+		f.options.Configuration.SnapshotFile = filepath.Join(path, "missing-dir", "snapshot")
+		require.NoError(t, f.Initialize(nil, zap.NewNop()))
+		assert.Contains(t, f.Close().Error(), "Failed to create snapshot file")
+	})
+}
+
+func TestStoreSnapshotPeriodically(t *testing.T) {
+	withSnapshotFile(t, func(path string) {
+		store := WithConfiguration(config.Configuration{SnapshotFile: path, SnapshotInterval: time.Millisecond})
+		defer store.Close()
+		require.NoError(t, store.WriteSpan(snapshotSpan(model.NewTraceID(1, 2), "service1")))
+		for i := 0; i < 100; i++ {
+			if restored, _, _ := NewStore().Restore(path); restored == 1 {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("snapshot was not written periodically")
+	})
+}
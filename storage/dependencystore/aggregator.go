@@ -0,0 +1,73 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencystore
+
+import (
+	"sort"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+type serviceLink struct {
+	parent string
+	child  string
+}
+
+// Aggregator counts the calls between services in traces, i.e. the spans whose parent span
+// belongs to a different service. It is not safe for concurrent use.
+type Aggregator struct {
+	callCounts map[serviceLink]uint64
+}
+
+// NewAggregator creates an empty Aggregator
+func NewAggregator() *Aggregator {
+	return &Aggregator{callCounts: map[serviceLink]uint64{}}
+}
+
+// AddTrace counts the calls between services in the given trace. The span IDs of the trace
+// must be unique, see adjuster.SpanIDDeduper.
+func (a *Aggregator) AddTrace(trace *model.Trace) {
+	for _, span := range trace.Spans {
+		parentSpan := trace.FindSpanByID(span.ParentSpanID())
+		if parentSpan == nil || parentSpan.Process.ServiceName == span.Process.ServiceName {
+			continue
+		}
+		a.AddCalls(parentSpan.Process.ServiceName, span.Process.ServiceName, 1)
+	}
+}
+
+// AddCalls adds callCount calls from the parent service to the child service
+func (a *Aggregator) AddCalls(parent, child string, callCount uint64) {
+	a.callCounts[serviceLink{parent: parent, child: child}] += callCount
+}
+
+// Links returns the dependency links counted so far, sorted by parent and child service
+func (a *Aggregator) Links() []model.DependencyLink {
+	links := make([]model.DependencyLink, 0, len(a.callCounts))
+	for link, callCount := range a.callCounts {
+		links = append(links, model.DependencyLink{
+			Parent:    link.parent,
+			Child:     link.child,
+			CallCount: callCount,
+		})
+	}
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Parent != links[j].Parent {
+			return links[i].Parent < links[j].Parent
+		}
+		return links[i].Child < links[j].Child
+	})
+	return links
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencystore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+func span(traceID model.TraceID, spanID, parentID uint64, service string) *model.Span {
+	s := &model.Span{
+		TraceID: traceID,
+		SpanID:  model.SpanID(spanID),
+		Process: model.NewProcess(service, nil),
+	}
+	if parentID != 0 {
+		s.References = []model.SpanRef{model.NewChildOfRef(traceID, model.SpanID(parentID))}
+	}
+	return s
+}
+
+func TestAggregator(t *testing.T) {
+	traceID := model.NewTraceID(0, 1)
+	aggregator := NewAggregator()
+	assert.Empty(t, aggregator.Links())
+
+	aggregator.AddTrace(&model.Trace{Spans: []*model.Span{
+		span(traceID, 1, 0, "frontend"),
+		span(traceID, 2, 1, "frontend"),
+		span(traceID, 3, 2, "customer"),
+		span(traceID, 4, 1, "driver"),
+		span(traceID, 5, 4, "redis"),
+		span(traceID, 6, 4, "redis"),
+		span(traceID, 7, 42, "orphan"),
+	}})
+	aggregator.AddTrace(&model.Trace{Spans: []*model.Span{
+		span(traceID, 1, 0, "frontend"),
+		span(traceID, 2, 1, "customer"),
+	}})
+
+	assert.Equal(t, []model.DependencyLink{
+		{Parent: "driver", Child: "redis", CallCount: 2},
+		{Parent: "frontend", Child: "customer", CallCount: 2},
+		{Parent: "frontend", Child: "driver", CallCount: 1},
+	}, aggregator.Links())
+}
+
+func TestAggregatorAddCalls(t *testing.T) {
+	aggregator := NewAggregator()
+	aggregator.AddCalls("frontend", "backend", 3)
+	aggregator.AddCalls("frontend", "backend", 2)
+	assert.Equal(t, []model.DependencyLink{{Parent: "frontend", Child: "backend", CallCount: 5}}, aggregator.Links())
+}
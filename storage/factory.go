@@ -20,7 +20,9 @@ import (
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
+	"github.com/jaegertracing/jaeger/pkg/distributedlock"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
@@ -51,6 +53,15 @@ var (
 
 	// ErrArchiveStorageNotSupported can be returned by the ArchiveFactory when the archive storage is not supported by the backend.
 	ErrArchiveStorageNotSupported = errors.New("Archive storage not supported")
+
+	// ErrPurgerNotSupported can be returned by the meta-factory when the backend cannot delete traces.
+	ErrPurgerNotSupported = errors.New("Purger not supported")
+
+	// ErrDependencyWriterNotSupported can be returned by the meta-factory when the backend cannot store dependencies.
+	ErrDependencyWriterNotSupported = errors.New("Dependency writer not supported")
+
+	// ErrSamplingStoreNotSupported can be returned by the meta-factory when the backend cannot store sampling data.
+	ErrSamplingStoreNotSupported = errors.New("Sampling store not supported")
 )
 
 // ArchiveFactory is an additional interface that can be implemented by a factory to support trace archiving.
@@ -61,3 +72,27 @@ type ArchiveFactory interface {
 	// CreateArchiveSpanWriter creates a spanstore.Writer.
 	CreateArchiveSpanWriter() (spanstore.Writer, error)
 }
+
+// SamplingStoreFactory is an additional interface that can be implemented by a factory to support
+// adaptive sampling, which needs to share throughput and probabilities between collectors.
+type SamplingStoreFactory interface {
+	// CreateLock creates a distributedlock.Lock.
+	CreateLock() (distributedlock.Lock, error)
+
+	// CreateSamplingStore creates a samplingstore.Store.
+	CreateSamplingStore() (samplingstore.Store, error)
+}
+
+// PurgerFactory is an additional interface that can be implemented by a factory to support
+// deleting traces from the primary span storage. Archive storage is not affected.
+type PurgerFactory interface {
+	// CreatePurger creates a spanstore.Purger.
+	CreatePurger() (spanstore.Purger, error)
+}
+
+// DependencyWriterFactory is an additional interface that can be implemented by a factory whose backend
+// stores precomputed dependency links, rather than computing them from the spans when they are read.
+type DependencyWriterFactory interface {
+	// CreateDependencyWriter creates a dependencystore.Writer.
+	CreateDependencyWriter() (dependencystore.Writer, error)
+}
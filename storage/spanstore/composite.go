@@ -15,6 +15,8 @@
 package spanstore
 
 import (
+	"time"
+
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/pkg/multierror"
 )
@@ -41,3 +43,37 @@ func (c *CompositeWriter) WriteSpan(span *model.Span) error {
 	}
 	return multierror.Wrap(errors)
 }
+
+// CompositePurger is a span Purger that deletes spans from several underlying span Purgers
+type CompositePurger struct {
+	purgers []Purger
+}
+
+// NewCompositePurger creates a CompositePurger
+func NewCompositePurger(purgers ...Purger) *CompositePurger {
+	return &CompositePurger{
+		purgers: purgers,
+	}
+}
+
+// DeleteTrace calls DeleteTrace on each purger. It will sum up failures, it is not transactional
+func (c *CompositePurger) DeleteTrace(traceID model.TraceID) error {
+	var errors []error
+	for _, purger := range c.purgers {
+		if err := purger.DeleteTrace(traceID); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return multierror.Wrap(errors)
+}
+
+// Purge calls Purge on each purger. It will sum up failures, it is not transactional
+func (c *CompositePurger) Purge(cutoff time.Time) error {
+	var errors []error
+	for _, purger := range c.purgers {
+		if err := purger.Purge(cutoff); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return multierror.Wrap(errors)
+}
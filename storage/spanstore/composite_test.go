@@ -18,11 +18,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/jaegertracing/jaeger/model"
 	. "github.com/jaegertracing/jaeger/storage/spanstore"
+	"github.com/jaegertracing/jaeger/storage/spanstore/mocks"
 )
 
 var errIWillAlwaysFail = errors.New("ErrProneWriteSpanStore will always fail")
@@ -53,3 +55,20 @@ func TestCompositeWriteSpanStoreFirstFailure(t *testing.T) {
 	c := NewCompositeWriter(&errProneWriteSpanStore{}, &noopWriteSpanStore{})
 	assert.Equal(t, errIWillAlwaysFail, c.WriteSpan(nil))
 }
+
+func TestCompositePurger(t *testing.T) {
+	traceID := model.TraceID{Low: 1}
+	cutoff := time.Unix(1000, 0)
+	first := &mocks.Purger{}
+	first.On("DeleteTrace", traceID).Return(nil)
+	first.On("Purge", cutoff).Return(errIWillAlwaysFail)
+	second := &mocks.Purger{}
+	second.On("DeleteTrace", traceID).Return(nil)
+	second.On("Purge", cutoff).Return(errIWillAlwaysFail)
+
+	c := NewCompositePurger(first, second)
+	assert.NoError(t, c.DeleteTrace(traceID))
+	assert.EqualError(t, c.Purge(cutoff), fmt.Sprintf("[%s, %s]", errIWillAlwaysFail, errIWillAlwaysFail))
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+
+	"github.com/uber/jaeger-lib/metrics"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+// DownsamplingOptions contains the options for constructing a DownsamplingWriter.
+type DownsamplingOptions struct {
+	// Ratio is the fraction of traces that are kept, in the range [0, 1]
+	Ratio float64
+	// HashSalt is prepended to the trace ID before hashing, so that different deployments
+	// can keep different subsets of traces
+	HashSalt string
+	// MetricsFactory is used to emit the counters of kept and dropped spans
+	MetricsFactory metrics.Factory
+}
+
+type downsamplingWriterMetrics struct {
+	SpansDropped  metrics.Counter `metric:"spans.dropped"`
+	SpansAccepted metrics.Counter `metric:"spans.accepted"`
+}
+
+// DownsamplingWriter is a span Writer that keeps a fraction of traces and drops the others.
+// The decision is based on the hash of the trace ID, so all spans of a trace are kept or dropped together.
+type DownsamplingWriter struct {
+	spanWriter Writer
+	threshold  uint64
+	hashSalt   []byte
+	metrics    downsamplingWriterMetrics
+}
+
+// NewDownsamplingWriter creates a DownsamplingWriter
+func NewDownsamplingWriter(spanWriter Writer, options DownsamplingOptions) *DownsamplingWriter {
+	metricsFactory := options.MetricsFactory
+	if metricsFactory == nil {
+		metricsFactory = metrics.NullFactory
+	}
+	writer := &DownsamplingWriter{
+		spanWriter: spanWriter,
+		threshold:  ratioToThreshold(options.Ratio),
+		hashSalt:   []byte(options.HashSalt),
+	}
+	metrics.Init(&writer.metrics, metricsFactory.Namespace("downsampling", nil), nil)
+	return writer
+}
+
+// WriteSpan calls WriteSpan on the wrapped span writer if the trace of the span is kept
+func (ds *DownsamplingWriter) WriteSpan(span *model.Span) error {
+	if !ds.shouldKeep(span.TraceID) {
+		ds.metrics.SpansDropped.Inc(1)
+		return nil
+	}
+	ds.metrics.SpansAccepted.Inc(1)
+	return ds.spanWriter.WriteSpan(span)
+}
+
+func (ds *DownsamplingWriter) shouldKeep(traceID model.TraceID) bool {
+	if ds.threshold == math.MaxUint64 {
+		return true
+	}
+	var idBytes [16]byte
+	binary.BigEndian.PutUint64(idBytes[:8], traceID.High)
+	binary.BigEndian.PutUint64(idBytes[8:], traceID.Low)
+	hasher := fnv.New64a()
+	hasher.Write(ds.hashSalt)
+	hasher.Write(idBytes[:])
+	return mix(hasher.Sum64()) < ds.threshold
+}
+
+// mix is the finalizer of MurmurHash3. The high bits of FNV-1a are poorly distributed
+// when only the last bytes of the input vary, as with sequential trace IDs.
+func mix(hash uint64) uint64 {
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return hash
+}
+
+func ratioToThreshold(ratio float64) uint64 {
+	if ratio >= 1 {
+		return math.MaxUint64
+	}
+	if ratio <= 0 {
+		return 0
+	}
+	return uint64(ratio * math.MaxUint64)
+}
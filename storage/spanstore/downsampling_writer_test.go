@@ -0,0 +1,97 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/jaeger-lib/metrics"
+	mTestutils "github.com/uber/jaeger-lib/metrics/testutils"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+type countingWriter struct {
+	count int
+	err   error
+}
+
+func (w *countingWriter) WriteSpan(span *model.Span) error {
+	w.count++
+	return w.err
+}
+
+func writeTraces(t *testing.T, writer Writer, numTraces int) {
+	for i := 0; i < numTraces; i++ {
+		for j := 0; j < 2; j++ {
+			assert.NoError(t, writer.WriteSpan(&model.Span{TraceID: model.NewTraceID(0, uint64(i))}))
+		}
+	}
+}
+
+func TestDownsamplingWriterRatio(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		min   int
+		max   int
+	}{
+		{ratio: 1, min: 2000, max: 2000},
+		{ratio: 0, min: 0, max: 0},
+		{ratio: 0.5, min: 900, max: 1100},
+	}
+	for _, test := range tests {
+		underlying := &countingWriter{}
+		metricsFactory := metrics.NewLocalFactory(0)
+		writer := NewDownsamplingWriter(underlying, DownsamplingOptions{
+			Ratio:          test.ratio,
+			MetricsFactory: metricsFactory,
+		})
+		writeTraces(t, writer, 1000)
+		assert.True(t, underlying.count >= test.min && underlying.count <= test.max,
+			"ratio %v: %d spans written", test.ratio, underlying.count)
+		// both spans of every trace are either kept or dropped
+		assert.Equal(t, 0, underlying.count%2)
+		mTestutils.AssertCounterMetrics(t, metricsFactory,
+			mTestutils.ExpectedMetric{Name: "downsampling.spans.accepted", Value: underlying.count},
+			mTestutils.ExpectedMetric{Name: "downsampling.spans.dropped", Value: 2000 - underlying.count},
+		)
+	}
+}
+
+func TestDownsamplingWriterIsDeterministic(t *testing.T) {
+	first, second := &countingWriter{}, &countingWriter{}
+	writeTraces(t, NewDownsamplingWriter(first, DownsamplingOptions{Ratio: 0.3}), 100)
+	writeTraces(t, NewDownsamplingWriter(second, DownsamplingOptions{Ratio: 0.3}), 100)
+	assert.Equal(t, first.count, second.count)
+
+	unsalted := NewDownsamplingWriter(first, DownsamplingOptions{Ratio: 0.3})
+	salted := NewDownsamplingWriter(first, DownsamplingOptions{Ratio: 0.3, HashSalt: "salt"})
+	differentDecisions := 0
+	for i := 0; i < 100; i++ {
+		traceID := model.NewTraceID(0, uint64(i))
+		if unsalted.shouldKeep(traceID) != salted.shouldKeep(traceID) {
+			differentDecisions++
+		}
+	}
+	assert.True(t, differentDecisions > 0, "the salt must change which traces are kept")
+}
+
+func TestDownsamplingWriterError(t *testing.T) {
+	errWrite := errors.New("write failed")
+	writer := NewDownsamplingWriter(&countingWriter{err: errWrite}, DownsamplingOptions{Ratio: 1})
+	assert.Equal(t, errWrite, writer.WriteSpan(&model.Span{}))
+}
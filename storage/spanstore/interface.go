@@ -39,6 +39,16 @@ type Reader interface {
 	FindTraces(query *TraceQueryParameters) ([]*model.Trace, error)
 }
 
+// Purger deletes traces from storage, e.g. to honor requests to remove personal data,
+// or to enforce a retention period on backends without a time to live.
+type Purger interface {
+	// DeleteTrace deletes all spans of the given trace. Deleting a trace that does not exist is not an error.
+	DeleteTrace(traceID model.TraceID) error
+	// Purge deletes all spans that started before the cutoff. Backends that cannot find spans by their
+	// start time return storage.ErrPurgerNotSupported.
+	Purge(cutoff time.Time) error
+}
+
 // TraceQueryParameters contains parameters of a trace query.
 type TraceQueryParameters struct {
 	ServiceName   string
@@ -0,0 +1,55 @@
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+import model "github.com/jaegertracing/jaeger/model"
+import spanstore "github.com/jaegertracing/jaeger/storage/spanstore"
+import time "time"
+
+// Purger is an autogenerated mock type for the Purger type
+type Purger struct {
+	mock.Mock
+}
+
+// DeleteTrace provides a mock function with given fields: traceID
+func (_m *Purger) DeleteTrace(traceID model.TraceID) error {
+	ret := _m.Called(traceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.TraceID) error); ok {
+		r0 = rf(traceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Purge provides a mock function with given fields: cutoff
+func (_m *Purger) Purge(cutoff time.Time) error {
+	ret := _m.Called(cutoff)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(cutoff)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+var _ spanstore.Purger = (*Purger)(nil)